@@ -0,0 +1,80 @@
+-- Block and Mute Queries
+
+-- name: BlockUser :one
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+RETURNING *;
+
+-- name: UnblockUser :execrows
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: MuteUser :one
+INSERT INTO user_mutes (muter_id, muted_id)
+VALUES ($1, $2)
+ON CONFLICT (muter_id, muted_id) DO NOTHING
+RETURNING *;
+
+-- name: UnmuteUser :execrows
+DELETE FROM user_mutes
+WHERE muter_id = $1 AND muted_id = $2;
+
+-- name: GetBlockedUsers :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    ub.created_at AS blocked_at
+FROM user_blocks ub
+JOIN users u ON ub.blocked_id = u.id
+WHERE ub.blocker_id = $1
+ORDER BY ub.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetMutedUsers :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    um.created_at AS muted_at
+FROM user_mutes um
+JOIN users u ON um.muted_id = u.id
+WHERE um.muter_id = $1
+ORDER BY um.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: IsBlockedBetween :one
+SELECT is_blocked_between(sqlc.arg(user_a)::uuid, sqlc.arg(user_b)::uuid)::bool AS is_blocked;
+
+-- name: GetRelationshipStatus :one
+SELECT
+    EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = sqlc.arg(viewer_id) AND blocked_id = sqlc.arg(target_id)) AS is_blocking,
+    EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = sqlc.arg(target_id) AND blocked_id = sqlc.arg(viewer_id)) AS is_blocked_by,
+    EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = sqlc.arg(viewer_id) AND muted_id = sqlc.arg(target_id)) AS is_muting,
+    EXISTS(SELECT 1 FROM follow_requests WHERE requester_id = sqlc.arg(viewer_id) AND target_id = sqlc.arg(target_id)) AS is_requested;
+
+-- name: DeleteFollowsBetween :many
+DELETE FROM follows
+WHERE (follower_id = sqlc.arg(user_a) AND following_id = sqlc.arg(user_b))
+   OR (follower_id = sqlc.arg(user_b) AND following_id = sqlc.arg(user_a))
+RETURNING follower_id, following_id;
+
+-- name: GetHiddenAudience :many
+SELECT blocker_id AS user_id FROM user_blocks WHERE blocked_id = sqlc.arg(actor_id)
+UNION
+SELECT blocked_id AS user_id FROM user_blocks WHERE blocker_id = sqlc.arg(actor_id)
+UNION
+SELECT muter_id AS user_id FROM user_mutes WHERE muted_id = sqlc.arg(actor_id);
+
+-- name: IsDirectConversationBlocked :one
+SELECT EXISTS (
+    SELECT 1
+    FROM conversations c
+    JOIN conversation_participants cp ON cp.conversation_id = c.id AND cp.user_id <> sqlc.arg(sender_id)::uuid
+    WHERE c.id = sqlc.arg(conversation_id)::uuid
+      AND c.conversation_type = 'direct'
+      AND is_blocked_between(cp.user_id, sqlc.arg(sender_id)::uuid)
+)::bool AS is_blocked;
@@ -0,0 +1,67 @@
+-- Bookmark Queries
+
+-- name: CreateBookmarkCollection :one
+INSERT INTO bookmark_collections (user_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetBookmarkCollection :one
+SELECT * FROM bookmark_collections
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetBookmarkCollections :many
+SELECT
+    bc.*,
+    (SELECT COUNT(*) FROM bookmarks b WHERE b.collection_id = bc.id) as bookmarks_count
+FROM bookmark_collections bc
+WHERE bc.user_id = $1
+ORDER BY bc.name ASC;
+
+-- name: RenameBookmarkCollection :one
+UPDATE bookmark_collections
+SET name = $1, updated_at = NOW()
+WHERE id = $2 AND user_id = $3
+RETURNING *;
+
+-- name: DeleteBookmarkCollection :execrows
+DELETE FROM bookmark_collections
+WHERE id = $1 AND user_id = $2;
+
+-- name: UpsertBookmark :one
+INSERT INTO bookmarks (user_id, post_id, collection_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, post_id) DO UPDATE SET collection_id = EXCLUDED.collection_id
+RETURNING *;
+
+-- name: MoveBookmark :one
+UPDATE bookmarks
+SET collection_id = $1
+WHERE user_id = $2 AND post_id = $3
+RETURNING *;
+
+-- name: DeleteBookmark :execrows
+DELETE FROM bookmarks
+WHERE user_id = $1 AND post_id = $2;
+
+-- name: GetUserBookmarks :many
+SELECT
+    p.*,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    b.id as bookmark_id,
+    b.collection_id as bookmark_collection_id,
+    b.created_at as bookmarked_at,
+    EXISTS(SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = sqlc.arg(user_id)) as is_liked
+FROM bookmarks b
+JOIN posts p ON b.post_id = p.id
+JOIN users u ON p.author_id = u.id
+WHERE b.user_id = sqlc.arg(user_id)
+  AND p.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id)
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND (sqlc.narg(collection_id)::uuid IS NULL OR b.collection_id = sqlc.narg(collection_id)::uuid)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (b.created_at, b.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY b.created_at DESC, b.id DESC
+LIMIT sqlc.arg(page_size);
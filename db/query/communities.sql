@@ -97,12 +97,16 @@ SELECT
     cm.joined_at
 FROM community_members cm
 JOIN users u ON cm.user_id = u.id
-WHERE cm.community_id = $1 AND u.status = 'active'
-ORDER BY
-    cm.joined_at;
-
-
-
+WHERE cm.community_id = sqlc.arg(community_id) AND u.status = 'active'
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (cm.joined_at, u.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY cm.joined_at ASC, u.id ASC
+LIMIT sqlc.narg(page_size);
+
+-- name: CountCommunityMembers :one
+SELECT COUNT(*) FROM community_members cm
+JOIN users u ON cm.user_id = u.id
+WHERE cm.community_id = $1 AND u.status = 'active';
 
 -- name: AddCommunityModerator :one
 INSERT INTO community_members (community_id, user_id, permissions, role)
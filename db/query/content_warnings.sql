@@ -0,0 +1,26 @@
+-- name: GetAutoExpandContentWarnings :one
+SELECT COALESCE((settings->>'auto_expand_content_warnings')::bool, false)::bool AS auto_expand
+FROM users
+WHERE id = sqlc.arg(user_id);
+
+-- name: SetPostContentWarning :one
+UPDATE posts
+SET content_warning = sqlc.narg(content_warning),
+    is_sensitive = sqlc.arg(is_sensitive),
+    warning_by_moderator = sqlc.arg(warning_by_moderator),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND status = 'active'
+RETURNING *;
+
+-- name: CanModeratePost :one
+SELECT (
+    EXISTS(SELECT 1 FROM users u WHERE u.id = sqlc.arg(user_id) AND u.roles && ARRAY['admin', 'moderator']::text[])
+    OR EXISTS(
+        SELECT 1 FROM community_members cm
+        WHERE cm.community_id = p.community_id AND cm.user_id = sqlc.arg(user_id) AND cm.role IN ('admin', 'moderator'))
+    OR EXISTS(
+        SELECT 1 FROM group_members gm
+        WHERE gm.group_id = p.group_id AND gm.user_id = sqlc.arg(user_id) AND gm.role IN ('admin', 'moderator'))
+)::bool AS can_moderate
+FROM posts p
+WHERE p.id = sqlc.arg(post_id);
@@ -0,0 +1,74 @@
+-- name: AddGroupParticipants :many
+INSERT INTO conversation_participants (conversation_id, user_id, role)
+SELECT sqlc.arg(conversation_id), u.id, 'member'
+FROM users u
+WHERE u.id = ANY(sqlc.arg(user_ids)::uuid[]) AND u.status = 'active'
+  AND NOT is_blocked_between(u.id, sqlc.arg(actor_id))
+ON CONFLICT (conversation_id, user_id) DO UPDATE
+SET is_active = true, left_at = NULL, joined_at = NOW(), role = 'member'
+WHERE conversation_participants.is_active = false
+RETURNING user_id;
+
+-- name: RemoveConversationParticipant :execrows
+UPDATE conversation_participants
+SET is_active = false, left_at = NOW()
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id) AND is_active = true;
+
+-- name: SetParticipantRole :execrows
+UPDATE conversation_participants
+SET role = sqlc.arg(role)
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id) AND is_active = true;
+
+-- name: GetOwnershipSuccessor :one
+SELECT user_id
+FROM conversation_participants
+WHERE conversation_id = sqlc.arg(conversation_id)
+  AND user_id <> sqlc.arg(user_id)
+  AND is_active = true
+ORDER BY CASE role WHEN 'admin' THEN 0 ELSE 1 END, joined_at, id
+LIMIT 1;
+
+-- name: UpdateConversationDetails :one
+UPDATE conversations
+SET name = COALESCE(sqlc.narg(name), name),
+    avatar = COALESCE(sqlc.narg(avatar), avatar),
+    description = COALESCE(sqlc.narg(description), description),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id) AND is_active = true
+RETURNING *;
+
+-- name: CreateConversationInvite :one
+INSERT INTO conversation_invites (conversation_id, code, created_by, expires_at, max_uses)
+VALUES (sqlc.arg(conversation_id), sqlc.arg(code), sqlc.arg(created_by), sqlc.narg(expires_at), sqlc.narg(max_uses))
+RETURNING *;
+
+-- name: GetConversationInvites :many
+SELECT *
+FROM conversation_invites
+WHERE conversation_id = sqlc.arg(conversation_id)
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at DESC;
+
+-- name: GetConversationInviteByCode :one
+SELECT i.*
+FROM conversation_invites i
+JOIN conversations c ON c.id = i.conversation_id
+WHERE i.code = sqlc.arg(code)
+  AND i.revoked_at IS NULL
+  AND (i.expires_at IS NULL OR i.expires_at > NOW())
+  AND (i.max_uses IS NULL OR i.use_count < i.max_uses)
+  AND c.is_active = true;
+
+-- name: RedeemConversationInvite :execrows
+UPDATE conversation_invites
+SET use_count = use_count + 1
+WHERE id = sqlc.arg(id)
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND (max_uses IS NULL OR use_count < max_uses);
+
+-- name: RevokeConversationInvite :execrows
+UPDATE conversation_invites
+SET revoked_at = NOW()
+WHERE id = sqlc.arg(id) AND conversation_id = sqlc.arg(conversation_id) AND revoked_at IS NULL;
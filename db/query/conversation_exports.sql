@@ -0,0 +1,82 @@
+-- name: GetExportWindow :one
+SELECT joined_at, left_at
+FROM conversation_participants
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id);
+
+-- name: CountExportMessages :one
+SELECT COUNT(*)
+FROM messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id AND cp.user_id = sqlc.arg(user_id)
+WHERE m.conversation_id = sqlc.arg(conversation_id)
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND m.expires_at IS NULL
+  AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+  AND (cp.left_at IS NULL OR m.created_at <= cp.left_at);
+
+-- name: GetExportMessages :many
+SELECT
+    m.id,
+    m.sender_id,
+    u.username AS sender_username,
+    u.full_name AS sender_full_name,
+    m.content,
+    m.attachments,
+    m.message_type,
+    m.reply_to_id,
+    m.thread_root_id,
+    m.shared_type,
+    m.shared_id,
+    m.is_forwarded,
+    m.created_at,
+    m.edited_at
+FROM messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id AND cp.user_id = sqlc.arg(user_id)
+JOIN users u ON u.id = m.sender_id
+WHERE m.conversation_id = sqlc.arg(conversation_id)
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND m.expires_at IS NULL
+  AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+  AND (cp.left_at IS NULL OR m.created_at <= cp.left_at)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (m.created_at, m.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT sqlc.arg(page_size);
+
+-- name: CreateConversationExport :one
+INSERT INTO conversation_exports (conversation_id, requested_by, format, message_count)
+VALUES (sqlc.arg(conversation_id), sqlc.arg(requested_by), sqlc.arg(format), sqlc.arg(message_count))
+RETURNING *;
+
+-- name: GetConversationExport :one
+SELECT *
+FROM conversation_exports
+WHERE id = sqlc.arg(id) AND requested_by = sqlc.arg(requested_by);
+
+-- name: CompleteConversationExport :exec
+UPDATE conversation_exports
+SET status = 'completed',
+    content = sqlc.arg(content),
+    message_count = sqlc.arg(message_count),
+    completed_at = NOW(),
+    expires_at = NOW() + sqlc.arg(retention)::interval
+WHERE id = sqlc.arg(id);
+
+-- name: FailConversationExport :exec
+UPDATE conversation_exports
+SET status = 'failed',
+    error = sqlc.arg(error),
+    completed_at = NOW(),
+    expires_at = NOW() + sqlc.arg(retention)::interval
+WHERE id = sqlc.arg(id);
+
+-- name: FailStaleConversationExports :execrows
+UPDATE conversation_exports
+SET status = 'failed',
+    error = sqlc.arg(error),
+    completed_at = NOW(),
+    expires_at = NOW() + sqlc.arg(retention)::interval
+WHERE status = 'pending' AND created_at < sqlc.arg(stale_before);
+
+-- name: DeleteExpiredConversationExports :execrows
+DELETE FROM conversation_exports
+WHERE expires_at <= NOW();
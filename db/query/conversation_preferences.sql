@@ -0,0 +1,47 @@
+-- name: SetConversationMute :execrows
+UPDATE conversation_participants
+SET muted_until = sqlc.narg(muted_until)
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id) AND is_active = true;
+
+-- name: SetConversationArchived :execrows
+UPDATE conversation_participants
+SET archived_at = CASE WHEN sqlc.arg(archived)::bool THEN COALESCE(archived_at, NOW()) ELSE NULL END
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id) AND is_active = true;
+
+-- name: PinMessage :one
+INSERT INTO message_pins (conversation_id, message_id, pinned_by)
+VALUES (sqlc.arg(conversation_id), sqlc.arg(message_id), sqlc.arg(pinned_by))
+ON CONFLICT (message_id) DO NOTHING
+RETURNING *;
+
+-- name: UnpinMessage :execrows
+DELETE FROM message_pins
+WHERE conversation_id = sqlc.arg(conversation_id) AND message_id = sqlc.arg(message_id);
+
+-- name: CountPinnedMessages :one
+SELECT COUNT(*)
+FROM message_pins
+WHERE conversation_id = sqlc.arg(conversation_id);
+
+-- name: GetPinnedMessages :many
+SELECT
+    m.id,
+    m.conversation_id,
+    m.sender_id,
+    u.username AS sender_username,
+    u.full_name AS sender_full_name,
+    u.avatar AS sender_avatar,
+    m.content,
+    m.attachments,
+    m.message_type,
+    m.created_at,
+    p.pinned_by,
+    pinner.username AS pinned_by_username,
+    p.pinned_at
+FROM message_pins p
+JOIN messages m ON m.id = p.message_id
+JOIN users u ON u.id = m.sender_id
+JOIN users pinner ON pinner.id = p.pinned_by
+WHERE p.conversation_id = sqlc.arg(conversation_id)
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+ORDER BY p.pinned_at DESC;
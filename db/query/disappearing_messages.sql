@@ -0,0 +1,28 @@
+-- name: SetDisappearingMessagesTimer :execrows
+UPDATE conversations
+SET settings = CASE
+        WHEN sqlc.arg(seconds)::int > 0
+            THEN jsonb_set(COALESCE(settings, '{}'::jsonb), '{disappearing_messages_seconds}', to_jsonb(sqlc.arg(seconds)::int))
+        ELSE COALESCE(settings, '{}'::jsonb) - 'disappearing_messages_seconds'
+    END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(conversation_id) AND is_active = true;
+
+-- name: DeleteExpiredMessages :many
+WITH expired AS (
+    DELETE FROM messages
+    WHERE id IN (
+        SELECT id
+        FROM messages
+        WHERE expires_at <= NOW()
+        ORDER BY expires_at
+        LIMIT sqlc.arg(batch_size)
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, conversation_id, sender_id
+), purged_mentions AS (
+    DELETE FROM mentions
+    WHERE source_type = 'message' AND source_id IN (SELECT id FROM expired)
+)
+SELECT id, conversation_id, sender_id
+FROM expired;
@@ -0,0 +1,44 @@
+-- name: CreateFollowRequest :one
+INSERT INTO follow_requests (requester_id, target_id, space_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (requester_id, target_id) DO NOTHING
+RETURNING *;
+
+-- name: DeleteFollowRequest :one
+DELETE FROM follow_requests
+WHERE requester_id = $1 AND target_id = $2
+RETURNING *;
+
+-- name: DeleteFollowRequestsBetween :exec
+DELETE FROM follow_requests
+WHERE (requester_id = sqlc.arg(user_a) AND target_id = sqlc.arg(user_b))
+   OR (requester_id = sqlc.arg(user_b) AND target_id = sqlc.arg(user_a));
+
+-- name: GetIncomingFollowRequests :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    fr.created_at AS requested_at
+FROM follow_requests fr
+JOIN users u ON fr.requester_id = u.id
+WHERE fr.target_id = $1 AND u.status = 'active'
+ORDER BY fr.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: GetOutgoingFollowRequests :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    fr.created_at AS requested_at
+FROM follow_requests fr
+JOIN users u ON fr.target_id = u.id
+WHERE fr.requester_id = $1 AND u.status = 'active'
+ORDER BY fr.created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: IsPrivateAccount :one
+SELECT is_private_account(sqlc.arg(user_id)::uuid)::bool AS is_private;
@@ -0,0 +1,78 @@
+-- Mention Queries
+
+-- name: ResolveMentionedUsers :many
+SELECT
+    u.id,
+    u.username,
+    COALESCE(u.settings->>'allow_mentions_from', 'everyone')::text AS allow_mentions_from,
+    EXISTS(
+        SELECT 1 FROM follows f
+        WHERE f.follower_id = u.id AND f.following_id = sqlc.arg(author_id)
+    ) AS follows_author,
+    (sqlc.narg(post_id)::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM posts p
+        WHERE p.id = sqlc.narg(post_id)::uuid
+          AND can_view_post(u.id, p.author_id, p.visibility, p.community_id, p.group_id)
+    ))::bool AS can_view_post
+FROM users u
+WHERE lower(u.username) = ANY(sqlc.arg(usernames)::text[])
+  AND u.space_id = (SELECT a.space_id FROM users a WHERE a.id = sqlc.arg(author_id))
+  AND u.status = 'active'
+  AND NOT is_blocked_between(u.id, sqlc.arg(author_id))
+  AND (sqlc.narg(conversation_id)::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM conversation_participants cp
+      WHERE cp.conversation_id = sqlc.narg(conversation_id)::uuid
+        AND cp.user_id = u.id
+        AND cp.left_at IS NULL
+  ));
+
+-- name: CreateMentions :many
+INSERT INTO mentions (source_type, source_id, author_id, mentioned_user_id, start_offset, length)
+SELECT
+    sqlc.arg(source_type)::varchar,
+    sqlc.arg(source_id)::uuid,
+    sqlc.arg(author_id)::uuid,
+    m.user_id,
+    m.start_offset,
+    m.length
+FROM unnest(
+    sqlc.arg(user_ids)::uuid[],
+    sqlc.arg(offsets)::int[],
+    sqlc.arg(lengths)::int[]
+) AS m(user_id, start_offset, length)
+ON CONFLICT (source_type, source_id, start_offset) DO NOTHING
+RETURNING *;
+
+-- name: DeleteMentionsBySource :exec
+DELETE FROM mentions WHERE source_type = $1 AND source_id = $2;
+
+-- name: GetMentionsBySources :many
+SELECT
+    m.source_id,
+    m.mentioned_user_id,
+    u.username,
+    m.start_offset,
+    m.length
+FROM mentions m
+JOIN users u ON m.mentioned_user_id = u.id
+WHERE m.source_type = sqlc.arg(source_type) AND m.source_id = ANY(sqlc.arg(source_ids)::uuid[])
+ORDER BY m.source_id, m.start_offset;
+
+-- name: SearchMentionCandidates :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    EXISTS(
+        SELECT 1 FROM follows f
+        WHERE f.follower_id = sqlc.arg(user_id) AND f.following_id = u.id
+    ) AS is_following
+FROM users u
+WHERE u.space_id = (SELECT a.space_id FROM users a WHERE a.id = sqlc.arg(user_id))
+  AND u.id <> sqlc.arg(user_id)
+  AND u.status = 'active'
+  AND NOT is_blocked_between(u.id, sqlc.arg(user_id))
+  AND (lower(u.username) LIKE sqlc.arg(prefix)::text || '%' OR lower(u.full_name) LIKE sqlc.arg(prefix)::text || '%')
+ORDER BY is_following DESC, (lower(u.username) = sqlc.arg(prefix)::text) DESC, u.username ASC
+LIMIT sqlc.arg(page_limit);
@@ -0,0 +1,33 @@
+-- name: GetConversationParticipantRole :one
+SELECT COALESCE(role, 'member')::text AS role
+FROM conversation_participants
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id) AND is_active = true;
+
+-- name: EditMessage :one
+WITH previous AS (
+    SELECT id, content
+    FROM messages
+    WHERE id = sqlc.arg(id) AND COALESCE(status, 'sent') <> 'deleted'
+    FOR UPDATE
+), revision AS (
+    INSERT INTO message_revisions (message_id, content, edited_by)
+    SELECT previous.id, previous.content, sqlc.arg(edited_by)
+    FROM previous
+)
+UPDATE messages m
+SET content = sqlc.arg(content), edited_at = NOW()
+FROM previous
+WHERE m.id = previous.id
+RETURNING m.*;
+
+-- name: GetMessageRevisions :many
+SELECT
+    r.id,
+    r.content,
+    r.edited_by,
+    u.username AS editor_username,
+    r.created_at
+FROM message_revisions r
+JOIN users u ON r.edited_by = u.id
+WHERE r.message_id = sqlc.arg(message_id)
+ORDER BY r.created_at DESC, r.id DESC;
@@ -0,0 +1,74 @@
+-- name: GetDirectMessagePolicy :one
+SELECT
+    COALESCE(u.settings->>'allow_messages_from', 'connections')::text AS allow_messages_from,
+    (
+        EXISTS(
+            SELECT 1 FROM follows f
+            WHERE f.follower_id = u.id AND f.following_id = sqlc.arg(sender_id))
+        OR EXISTS(
+            SELECT 1 FROM group_members a
+            JOIN group_members b ON a.group_id = b.group_id
+            WHERE a.user_id = u.id AND b.user_id = sqlc.arg(sender_id))
+        OR EXISTS(
+            SELECT 1 FROM community_members a
+            JOIN community_members b ON a.community_id = b.community_id
+            WHERE a.user_id = u.id AND b.user_id = sqlc.arg(sender_id))
+    )::bool AS connected
+FROM users u
+WHERE u.id = sqlc.arg(recipient_id);
+
+-- name: AddDirectParticipant :exec
+INSERT INTO conversation_participants (conversation_id, user_id, role, request_status)
+VALUES (sqlc.arg(conversation_id), sqlc.arg(user_id), 'member', sqlc.narg(request_status))
+ON CONFLICT (conversation_id, user_id) DO NOTHING;
+
+-- name: GetParticipantRequestStatus :one
+SELECT COALESCE(request_status, 'accepted')::text AS request_status
+FROM conversation_participants
+WHERE conversation_id = sqlc.arg(conversation_id) AND user_id = sqlc.arg(user_id) AND is_active = true;
+
+-- name: UpdateMessageRequestStatus :execrows
+UPDATE conversation_participants
+SET request_status = sqlc.arg(request_status)
+WHERE conversation_id = sqlc.arg(conversation_id)
+  AND user_id = sqlc.arg(user_id)
+  AND is_active = true
+  AND request_status IN ('pending', 'declined');
+
+-- name: GetConversationPeer :one
+SELECT other.user_id
+FROM conversation_participants other
+WHERE other.conversation_id = sqlc.arg(conversation_id) AND other.user_id <> sqlc.arg(user_id)
+ORDER BY other.joined_at
+LIMIT 1;
+
+-- name: GetMessageRequests :many
+SELECT
+    c.id AS conversation_id,
+    cp.joined_at AS requested_at,
+    u.id AS requester_id,
+    u.username AS requester_username,
+    u.full_name AS requester_full_name,
+    u.avatar AS requester_avatar,
+    last_msg.content AS last_message_content,
+    last_msg.created_at AS last_message_time,
+    (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.sender_id <> cp.user_id) AS message_count
+FROM conversation_participants cp
+JOIN conversations c ON c.id = cp.conversation_id
+JOIN conversation_participants peer ON peer.conversation_id = c.id AND peer.user_id <> cp.user_id
+JOIN users u ON u.id = peer.user_id
+LEFT JOIN LATERAL (
+    SELECT m2.content, m2.created_at
+    FROM messages m2
+    WHERE m2.conversation_id = c.id
+    ORDER BY m2.created_at DESC
+    LIMIT 1
+) last_msg ON true
+WHERE cp.user_id = sqlc.arg(user_id)
+  AND cp.is_active = true
+  AND cp.request_status = 'pending'
+  AND c.is_active = true
+  AND c.conversation_type = 'direct'
+  AND NOT is_blocked_between(cp.user_id, peer.user_id)
+ORDER BY COALESCE(last_msg.created_at, cp.joined_at) DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
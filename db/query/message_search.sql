@@ -0,0 +1,82 @@
+-- name: SearchMessages :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq
+)
+SELECT
+    m.id,
+    m.conversation_id,
+    c.name AS conversation_name,
+    c.conversation_type,
+    m.sender_id,
+    u.username AS sender_username,
+    u.full_name AS sender_full_name,
+    u.avatar AS sender_avatar,
+    m.content,
+    ts_headline('english', html_escape(COALESCE(m.content, '')), q.tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=24, MinWords=8, FragmentDelimiter=" ... "')::text AS highlight,
+    m.attachments,
+    m.message_type,
+    m.created_at,
+    ts_rank(to_tsvector('english', COALESCE(m.content, '')), q.tsq)::float8 AS score
+FROM q, messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id
+JOIN conversations c ON c.id = m.conversation_id
+JOIN users u ON u.id = m.sender_id
+WHERE cp.user_id = sqlc.arg(user_id)
+  AND cp.is_active = true
+  AND c.is_active = true
+  AND COALESCE(cp.request_status, 'accepted') <> 'declined'
+  AND to_tsvector('english', COALESCE(m.content, '')) @@ q.tsq
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND (sqlc.narg(conversation_id)::uuid IS NULL OR m.conversation_id = sqlc.narg(conversation_id))
+  AND (sqlc.narg(sender_id)::uuid IS NULL OR m.sender_id = sqlc.narg(sender_id))
+  AND (sqlc.narg(after)::timestamptz IS NULL OR m.created_at >= sqlc.narg(after))
+  AND (sqlc.narg(before)::timestamptz IS NULL OR m.created_at < sqlc.narg(before))
+  AND (sqlc.narg(has_attachment)::bool IS NULL
+       OR (m.attachments IS NOT NULL AND m.attachments NOT IN ('null'::jsonb, '[]'::jsonb, '{}'::jsonb)) = sqlc.narg(has_attachment))
+  AND (sqlc.narg(cursor_id)::uuid IS NULL
+       OR (ts_rank(to_tsvector('english', COALESCE(m.content, '')), q.tsq)::float8, m.created_at, m.id) < (
+           SELECT ts_rank(to_tsvector('english', COALESCE(cm.content, '')), q.tsq)::float8, cm.created_at, cm.id
+           FROM messages cm
+           WHERE cm.id = sqlc.narg(cursor_id)::uuid
+       ))
+ORDER BY score DESC, m.created_at DESC, m.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: GetMessageSearchContext :many
+SELECT
+    h.id AS hit_id,
+    ctx.position,
+    ctx.id,
+    ctx.sender_id,
+    u.username AS sender_username,
+    ctx.content,
+    ctx.message_type,
+    ctx.created_at
+FROM messages h
+CROSS JOIN LATERAL (
+    (
+        SELECT 'before'::text AS position, b.id, b.sender_id, b.content, b.message_type, b.created_at
+        FROM messages b
+        WHERE b.conversation_id = h.conversation_id
+          AND COALESCE(b.status, 'sent') <> 'deleted'
+          AND (b.expires_at IS NULL OR b.expires_at > NOW())
+          AND (b.created_at, b.id) < (h.created_at, h.id)
+        ORDER BY b.created_at DESC, b.id DESC
+        LIMIT sqlc.arg(context_size)
+    )
+    UNION ALL
+    (
+        SELECT 'after'::text AS position, a.id, a.sender_id, a.content, a.message_type, a.created_at
+        FROM messages a
+        WHERE a.conversation_id = h.conversation_id
+          AND COALESCE(a.status, 'sent') <> 'deleted'
+          AND (a.expires_at IS NULL OR a.expires_at > NOW())
+          AND (a.created_at, a.id) > (h.created_at, h.id)
+        ORDER BY a.created_at ASC, a.id ASC
+        LIMIT sqlc.arg(context_size)
+    )
+) ctx
+JOIN users u ON u.id = ctx.sender_id
+WHERE h.id = ANY(sqlc.arg(hit_ids)::uuid[])
+ORDER BY h.id, ctx.created_at, ctx.id;
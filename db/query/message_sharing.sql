@@ -0,0 +1,34 @@
+-- name: GetSharedPost :one
+SELECT
+    p.id,
+    p.author_id,
+    u.username AS author_username,
+    u.full_name AS author_full_name,
+    u.avatar AS author_avatar,
+    p.content,
+    p.media,
+    p.content_warning,
+    p.created_at
+FROM posts p
+JOIN users u ON u.id = p.author_id
+WHERE p.id = sqlc.arg(id) AND p.status = 'active';
+
+-- name: GetSharedEvent :one
+SELECT *
+FROM events
+WHERE id = sqlc.arg(id) AND COALESCE(status, 'published') = 'published';
+
+-- name: GetSharedCommunity :one
+SELECT *
+FROM communities
+WHERE id = sqlc.arg(id) AND COALESCE(status, 'active') = 'active';
+
+-- name: GetParticipantsWithoutAccess :many
+SELECT cp.user_id
+FROM conversation_participants cp
+WHERE cp.conversation_id = sqlc.arg(conversation_id)
+  AND cp.is_active = true
+  AND NOT can_view_search_document(cp.user_id, sqlc.arg(shared_type)::varchar, sqlc.arg(shared_id)::uuid);
+
+-- name: CanViewSharedContent :one
+SELECT can_view_search_document(sqlc.arg(viewer_id)::uuid, sqlc.arg(shared_type)::varchar, sqlc.arg(shared_id)::uuid)::bool AS can_view;
@@ -0,0 +1,58 @@
+-- name: GetThreadReplies :many
+SELECT
+    m.*,
+    u.username as sender_username,
+    u.full_name as sender_full_name,
+    u.avatar as sender_avatar,
+    reply_msg.content as reply_content,
+    reply_user.username as reply_username
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+LEFT JOIN messages reply_msg ON m.reply_to_id = reply_msg.id
+LEFT JOIN users reply_user ON reply_msg.sender_id = reply_user.id
+WHERE m.thread_root_id = sqlc.arg(root_id)
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (m.created_at, m.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: GetThreadSummaries :many
+SELECT
+    m.thread_root_id::uuid AS root_id,
+    COUNT(*)::bigint AS reply_count,
+    MAX(m.created_at)::timestamptz AS last_reply_at
+FROM messages m
+WHERE m.thread_root_id = ANY(sqlc.arg(root_ids)::uuid[])
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+GROUP BY m.thread_root_id;
+
+-- name: GetThreadLatestRepliers :many
+SELECT root_id, user_id, username, avatar
+FROM (
+    SELECT
+        m.thread_root_id::uuid AS root_id,
+        u.id AS user_id,
+        u.username,
+        u.avatar,
+        MAX(m.created_at) AS last_reply_at,
+        ROW_NUMBER() OVER (PARTITION BY m.thread_root_id ORDER BY MAX(m.created_at) DESC) AS position
+    FROM messages m
+    JOIN users u ON m.sender_id = u.id
+    WHERE m.thread_root_id = ANY(sqlc.arg(root_ids)::uuid[])
+      AND COALESCE(m.status, 'sent') <> 'deleted'
+    GROUP BY m.thread_root_id, u.id, u.username, u.avatar
+) repliers
+WHERE position <= sqlc.arg(per_thread)::int
+ORDER BY root_id, last_reply_at DESC;
+
+-- name: GetThreadParticipantsToNotify :many
+SELECT cp.user_id
+FROM conversation_participants cp
+JOIN messages root ON root.id = sqlc.arg(root_id) AND root.conversation_id = cp.conversation_id
+WHERE cp.is_active = true
+  AND COALESCE(cp.notifications_enabled, true) = true
+  AND (cp.muted_until IS NULL OR cp.muted_until <= NOW())
+  AND cp.user_id <> sqlc.arg(replier_id)
+  AND (cp.user_id = root.sender_id
+       OR EXISTS (SELECT 1 FROM messages r WHERE r.thread_root_id = root.id AND r.sender_id = cp.user_id));
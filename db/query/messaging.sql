@@ -8,7 +8,15 @@ RETURNING *;
 -- name: GetConversationByID :one
 SELECT 
     c.*,
-    (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.recipient_id = $1) as unread_count
+    (SELECT COUNT(*)
+     FROM conversation_participants cp
+     JOIN messages m ON m.conversation_id = cp.conversation_id
+     WHERE cp.conversation_id = c.id AND cp.user_id = $1 AND cp.is_active = true
+       AND m.sender_id <> cp.user_id
+       AND COALESCE(m.status, 'sent') <> 'deleted'
+       AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+       AND (cp.last_read_message_at IS NULL
+            OR (m.created_at, m.id) > (cp.last_read_message_at, cp.last_read_message_id))) as unread_count
 FROM conversations c
 WHERE c.id = $2 AND c.is_active = true;
 
@@ -46,13 +54,25 @@ SELECT
     cp.role as user_role,
     cp.notifications_enabled,
     cp.custom_settings,
-    (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.recipient_id = $1) as unread_count,
+    cp.muted_until,
+    cp.archived_at,
+    unread.unread_count,
     last_msg.content as last_message_content,
     last_msg.created_at as last_message_time,
     last_sender.username as last_sender_username,
     last_sender.full_name as last_sender_full_name
 FROM conversations c
 JOIN conversation_participants cp ON c.id = cp.conversation_id
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS unread_count
+    FROM messages m
+    WHERE m.conversation_id = c.id
+      AND m.sender_id <> cp.user_id
+      AND COALESCE(m.status, 'sent') <> 'deleted'
+      AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+      AND (cp.last_read_message_at IS NULL
+           OR (m.created_at, m.id) > (cp.last_read_message_at, cp.last_read_message_id))
+) unread
 LEFT JOIN LATERAL (
     SELECT m2.content, m2.created_at, m2.sender_id
     FROM messages m2
@@ -61,7 +81,12 @@ LEFT JOIN LATERAL (
     LIMIT 1
 ) last_msg ON true
 LEFT JOIN users last_sender ON last_msg.sender_id = last_sender.id
-WHERE cp.user_id = $1 AND cp.is_active = true AND c.is_active = true
+WHERE cp.user_id = sqlc.arg(user_id) AND cp.is_active = true AND c.is_active = true
+  AND COALESCE(cp.request_status, 'accepted') = 'accepted'
+  AND (sqlc.narg(archived)::bool IS NULL OR (cp.archived_at IS NOT NULL) = sqlc.narg(archived))
+  AND (sqlc.narg(muted)::bool IS NULL
+       OR (COALESCE(cp.notifications_enabled, true) = false OR COALESCE(cp.muted_until > NOW(), false)) = sqlc.narg(muted))
+  AND (sqlc.narg(unread)::bool IS NULL OR (unread.unread_count > 0) = sqlc.narg(unread))
 ORDER BY c.last_message_at DESC NULLS LAST;
 
 -- name: GetConversationParticipants :many
@@ -81,8 +106,17 @@ WHERE cp.conversation_id = $1 AND u.status = 'active'
 ORDER BY cp.joined_at;
 
 -- name: SendMessage :one
-INSERT INTO messages (conversation_id, sender_id, recipient_id, content, attachments, message_type, reply_to_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO messages (conversation_id, sender_id, recipient_id, content, attachments, message_type, reply_to_id, thread_root_id, expires_at,
+    shared_type, shared_id, shared_preview, is_forwarded, forwarded_from_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7,
+    (SELECT COALESCE(p.thread_root_id, p.id) FROM messages p WHERE p.id = $7 AND p.conversation_id = $1),
+    (SELECT NOW() + (c.settings->>'disappearing_messages_seconds')::int * INTERVAL '1 second'
+     FROM conversations c
+     WHERE c.id = $1 AND $6 IS DISTINCT FROM 'system'
+       AND CASE WHEN jsonb_typeof(c.settings->'disappearing_messages_seconds') = 'number'
+                THEN (c.settings->>'disappearing_messages_seconds')::int > 0
+                ELSE false END),
+    $8, $9, $10, $11, $12)
 RETURNING *;
 
 -- name: UpdateConversationLastMessage :exec
@@ -102,18 +136,67 @@ FROM messages m
 JOIN users u ON m.sender_id = u.id
 LEFT JOIN messages reply_msg ON m.reply_to_id = reply_msg.id
 LEFT JOIN users reply_user ON reply_msg.sender_id = reply_user.id
+WHERE m.conversation_id = sqlc.arg(conversation_id)
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (m.created_at, m.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: CountConversationMessages :one
+SELECT COUNT(*) FROM messages m
 WHERE m.conversation_id = $1
-ORDER BY m.created_at ASC
-LIMIT $2 OFFSET $3;
-
--- name: MarkMessagesAsRead :exec
-UPDATE messages 
-SET is_read = true, read_at = NOW()
-WHERE conversation_id = $1 AND recipient_id = $2 AND is_read = false;
+  AND (m.expires_at IS NULL OR m.expires_at > NOW());
+
+-- name: MarkMessagesAsRead :one
+WITH target AS (
+    SELECT m.id, m.created_at
+    FROM messages m
+    WHERE m.conversation_id = sqlc.arg(conversation_id)
+      AND (sqlc.narg(message_id)::uuid IS NULL OR m.id = sqlc.narg(message_id)::uuid)
+    ORDER BY m.created_at DESC, m.id DESC
+    LIMIT 1
+), previous AS (
+    SELECT cp.id, cp.last_read_message_id, cp.last_read_message_at
+    FROM conversation_participants cp
+    WHERE cp.conversation_id = sqlc.arg(conversation_id) AND cp.user_id = sqlc.arg(user_id) AND cp.is_active = true
+)
+UPDATE conversation_participants cp
+SET last_read_message_id = target.id,
+    last_read_message_at = target.created_at,
+    last_read_at = NOW(),
+    last_delivered_message_id = CASE
+        WHEN cp.last_delivered_message_at IS NULL
+          OR (cp.last_delivered_message_at, cp.last_delivered_message_id) < (target.created_at, target.id)
+        THEN target.id ELSE cp.last_delivered_message_id END,
+    last_delivered_message_at = CASE
+        WHEN cp.last_delivered_message_at IS NULL
+          OR (cp.last_delivered_message_at, cp.last_delivered_message_id) < (target.created_at, target.id)
+        THEN target.created_at ELSE cp.last_delivered_message_at END,
+    last_delivered_at = CASE
+        WHEN cp.last_delivered_message_at IS NULL
+          OR (cp.last_delivered_message_at, cp.last_delivered_message_id) < (target.created_at, target.id)
+        THEN NOW() ELSE cp.last_delivered_at END
+FROM target, previous
+WHERE cp.id = previous.id
+  AND (previous.last_read_message_at IS NULL
+       OR (previous.last_read_message_at, previous.last_read_message_id) < (target.created_at, target.id))
+RETURNING
+    previous.last_read_message_id AS previous_message_id,
+    previous.last_read_message_at AS previous_message_at,
+    target.id AS message_id,
+    target.created_at AS message_at;
 
 -- name: GetUnreadMessageCount :one
-SELECT COUNT(*) FROM messages 
-WHERE conversation_id = $1 AND recipient_id = $2 AND is_read = false;
+SELECT COUNT(m.id)::bigint AS unread_count
+FROM conversation_participants cp
+JOIN messages m ON m.conversation_id = cp.conversation_id
+WHERE cp.conversation_id = sqlc.arg(conversation_id) AND cp.user_id = sqlc.arg(user_id) AND cp.is_active = true
+  AND m.sender_id <> cp.user_id
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+  AND (cp.last_read_message_at IS NULL
+       OR (m.created_at, m.id) > (cp.last_read_message_at, cp.last_read_message_id));
 
 -- name: AddMessageReaction :exec
 UPDATE messages 
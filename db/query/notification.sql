@@ -11,9 +11,11 @@ SELECT n.*,
        u.avatar as from_avatar
 FROM notifications n
 LEFT JOIN users u ON n.from_user_id = u.id
-WHERE n.to_user_id = $1
-ORDER BY n.created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE n.to_user_id = sqlc.arg(to_user_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (n.created_at, n.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY n.created_at DESC, n.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
 
 -- name: GetNotification :one
 SELECT * FROM notifications
@@ -53,4 +55,8 @@ WHERE n.to_user_id = $1
   AND (n.priority = $3 OR $3 = '')
   AND (n.is_read = $4 OR $4 IS NULL)
 ORDER BY n.created_at DESC
-LIMIT $5 OFFSET $6;
\ No newline at end of file
+LIMIT $5 OFFSET $6;
+
+-- name: CountUserNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE to_user_id = $1;
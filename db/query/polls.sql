@@ -0,0 +1,75 @@
+-- Poll Queries
+
+-- name: CreatePoll :one
+INSERT INTO polls (post_id, allow_multiple, is_anonymous, closes_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CreatePollOptions :many
+INSERT INTO poll_options (poll_id, position, text)
+SELECT sqlc.arg(poll_id)::uuid, o.position::int, o.text
+FROM unnest(sqlc.arg(options)::text[]) WITH ORDINALITY AS o(text, position)
+RETURNING *;
+
+-- name: GetPollByPostID :one
+SELECT * FROM polls WHERE post_id = $1;
+
+-- name: GetPollsByPostIDs :many
+SELECT
+    p.*,
+    COALESCE((
+        SELECT array_agg(v.option_id) FROM poll_votes v
+        WHERE v.poll_id = p.id AND v.user_id = sqlc.arg(viewer_id)
+    ), '{}')::uuid[] as viewer_option_ids
+FROM polls p
+WHERE p.post_id = ANY(sqlc.arg(post_ids)::uuid[]);
+
+-- name: GetPollOptionsByPollIDs :many
+SELECT * FROM poll_options
+WHERE poll_id = ANY(sqlc.arg(poll_ids)::uuid[])
+ORDER BY poll_id, position;
+
+-- name: CastPollVote :one
+WITH ballot AS (
+    INSERT INTO poll_voters (poll_id, user_id)
+    SELECT p.id, sqlc.arg(user_id)::uuid
+    FROM polls p
+    WHERE p.id = sqlc.arg(poll_id)
+      AND (p.closes_at IS NULL OR p.closes_at > NOW())
+    ON CONFLICT (poll_id, user_id) DO NOTHING
+    RETURNING poll_id, user_id
+),
+votes AS (
+    INSERT INTO poll_votes (poll_id, option_id, user_id)
+    SELECT b.poll_id, o.id, b.user_id
+    FROM ballot b
+    JOIN poll_options o ON o.poll_id = b.poll_id
+    WHERE o.id = ANY(sqlc.arg(option_ids)::uuid[])
+    RETURNING option_id
+),
+bump_options AS (
+    UPDATE poll_options
+    SET votes_count = votes_count + 1
+    WHERE id IN (SELECT option_id FROM votes)
+    RETURNING id
+),
+bump_poll AS (
+    UPDATE polls
+    SET voters_count = voters_count + 1
+    WHERE id IN (SELECT poll_id FROM ballot)
+    RETURNING id
+)
+SELECT COUNT(*) FROM votes;
+
+-- name: GetPollOptionVoters :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    v.created_at
+FROM poll_votes v
+JOIN users u ON v.user_id = u.id
+WHERE v.option_id = $1 AND u.status = 'active'
+ORDER BY v.created_at DESC
+LIMIT $2 OFFSET $3;
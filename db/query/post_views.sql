@@ -0,0 +1,23 @@
+-- name: FlushPostViews :exec
+WITH batch AS (
+    SELECT
+        UNNEST(sqlc.arg(post_ids)::uuid[]) AS post_id,
+        UNNEST(sqlc.arg(days)::text[])::date AS day,
+        UNNEST(sqlc.arg(view_counts)::int[]) AS views
+), daily AS (
+    INSERT INTO post_daily_views (post_id, day, views)
+    SELECT b.post_id, b.day, b.views
+    FROM batch b
+    JOIN posts p ON p.id = b.post_id
+    ON CONFLICT (post_id, day) DO UPDATE SET views = post_daily_views.views + EXCLUDED.views
+)
+UPDATE posts p
+SET views_count = COALESCE(p.views_count, 0) + t.views
+FROM (SELECT post_id, SUM(views)::int AS views FROM batch GROUP BY post_id) t
+WHERE p.id = t.post_id;
+
+-- name: GetPostDailyViews :many
+SELECT day, views
+FROM post_daily_views
+WHERE post_id = sqlc.arg(post_id) AND day >= sqlc.arg(since)::date
+ORDER BY day;
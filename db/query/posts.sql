@@ -3,9 +3,14 @@
 -- name: CreatePost :one
 INSERT INTO posts (
     author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id,
-    content, media, tags, visibility
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING *;
+    content, media, tags, visibility, status, publish_at, content_warning, is_sensitive
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    COALESCE(sqlc.narg(status)::varchar, 'active'), sqlc.narg(publish_at)::timestamptz,
+    sqlc.narg(content_warning), sqlc.arg(is_sensitive)
+)
+RETURNING *
+;
 
 -- name: GetPostByID :one
 SELECT
@@ -23,7 +28,9 @@ SELECT
     COALESCE(NULLIF(qu.username, ''), 'user_' || SUBSTRING(qu.id::text, 1, 8)) as quoted_username,
     COALESCE(NULLIF(qu.full_name, ''), 'User') as quoted_full_name,
     EXISTS(SELECT 1 FROM likes l2 WHERE l2.post_id = p.id AND l2.user_id = $1) as is_liked,
-    EXISTS(SELECT 1 FROM posts pq2 WHERE pq2.quoted_post_id = p.id AND pq2.author_id = $1) as is_quoted,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked,
+    EXISTS(SELECT 1 FROM posts pq2 WHERE pq2.quoted_post_id = p.id AND pq2.author_id = $1 AND pq2.status = 'active') as is_quoted,
+    EXISTS(SELECT 1 FROM posts pr WHERE pr.quoted_post_id = p.id AND pr.author_id = $1 AND pr.content = '' AND pr.status = 'active') as is_reposted,
     (SELECT COUNT(*) FROM likes l3 WHERE l3.post_id = p.id) as actual_likes_count,
     (SELECT COUNT(*) FROM comments c2 WHERE c2.post_id = p.id AND c2.status = 'active') as actual_comments_count,
     (SELECT COUNT(*) FROM posts p2 WHERE p2.quoted_post_id = p.id AND p2.status = 'active') as actual_quotes_count
@@ -31,9 +38,13 @@ FROM posts p
 JOIN users u ON p.author_id = u.id
 LEFT JOIN communities c ON p.community_id = c.id
 LEFT JOIN groups g ON p.group_id = g.id
-LEFT JOIN posts qp ON p.quoted_post_id = qp.id
+LEFT JOIN posts qp ON p.quoted_post_id = qp.id AND qp.status = 'active'
+    AND NOT is_hidden_from($1, qp.author_id)
+    AND can_view_post($1, qp.author_id, qp.visibility, qp.community_id, qp.group_id)
 LEFT JOIN users qu ON qp.author_id = qu.id
-WHERE p.id = $2 AND p.status = 'active';
+WHERE p.id = $2 AND p.status = 'active'
+  AND NOT is_hidden_from($1, p.author_id)
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id);
 
 -- name: GetUserFeed :many
 SELECT
@@ -44,21 +55,28 @@ SELECT
     u.verified as author_verified,
     c.name as community_name,
     g.name as group_name,
-    EXISTS(SELECT 1 FROM likes l4 WHERE l4.post_id = p.id AND l4.user_id = $1) as is_liked,
-    EXISTS(SELECT 1 FROM posts pq3 WHERE pq3.quoted_post_id = p.id AND pq3.author_id = $1) as is_quoted
+    EXISTS(SELECT 1 FROM likes l4 WHERE l4.post_id = p.id AND l4.user_id = sqlc.arg(user_id)) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = sqlc.arg(user_id)) as is_bookmarked,
+    EXISTS(SELECT 1 FROM posts pq3 WHERE pq3.quoted_post_id = p.id AND pq3.author_id = sqlc.arg(user_id)) as is_quoted
 FROM posts p
 JOIN users u ON p.author_id = u.id
 LEFT JOIN communities c ON p.community_id = c.id
 LEFT JOIN groups g ON p.group_id = g.id
-WHERE p.space_id = $2
+WHERE p.space_id = sqlc.arg(space_id)
   AND p.status = 'active'
-  AND (p.visibility = 'public'
-       OR p.author_id = $1
-       OR p.author_id IN (SELECT following_id FROM follows WHERE follower_id = $1)
-       OR p.community_id IN (SELECT community_id FROM community_members WHERE user_id = $1)
-       OR p.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1))
-ORDER BY p.created_at DESC
-LIMIT $3 OFFSET $4;
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (p.created_at, p.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUserFeed :one
+SELECT COUNT(*) FROM posts p
+WHERE p.space_id = sqlc.arg(space_id)
+  AND p.status = 'active'
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id);
 
 -- name: GetUserPosts :many
 SELECT
@@ -68,14 +86,25 @@ SELECT
     u.avatar as author_avatar,
     c.name as community_name,
     g.name as group_name,
-    EXISTS(SELECT 1 FROM likes l5 WHERE l5.post_id = p.id AND l5.user_id = $1) as is_liked
+    EXISTS(SELECT 1 FROM likes l5 WHERE l5.post_id = p.id AND l5.user_id = sqlc.arg(viewer_id)) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = sqlc.arg(viewer_id)) as is_bookmarked
 FROM posts p
 JOIN users u ON p.author_id = u.id
 LEFT JOIN communities c ON p.community_id = c.id
 LEFT JOIN groups g ON p.group_id = g.id
-WHERE p.author_id = $1 AND p.status = 'active'
-ORDER BY p.created_at DESC
-LIMIT $2 OFFSET $3;
+WHERE p.author_id = sqlc.arg(author_id) AND p.status = 'active'
+  AND can_view_post(sqlc.arg(viewer_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), p.author_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (p.created_at, p.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: CountUserPosts :one
+SELECT COUNT(*) FROM posts p
+WHERE p.author_id = sqlc.arg(author_id) AND p.status = 'active'
+  AND can_view_post(sqlc.arg(viewer_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), p.author_id);
 
 -- name: GetCommunityPosts :many
 SELECT
@@ -83,12 +112,23 @@ SELECT
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
-    EXISTS(SELECT 1 FROM likes l6 WHERE l6.post_id = p.id AND l6.user_id = $1) as is_liked
+    EXISTS(SELECT 1 FROM likes l6 WHERE l6.post_id = p.id AND l6.user_id = sqlc.arg(user_id)) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = sqlc.arg(user_id)) as is_bookmarked
 FROM posts p
 JOIN users u ON p.author_id = u.id
-WHERE p.community_id = $2 AND p.status = 'active'
-ORDER BY p.is_pinned DESC, p.created_at DESC
-LIMIT $3 OFFSET $4;
+WHERE p.community_id = sqlc.arg(community_id) AND p.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id)
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (p.created_at, p.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY (sqlc.arg(pinned_first)::bool AND COALESCE(p.is_pinned, false)) DESC, p.created_at DESC, p.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: CountCommunityPosts :one
+SELECT COUNT(*) FROM posts p
+WHERE p.community_id = sqlc.arg(community_id) AND p.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id)
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id);
 
 -- name: GetGroupPosts :many
 SELECT
@@ -96,12 +136,23 @@ SELECT
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
-    EXISTS(SELECT 1 FROM likes l7 WHERE l7.post_id = p.id AND l7.user_id = $1) as is_liked
+    EXISTS(SELECT 1 FROM likes l7 WHERE l7.post_id = p.id AND l7.user_id = sqlc.arg(user_id)) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = sqlc.arg(user_id)) as is_bookmarked
 FROM posts p
 JOIN users u ON p.author_id = u.id
-WHERE p.group_id = $2 AND p.status = 'active'
-ORDER BY p.is_pinned DESC, p.created_at DESC
-LIMIT $3 OFFSET $4;
+WHERE p.group_id = sqlc.arg(group_id) AND p.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id)
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (p.created_at, p.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY (sqlc.arg(pinned_first)::bool AND COALESCE(p.is_pinned, false)) DESC, p.created_at DESC, p.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: CountGroupPosts :one
+SELECT COUNT(*) FROM posts p
+WHERE p.group_id = sqlc.arg(group_id) AND p.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(user_id), p.author_id)
+  AND can_view_post(sqlc.arg(user_id), p.author_id, p.visibility, p.community_id, p.group_id);
 
 -- name: GetTrendingPosts :many
 SELECT
@@ -115,6 +166,8 @@ JOIN users u ON p.author_id = u.id
 WHERE p.space_id = $1
   AND p.status = 'active'
   AND p.created_at >= NOW() - INTERVAL '7 days'
+  AND NOT is_hidden_from($2, p.author_id)
+  AND can_view_post($2, p.author_id, p.visibility, p.community_id, p.group_id)
 ORDER BY engagement_score DESC, p.created_at DESC
 LIMIT 20;
 
@@ -134,12 +187,11 @@ LEFT JOIN groups g ON p.group_id = g.id
 WHERE p.space_id = $1
   AND p.status = 'active'
   AND (p.content ILIKE $3 OR p.tags @> ARRAY[$2]::text[] OR to_tsvector('english', p.content) @@ plainto_tsquery('english', $2))
+  AND NOT is_hidden_from($6, p.author_id)
+  AND can_view_post($6, p.author_id, p.visibility, p.community_id, p.group_id)
 ORDER BY rank DESC, p.created_at DESC
 LIMIT $4 OFFSET $5;
 
--- name: IncrementPostViews :exec
-UPDATE posts SET views_count = views_count + 1 WHERE id = $1;
-
 -- name: TogglePostLike :one
 WITH like_action AS (
     INSERT INTO likes (user_id, post_id) 
@@ -158,22 +210,34 @@ WHERE posts.id = $2
 RETURNING likes_count;
 
 -- name: CreateComment :one
-INSERT INTO comments (post_id, author_id, parent_comment_id, content)
-VALUES ($1, $2, $3, $4)
+INSERT INTO comments (post_id, author_id, parent_comment_id, content, content_warning, is_sensitive)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetPostComments :many
-WITH RECURSIVE comment_tree AS (
+WITH RECURSIVE roots AS (
+    SELECT c.id
+    FROM comments c
+    WHERE c.post_id = sqlc.arg(post_id) AND c.parent_comment_id IS NULL AND c.status = 'active'
+      AND NOT is_hidden_from(sqlc.arg(viewer_id), c.author_id)
+      AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+           OR (c.created_at, c.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+    ORDER BY c.created_at ASC, c.id ASC
+    LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset)
+),
+comment_tree AS (
     SELECT 
         c.*, 
         u.username,
         u.full_name,
         u.avatar,
         0 as depth,
-        ARRAY[c.id] as path
+        ARRAY[c.id] as path,
+        c.id as root_id,
+        c.created_at as root_created_at
     FROM comments c
     JOIN users u ON c.author_id = u.id
-    WHERE c.post_id = $1 AND c.parent_comment_id IS NULL AND c.status = 'active'
+    WHERE c.id IN (SELECT id FROM roots)
     
     UNION ALL
     
@@ -183,14 +247,22 @@ WITH RECURSIVE comment_tree AS (
         u.full_name,
         u.avatar,
         ct.depth + 1 as depth,
-        ct.path || c.id as path
+        ct.path || c.id as path,
+        ct.root_id,
+        ct.root_created_at
     FROM comments c
     JOIN users u ON c.author_id = u.id
     JOIN comment_tree ct ON c.parent_comment_id = ct.id
     WHERE c.status = 'active'
+      AND NOT is_hidden_from(sqlc.arg(viewer_id), c.author_id)
 )
 SELECT * FROM comment_tree
-ORDER BY path, created_at;
+ORDER BY root_created_at, root_id, path, created_at;
+
+-- name: CountTopLevelComments :one
+SELECT COUNT(*) FROM comments c
+WHERE c.post_id = sqlc.arg(post_id) AND c.parent_comment_id IS NULL AND c.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), c.author_id);
 
 -- name: ToggleCommentLike :one
 INSERT INTO likes (user_id, comment_id) 
@@ -220,6 +292,8 @@ ORDER BY l.created_at DESC;
 -- name: CreateRepost :one
 INSERT INTO posts (author_id, space_id, quoted_post_id, content, visibility)
 VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (author_id, quoted_post_id) WHERE quoted_post_id IS NOT NULL AND content = '' AND status = 'active'
+DO NOTHING
 RETURNING *;
 
 -- name: GetUserLikedPosts :many
@@ -260,29 +334,201 @@ WHERE p.space_id = $2
     OR p.content ILIKE '%' || $1 || '%'
     OR p.tags @> ARRAY[$1]::text[]
   )
+  AND NOT is_hidden_from($5, p.author_id)
+  AND can_view_post($5, p.author_id, p.visibility, p.community_id, p.group_id)
 ORDER BY relevance_score DESC, p.created_at DESC
 LIMIT $3 OFFSET $4;
 
 -- name: GetTrendingTopics :many
-WITH hashtags AS (
-  SELECT
-    unnest(regexp_matches(content, '#([a-zA-Z0-9_]+)', 'g')) AS tag,
-    p.id,
-    p.created_at,
-    COALESCE(p.likes_count, 0) + COALESCE(p.comments_count, 0) + COALESCE(p.reposts_count, 0) AS engagement
-  FROM posts p
-  WHERE p.space_id = $1
-    AND p.status = 'active'
-    AND p.created_at > NOW() - INTERVAL '24 hours'
+SELECT
+    tt.name::varchar AS id,
+    '#' || tt.name AS name,
+    COALESCE(tt.category, 'hashtag')::varchar AS category,
+    COALESCE(tt.post_count, 0)::bigint AS posts_count,
+    COALESCE(tt.trend_score, 0)::float8 AS trend_score,
+    tt.recorded_at
+FROM trending_topics tt
+WHERE tt.space_id = $1 AND tt.period = $2
+ORDER BY tt.trend_score DESC, tt.name ASC
+LIMIT $3 OFFSET $4;
+
+-- name: GetCommentByID :one
+SELECT * FROM comments WHERE id = $1;
+
+-- name: UpdateComment :one
+UPDATE comments
+SET content = $1, updated_at = NOW()
+WHERE id = $2 AND author_id = $3 AND status = 'active'
+RETURNING *;
+
+-- name: SoftDeleteComment :one
+UPDATE comments
+SET status = 'deleted', content = '', updated_at = NOW()
+WHERE id = $1 AND author_id = $2 AND status = 'active'
+RETURNING *;
+
+-- name: GetTopLevelComments :many
+SELECT
+    c.*,
+    u.username,
+    u.full_name,
+    u.avatar,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active') as reply_count,
+    EXISTS(SELECT 1 FROM likes l WHERE l.comment_id = c.id AND l.user_id = sqlc.arg(viewer_id)) as is_liked
+FROM comments c
+JOIN users u ON c.author_id = u.id
+WHERE c.post_id = sqlc.arg(post_id)
+  AND c.parent_comment_id IS NULL
+  AND (c.status = 'active' OR EXISTS (
+      SELECT 1 FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active'
+  ))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (c.created_at, c.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), c.author_id)
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT sqlc.arg(page_size);
+
+-- name: GetCommentReplies :many
+SELECT
+    c.*,
+    u.username,
+    u.full_name,
+    u.avatar,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active') as reply_count,
+    EXISTS(SELECT 1 FROM likes l WHERE l.comment_id = c.id AND l.user_id = sqlc.arg(viewer_id)) as is_liked
+FROM comments c
+JOIN users u ON c.author_id = u.id
+WHERE c.parent_comment_id = sqlc.arg(parent_comment_id)::uuid
+  AND (c.status = 'active' OR EXISTS (
+      SELECT 1 FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active'
+  ))
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (c.created_at, c.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), c.author_id)
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT sqlc.arg(page_size);
+
+-- name: GetPendingPosts :many
+SELECT * FROM posts
+WHERE author_id = sqlc.arg(author_id)
+  AND status IN ('draft', 'scheduled')
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+ORDER BY publish_at ASC NULLS LAST, created_at DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: GetPendingPost :one
+SELECT * FROM posts
+WHERE id = $1 AND author_id = $2 AND status IN ('draft', 'scheduled');
+
+-- name: UpdatePendingPost :one
+UPDATE posts
+SET content = $1, tags = $2, visibility = $3, status = $4, publish_at = $5, updated_at = NOW()
+WHERE id = $6 AND author_id = $7 AND status IN ('draft', 'scheduled')
+RETURNING *;
+
+-- name: PublishPendingPost :one
+UPDATE posts
+SET status = 'active', created_at = NOW()
+WHERE id = $1 AND author_id = $2 AND status IN ('draft', 'scheduled')
+RETURNING *;
+
+-- name: PublishDuePosts :many
+UPDATE posts
+SET status = 'active', created_at = NOW()
+WHERE id IN (
+    SELECT sp.id FROM posts sp
+    WHERE sp.status = 'scheduled' AND sp.publish_at <= NOW()
+    ORDER BY sp.publish_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: GetRankedFeed :many
+WITH candidates AS (
+    SELECT
+        p.id,
+        p.author_id,
+        p.community_id,
+        p.group_id,
+        GREATEST(EXTRACT(EPOCH FROM (sqlc.arg(snapshot_at)::timestamptz - p.created_at)) / 3600.0, 0)::float8 AS age_hours
+    FROM posts p
+    WHERE p.space_id = sqlc.arg(space_id)
+      AND p.status = 'active'
+      AND p.created_at <= sqlc.arg(snapshot_at)::timestamptz
+      AND p.created_at > sqlc.arg(snapshot_at)::timestamptz - make_interval(hours => sqlc.arg(window_hours)::int)
+      AND can_view_post(sqlc.arg(viewer_id), p.author_id, p.visibility, p.community_id, p.group_id)
+      AND NOT is_hidden_from(sqlc.arg(viewer_id), p.author_id)
+),
+signals AS (
+    SELECT
+        c.*,
+        EXISTS(SELECT 1 FROM follows f WHERE f.follower_id = sqlc.arg(viewer_id) AND f.following_id = c.author_id) AS is_following,
+        (
+            (SELECT COUNT(*) FROM likes l JOIN posts ap ON l.post_id = ap.id
+             WHERE l.user_id = sqlc.arg(viewer_id) AND ap.author_id = c.author_id
+               AND l.created_at <= sqlc.arg(snapshot_at)::timestamptz
+               AND l.created_at > sqlc.arg(snapshot_at)::timestamptz - INTERVAL '30 days')
+            +
+            (SELECT COUNT(*) FROM comments cm JOIN posts ap ON cm.post_id = ap.id
+             WHERE cm.author_id = sqlc.arg(viewer_id) AND ap.author_id = c.author_id AND cm.status = 'active'
+               AND cm.created_at <= sqlc.arg(snapshot_at)::timestamptz
+               AND cm.created_at > sqlc.arg(snapshot_at)::timestamptz - INTERVAL '30 days')
+        ) AS interaction_count,
+        (SELECT COUNT(*) FROM likes l WHERE l.post_id = c.id AND l.created_at <= sqlc.arg(snapshot_at)::timestamptz) AS like_count,
+        (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = c.id AND cm.status = 'active' AND cm.created_at <= sqlc.arg(snapshot_at)::timestamptz) AS comment_count,
+        (SELECT COUNT(*) FROM posts rp WHERE rp.quoted_post_id = c.id AND rp.status = 'active' AND rp.created_at <= sqlc.arg(snapshot_at)::timestamptz) AS repost_count,
+        (EXISTS(SELECT 1 FROM community_members cmm WHERE cmm.community_id = c.community_id AND cmm.user_id = sqlc.arg(viewer_id))
+         OR EXISTS(SELECT 1 FROM group_members gm WHERE gm.group_id = c.group_id AND gm.user_id = sqlc.arg(viewer_id))) AS is_member
+    FROM candidates c
+),
+scored AS (
+    SELECT
+        s.*,
+        POWER(0.5, s.age_hours / sqlc.arg(half_life_hours)::float8)::float8 AS recency_score,
+        (CASE WHEN s.author_id = sqlc.arg(viewer_id) THEN 0
+              ELSE (CASE WHEN s.is_following THEN 1 ELSE 0 END) + LN(1 + s.interaction_count) END)::float8 AS affinity_score,
+        LN(1 + (s.like_count + 2 * s.comment_count + 3 * s.repost_count) / GREATEST(s.age_hours, 1))::float8 AS velocity_score,
+        (CASE WHEN s.is_member THEN 1 ELSE 0 END)::float8 AS membership_score
+    FROM signals s
+),
+ranked AS (
+    SELECT
+        sc.*,
+        (sc.recency_score * (1
+            + sqlc.arg(affinity_weight)::float8 * sc.affinity_score
+            + sqlc.arg(velocity_weight)::float8 * sc.velocity_score
+            + sqlc.arg(membership_weight)::float8 * sc.membership_score))::float8 AS score
+    FROM scored sc
 )
 SELECT
-  lower(tag) AS id,
-  '#' || tag AS name,
-  'General' AS category,
-  COUNT(DISTINCT id) AS posts_count,
-  -- Trend score: posts * avg_engagement * recency_factor
-  COUNT(*) * AVG(engagement)::int AS trend_score
-FROM hashtags
-GROUP BY lower(tag), tag
-ORDER BY trend_score DESC
-LIMIT $2 OFFSET $3;
\ No newline at end of file
+    p.*,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    u.verified as author_verified,
+    co.name as community_name,
+    g.name as group_name,
+    EXISTS(SELECT 1 FROM likes lk WHERE lk.post_id = p.id AND lk.user_id = sqlc.arg(viewer_id)) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = sqlc.arg(viewer_id)) as is_bookmarked,
+    r.age_hours,
+    r.is_following,
+    r.interaction_count::int AS interaction_count,
+    r.like_count::int AS like_count,
+    r.comment_count::int AS comment_count,
+    r.repost_count::int AS repost_count,
+    r.is_member,
+    r.recency_score,
+    r.affinity_score,
+    r.velocity_score,
+    r.membership_score,
+    r.score
+FROM ranked r
+JOIN posts p ON p.id = r.id
+JOIN users u ON p.author_id = u.id
+LEFT JOIN communities co ON p.community_id = co.id
+LEFT JOIN groups g ON p.group_id = g.id
+WHERE (sqlc.narg(cursor_score)::float8 IS NULL
+       OR (r.score, r.id) < (sqlc.narg(cursor_score)::float8, sqlc.narg(cursor_id)::uuid))
+ORDER BY r.score DESC, r.id DESC
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,103 @@
+-- name: UpsertPostReaction :one
+WITH previous AS (
+    SELECT reaction FROM likes WHERE user_id = sqlc.arg(user_id) AND post_id = sqlc.arg(post_id)
+), upserted AS (
+    INSERT INTO likes (user_id, post_id, reaction)
+    VALUES (sqlc.arg(user_id), sqlc.arg(post_id), sqlc.arg(reaction))
+    ON CONFLICT (user_id, post_id) DO UPDATE SET reaction = EXCLUDED.reaction
+    RETURNING (xmax = 0) AS inserted
+)
+UPDATE posts
+SET likes_count = COALESCE(likes_count, 0) + CASE WHEN (SELECT inserted FROM upserted) THEN 1 ELSE 0 END
+WHERE posts.id = sqlc.arg(post_id)
+RETURNING likes_count, (SELECT reaction FROM previous)::text AS previous_reaction;
+
+-- name: RemovePostReaction :one
+WITH removed AS (
+    DELETE FROM likes
+    WHERE user_id = sqlc.arg(user_id) AND post_id = sqlc.arg(post_id)
+    RETURNING reaction
+)
+UPDATE posts
+SET likes_count = GREATEST(COALESCE(likes_count, 0) - (SELECT COUNT(*) FROM removed), 0)
+WHERE posts.id = sqlc.arg(post_id)
+RETURNING likes_count, (SELECT reaction FROM removed)::text AS removed_reaction;
+
+-- name: GetPostReactionCounts :many
+SELECT
+    l.post_id::uuid AS post_id,
+    l.reaction,
+    COUNT(*) AS reaction_count,
+    BOOL_OR(l.user_id = sqlc.arg(viewer_id))::bool AS viewer_reacted
+FROM likes l
+WHERE l.post_id = ANY(sqlc.arg(post_ids)::uuid[])
+GROUP BY l.post_id, l.reaction
+ORDER BY l.post_id, reaction_count DESC, l.reaction;
+
+-- name: GetPostReactors :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    l.reaction,
+    l.created_at AS reacted_at
+FROM likes l
+JOIN users u ON l.user_id = u.id
+WHERE l.post_id = sqlc.arg(post_id)
+  AND (sqlc.narg(reaction)::text IS NULL OR l.reaction = sqlc.narg(reaction)::text)
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), u.id)
+ORDER BY l.created_at DESC, l.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: UpsertCommentReaction :one
+WITH previous AS (
+    SELECT reaction FROM likes WHERE user_id = sqlc.arg(user_id) AND comment_id = sqlc.arg(comment_id)
+), upserted AS (
+    INSERT INTO likes (user_id, comment_id, reaction)
+    VALUES (sqlc.arg(user_id), sqlc.arg(comment_id), sqlc.arg(reaction))
+    ON CONFLICT (user_id, comment_id) DO UPDATE SET reaction = EXCLUDED.reaction
+    RETURNING (xmax = 0) AS inserted
+)
+UPDATE comments
+SET likes_count = COALESCE(likes_count, 0) + CASE WHEN (SELECT inserted FROM upserted) THEN 1 ELSE 0 END
+WHERE comments.id = sqlc.arg(comment_id)
+RETURNING likes_count, (SELECT reaction FROM previous)::text AS previous_reaction;
+
+-- name: RemoveCommentReaction :one
+WITH removed AS (
+    DELETE FROM likes
+    WHERE user_id = sqlc.arg(user_id) AND comment_id = sqlc.arg(comment_id)
+    RETURNING reaction
+)
+UPDATE comments
+SET likes_count = GREATEST(COALESCE(likes_count, 0) - (SELECT COUNT(*) FROM removed), 0)
+WHERE comments.id = sqlc.arg(comment_id)
+RETURNING likes_count, (SELECT reaction FROM removed)::text AS removed_reaction;
+
+-- name: GetCommentReactionCounts :many
+SELECT
+    l.comment_id::uuid AS comment_id,
+    l.reaction,
+    COUNT(*) AS reaction_count,
+    BOOL_OR(l.user_id = sqlc.arg(viewer_id))::bool AS viewer_reacted
+FROM likes l
+WHERE l.comment_id = ANY(sqlc.arg(comment_ids)::uuid[])
+GROUP BY l.comment_id, l.reaction
+ORDER BY l.comment_id, reaction_count DESC, l.reaction;
+
+-- name: GetCommentReactors :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    l.reaction,
+    l.created_at AS reacted_at
+FROM likes l
+JOIN users u ON l.user_id = u.id
+WHERE l.comment_id = sqlc.arg(comment_id)
+  AND (sqlc.narg(reaction)::text IS NULL OR l.reaction = sqlc.narg(reaction)::text)
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), u.id)
+ORDER BY l.created_at DESC, l.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
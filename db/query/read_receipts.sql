@@ -0,0 +1,74 @@
+-- name: MarkMessagesAsDelivered :one
+WITH target AS (
+    SELECT m.id, m.created_at
+    FROM messages m
+    WHERE m.conversation_id = sqlc.arg(conversation_id)
+      AND (sqlc.narg(message_id)::uuid IS NULL OR m.id = sqlc.narg(message_id)::uuid)
+    ORDER BY m.created_at DESC, m.id DESC
+    LIMIT 1
+), previous AS (
+    SELECT cp.id, cp.last_delivered_message_id, cp.last_delivered_message_at
+    FROM conversation_participants cp
+    WHERE cp.conversation_id = sqlc.arg(conversation_id) AND cp.user_id = sqlc.arg(user_id) AND cp.is_active = true
+)
+UPDATE conversation_participants cp
+SET last_delivered_message_id = target.id,
+    last_delivered_message_at = target.created_at,
+    last_delivered_at = NOW()
+FROM target, previous
+WHERE cp.id = previous.id
+  AND (previous.last_delivered_message_at IS NULL
+       OR (previous.last_delivered_message_at, previous.last_delivered_message_id) < (target.created_at, target.id))
+RETURNING
+    previous.last_delivered_message_id AS previous_message_id,
+    previous.last_delivered_message_at AS previous_message_at,
+    target.id AS message_id,
+    target.created_at AS message_at;
+
+-- name: GetMessageIDsInCursorRange :many
+SELECT m.id
+FROM messages m
+WHERE m.conversation_id = sqlc.arg(conversation_id)
+  AND m.sender_id <> sqlc.arg(user_id)
+  AND (sqlc.narg(after_at)::timestamptz IS NULL
+       OR (m.created_at, m.id) > (sqlc.narg(after_at)::timestamptz, sqlc.narg(after_id)::uuid))
+  AND (m.created_at, m.id) <= (sqlc.arg(upto_at)::timestamptz, sqlc.arg(upto_id)::uuid)
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT 100;
+
+-- name: GetMessageReceiptCounts :many
+SELECT
+    m.id AS message_id,
+    COUNT(cp.user_id)::bigint AS recipient_count,
+    COUNT(cp.user_id) FILTER (
+        WHERE (cp.last_delivered_message_at, cp.last_delivered_message_id) >= (m.created_at, m.id)
+    )::bigint AS delivered_count,
+    COUNT(cp.user_id) FILTER (
+        WHERE (cp.last_read_message_at, cp.last_read_message_id) >= (m.created_at, m.id)
+          AND COALESCE(cp.request_status, 'accepted') = 'accepted'
+    )::bigint AS read_count
+FROM messages m
+LEFT JOIN conversation_participants cp
+    ON cp.conversation_id = m.conversation_id
+   AND cp.user_id <> m.sender_id
+   AND cp.is_active = true
+   AND COALESCE(cp.joined_at, '-infinity'::timestamptz) <= m.created_at
+WHERE m.id = ANY(sqlc.arg(message_ids)::uuid[])
+GROUP BY m.id;
+
+-- name: GetMessageSeenBy :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    cp.last_read_at
+FROM messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id
+JOIN users u ON cp.user_id = u.id
+WHERE m.id = sqlc.arg(message_id)
+  AND cp.user_id <> m.sender_id
+  AND cp.is_active = true
+  AND COALESCE(cp.request_status, 'accepted') = 'accepted'
+  AND (cp.last_read_message_at, cp.last_read_message_id) >= (m.created_at, m.id)
+ORDER BY cp.last_read_at DESC;
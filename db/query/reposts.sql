@@ -0,0 +1,50 @@
+-- name: RemoveRepost :one
+UPDATE posts SET status = 'removed', updated_at = NOW()
+WHERE author_id = sqlc.arg(author_id)
+  AND quoted_post_id = sqlc.arg(quoted_post_id)::uuid
+  AND content = ''
+  AND status = 'active'
+RETURNING *;
+
+-- name: GetPostQuotes :many
+SELECT
+    p.*,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    c.name as community_name,
+    g.name as group_name,
+    EXISTS(SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = sqlc.arg(viewer_id)) as is_liked
+FROM posts p
+JOIN users u ON p.author_id = u.id
+LEFT JOIN communities c ON p.community_id = c.id
+LEFT JOIN groups g ON p.group_id = g.id
+WHERE p.quoted_post_id = sqlc.arg(quoted_post_id)::uuid
+  AND p.content <> ''
+  AND p.status = 'active'
+  AND can_view_post(sqlc.arg(viewer_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), p.author_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (p.created_at, p.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: GetQuotedPostPreviews :many
+SELECT
+    qp.id,
+    qp.author_id,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8))::text as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User')::text as full_name,
+    u.avatar as author_avatar,
+    u.verified as author_verified,
+    qp.content,
+    qp.media,
+    qp.created_at,
+    qp.content_warning,
+    qp.is_sensitive
+FROM posts qp
+JOIN users u ON qp.author_id = u.id
+WHERE qp.id = ANY(sqlc.arg(post_ids)::uuid[])
+  AND qp.status = 'active'
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), qp.author_id)
+  AND can_view_post(sqlc.arg(viewer_id), qp.author_id, qp.visibility, qp.community_id, qp.group_id);
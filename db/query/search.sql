@@ -0,0 +1,60 @@
+-- name: SearchDocuments :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq
+),
+matches AS (
+    SELECT
+        d.entity_type,
+        d.entity_id,
+        d.title,
+        d.subtitle,
+        d.image,
+        d.body,
+        d.created_at,
+        COALESCE(d.created_at, '-infinity'::timestamptz) AS sort_at,
+        (ts_rank_cd(d.search_vector, q.tsq, 32) + word_similarity(sqlc.arg(query)::text, d.title))::float8 AS score,
+        wp.content_warning,
+        COALESCE(wp.is_sensitive, false)::bool AS is_sensitive
+    FROM search_documents d
+    CROSS JOIN q
+    LEFT JOIN posts wp ON d.entity_type = 'post' AND wp.id = d.entity_id
+    WHERE d.space_id = sqlc.arg(space_id)
+      AND (cardinality(sqlc.arg(entity_types)::text[]) = 0 OR d.entity_type = ANY(sqlc.arg(entity_types)::text[]))
+      AND (d.search_vector @@ q.tsq OR d.title % sqlc.arg(query)::text OR sqlc.arg(query)::text <% d.title)
+      AND can_view_search_document(sqlc.arg(viewer_id), d.entity_type, d.entity_id)
+)
+SELECT
+    m.entity_type,
+    m.entity_id,
+    m.title,
+    m.subtitle,
+    m.image,
+    m.created_at,
+    ts_headline('english', html_escape(m.title), q.tsq, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS title_highlight,
+    ts_headline('english', html_escape(m.body), q.tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=24, MinWords=8, FragmentDelimiter=" ... "')::text AS snippet,
+    m.score,
+    m.content_warning,
+    m.is_sensitive
+FROM matches m
+CROSS JOIN q
+WHERE sqlc.narg(cursor_id)::uuid IS NULL
+   OR (m.score, m.sort_at, m.entity_id) < (
+       SELECT c.score, c.sort_at, c.entity_id
+       FROM matches c
+       WHERE c.entity_id = sqlc.narg(cursor_id)::uuid
+       LIMIT 1
+   )
+ORDER BY m.score DESC, m.sort_at DESC, m.entity_id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
+
+-- name: GetSearchFacets :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS tsq
+)
+SELECT d.entity_type, COUNT(*) AS result_count
+FROM search_documents d, q
+WHERE d.space_id = sqlc.arg(space_id)
+  AND (d.search_vector @@ q.tsq OR d.title % sqlc.arg(query)::text OR sqlc.arg(query)::text <% d.title)
+  AND can_view_search_document(sqlc.arg(viewer_id), d.entity_type, d.entity_id)
+GROUP BY d.entity_type
+ORDER BY d.entity_type;
@@ -0,0 +1,88 @@
+-- Tag Queries
+
+-- name: SyncPostTags :exec
+WITH input AS (
+    SELECT DISTINCT unnest(sqlc.arg(names)::text[]) AS name
+),
+inserted AS (
+    INSERT INTO tags (name)
+    SELECT name FROM input
+    ON CONFLICT (name) DO NOTHING
+    RETURNING id
+),
+wanted AS (
+    SELECT id FROM inserted
+    UNION
+    SELECT t.id FROM tags t JOIN input i ON t.name = i.name
+),
+removed AS (
+    DELETE FROM post_tags
+    WHERE post_id = sqlc.arg(post_id)::uuid AND tag_id NOT IN (SELECT id FROM wanted)
+)
+INSERT INTO post_tags (post_id, tag_id, space_id)
+SELECT sqlc.arg(post_id)::uuid, id, sqlc.arg(space_id)::uuid FROM wanted
+ON CONFLICT (post_id, tag_id) DO NOTHING;
+
+-- name: GetTagByName :one
+SELECT * FROM tags WHERE name = $1;
+
+-- name: SearchTags :many
+SELECT
+    t.id,
+    t.name,
+    COALESCE(u.usage_count, 0)::int AS usage_count
+FROM tags t
+LEFT JOIN tag_space_usage u ON u.tag_id = t.id AND u.space_id = sqlc.arg(space_id)
+WHERE t.name LIKE sqlc.arg(prefix)::text || '%'
+ORDER BY COALESCE(u.usage_count, 0) DESC, t.name ASC
+LIMIT sqlc.arg(page_limit);
+
+-- name: GetPostsByTag :many
+SELECT
+    p.*,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    EXISTS(SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = sqlc.arg(viewer_id)) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = sqlc.arg(viewer_id)) as is_bookmarked
+FROM post_tags pt
+JOIN posts p ON pt.post_id = p.id
+JOIN users u ON p.author_id = u.id
+WHERE pt.tag_id = sqlc.arg(tag_id)
+  AND pt.space_id = sqlc.arg(space_id)
+  AND p.status = 'active'
+  AND can_view_post(sqlc.arg(viewer_id), p.author_id, p.visibility, p.community_id, p.group_id)
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (p.created_at, p.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+  AND NOT is_hidden_from(sqlc.arg(viewer_id), p.author_id)
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT sqlc.arg(page_size);
+
+-- name: DeleteTrendingTopicsByPeriod :exec
+DELETE FROM trending_topics WHERE period = $1;
+
+-- name: InsertTrendingTopics :execrows
+WITH scored AS (
+    SELECT
+        pt.space_id,
+        t.name,
+        COUNT(DISTINCT pt.post_id)::int AS post_count,
+        SUM(
+            (1 + LN(1 + COALESCE(p.likes_count, 0) + COALESCE(p.comments_count, 0) + COALESCE(p.reposts_count, 0)))
+            * POWER(0.5, EXTRACT(EPOCH FROM (NOW() - pt.created_at)) / 3600.0 / sqlc.arg(half_life_hours)::float8)
+        )::float8 AS trend_score
+    FROM post_tags pt
+    JOIN tags t ON pt.tag_id = t.id
+    JOIN posts p ON pt.post_id = p.id
+    WHERE p.status = 'active'
+      AND pt.created_at > NOW() - make_interval(hours => sqlc.arg(window_hours)::int)
+    GROUP BY pt.space_id, t.name
+),
+ranked AS (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY space_id ORDER BY trend_score DESC, name ASC) AS rank
+    FROM scored
+)
+INSERT INTO trending_topics (space_id, name, category, post_count, trend_score, period, recorded_at)
+SELECT space_id, name, 'hashtag', post_count, trend_score, sqlc.arg(period)::varchar, NOW()
+FROM ranked
+WHERE rank <= sqlc.arg(per_space_limit)::int;
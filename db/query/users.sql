@@ -286,10 +286,12 @@ SELECT
     f.created_at as followed_at
 FROM follows f
 JOIN users u ON f.follower_id = u.id
-WHERE f.following_id = $1
+WHERE f.following_id = sqlc.arg(following_id)
   AND u.status = 'active'
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3;
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (f.created_at, u.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
 
 -- name: GetUserFollowing :many
 SELECT
@@ -304,10 +306,12 @@ SELECT
     f.created_at as followed_at
 FROM follows f
 JOIN users u ON f.following_id = u.id
-WHERE f.follower_id = $1
+WHERE f.follower_id = sqlc.arg(follower_id)
   AND u.status = 'active'
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3;
+  AND (sqlc.narg(cursor_created_at)::timestamptz IS NULL
+       OR (f.created_at, u.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT sqlc.arg(page_size) OFFSET sqlc.arg(page_offset);
 
 -- name: IncrementFollowersCount :exec
 UPDATE users
@@ -351,4 +355,35 @@ ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: DeleteUser :exec
-DELETE FROM users WHERE id = $1;
\ No newline at end of file
+DELETE FROM users WHERE id = $1;
+
+-- name: CountUserFollowers :one
+SELECT COUNT(*) FROM follows f
+JOIN users u ON f.follower_id = u.id
+WHERE f.following_id = $1 AND u.status = 'active';
+
+-- name: CountUserFollowing :one
+SELECT COUNT(*) FROM follows f
+JOIN users u ON f.following_id = u.id
+WHERE f.follower_id = $1 AND u.status = 'active';
+
+-- name: SetAccountPrivacy :one
+UPDATE users
+SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{private_account}', to_jsonb(sqlc.arg(private_account)::bool)),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetAutoExpandContentWarnings :one
+UPDATE users
+SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{auto_expand_content_warnings}', to_jsonb(sqlc.arg(auto_expand)::bool)),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetAllowMessagesFrom :one
+UPDATE users
+SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{allow_messages_from}', to_jsonb(sqlc.arg(allow_messages_from)::text)),
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
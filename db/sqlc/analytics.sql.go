@@ -771,7 +771,7 @@ func (q *Queries) GetTopGroups(ctx context.Context, spaceID uuid.UUID) ([]GetTop
 
 const getTopPosts = `-- name: GetTopPosts :many
 SELECT 
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     u.username,
     u.full_name,
     (p.likes_count + p.comments_count + p.views_count) as engagement_score
@@ -785,29 +785,33 @@ LIMIT 10
 `
 
 type GetTopPostsRow struct {
-	ID              uuid.UUID             `json:"id"`
-	AuthorID        uuid.UUID             `json:"author_id"`
-	SpaceID         uuid.UUID             `json:"space_id"`
-	CommunityID     uuid.NullUUID         `json:"community_id"`
-	GroupID         uuid.NullUUID         `json:"group_id"`
-	ParentPostID    uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID    uuid.NullUUID         `json:"quoted_post_id"`
-	Content         string                `json:"content"`
-	Media           pqtype.NullRawMessage `json:"media"`
-	Tags            []string              `json:"tags"`
-	LikesCount      sql.NullInt32         `json:"likes_count"`
-	CommentsCount   sql.NullInt32         `json:"comments_count"`
-	RepostsCount    sql.NullInt32         `json:"reposts_count"`
-	QuotesCount     sql.NullInt32         `json:"quotes_count"`
-	ViewsCount      sql.NullInt32         `json:"views_count"`
-	IsPinned        sql.NullBool          `json:"is_pinned"`
-	Visibility      sql.NullString        `json:"visibility"`
-	Status          sql.NullString        `json:"status"`
-	CreatedAt       sql.NullTime          `json:"created_at"`
-	UpdatedAt       sql.NullTime          `json:"updated_at"`
-	Username        string                `json:"username"`
-	FullName        string                `json:"full_name"`
-	EngagementScore int32                 `json:"engagement_score"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           string                `json:"username"`
+	FullName           string                `json:"full_name"`
+	EngagementScore    int32                 `json:"engagement_score"`
 }
 
 func (q *Queries) GetTopPosts(ctx context.Context, spaceID uuid.UUID) ([]GetTopPostsRow, error) {
@@ -840,6 +844,10 @@ func (q *Queries) GetTopPosts(ctx context.Context, spaceID uuid.UUID) ([]GetTopP
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.EngagementScore,
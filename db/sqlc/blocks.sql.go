@@ -0,0 +1,357 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const blockUser = `-- name: BlockUser :one
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+RETURNING id, blocker_id, blocked_id, created_at
+`
+
+type BlockUserParams struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+}
+
+func (q *Queries) BlockUser(ctx context.Context, arg BlockUserParams) (UserBlock, error) {
+	row := q.db.QueryRowContext(ctx, blockUser, arg.BlockerID, arg.BlockedID)
+	var i UserBlock
+	err := row.Scan(
+		&i.ID,
+		&i.BlockerID,
+		&i.BlockedID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteFollowsBetween = `-- name: DeleteFollowsBetween :many
+DELETE FROM follows
+WHERE (follower_id = $1 AND following_id = $2)
+   OR (follower_id = $2 AND following_id = $1)
+RETURNING follower_id, following_id
+`
+
+type DeleteFollowsBetweenParams struct {
+	UserA uuid.UUID `json:"user_a"`
+	UserB uuid.UUID `json:"user_b"`
+}
+
+type DeleteFollowsBetweenRow struct {
+	FollowerID  uuid.UUID `json:"follower_id"`
+	FollowingID uuid.UUID `json:"following_id"`
+}
+
+func (q *Queries) DeleteFollowsBetween(ctx context.Context, arg DeleteFollowsBetweenParams) ([]DeleteFollowsBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, deleteFollowsBetween, arg.UserA, arg.UserB)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DeleteFollowsBetweenRow{}
+	for rows.Next() {
+		var i DeleteFollowsBetweenRow
+		if err := rows.Scan(
+			&i.FollowerID,
+			&i.FollowingID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBlockedUsers = `-- name: GetBlockedUsers :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    ub.created_at AS blocked_at
+FROM user_blocks ub
+JOIN users u ON ub.blocked_id = u.id
+WHERE ub.blocker_id = $1
+ORDER BY ub.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetBlockedUsersParams struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+}
+
+type GetBlockedUsersRow struct {
+	ID        uuid.UUID      `json:"id"`
+	Username  string         `json:"username"`
+	FullName  string         `json:"full_name"`
+	Avatar    sql.NullString `json:"avatar"`
+	BlockedAt time.Time      `json:"blocked_at"`
+}
+
+func (q *Queries) GetBlockedUsers(ctx context.Context, arg GetBlockedUsersParams) ([]GetBlockedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBlockedUsers, arg.BlockerID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBlockedUsersRow{}
+	for rows.Next() {
+		var i GetBlockedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.BlockedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHiddenAudience = `-- name: GetHiddenAudience :many
+SELECT blocker_id AS user_id FROM user_blocks WHERE blocked_id = $1
+UNION
+SELECT blocked_id AS user_id FROM user_blocks WHERE blocker_id = $1
+UNION
+SELECT muter_id AS user_id FROM user_mutes WHERE muted_id = $1
+`
+
+func (q *Queries) GetHiddenAudience(ctx context.Context, actorID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getHiddenAudience, actorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMutedUsers = `-- name: GetMutedUsers :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    um.created_at AS muted_at
+FROM user_mutes um
+JOIN users u ON um.muted_id = u.id
+WHERE um.muter_id = $1
+ORDER BY um.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetMutedUsersParams struct {
+	MuterID uuid.UUID `json:"muter_id"`
+	Limit   int32     `json:"limit"`
+	Offset  int32     `json:"offset"`
+}
+
+type GetMutedUsersRow struct {
+	ID       uuid.UUID      `json:"id"`
+	Username string         `json:"username"`
+	FullName string         `json:"full_name"`
+	Avatar   sql.NullString `json:"avatar"`
+	MutedAt  time.Time      `json:"muted_at"`
+}
+
+func (q *Queries) GetMutedUsers(ctx context.Context, arg GetMutedUsersParams) ([]GetMutedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMutedUsers, arg.MuterID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMutedUsersRow{}
+	for rows.Next() {
+		var i GetMutedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.MutedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRelationshipStatus = `-- name: GetRelationshipStatus :one
+SELECT
+    EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2) AS is_blocking,
+    EXISTS(SELECT 1 FROM user_blocks WHERE blocker_id = $2 AND blocked_id = $1) AS is_blocked_by,
+    EXISTS(SELECT 1 FROM user_mutes WHERE muter_id = $1 AND muted_id = $2) AS is_muting,
+    EXISTS(SELECT 1 FROM follow_requests WHERE requester_id = $1 AND target_id = $2) AS is_requested
+`
+
+type GetRelationshipStatusParams struct {
+	ViewerID uuid.UUID `json:"viewer_id"`
+	TargetID uuid.UUID `json:"target_id"`
+}
+
+type GetRelationshipStatusRow struct {
+	IsBlocking  bool `json:"is_blocking"`
+	IsBlockedBy bool `json:"is_blocked_by"`
+	IsMuting    bool `json:"is_muting"`
+	IsRequested bool `json:"is_requested"`
+}
+
+func (q *Queries) GetRelationshipStatus(ctx context.Context, arg GetRelationshipStatusParams) (GetRelationshipStatusRow, error) {
+	row := q.db.QueryRowContext(ctx, getRelationshipStatus, arg.ViewerID, arg.TargetID)
+	var i GetRelationshipStatusRow
+	err := row.Scan(
+		&i.IsBlocking,
+		&i.IsBlockedBy,
+		&i.IsMuting,
+		&i.IsRequested,
+	)
+	return i, err
+}
+
+const isBlockedBetween = `-- name: IsBlockedBetween :one
+SELECT is_blocked_between($1::uuid, $2::uuid)::bool AS is_blocked
+`
+
+type IsBlockedBetweenParams struct {
+	UserA uuid.UUID `json:"user_a"`
+	UserB uuid.UUID `json:"user_b"`
+}
+
+func (q *Queries) IsBlockedBetween(ctx context.Context, arg IsBlockedBetweenParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isBlockedBetween, arg.UserA, arg.UserB)
+	var is_blocked bool
+	err := row.Scan(&is_blocked)
+	return is_blocked, err
+}
+
+const isDirectConversationBlocked = `-- name: IsDirectConversationBlocked :one
+SELECT EXISTS (
+    SELECT 1
+    FROM conversations c
+    JOIN conversation_participants cp ON cp.conversation_id = c.id AND cp.user_id <> $1::uuid
+    WHERE c.id = $2::uuid
+      AND c.conversation_type = 'direct'
+      AND is_blocked_between(cp.user_id, $1::uuid)
+)::bool AS is_blocked
+`
+
+type IsDirectConversationBlockedParams struct {
+	SenderID       uuid.UUID `json:"sender_id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) IsDirectConversationBlocked(ctx context.Context, arg IsDirectConversationBlockedParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isDirectConversationBlocked, arg.SenderID, arg.ConversationID)
+	var is_blocked bool
+	err := row.Scan(&is_blocked)
+	return is_blocked, err
+}
+
+const muteUser = `-- name: MuteUser :one
+INSERT INTO user_mutes (muter_id, muted_id)
+VALUES ($1, $2)
+ON CONFLICT (muter_id, muted_id) DO NOTHING
+RETURNING id, muter_id, muted_id, created_at
+`
+
+type MuteUserParams struct {
+	MuterID uuid.UUID `json:"muter_id"`
+	MutedID uuid.UUID `json:"muted_id"`
+}
+
+func (q *Queries) MuteUser(ctx context.Context, arg MuteUserParams) (UserMute, error) {
+	row := q.db.QueryRowContext(ctx, muteUser, arg.MuterID, arg.MutedID)
+	var i UserMute
+	err := row.Scan(
+		&i.ID,
+		&i.MuterID,
+		&i.MutedID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const unblockUser = `-- name: UnblockUser :execrows
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2
+`
+
+type UnblockUserParams struct {
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+}
+
+func (q *Queries) UnblockUser(ctx context.Context, arg UnblockUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unblockUser, arg.BlockerID, arg.BlockedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unmuteUser = `-- name: UnmuteUser :execrows
+DELETE FROM user_mutes
+WHERE muter_id = $1 AND muted_id = $2
+`
+
+type UnmuteUserParams struct {
+	MuterID uuid.UUID `json:"muter_id"`
+	MutedID uuid.UUID `json:"muted_id"`
+}
+
+func (q *Queries) UnmuteUser(ctx context.Context, arg UnmuteUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unmuteUser, arg.MuterID, arg.MutedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
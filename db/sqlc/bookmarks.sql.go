@@ -0,0 +1,352 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const createBookmarkCollection = `-- name: CreateBookmarkCollection :one
+INSERT INTO bookmark_collections (user_id, name)
+VALUES ($1, $2)
+RETURNING id, user_id, name, created_at, updated_at
+`
+
+type CreateBookmarkCollectionParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+func (q *Queries) CreateBookmarkCollection(ctx context.Context, arg CreateBookmarkCollectionParams) (BookmarkCollection, error) {
+	row := q.db.QueryRowContext(ctx, createBookmarkCollection, arg.UserID, arg.Name)
+	var i BookmarkCollection
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteBookmark = `-- name: DeleteBookmark :execrows
+DELETE FROM bookmarks
+WHERE user_id = $1 AND post_id = $2
+`
+
+type DeleteBookmarkParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	PostID uuid.UUID `json:"post_id"`
+}
+
+func (q *Queries) DeleteBookmark(ctx context.Context, arg DeleteBookmarkParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBookmark, arg.UserID, arg.PostID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteBookmarkCollection = `-- name: DeleteBookmarkCollection :execrows
+DELETE FROM bookmark_collections
+WHERE id = $1 AND user_id = $2
+`
+
+type DeleteBookmarkCollectionParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteBookmarkCollection(ctx context.Context, arg DeleteBookmarkCollectionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteBookmarkCollection, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getBookmarkCollection = `-- name: GetBookmarkCollection :one
+SELECT id, user_id, name, created_at, updated_at FROM bookmark_collections
+WHERE id = $1 AND user_id = $2
+`
+
+type GetBookmarkCollectionParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetBookmarkCollection(ctx context.Context, arg GetBookmarkCollectionParams) (BookmarkCollection, error) {
+	row := q.db.QueryRowContext(ctx, getBookmarkCollection, arg.ID, arg.UserID)
+	var i BookmarkCollection
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getBookmarkCollections = `-- name: GetBookmarkCollections :many
+SELECT
+    bc.id, bc.user_id, bc.name, bc.created_at, bc.updated_at,
+    (SELECT COUNT(*) FROM bookmarks b WHERE b.collection_id = bc.id) as bookmarks_count
+FROM bookmark_collections bc
+WHERE bc.user_id = $1
+ORDER BY bc.name ASC
+`
+
+type GetBookmarkCollectionsRow struct {
+	ID             uuid.UUID `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Name           string    `json:"name"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	BookmarksCount int64     `json:"bookmarks_count"`
+}
+
+func (q *Queries) GetBookmarkCollections(ctx context.Context, userID uuid.UUID) ([]GetBookmarkCollectionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getBookmarkCollections, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBookmarkCollectionsRow{}
+	for rows.Next() {
+		var i GetBookmarkCollectionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.BookmarksCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserBookmarks = `-- name: GetUserBookmarks :many
+SELECT
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    b.id as bookmark_id,
+    b.collection_id as bookmark_collection_id,
+    b.created_at as bookmarked_at,
+    EXISTS(SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $1) as is_liked
+FROM bookmarks b
+JOIN posts p ON b.post_id = p.id
+JOIN users u ON p.author_id = u.id
+WHERE b.user_id = $1
+  AND p.status = 'active'
+  AND NOT is_hidden_from($1, p.author_id)
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND ($2::uuid IS NULL OR b.collection_id = $2::uuid)
+  AND ($3::timestamptz IS NULL
+       OR (b.created_at, b.id) < ($3::timestamptz, $4::uuid))
+ORDER BY b.created_at DESC, b.id DESC
+LIMIT $5
+`
+
+type GetUserBookmarksParams struct {
+	UserID          uuid.UUID     `json:"user_id"`
+	CollectionID    uuid.NullUUID `json:"collection_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+}
+
+type GetUserBookmarksRow struct {
+	ID                   uuid.UUID             `json:"id"`
+	AuthorID             uuid.UUID             `json:"author_id"`
+	SpaceID              uuid.UUID             `json:"space_id"`
+	CommunityID          uuid.NullUUID         `json:"community_id"`
+	GroupID              uuid.NullUUID         `json:"group_id"`
+	ParentPostID         uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID         uuid.NullUUID         `json:"quoted_post_id"`
+	Content              string                `json:"content"`
+	Media                pqtype.NullRawMessage `json:"media"`
+	Tags                 []string              `json:"tags"`
+	LikesCount           sql.NullInt32         `json:"likes_count"`
+	CommentsCount        sql.NullInt32         `json:"comments_count"`
+	RepostsCount         sql.NullInt32         `json:"reposts_count"`
+	QuotesCount          sql.NullInt32         `json:"quotes_count"`
+	ViewsCount           sql.NullInt32         `json:"views_count"`
+	IsPinned             sql.NullBool          `json:"is_pinned"`
+	Visibility           sql.NullString        `json:"visibility"`
+	Status               sql.NullString        `json:"status"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
+	PublishAt            sql.NullTime          `json:"publish_at"`
+	ContentWarning       sql.NullString        `json:"content_warning"`
+	IsSensitive          bool                  `json:"is_sensitive"`
+	WarningByModerator   bool                  `json:"warning_by_moderator"`
+	Username             interface{}           `json:"username"`
+	FullName             interface{}           `json:"full_name"`
+	AuthorAvatar         sql.NullString        `json:"author_avatar"`
+	BookmarkID           uuid.UUID             `json:"bookmark_id"`
+	BookmarkCollectionID uuid.NullUUID         `json:"bookmark_collection_id"`
+	BookmarkedAt         time.Time             `json:"bookmarked_at"`
+	IsLiked              bool                  `json:"is_liked"`
+}
+
+func (q *Queries) GetUserBookmarks(ctx context.Context, arg GetUserBookmarksParams) ([]GetUserBookmarksRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserBookmarks,
+		arg.UserID,
+		arg.CollectionID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserBookmarksRow{}
+	for rows.Next() {
+		var i GetUserBookmarksRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.SpaceID,
+			&i.CommunityID,
+			&i.GroupID,
+			&i.ParentPostID,
+			&i.QuotedPostID,
+			&i.Content,
+			&i.Media,
+			pq.Array(&i.Tags),
+			&i.LikesCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+			&i.QuotesCount,
+			&i.ViewsCount,
+			&i.IsPinned,
+			&i.Visibility,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
+			&i.Username,
+			&i.FullName,
+			&i.AuthorAvatar,
+			&i.BookmarkID,
+			&i.BookmarkCollectionID,
+			&i.BookmarkedAt,
+			&i.IsLiked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveBookmark = `-- name: MoveBookmark :one
+UPDATE bookmarks
+SET collection_id = $1
+WHERE user_id = $2 AND post_id = $3
+RETURNING id, user_id, post_id, collection_id, created_at
+`
+
+type MoveBookmarkParams struct {
+	CollectionID uuid.NullUUID `json:"collection_id"`
+	UserID       uuid.UUID     `json:"user_id"`
+	PostID       uuid.UUID     `json:"post_id"`
+}
+
+func (q *Queries) MoveBookmark(ctx context.Context, arg MoveBookmarkParams) (Bookmark, error) {
+	row := q.db.QueryRowContext(ctx, moveBookmark, arg.CollectionID, arg.UserID, arg.PostID)
+	var i Bookmark
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PostID,
+		&i.CollectionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const renameBookmarkCollection = `-- name: RenameBookmarkCollection :one
+UPDATE bookmark_collections
+SET name = $1, updated_at = NOW()
+WHERE id = $2 AND user_id = $3
+RETURNING id, user_id, name, created_at, updated_at
+`
+
+type RenameBookmarkCollectionParams struct {
+	Name   string    `json:"name"`
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) RenameBookmarkCollection(ctx context.Context, arg RenameBookmarkCollectionParams) (BookmarkCollection, error) {
+	row := q.db.QueryRowContext(ctx, renameBookmarkCollection, arg.Name, arg.ID, arg.UserID)
+	var i BookmarkCollection
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertBookmark = `-- name: UpsertBookmark :one
+INSERT INTO bookmarks (user_id, post_id, collection_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, post_id) DO UPDATE SET collection_id = EXCLUDED.collection_id
+RETURNING id, user_id, post_id, collection_id, created_at
+`
+
+type UpsertBookmarkParams struct {
+	UserID       uuid.UUID     `json:"user_id"`
+	PostID       uuid.UUID     `json:"post_id"`
+	CollectionID uuid.NullUUID `json:"collection_id"`
+}
+
+func (q *Queries) UpsertBookmark(ctx context.Context, arg UpsertBookmarkParams) (Bookmark, error) {
+	row := q.db.QueryRowContext(ctx, upsertBookmark, arg.UserID, arg.PostID, arg.CollectionID)
+	var i Bookmark
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PostID,
+		&i.CollectionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -40,6 +40,19 @@ func (q *Queries) AddCommunityModerator(ctx context.Context, arg AddCommunityMod
 	return i, err
 }
 
+const countCommunityMembers = `-- name: CountCommunityMembers :one
+SELECT COUNT(*) FROM community_members cm
+JOIN users u ON cm.user_id = u.id
+WHERE cm.community_id = $1 AND u.status = 'active'
+`
+
+func (q *Queries) CountCommunityMembers(ctx context.Context, communityID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCommunityMembers, communityID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCommunity = `-- name: CreateCommunity :one
 
 INSERT INTO communities (
@@ -330,10 +343,19 @@ SELECT
 FROM community_members cm
 JOIN users u ON cm.user_id = u.id
 WHERE cm.community_id = $1 AND u.status = 'active'
-ORDER BY
-    cm.joined_at
+  AND ($2::timestamptz IS NULL
+       OR (cm.joined_at, u.id) > ($2::timestamptz, $3::uuid))
+ORDER BY cm.joined_at ASC, u.id ASC
+LIMIT $4
 `
 
+type GetCommunityMembersParams struct {
+	CommunityID     uuid.UUID     `json:"community_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        sql.NullInt32 `json:"page_size"`
+}
+
 type GetCommunityMembersRow struct {
 	ID         uuid.UUID      `json:"id"`
 	Username   string         `json:"username"`
@@ -346,8 +368,13 @@ type GetCommunityMembersRow struct {
 	JoinedAt   sql.NullTime   `json:"joined_at"`
 }
 
-func (q *Queries) GetCommunityMembers(ctx context.Context, communityID uuid.UUID) ([]GetCommunityMembersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getCommunityMembers, communityID)
+func (q *Queries) GetCommunityMembers(ctx context.Context, arg GetCommunityMembersParams) ([]GetCommunityMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCommunityMembers,
+		arg.CommunityID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,107 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const canModeratePost = `-- name: CanModeratePost :one
+SELECT (
+    EXISTS(SELECT 1 FROM users u WHERE u.id = $1 AND u.roles && ARRAY['admin', 'moderator']::text[])
+    OR EXISTS(
+        SELECT 1 FROM community_members cm
+        WHERE cm.community_id = p.community_id AND cm.user_id = $1 AND cm.role IN ('admin', 'moderator'))
+    OR EXISTS(
+        SELECT 1 FROM group_members gm
+        WHERE gm.group_id = p.group_id AND gm.user_id = $1 AND gm.role IN ('admin', 'moderator'))
+)::bool AS can_moderate
+FROM posts p
+WHERE p.id = $2
+`
+
+type CanModeratePostParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	PostID uuid.UUID `json:"post_id"`
+}
+
+func (q *Queries) CanModeratePost(ctx context.Context, arg CanModeratePostParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, canModeratePost, arg.UserID, arg.PostID)
+	var can_moderate bool
+	err := row.Scan(&can_moderate)
+	return can_moderate, err
+}
+
+const getAutoExpandContentWarnings = `-- name: GetAutoExpandContentWarnings :one
+SELECT COALESCE((settings->>'auto_expand_content_warnings')::bool, false)::bool AS auto_expand
+FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetAutoExpandContentWarnings(ctx context.Context, userID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, getAutoExpandContentWarnings, userID)
+	var auto_expand bool
+	err := row.Scan(&auto_expand)
+	return auto_expand, err
+}
+
+const setPostContentWarning = `-- name: SetPostContentWarning :one
+UPDATE posts
+SET content_warning = $1,
+    is_sensitive = $2,
+    warning_by_moderator = $3,
+    updated_at = NOW()
+WHERE id = $4 AND status = 'active'
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
+`
+
+type SetPostContentWarningParams struct {
+	ContentWarning     sql.NullString `json:"content_warning"`
+	IsSensitive        bool           `json:"is_sensitive"`
+	WarningByModerator bool           `json:"warning_by_moderator"`
+	ID                 uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) SetPostContentWarning(ctx context.Context, arg SetPostContentWarningParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, setPostContentWarning,
+		arg.ContentWarning,
+		arg.IsSensitive,
+		arg.WarningByModerator,
+		arg.ID,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.SpaceID,
+		&i.CommunityID,
+		&i.GroupID,
+		&i.ParentPostID,
+		&i.QuotedPostID,
+		&i.Content,
+		&i.Media,
+		pq.Array(&i.Tags),
+		&i.LikesCount,
+		&i.CommentsCount,
+		&i.RepostsCount,
+		&i.QuotesCount,
+		&i.ViewsCount,
+		&i.IsPinned,
+		&i.Visibility,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
+	)
+	return i, err
+}
@@ -0,0 +1,301 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addGroupParticipants = `-- name: AddGroupParticipants :many
+INSERT INTO conversation_participants (conversation_id, user_id, role)
+SELECT $1, u.id, 'member'
+FROM users u
+WHERE u.id = ANY($2::uuid[]) AND u.status = 'active'
+  AND NOT is_blocked_between(u.id, $3)
+ON CONFLICT (conversation_id, user_id) DO UPDATE
+SET is_active = true, left_at = NULL, joined_at = NOW(), role = 'member'
+WHERE conversation_participants.is_active = false
+RETURNING user_id
+`
+
+type AddGroupParticipantsParams struct {
+	ConversationID uuid.UUID   `json:"conversation_id"`
+	UserIds        []uuid.UUID `json:"user_ids"`
+	ActorID        uuid.UUID   `json:"actor_id"`
+}
+
+func (q *Queries) AddGroupParticipants(ctx context.Context, arg AddGroupParticipantsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, addGroupParticipants, arg.ConversationID, pq.Array(arg.UserIds), arg.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createConversationInvite = `-- name: CreateConversationInvite :one
+INSERT INTO conversation_invites (conversation_id, code, created_by, expires_at, max_uses)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, conversation_id, code, created_by, expires_at, max_uses, use_count, revoked_at, created_at
+`
+
+type CreateConversationInviteParams struct {
+	ConversationID uuid.UUID     `json:"conversation_id"`
+	Code           string        `json:"code"`
+	CreatedBy      uuid.UUID     `json:"created_by"`
+	ExpiresAt      sql.NullTime  `json:"expires_at"`
+	MaxUses        sql.NullInt32 `json:"max_uses"`
+}
+
+func (q *Queries) CreateConversationInvite(ctx context.Context, arg CreateConversationInviteParams) (ConversationInvite, error) {
+	row := q.db.QueryRowContext(ctx, createConversationInvite,
+		arg.ConversationID,
+		arg.Code,
+		arg.CreatedBy,
+		arg.ExpiresAt,
+		arg.MaxUses,
+	)
+	var i ConversationInvite
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.Code,
+		&i.CreatedBy,
+		&i.ExpiresAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getConversationInviteByCode = `-- name: GetConversationInviteByCode :one
+SELECT i.id, i.conversation_id, i.code, i.created_by, i.expires_at, i.max_uses, i.use_count, i.revoked_at, i.created_at
+FROM conversation_invites i
+JOIN conversations c ON c.id = i.conversation_id
+WHERE i.code = $1
+  AND i.revoked_at IS NULL
+  AND (i.expires_at IS NULL OR i.expires_at > NOW())
+  AND (i.max_uses IS NULL OR i.use_count < i.max_uses)
+  AND c.is_active = true
+`
+
+func (q *Queries) GetConversationInviteByCode(ctx context.Context, code string) (ConversationInvite, error) {
+	row := q.db.QueryRowContext(ctx, getConversationInviteByCode, code)
+	var i ConversationInvite
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.Code,
+		&i.CreatedBy,
+		&i.ExpiresAt,
+		&i.MaxUses,
+		&i.UseCount,
+		&i.RevokedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getConversationInvites = `-- name: GetConversationInvites :many
+SELECT id, conversation_id, code, created_by, expires_at, max_uses, use_count, revoked_at, created_at
+FROM conversation_invites
+WHERE conversation_id = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetConversationInvites(ctx context.Context, conversationID uuid.UUID) ([]ConversationInvite, error) {
+	rows, err := q.db.QueryContext(ctx, getConversationInvites, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ConversationInvite{}
+	for rows.Next() {
+		var i ConversationInvite
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.Code,
+			&i.CreatedBy,
+			&i.ExpiresAt,
+			&i.MaxUses,
+			&i.UseCount,
+			&i.RevokedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOwnershipSuccessor = `-- name: GetOwnershipSuccessor :one
+SELECT user_id
+FROM conversation_participants
+WHERE conversation_id = $1
+  AND user_id <> $2
+  AND is_active = true
+ORDER BY CASE role WHEN 'admin' THEN 0 ELSE 1 END, joined_at, id
+LIMIT 1
+`
+
+type GetOwnershipSuccessorParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetOwnershipSuccessor(ctx context.Context, arg GetOwnershipSuccessorParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getOwnershipSuccessor, arg.ConversationID, arg.UserID)
+	var user_id uuid.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const redeemConversationInvite = `-- name: RedeemConversationInvite :execrows
+UPDATE conversation_invites
+SET use_count = use_count + 1
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW())
+  AND (max_uses IS NULL OR use_count < max_uses)
+`
+
+func (q *Queries) RedeemConversationInvite(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redeemConversationInvite, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const removeConversationParticipant = `-- name: RemoveConversationParticipant :execrows
+UPDATE conversation_participants
+SET is_active = false, left_at = NOW()
+WHERE conversation_id = $1 AND user_id = $2 AND is_active = true
+`
+
+type RemoveConversationParticipantParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) RemoveConversationParticipant(ctx context.Context, arg RemoveConversationParticipantParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, removeConversationParticipant, arg.ConversationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeConversationInvite = `-- name: RevokeConversationInvite :execrows
+UPDATE conversation_invites
+SET revoked_at = NOW()
+WHERE id = $1 AND conversation_id = $2 AND revoked_at IS NULL
+`
+
+type RevokeConversationInviteParams struct {
+	ID             uuid.UUID `json:"id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) RevokeConversationInvite(ctx context.Context, arg RevokeConversationInviteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeConversationInvite, arg.ID, arg.ConversationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setParticipantRole = `-- name: SetParticipantRole :execrows
+UPDATE conversation_participants
+SET role = $1
+WHERE conversation_id = $2 AND user_id = $3 AND is_active = true
+`
+
+type SetParticipantRoleParams struct {
+	Role           sql.NullString `json:"role"`
+	ConversationID uuid.UUID      `json:"conversation_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+}
+
+func (q *Queries) SetParticipantRole(ctx context.Context, arg SetParticipantRoleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setParticipantRole, arg.Role, arg.ConversationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateConversationDetails = `-- name: UpdateConversationDetails :one
+UPDATE conversations
+SET name = COALESCE($1, name),
+    avatar = COALESCE($2, avatar),
+    description = COALESCE($3, description),
+    updated_at = NOW()
+WHERE id = $4 AND is_active = true
+RETURNING id, space_id, name, avatar, description, conversation_type, last_message_id, last_message_at, is_active, settings, created_at, updated_at
+`
+
+type UpdateConversationDetailsParams struct {
+	Name        sql.NullString `json:"name"`
+	Avatar      sql.NullString `json:"avatar"`
+	Description sql.NullString `json:"description"`
+	ID          uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) UpdateConversationDetails(ctx context.Context, arg UpdateConversationDetailsParams) (Conversation, error) {
+	row := q.db.QueryRowContext(ctx, updateConversationDetails,
+		arg.Name,
+		arg.Avatar,
+		arg.Description,
+		arg.ID,
+	)
+	var i Conversation
+	err := row.Scan(
+		&i.ID,
+		&i.SpaceID,
+		&i.Name,
+		&i.Avatar,
+		&i.Description,
+		&i.ConversationType,
+		&i.LastMessageID,
+		&i.LastMessageAt,
+		&i.IsActive,
+		&i.Settings,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,313 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const completeConversationExport = `-- name: CompleteConversationExport :exec
+UPDATE conversation_exports
+SET status = 'completed',
+    content = $1,
+    message_count = $2,
+    completed_at = NOW(),
+    expires_at = NOW() + $3::interval
+WHERE id = $4
+`
+
+type CompleteConversationExportParams struct {
+	Content      []byte    `json:"content"`
+	MessageCount int64     `json:"message_count"`
+	Retention    string    `json:"retention"`
+	ID           uuid.UUID `json:"id"`
+}
+
+func (q *Queries) CompleteConversationExport(ctx context.Context, arg CompleteConversationExportParams) error {
+	_, err := q.db.ExecContext(ctx, completeConversationExport,
+		arg.Content,
+		arg.MessageCount,
+		arg.Retention,
+		arg.ID,
+	)
+	return err
+}
+
+const countExportMessages = `-- name: CountExportMessages :one
+SELECT COUNT(*)
+FROM messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id AND cp.user_id = $1
+WHERE m.conversation_id = $2
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND m.expires_at IS NULL
+  AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+  AND (cp.left_at IS NULL OR m.created_at <= cp.left_at)
+`
+
+type CountExportMessagesParams struct {
+	UserID         uuid.UUID `json:"user_id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) CountExportMessages(ctx context.Context, arg CountExportMessagesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countExportMessages, arg.UserID, arg.ConversationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createConversationExport = `-- name: CreateConversationExport :one
+INSERT INTO conversation_exports (conversation_id, requested_by, format, message_count)
+VALUES ($1, $2, $3, $4)
+RETURNING id, conversation_id, requested_by, format, status, message_count, content, error, created_at, completed_at, expires_at
+`
+
+type CreateConversationExportParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	RequestedBy    uuid.UUID `json:"requested_by"`
+	Format         string    `json:"format"`
+	MessageCount   int64     `json:"message_count"`
+}
+
+func (q *Queries) CreateConversationExport(ctx context.Context, arg CreateConversationExportParams) (ConversationExport, error) {
+	row := q.db.QueryRowContext(ctx, createConversationExport,
+		arg.ConversationID,
+		arg.RequestedBy,
+		arg.Format,
+		arg.MessageCount,
+	)
+	var i ConversationExport
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.RequestedBy,
+		&i.Format,
+		&i.Status,
+		&i.MessageCount,
+		&i.Content,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteExpiredConversationExports = `-- name: DeleteExpiredConversationExports :execrows
+DELETE FROM conversation_exports
+WHERE expires_at <= NOW()
+`
+
+func (q *Queries) DeleteExpiredConversationExports(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredConversationExports)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const failConversationExport = `-- name: FailConversationExport :exec
+UPDATE conversation_exports
+SET status = 'failed',
+    error = $1,
+    completed_at = NOW(),
+    expires_at = NOW() + $2::interval
+WHERE id = $3
+`
+
+type FailConversationExportParams struct {
+	Error     sql.NullString `json:"error"`
+	Retention string         `json:"retention"`
+	ID        uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) FailConversationExport(ctx context.Context, arg FailConversationExportParams) error {
+	_, err := q.db.ExecContext(ctx, failConversationExport, arg.Error, arg.Retention, arg.ID)
+	return err
+}
+
+const failStaleConversationExports = `-- name: FailStaleConversationExports :execrows
+UPDATE conversation_exports
+SET status = 'failed',
+    error = $1,
+    completed_at = NOW(),
+    expires_at = NOW() + $2::interval
+WHERE status = 'pending' AND created_at < $3
+`
+
+type FailStaleConversationExportsParams struct {
+	Error       sql.NullString `json:"error"`
+	Retention   string         `json:"retention"`
+	StaleBefore time.Time      `json:"stale_before"`
+}
+
+func (q *Queries) FailStaleConversationExports(ctx context.Context, arg FailStaleConversationExportsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, failStaleConversationExports, arg.Error, arg.Retention, arg.StaleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getConversationExport = `-- name: GetConversationExport :one
+SELECT id, conversation_id, requested_by, format, status, message_count, content, error, created_at, completed_at, expires_at
+FROM conversation_exports
+WHERE id = $1 AND requested_by = $2
+`
+
+type GetConversationExportParams struct {
+	ID          uuid.UUID `json:"id"`
+	RequestedBy uuid.UUID `json:"requested_by"`
+}
+
+func (q *Queries) GetConversationExport(ctx context.Context, arg GetConversationExportParams) (ConversationExport, error) {
+	row := q.db.QueryRowContext(ctx, getConversationExport, arg.ID, arg.RequestedBy)
+	var i ConversationExport
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.RequestedBy,
+		&i.Format,
+		&i.Status,
+		&i.MessageCount,
+		&i.Content,
+		&i.Error,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getExportMessages = `-- name: GetExportMessages :many
+SELECT
+    m.id,
+    m.sender_id,
+    u.username AS sender_username,
+    u.full_name AS sender_full_name,
+    m.content,
+    m.attachments,
+    m.message_type,
+    m.reply_to_id,
+    m.thread_root_id,
+    m.shared_type,
+    m.shared_id,
+    m.is_forwarded,
+    m.created_at,
+    m.edited_at
+FROM messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id AND cp.user_id = $1
+JOIN users u ON u.id = m.sender_id
+WHERE m.conversation_id = $2
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND m.expires_at IS NULL
+  AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+  AND (cp.left_at IS NULL OR m.created_at <= cp.left_at)
+  AND ($3::timestamptz IS NULL
+       OR (m.created_at, m.id) > ($3::timestamptz, $4::uuid))
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT $5
+`
+
+type GetExportMessagesParams struct {
+	UserID          uuid.UUID     `json:"user_id"`
+	ConversationID  uuid.UUID     `json:"conversation_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+}
+
+type GetExportMessagesRow struct {
+	ID             uuid.UUID             `json:"id"`
+	SenderID       uuid.UUID             `json:"sender_id"`
+	SenderUsername string                `json:"sender_username"`
+	SenderFullName string                `json:"sender_full_name"`
+	Content        sql.NullString        `json:"content"`
+	Attachments    pqtype.NullRawMessage `json:"attachments"`
+	MessageType    sql.NullString        `json:"message_type"`
+	ReplyToID      uuid.NullUUID         `json:"reply_to_id"`
+	ThreadRootID   uuid.NullUUID         `json:"thread_root_id"`
+	SharedType     sql.NullString        `json:"shared_type"`
+	SharedID       uuid.NullUUID         `json:"shared_id"`
+	IsForwarded    bool                  `json:"is_forwarded"`
+	CreatedAt      sql.NullTime          `json:"created_at"`
+	EditedAt       sql.NullTime          `json:"edited_at"`
+}
+
+func (q *Queries) GetExportMessages(ctx context.Context, arg GetExportMessagesParams) ([]GetExportMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExportMessages,
+		arg.UserID,
+		arg.ConversationID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetExportMessagesRow{}
+	for rows.Next() {
+		var i GetExportMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SenderID,
+			&i.SenderUsername,
+			&i.SenderFullName,
+			&i.Content,
+			&i.Attachments,
+			&i.MessageType,
+			&i.ReplyToID,
+			&i.ThreadRootID,
+			&i.SharedType,
+			&i.SharedID,
+			&i.IsForwarded,
+			&i.CreatedAt,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExportWindow = `-- name: GetExportWindow :one
+SELECT joined_at, left_at
+FROM conversation_participants
+WHERE conversation_id = $1 AND user_id = $2
+`
+
+type GetExportWindowParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+type GetExportWindowRow struct {
+	JoinedAt sql.NullTime `json:"joined_at"`
+	LeftAt   sql.NullTime `json:"left_at"`
+}
+
+func (q *Queries) GetExportWindow(ctx context.Context, arg GetExportWindowParams) (GetExportWindowRow, error) {
+	row := q.db.QueryRowContext(ctx, getExportWindow, arg.ConversationID, arg.UserID)
+	var i GetExportWindowRow
+	err := row.Scan(
+		&i.JoinedAt,
+		&i.LeftAt,
+	)
+	return i, err
+}
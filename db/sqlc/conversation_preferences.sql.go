@@ -0,0 +1,189 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const countPinnedMessages = `-- name: CountPinnedMessages :one
+SELECT COUNT(*)
+FROM message_pins
+WHERE conversation_id = $1
+`
+
+func (q *Queries) CountPinnedMessages(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPinnedMessages, conversationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getPinnedMessages = `-- name: GetPinnedMessages :many
+SELECT
+    m.id,
+    m.conversation_id,
+    m.sender_id,
+    u.username AS sender_username,
+    u.full_name AS sender_full_name,
+    u.avatar AS sender_avatar,
+    m.content,
+    m.attachments,
+    m.message_type,
+    m.created_at,
+    p.pinned_by,
+    pinner.username AS pinned_by_username,
+    p.pinned_at
+FROM message_pins p
+JOIN messages m ON m.id = p.message_id
+JOIN users u ON u.id = m.sender_id
+JOIN users pinner ON pinner.id = p.pinned_by
+WHERE p.conversation_id = $1
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+ORDER BY p.pinned_at DESC
+`
+
+type GetPinnedMessagesRow struct {
+	ID               uuid.UUID             `json:"id"`
+	ConversationID   uuid.UUID             `json:"conversation_id"`
+	SenderID         uuid.UUID             `json:"sender_id"`
+	SenderUsername   string                `json:"sender_username"`
+	SenderFullName   string                `json:"sender_full_name"`
+	SenderAvatar     sql.NullString        `json:"sender_avatar"`
+	Content          sql.NullString        `json:"content"`
+	Attachments      pqtype.NullRawMessage `json:"attachments"`
+	MessageType      sql.NullString        `json:"message_type"`
+	CreatedAt        sql.NullTime          `json:"created_at"`
+	PinnedBy         uuid.UUID             `json:"pinned_by"`
+	PinnedByUsername string                `json:"pinned_by_username"`
+	PinnedAt         time.Time             `json:"pinned_at"`
+}
+
+func (q *Queries) GetPinnedMessages(ctx context.Context, conversationID uuid.UUID) ([]GetPinnedMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPinnedMessages, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPinnedMessagesRow{}
+	for rows.Next() {
+		var i GetPinnedMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.SenderID,
+			&i.SenderUsername,
+			&i.SenderFullName,
+			&i.SenderAvatar,
+			&i.Content,
+			&i.Attachments,
+			&i.MessageType,
+			&i.CreatedAt,
+			&i.PinnedBy,
+			&i.PinnedByUsername,
+			&i.PinnedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const pinMessage = `-- name: PinMessage :one
+INSERT INTO message_pins (conversation_id, message_id, pinned_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (message_id) DO NOTHING
+RETURNING id, conversation_id, message_id, pinned_by, pinned_at
+`
+
+type PinMessageParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	MessageID      uuid.UUID `json:"message_id"`
+	PinnedBy       uuid.UUID `json:"pinned_by"`
+}
+
+func (q *Queries) PinMessage(ctx context.Context, arg PinMessageParams) (MessagePin, error) {
+	row := q.db.QueryRowContext(ctx, pinMessage, arg.ConversationID, arg.MessageID, arg.PinnedBy)
+	var i MessagePin
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.MessageID,
+		&i.PinnedBy,
+		&i.PinnedAt,
+	)
+	return i, err
+}
+
+const setConversationArchived = `-- name: SetConversationArchived :execrows
+UPDATE conversation_participants
+SET archived_at = CASE WHEN $1::bool THEN COALESCE(archived_at, NOW()) ELSE NULL END
+WHERE conversation_id = $2 AND user_id = $3 AND is_active = true
+`
+
+type SetConversationArchivedParams struct {
+	Archived       bool      `json:"archived"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) SetConversationArchived(ctx context.Context, arg SetConversationArchivedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setConversationArchived, arg.Archived, arg.ConversationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setConversationMute = `-- name: SetConversationMute :execrows
+UPDATE conversation_participants
+SET muted_until = $1
+WHERE conversation_id = $2 AND user_id = $3 AND is_active = true
+`
+
+type SetConversationMuteParams struct {
+	MutedUntil     sql.NullTime `json:"muted_until"`
+	ConversationID uuid.UUID    `json:"conversation_id"`
+	UserID         uuid.UUID    `json:"user_id"`
+}
+
+func (q *Queries) SetConversationMute(ctx context.Context, arg SetConversationMuteParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setConversationMute, arg.MutedUntil, arg.ConversationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const unpinMessage = `-- name: UnpinMessage :execrows
+DELETE FROM message_pins
+WHERE conversation_id = $1 AND message_id = $2
+`
+
+type UnpinMessageParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	MessageID      uuid.UUID `json:"message_id"`
+}
+
+func (q *Queries) UnpinMessage(ctx context.Context, arg UnpinMessageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unpinMessage, arg.ConversationID, arg.MessageID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
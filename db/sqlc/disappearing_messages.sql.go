@@ -0,0 +1,89 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const deleteExpiredMessages = `-- name: DeleteExpiredMessages :many
+WITH expired AS (
+    DELETE FROM messages
+    WHERE id IN (
+        SELECT id
+        FROM messages
+        WHERE expires_at <= NOW()
+        ORDER BY expires_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING id, conversation_id, sender_id
+), purged_mentions AS (
+    DELETE FROM mentions
+    WHERE source_type = 'message' AND source_id IN (SELECT id FROM expired)
+)
+SELECT id, conversation_id, sender_id
+FROM expired
+`
+
+type DeleteExpiredMessagesRow struct {
+	ID             uuid.UUID `json:"id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	SenderID       uuid.UUID `json:"sender_id"`
+}
+
+func (q *Queries) DeleteExpiredMessages(ctx context.Context, batchSize int32) ([]DeleteExpiredMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, deleteExpiredMessages, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DeleteExpiredMessagesRow{}
+	for rows.Next() {
+		var i DeleteExpiredMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.SenderID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setDisappearingMessagesTimer = `-- name: SetDisappearingMessagesTimer :execrows
+UPDATE conversations
+SET settings = CASE
+        WHEN $1::int > 0
+            THEN jsonb_set(COALESCE(settings, '{}'::jsonb), '{disappearing_messages_seconds}', to_jsonb($1::int))
+        ELSE COALESCE(settings, '{}'::jsonb) - 'disappearing_messages_seconds'
+    END,
+    updated_at = NOW()
+WHERE id = $2 AND is_active = true
+`
+
+type SetDisappearingMessagesTimerParams struct {
+	Seconds        int32     `json:"seconds"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+}
+
+func (q *Queries) SetDisappearingMessagesTimer(ctx context.Context, arg SetDisappearingMessagesTimerParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setDisappearingMessagesTimer, arg.Seconds, arg.ConversationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,205 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createFollowRequest = `-- name: CreateFollowRequest :one
+INSERT INTO follow_requests (requester_id, target_id, space_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (requester_id, target_id) DO NOTHING
+RETURNING id, requester_id, target_id, space_id, created_at
+`
+
+type CreateFollowRequestParams struct {
+	RequesterID uuid.UUID `json:"requester_id"`
+	TargetID    uuid.UUID `json:"target_id"`
+	SpaceID     uuid.UUID `json:"space_id"`
+}
+
+func (q *Queries) CreateFollowRequest(ctx context.Context, arg CreateFollowRequestParams) (FollowRequest, error) {
+	row := q.db.QueryRowContext(ctx, createFollowRequest, arg.RequesterID, arg.TargetID, arg.SpaceID)
+	var i FollowRequest
+	err := row.Scan(
+		&i.ID,
+		&i.RequesterID,
+		&i.TargetID,
+		&i.SpaceID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteFollowRequest = `-- name: DeleteFollowRequest :one
+DELETE FROM follow_requests
+WHERE requester_id = $1 AND target_id = $2
+RETURNING id, requester_id, target_id, space_id, created_at
+`
+
+type DeleteFollowRequestParams struct {
+	RequesterID uuid.UUID `json:"requester_id"`
+	TargetID    uuid.UUID `json:"target_id"`
+}
+
+func (q *Queries) DeleteFollowRequest(ctx context.Context, arg DeleteFollowRequestParams) (FollowRequest, error) {
+	row := q.db.QueryRowContext(ctx, deleteFollowRequest, arg.RequesterID, arg.TargetID)
+	var i FollowRequest
+	err := row.Scan(
+		&i.ID,
+		&i.RequesterID,
+		&i.TargetID,
+		&i.SpaceID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteFollowRequestsBetween = `-- name: DeleteFollowRequestsBetween :exec
+DELETE FROM follow_requests
+WHERE (requester_id = $1 AND target_id = $2)
+   OR (requester_id = $2 AND target_id = $1)
+`
+
+type DeleteFollowRequestsBetweenParams struct {
+	UserA uuid.UUID `json:"user_a"`
+	UserB uuid.UUID `json:"user_b"`
+}
+
+func (q *Queries) DeleteFollowRequestsBetween(ctx context.Context, arg DeleteFollowRequestsBetweenParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFollowRequestsBetween, arg.UserA, arg.UserB)
+	return err
+}
+
+const getIncomingFollowRequests = `-- name: GetIncomingFollowRequests :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    fr.created_at AS requested_at
+FROM follow_requests fr
+JOIN users u ON fr.requester_id = u.id
+WHERE fr.target_id = $1 AND u.status = 'active'
+ORDER BY fr.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetIncomingFollowRequestsParams struct {
+	TargetID uuid.UUID `json:"target_id"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+type GetIncomingFollowRequestsRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Username    string         `json:"username"`
+	FullName    string         `json:"full_name"`
+	Avatar      sql.NullString `json:"avatar"`
+	RequestedAt time.Time      `json:"requested_at"`
+}
+
+func (q *Queries) GetIncomingFollowRequests(ctx context.Context, arg GetIncomingFollowRequestsParams) ([]GetIncomingFollowRequestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getIncomingFollowRequests, arg.TargetID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetIncomingFollowRequestsRow{}
+	for rows.Next() {
+		var i GetIncomingFollowRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.RequestedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOutgoingFollowRequests = `-- name: GetOutgoingFollowRequests :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    fr.created_at AS requested_at
+FROM follow_requests fr
+JOIN users u ON fr.target_id = u.id
+WHERE fr.requester_id = $1 AND u.status = 'active'
+ORDER BY fr.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetOutgoingFollowRequestsParams struct {
+	RequesterID uuid.UUID `json:"requester_id"`
+	Limit       int32     `json:"limit"`
+	Offset      int32     `json:"offset"`
+}
+
+type GetOutgoingFollowRequestsRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Username    string         `json:"username"`
+	FullName    string         `json:"full_name"`
+	Avatar      sql.NullString `json:"avatar"`
+	RequestedAt time.Time      `json:"requested_at"`
+}
+
+func (q *Queries) GetOutgoingFollowRequests(ctx context.Context, arg GetOutgoingFollowRequestsParams) ([]GetOutgoingFollowRequestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getOutgoingFollowRequests, arg.RequesterID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOutgoingFollowRequestsRow{}
+	for rows.Next() {
+		var i GetOutgoingFollowRequestsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.RequestedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isPrivateAccount = `-- name: IsPrivateAccount :one
+SELECT is_private_account($1::uuid)::bool AS is_private
+`
+
+func (q *Queries) IsPrivateAccount(ctx context.Context, userID uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isPrivateAccount, userID)
+	var is_private bool
+	err := row.Scan(&is_private)
+	return is_private, err
+}
@@ -0,0 +1,288 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const createMentions = `-- name: CreateMentions :many
+INSERT INTO mentions (source_type, source_id, author_id, mentioned_user_id, start_offset, length)
+SELECT
+    $1::varchar,
+    $2::uuid,
+    $3::uuid,
+    m.user_id,
+    m.start_offset,
+    m.length
+FROM unnest(
+    $4::uuid[],
+    $5::int[],
+    $6::int[]
+) AS m(user_id, start_offset, length)
+ON CONFLICT (source_type, source_id, start_offset) DO NOTHING
+RETURNING id, source_type, source_id, author_id, mentioned_user_id, start_offset, length, created_at
+`
+
+type CreateMentionsParams struct {
+	SourceType string      `json:"source_type"`
+	SourceID   uuid.UUID   `json:"source_id"`
+	AuthorID   uuid.UUID   `json:"author_id"`
+	UserIds    []uuid.UUID `json:"user_ids"`
+	Offsets    []int32     `json:"offsets"`
+	Lengths    []int32     `json:"lengths"`
+}
+
+func (q *Queries) CreateMentions(ctx context.Context, arg CreateMentionsParams) ([]Mention, error) {
+	rows, err := q.db.QueryContext(ctx, createMentions,
+		arg.SourceType,
+		arg.SourceID,
+		arg.AuthorID,
+		pq.Array(arg.UserIds),
+		pq.Array(arg.Offsets),
+		pq.Array(arg.Lengths),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Mention{}
+	for rows.Next() {
+		var i Mention
+		if err := rows.Scan(
+			&i.ID,
+			&i.SourceType,
+			&i.SourceID,
+			&i.AuthorID,
+			&i.MentionedUserID,
+			&i.StartOffset,
+			&i.Length,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteMentionsBySource = `-- name: DeleteMentionsBySource :exec
+DELETE FROM mentions WHERE source_type = $1 AND source_id = $2
+`
+
+type DeleteMentionsBySourceParams struct {
+	SourceType string    `json:"source_type"`
+	SourceID   uuid.UUID `json:"source_id"`
+}
+
+func (q *Queries) DeleteMentionsBySource(ctx context.Context, arg DeleteMentionsBySourceParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMentionsBySource, arg.SourceType, arg.SourceID)
+	return err
+}
+
+const getMentionsBySources = `-- name: GetMentionsBySources :many
+SELECT
+    m.source_id,
+    m.mentioned_user_id,
+    u.username,
+    m.start_offset,
+    m.length
+FROM mentions m
+JOIN users u ON m.mentioned_user_id = u.id
+WHERE m.source_type = $1 AND m.source_id = ANY($2::uuid[])
+ORDER BY m.source_id, m.start_offset
+`
+
+type GetMentionsBySourcesParams struct {
+	SourceType string      `json:"source_type"`
+	SourceIds  []uuid.UUID `json:"source_ids"`
+}
+
+type GetMentionsBySourcesRow struct {
+	SourceID        uuid.UUID `json:"source_id"`
+	MentionedUserID uuid.UUID `json:"mentioned_user_id"`
+	Username        string    `json:"username"`
+	StartOffset     int32     `json:"start_offset"`
+	Length          int32     `json:"length"`
+}
+
+func (q *Queries) GetMentionsBySources(ctx context.Context, arg GetMentionsBySourcesParams) ([]GetMentionsBySourcesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMentionsBySources, arg.SourceType, pq.Array(arg.SourceIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMentionsBySourcesRow{}
+	for rows.Next() {
+		var i GetMentionsBySourcesRow
+		if err := rows.Scan(
+			&i.SourceID,
+			&i.MentionedUserID,
+			&i.Username,
+			&i.StartOffset,
+			&i.Length,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resolveMentionedUsers = `-- name: ResolveMentionedUsers :many
+SELECT
+    u.id,
+    u.username,
+    COALESCE(u.settings->>'allow_mentions_from', 'everyone')::text AS allow_mentions_from,
+    EXISTS(
+        SELECT 1 FROM follows f
+        WHERE f.follower_id = u.id AND f.following_id = $1
+    ) AS follows_author,
+    ($4::uuid IS NULL OR EXISTS (
+        SELECT 1 FROM posts p
+        WHERE p.id = $4::uuid
+          AND can_view_post(u.id, p.author_id, p.visibility, p.community_id, p.group_id)
+    ))::bool AS can_view_post
+FROM users u
+WHERE lower(u.username) = ANY($2::text[])
+  AND u.space_id = (SELECT a.space_id FROM users a WHERE a.id = $1)
+  AND u.status = 'active'
+  AND NOT is_blocked_between(u.id, $1)
+  AND ($3::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM conversation_participants cp
+      WHERE cp.conversation_id = $3::uuid
+        AND cp.user_id = u.id
+        AND cp.left_at IS NULL
+  ))
+`
+
+type ResolveMentionedUsersParams struct {
+	AuthorID       uuid.UUID     `json:"author_id"`
+	Usernames      []string      `json:"usernames"`
+	ConversationID uuid.NullUUID `json:"conversation_id"`
+	PostID         uuid.NullUUID `json:"post_id"`
+}
+
+type ResolveMentionedUsersRow struct {
+	ID                uuid.UUID `json:"id"`
+	Username          string    `json:"username"`
+	AllowMentionsFrom string    `json:"allow_mentions_from"`
+	FollowsAuthor     bool      `json:"follows_author"`
+	CanViewPost       bool      `json:"can_view_post"`
+}
+
+func (q *Queries) ResolveMentionedUsers(ctx context.Context, arg ResolveMentionedUsersParams) ([]ResolveMentionedUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, resolveMentionedUsers,
+		arg.AuthorID,
+		pq.Array(arg.Usernames),
+		arg.ConversationID,
+		arg.PostID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ResolveMentionedUsersRow{}
+	for rows.Next() {
+		var i ResolveMentionedUsersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.AllowMentionsFrom,
+			&i.FollowsAuthor,
+			&i.CanViewPost,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchMentionCandidates = `-- name: SearchMentionCandidates :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    EXISTS(
+        SELECT 1 FROM follows f
+        WHERE f.follower_id = $1 AND f.following_id = u.id
+    ) AS is_following
+FROM users u
+WHERE u.space_id = (SELECT a.space_id FROM users a WHERE a.id = $1)
+  AND u.id <> $1
+  AND u.status = 'active'
+  AND NOT is_blocked_between(u.id, $1)
+  AND (lower(u.username) LIKE $2::text || '%' OR lower(u.full_name) LIKE $2::text || '%')
+ORDER BY is_following DESC, (lower(u.username) = $2::text) DESC, u.username ASC
+LIMIT $3
+`
+
+type SearchMentionCandidatesParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Prefix    string    `json:"prefix"`
+	PageLimit int32     `json:"page_limit"`
+}
+
+type SearchMentionCandidatesRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Username    string         `json:"username"`
+	FullName    string         `json:"full_name"`
+	Avatar      sql.NullString `json:"avatar"`
+	IsFollowing bool           `json:"is_following"`
+}
+
+func (q *Queries) SearchMentionCandidates(ctx context.Context, arg SearchMentionCandidatesParams) ([]SearchMentionCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchMentionCandidates, arg.UserID, arg.Prefix, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchMentionCandidatesRow{}
+	for rows.Next() {
+		var i SearchMentionCandidatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.IsFollowing,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
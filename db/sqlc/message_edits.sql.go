@@ -0,0 +1,135 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const editMessage = `-- name: EditMessage :one
+WITH previous AS (
+    SELECT id, content
+    FROM messages
+    WHERE id = $1 AND COALESCE(status, 'sent') <> 'deleted'
+    FOR UPDATE
+), revision AS (
+    INSERT INTO message_revisions (message_id, content, edited_by)
+    SELECT previous.id, previous.content, $2
+    FROM previous
+)
+UPDATE messages m
+SET content = $3, edited_at = NOW()
+FROM previous
+WHERE m.id = previous.id
+RETURNING m.id, m.conversation_id, m.sender_id, m.recipient_id, m.content, m.attachments, m.message_type, m.is_read, m.read_at, m.reactions, m.reply_to_id, m.status, m.created_at, m.edited_at, m.thread_root_id, m.expires_at, m.shared_type, m.shared_id, m.shared_preview, m.is_forwarded, m.forwarded_from_id
+`
+
+type EditMessageParams struct {
+	ID       uuid.UUID      `json:"id"`
+	EditedBy uuid.UUID      `json:"edited_by"`
+	Content  sql.NullString `json:"content"`
+}
+
+func (q *Queries) EditMessage(ctx context.Context, arg EditMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, editMessage, arg.ID, arg.EditedBy, arg.Content)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.SenderID,
+		&i.RecipientID,
+		&i.Content,
+		&i.Attachments,
+		&i.MessageType,
+		&i.IsRead,
+		&i.ReadAt,
+		&i.Reactions,
+		&i.ReplyToID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.ThreadRootID,
+		&i.ExpiresAt,
+		&i.SharedType,
+		&i.SharedID,
+		&i.SharedPreview,
+		&i.IsForwarded,
+		&i.ForwardedFromID,
+	)
+	return i, err
+}
+
+const getConversationParticipantRole = `-- name: GetConversationParticipantRole :one
+SELECT COALESCE(role, 'member')::text AS role
+FROM conversation_participants
+WHERE conversation_id = $1 AND user_id = $2 AND is_active = true
+`
+
+type GetConversationParticipantRoleParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetConversationParticipantRole(ctx context.Context, arg GetConversationParticipantRoleParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getConversationParticipantRole, arg.ConversationID, arg.UserID)
+	var role string
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getMessageRevisions = `-- name: GetMessageRevisions :many
+SELECT
+    r.id,
+    r.content,
+    r.edited_by,
+    u.username AS editor_username,
+    r.created_at
+FROM message_revisions r
+JOIN users u ON r.edited_by = u.id
+WHERE r.message_id = $1
+ORDER BY r.created_at DESC, r.id DESC
+`
+
+type GetMessageRevisionsRow struct {
+	ID             uuid.UUID      `json:"id"`
+	Content        sql.NullString `json:"content"`
+	EditedBy       uuid.UUID      `json:"edited_by"`
+	EditorUsername string         `json:"editor_username"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+func (q *Queries) GetMessageRevisions(ctx context.Context, messageID uuid.UUID) ([]GetMessageRevisionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageRevisions, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageRevisionsRow{}
+	for rows.Next() {
+		var i GetMessageRevisionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Content,
+			&i.EditedBy,
+			&i.EditorUsername,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,214 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const addDirectParticipant = `-- name: AddDirectParticipant :exec
+INSERT INTO conversation_participants (conversation_id, user_id, role, request_status)
+VALUES ($1, $2, 'member', $3)
+ON CONFLICT (conversation_id, user_id) DO NOTHING
+`
+
+type AddDirectParticipantParams struct {
+	ConversationID uuid.UUID      `json:"conversation_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	RequestStatus  sql.NullString `json:"request_status"`
+}
+
+func (q *Queries) AddDirectParticipant(ctx context.Context, arg AddDirectParticipantParams) error {
+	_, err := q.db.ExecContext(ctx, addDirectParticipant, arg.ConversationID, arg.UserID, arg.RequestStatus)
+	return err
+}
+
+const getConversationPeer = `-- name: GetConversationPeer :one
+SELECT other.user_id
+FROM conversation_participants other
+WHERE other.conversation_id = $1 AND other.user_id <> $2
+ORDER BY other.joined_at
+LIMIT 1
+`
+
+type GetConversationPeerParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetConversationPeer(ctx context.Context, arg GetConversationPeerParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getConversationPeer, arg.ConversationID, arg.UserID)
+	var user_id uuid.UUID
+	err := row.Scan(&user_id)
+	return user_id, err
+}
+
+const getDirectMessagePolicy = `-- name: GetDirectMessagePolicy :one
+SELECT
+    COALESCE(u.settings->>'allow_messages_from', 'connections')::text AS allow_messages_from,
+    (
+        EXISTS(
+            SELECT 1 FROM follows f
+            WHERE f.follower_id = u.id AND f.following_id = $1)
+        OR EXISTS(
+            SELECT 1 FROM group_members a
+            JOIN group_members b ON a.group_id = b.group_id
+            WHERE a.user_id = u.id AND b.user_id = $1)
+        OR EXISTS(
+            SELECT 1 FROM community_members a
+            JOIN community_members b ON a.community_id = b.community_id
+            WHERE a.user_id = u.id AND b.user_id = $1)
+    )::bool AS connected
+FROM users u
+WHERE u.id = $2
+`
+
+type GetDirectMessagePolicyParams struct {
+	SenderID    uuid.UUID `json:"sender_id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+}
+
+type GetDirectMessagePolicyRow struct {
+	AllowMessagesFrom string `json:"allow_messages_from"`
+	Connected         bool   `json:"connected"`
+}
+
+func (q *Queries) GetDirectMessagePolicy(ctx context.Context, arg GetDirectMessagePolicyParams) (GetDirectMessagePolicyRow, error) {
+	row := q.db.QueryRowContext(ctx, getDirectMessagePolicy, arg.SenderID, arg.RecipientID)
+	var i GetDirectMessagePolicyRow
+	err := row.Scan(
+		&i.AllowMessagesFrom,
+		&i.Connected,
+	)
+	return i, err
+}
+
+const getMessageRequests = `-- name: GetMessageRequests :many
+SELECT
+    c.id AS conversation_id,
+    cp.joined_at AS requested_at,
+    u.id AS requester_id,
+    u.username AS requester_username,
+    u.full_name AS requester_full_name,
+    u.avatar AS requester_avatar,
+    last_msg.content AS last_message_content,
+    last_msg.created_at AS last_message_time,
+    (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.sender_id <> cp.user_id) AS message_count
+FROM conversation_participants cp
+JOIN conversations c ON c.id = cp.conversation_id
+JOIN conversation_participants peer ON peer.conversation_id = c.id AND peer.user_id <> cp.user_id
+JOIN users u ON u.id = peer.user_id
+LEFT JOIN LATERAL (
+    SELECT m2.content, m2.created_at
+    FROM messages m2
+    WHERE m2.conversation_id = c.id
+    ORDER BY m2.created_at DESC
+    LIMIT 1
+) last_msg ON true
+WHERE cp.user_id = $1
+  AND cp.is_active = true
+  AND cp.request_status = 'pending'
+  AND c.is_active = true
+  AND c.conversation_type = 'direct'
+  AND NOT is_blocked_between(cp.user_id, peer.user_id)
+ORDER BY COALESCE(last_msg.created_at, cp.joined_at) DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetMessageRequestsParams struct {
+	UserID     uuid.UUID `json:"user_id"`
+	PageSize   int32     `json:"page_size"`
+	PageOffset int32     `json:"page_offset"`
+}
+
+type GetMessageRequestsRow struct {
+	ConversationID     uuid.UUID      `json:"conversation_id"`
+	RequestedAt        sql.NullTime   `json:"requested_at"`
+	RequesterID        uuid.UUID      `json:"requester_id"`
+	RequesterUsername  string         `json:"requester_username"`
+	RequesterFullName  string         `json:"requester_full_name"`
+	RequesterAvatar    sql.NullString `json:"requester_avatar"`
+	LastMessageContent sql.NullString `json:"last_message_content"`
+	LastMessageTime    sql.NullTime   `json:"last_message_time"`
+	MessageCount       int64          `json:"message_count"`
+}
+
+func (q *Queries) GetMessageRequests(ctx context.Context, arg GetMessageRequestsParams) ([]GetMessageRequestsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageRequests, arg.UserID, arg.PageSize, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageRequestsRow{}
+	for rows.Next() {
+		var i GetMessageRequestsRow
+		if err := rows.Scan(
+			&i.ConversationID,
+			&i.RequestedAt,
+			&i.RequesterID,
+			&i.RequesterUsername,
+			&i.RequesterFullName,
+			&i.RequesterAvatar,
+			&i.LastMessageContent,
+			&i.LastMessageTime,
+			&i.MessageCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParticipantRequestStatus = `-- name: GetParticipantRequestStatus :one
+SELECT COALESCE(request_status, 'accepted')::text AS request_status
+FROM conversation_participants
+WHERE conversation_id = $1 AND user_id = $2 AND is_active = true
+`
+
+type GetParticipantRequestStatusParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetParticipantRequestStatus(ctx context.Context, arg GetParticipantRequestStatusParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getParticipantRequestStatus, arg.ConversationID, arg.UserID)
+	var request_status string
+	err := row.Scan(&request_status)
+	return request_status, err
+}
+
+const updateMessageRequestStatus = `-- name: UpdateMessageRequestStatus :execrows
+UPDATE conversation_participants
+SET request_status = $1
+WHERE conversation_id = $2
+  AND user_id = $3
+  AND is_active = true
+  AND request_status IN ('pending', 'declined')
+`
+
+type UpdateMessageRequestStatusParams struct {
+	RequestStatus  sql.NullString `json:"request_status"`
+	ConversationID uuid.UUID      `json:"conversation_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+}
+
+func (q *Queries) UpdateMessageRequestStatus(ctx context.Context, arg UpdateMessageRequestStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateMessageRequestStatus, arg.RequestStatus, arg.ConversationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
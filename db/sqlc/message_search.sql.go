@@ -0,0 +1,227 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const getMessageSearchContext = `-- name: GetMessageSearchContext :many
+SELECT
+    h.id AS hit_id,
+    ctx.position,
+    ctx.id,
+    ctx.sender_id,
+    u.username AS sender_username,
+    ctx.content,
+    ctx.message_type,
+    ctx.created_at
+FROM messages h
+CROSS JOIN LATERAL (
+    (
+        SELECT 'before'::text AS position, b.id, b.sender_id, b.content, b.message_type, b.created_at
+        FROM messages b
+        WHERE b.conversation_id = h.conversation_id
+          AND COALESCE(b.status, 'sent') <> 'deleted'
+          AND (b.expires_at IS NULL OR b.expires_at > NOW())
+          AND (b.created_at, b.id) < (h.created_at, h.id)
+        ORDER BY b.created_at DESC, b.id DESC
+        LIMIT $1
+    )
+    UNION ALL
+    (
+        SELECT 'after'::text AS position, a.id, a.sender_id, a.content, a.message_type, a.created_at
+        FROM messages a
+        WHERE a.conversation_id = h.conversation_id
+          AND COALESCE(a.status, 'sent') <> 'deleted'
+          AND (a.expires_at IS NULL OR a.expires_at > NOW())
+          AND (a.created_at, a.id) > (h.created_at, h.id)
+        ORDER BY a.created_at ASC, a.id ASC
+        LIMIT $1
+    )
+) ctx
+JOIN users u ON u.id = ctx.sender_id
+WHERE h.id = ANY($2::uuid[])
+ORDER BY h.id, ctx.created_at, ctx.id
+`
+
+type GetMessageSearchContextParams struct {
+	ContextSize int32       `json:"context_size"`
+	HitIds      []uuid.UUID `json:"hit_ids"`
+}
+
+type GetMessageSearchContextRow struct {
+	HitID          uuid.UUID      `json:"hit_id"`
+	Position       string         `json:"position"`
+	ID             uuid.UUID      `json:"id"`
+	SenderID       uuid.UUID      `json:"sender_id"`
+	SenderUsername string         `json:"sender_username"`
+	Content        sql.NullString `json:"content"`
+	MessageType    sql.NullString `json:"message_type"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+}
+
+func (q *Queries) GetMessageSearchContext(ctx context.Context, arg GetMessageSearchContextParams) ([]GetMessageSearchContextRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageSearchContext, arg.ContextSize, pq.Array(arg.HitIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageSearchContextRow{}
+	for rows.Next() {
+		var i GetMessageSearchContextRow
+		if err := rows.Scan(
+			&i.HitID,
+			&i.Position,
+			&i.ID,
+			&i.SenderID,
+			&i.SenderUsername,
+			&i.Content,
+			&i.MessageType,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchMessages = `-- name: SearchMessages :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', $1::text) AS tsq
+)
+SELECT
+    m.id,
+    m.conversation_id,
+    c.name AS conversation_name,
+    c.conversation_type,
+    m.sender_id,
+    u.username AS sender_username,
+    u.full_name AS sender_full_name,
+    u.avatar AS sender_avatar,
+    m.content,
+    ts_headline('english', html_escape(COALESCE(m.content, '')), q.tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=24, MinWords=8, FragmentDelimiter=" ... "')::text AS highlight,
+    m.attachments,
+    m.message_type,
+    m.created_at,
+    ts_rank(to_tsvector('english', COALESCE(m.content, '')), q.tsq)::float8 AS score
+FROM q, messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id
+JOIN conversations c ON c.id = m.conversation_id
+JOIN users u ON u.id = m.sender_id
+WHERE cp.user_id = $2
+  AND cp.is_active = true
+  AND c.is_active = true
+  AND COALESCE(cp.request_status, 'accepted') <> 'declined'
+  AND to_tsvector('english', COALESCE(m.content, '')) @@ q.tsq
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND ($3::uuid IS NULL OR m.conversation_id = $3)
+  AND ($4::uuid IS NULL OR m.sender_id = $4)
+  AND ($5::timestamptz IS NULL OR m.created_at >= $5)
+  AND ($6::timestamptz IS NULL OR m.created_at < $6)
+  AND ($7::bool IS NULL
+       OR (m.attachments IS NOT NULL AND m.attachments NOT IN ('null'::jsonb, '[]'::jsonb, '{}'::jsonb)) = $7)
+  AND ($8::uuid IS NULL
+       OR (ts_rank(to_tsvector('english', COALESCE(m.content, '')), q.tsq)::float8, m.created_at, m.id) < (
+           SELECT ts_rank(to_tsvector('english', COALESCE(cm.content, '')), q.tsq)::float8, cm.created_at, cm.id
+           FROM messages cm
+           WHERE cm.id = $8::uuid
+       ))
+ORDER BY score DESC, m.created_at DESC, m.id DESC
+LIMIT $9 OFFSET $10
+`
+
+type SearchMessagesParams struct {
+	Query          string        `json:"query"`
+	UserID         uuid.UUID     `json:"user_id"`
+	ConversationID uuid.NullUUID `json:"conversation_id"`
+	SenderID       uuid.NullUUID `json:"sender_id"`
+	After          sql.NullTime  `json:"after"`
+	Before         sql.NullTime  `json:"before"`
+	HasAttachment  sql.NullBool  `json:"has_attachment"`
+	CursorID       uuid.NullUUID `json:"cursor_id"`
+	PageSize       int32         `json:"page_size"`
+	PageOffset     int32         `json:"page_offset"`
+}
+
+type SearchMessagesRow struct {
+	ID               uuid.UUID             `json:"id"`
+	ConversationID   uuid.UUID             `json:"conversation_id"`
+	ConversationName sql.NullString        `json:"conversation_name"`
+	ConversationType sql.NullString        `json:"conversation_type"`
+	SenderID         uuid.UUID             `json:"sender_id"`
+	SenderUsername   string                `json:"sender_username"`
+	SenderFullName   string                `json:"sender_full_name"`
+	SenderAvatar     sql.NullString        `json:"sender_avatar"`
+	Content          sql.NullString        `json:"content"`
+	Highlight        string                `json:"highlight"`
+	Attachments      pqtype.NullRawMessage `json:"attachments"`
+	MessageType      sql.NullString        `json:"message_type"`
+	CreatedAt        sql.NullTime          `json:"created_at"`
+	Score            float64               `json:"score"`
+}
+
+func (q *Queries) SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchMessages,
+		arg.Query,
+		arg.UserID,
+		arg.ConversationID,
+		arg.SenderID,
+		arg.After,
+		arg.Before,
+		arg.HasAttachment,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchMessagesRow{}
+	for rows.Next() {
+		var i SearchMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.ConversationName,
+			&i.ConversationType,
+			&i.SenderID,
+			&i.SenderUsername,
+			&i.SenderFullName,
+			&i.SenderAvatar,
+			&i.Content,
+			&i.Highlight,
+			&i.Attachments,
+			&i.MessageType,
+			&i.CreatedAt,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
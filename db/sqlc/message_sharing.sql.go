@@ -0,0 +1,177 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const canViewSharedContent = `-- name: CanViewSharedContent :one
+SELECT can_view_search_document($1::uuid, $2::varchar, $3::uuid)::bool AS can_view
+`
+
+type CanViewSharedContentParams struct {
+	ViewerID   uuid.UUID `json:"viewer_id"`
+	SharedType string    `json:"shared_type"`
+	SharedID   uuid.UUID `json:"shared_id"`
+}
+
+func (q *Queries) CanViewSharedContent(ctx context.Context, arg CanViewSharedContentParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, canViewSharedContent, arg.ViewerID, arg.SharedType, arg.SharedID)
+	var can_view bool
+	err := row.Scan(&can_view)
+	return can_view, err
+}
+
+const getParticipantsWithoutAccess = `-- name: GetParticipantsWithoutAccess :many
+SELECT cp.user_id
+FROM conversation_participants cp
+WHERE cp.conversation_id = $1
+  AND cp.is_active = true
+  AND NOT can_view_search_document(cp.user_id, $2::varchar, $3::uuid)
+`
+
+type GetParticipantsWithoutAccessParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	SharedType     string    `json:"shared_type"`
+	SharedID       uuid.UUID `json:"shared_id"`
+}
+
+func (q *Queries) GetParticipantsWithoutAccess(ctx context.Context, arg GetParticipantsWithoutAccessParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getParticipantsWithoutAccess, arg.ConversationID, arg.SharedType, arg.SharedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSharedCommunity = `-- name: GetSharedCommunity :one
+SELECT id, space_id, name, description, category, cover_image, member_count, status, post_count, is_public, created_by, settings, created_at, updated_at
+FROM communities
+WHERE id = $1 AND COALESCE(status, 'active') = 'active'
+`
+
+func (q *Queries) GetSharedCommunity(ctx context.Context, id uuid.UUID) (Community, error) {
+	row := q.db.QueryRowContext(ctx, getSharedCommunity, id)
+	var i Community
+	err := row.Scan(
+		&i.ID,
+		&i.SpaceID,
+		&i.Name,
+		&i.Description,
+		&i.Category,
+		&i.CoverImage,
+		&i.MemberCount,
+		&i.Status,
+		&i.PostCount,
+		&i.IsPublic,
+		&i.CreatedBy,
+		&i.Settings,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSharedEvent = `-- name: GetSharedEvent :one
+SELECT id, space_id, title, description, category, location, venue_details, start_date, end_date, timezone, organizer, tags, image_url, max_attendees, current_attendees, registration_required, registration_deadline, status, is_public, created_at, updated_at
+FROM events
+WHERE id = $1 AND COALESCE(status, 'published') = 'published'
+`
+
+func (q *Queries) GetSharedEvent(ctx context.Context, id uuid.UUID) (Event, error) {
+	row := q.db.QueryRowContext(ctx, getSharedEvent, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.SpaceID,
+		&i.Title,
+		&i.Description,
+		&i.Category,
+		&i.Location,
+		&i.VenueDetails,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Timezone,
+		&i.Organizer,
+		pq.Array(&i.Tags),
+		&i.ImageUrl,
+		&i.MaxAttendees,
+		&i.CurrentAttendees,
+		&i.RegistrationRequired,
+		&i.RegistrationDeadline,
+		&i.Status,
+		&i.IsPublic,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSharedPost = `-- name: GetSharedPost :one
+SELECT
+    p.id,
+    p.author_id,
+    u.username AS author_username,
+    u.full_name AS author_full_name,
+    u.avatar AS author_avatar,
+    p.content,
+    p.media,
+    p.content_warning,
+    p.created_at
+FROM posts p
+JOIN users u ON u.id = p.author_id
+WHERE p.id = $1 AND p.status = 'active'
+`
+
+type GetSharedPostRow struct {
+	ID             uuid.UUID             `json:"id"`
+	AuthorID       uuid.UUID             `json:"author_id"`
+	AuthorUsername string                `json:"author_username"`
+	AuthorFullName string                `json:"author_full_name"`
+	AuthorAvatar   sql.NullString        `json:"author_avatar"`
+	Content        string                `json:"content"`
+	Media          pqtype.NullRawMessage `json:"media"`
+	ContentWarning sql.NullString        `json:"content_warning"`
+	CreatedAt      sql.NullTime          `json:"created_at"`
+}
+
+func (q *Queries) GetSharedPost(ctx context.Context, id uuid.UUID) (GetSharedPostRow, error) {
+	row := q.db.QueryRowContext(ctx, getSharedPost, id)
+	var i GetSharedPostRow
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.AuthorUsername,
+		&i.AuthorFullName,
+		&i.AuthorAvatar,
+		&i.Content,
+		&i.Media,
+		&i.ContentWarning,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,273 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const getThreadLatestRepliers = `-- name: GetThreadLatestRepliers :many
+SELECT root_id, user_id, username, avatar
+FROM (
+    SELECT
+        m.thread_root_id::uuid AS root_id,
+        u.id AS user_id,
+        u.username,
+        u.avatar,
+        MAX(m.created_at) AS last_reply_at,
+        ROW_NUMBER() OVER (PARTITION BY m.thread_root_id ORDER BY MAX(m.created_at) DESC) AS position
+    FROM messages m
+    JOIN users u ON m.sender_id = u.id
+    WHERE m.thread_root_id = ANY($1::uuid[])
+      AND COALESCE(m.status, 'sent') <> 'deleted'
+    GROUP BY m.thread_root_id, u.id, u.username, u.avatar
+) repliers
+WHERE position <= $2::int
+ORDER BY root_id, last_reply_at DESC
+`
+
+type GetThreadLatestRepliersParams struct {
+	RootIds   []uuid.UUID `json:"root_ids"`
+	PerThread int32       `json:"per_thread"`
+}
+
+type GetThreadLatestRepliersRow struct {
+	RootID   uuid.UUID      `json:"root_id"`
+	UserID   uuid.UUID      `json:"user_id"`
+	Username string         `json:"username"`
+	Avatar   sql.NullString `json:"avatar"`
+}
+
+func (q *Queries) GetThreadLatestRepliers(ctx context.Context, arg GetThreadLatestRepliersParams) ([]GetThreadLatestRepliersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getThreadLatestRepliers, pq.Array(arg.RootIds), arg.PerThread)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetThreadLatestRepliersRow{}
+	for rows.Next() {
+		var i GetThreadLatestRepliersRow
+		if err := rows.Scan(
+			&i.RootID,
+			&i.UserID,
+			&i.Username,
+			&i.Avatar,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThreadParticipantsToNotify = `-- name: GetThreadParticipantsToNotify :many
+SELECT cp.user_id
+FROM conversation_participants cp
+JOIN messages root ON root.id = $1 AND root.conversation_id = cp.conversation_id
+WHERE cp.is_active = true
+  AND COALESCE(cp.notifications_enabled, true) = true
+  AND (cp.muted_until IS NULL OR cp.muted_until <= NOW())
+  AND cp.user_id <> $2
+  AND (cp.user_id = root.sender_id
+       OR EXISTS (SELECT 1 FROM messages r WHERE r.thread_root_id = root.id AND r.sender_id = cp.user_id))
+`
+
+type GetThreadParticipantsToNotifyParams struct {
+	RootID    uuid.UUID `json:"root_id"`
+	ReplierID uuid.UUID `json:"replier_id"`
+}
+
+func (q *Queries) GetThreadParticipantsToNotify(ctx context.Context, arg GetThreadParticipantsToNotifyParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getThreadParticipantsToNotify, arg.RootID, arg.ReplierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThreadReplies = `-- name: GetThreadReplies :many
+SELECT
+    m.id, m.conversation_id, m.sender_id, m.recipient_id, m.content, m.attachments, m.message_type, m.is_read, m.read_at, m.reactions, m.reply_to_id, m.status, m.created_at, m.edited_at, m.thread_root_id, m.expires_at, m.shared_type, m.shared_id, m.shared_preview, m.is_forwarded, m.forwarded_from_id,
+    u.username as sender_username,
+    u.full_name as sender_full_name,
+    u.avatar as sender_avatar,
+    reply_msg.content as reply_content,
+    reply_user.username as reply_username
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+LEFT JOIN messages reply_msg ON m.reply_to_id = reply_msg.id
+LEFT JOIN users reply_user ON reply_msg.sender_id = reply_user.id
+WHERE m.thread_root_id = $1
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND ($2::timestamptz IS NULL
+       OR (m.created_at, m.id) > ($2::timestamptz, $3::uuid))
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT $4 OFFSET $5
+`
+
+type GetThreadRepliesParams struct {
+	RootID          uuid.UUID     `json:"root_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
+}
+
+type GetThreadRepliesRow struct {
+	ID              uuid.UUID             `json:"id"`
+	ConversationID  uuid.UUID             `json:"conversation_id"`
+	SenderID        uuid.UUID             `json:"sender_id"`
+	RecipientID     uuid.NullUUID         `json:"recipient_id"`
+	Content         sql.NullString        `json:"content"`
+	Attachments     pqtype.NullRawMessage `json:"attachments"`
+	MessageType     sql.NullString        `json:"message_type"`
+	IsRead          sql.NullBool          `json:"is_read"`
+	ReadAt          sql.NullTime          `json:"read_at"`
+	Reactions       pqtype.NullRawMessage `json:"reactions"`
+	ReplyToID       uuid.NullUUID         `json:"reply_to_id"`
+	Status          sql.NullString        `json:"status"`
+	CreatedAt       sql.NullTime          `json:"created_at"`
+	EditedAt        sql.NullTime          `json:"edited_at"`
+	ThreadRootID    uuid.NullUUID         `json:"thread_root_id"`
+	ExpiresAt       sql.NullTime          `json:"expires_at"`
+	SharedType      sql.NullString        `json:"shared_type"`
+	SharedID        uuid.NullUUID         `json:"shared_id"`
+	SharedPreview   pqtype.NullRawMessage `json:"shared_preview"`
+	IsForwarded     bool                  `json:"is_forwarded"`
+	ForwardedFromID uuid.NullUUID         `json:"forwarded_from_id"`
+	SenderUsername  string                `json:"sender_username"`
+	SenderFullName  string                `json:"sender_full_name"`
+	SenderAvatar    sql.NullString        `json:"sender_avatar"`
+	ReplyContent    sql.NullString        `json:"reply_content"`
+	ReplyUsername   sql.NullString        `json:"reply_username"`
+}
+
+func (q *Queries) GetThreadReplies(ctx context.Context, arg GetThreadRepliesParams) ([]GetThreadRepliesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getThreadReplies,
+		arg.RootID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetThreadRepliesRow{}
+	for rows.Next() {
+		var i GetThreadRepliesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ConversationID,
+			&i.SenderID,
+			&i.RecipientID,
+			&i.Content,
+			&i.Attachments,
+			&i.MessageType,
+			&i.IsRead,
+			&i.ReadAt,
+			&i.Reactions,
+			&i.ReplyToID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.EditedAt,
+			&i.ThreadRootID,
+			&i.ExpiresAt,
+			&i.SharedType,
+			&i.SharedID,
+			&i.SharedPreview,
+			&i.IsForwarded,
+			&i.ForwardedFromID,
+			&i.SenderUsername,
+			&i.SenderFullName,
+			&i.SenderAvatar,
+			&i.ReplyContent,
+			&i.ReplyUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThreadSummaries = `-- name: GetThreadSummaries :many
+SELECT
+    m.thread_root_id::uuid AS root_id,
+    COUNT(*)::bigint AS reply_count,
+    MAX(m.created_at)::timestamptz AS last_reply_at
+FROM messages m
+WHERE m.thread_root_id = ANY($1::uuid[])
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+GROUP BY m.thread_root_id
+`
+
+type GetThreadSummariesRow struct {
+	RootID      uuid.UUID `json:"root_id"`
+	ReplyCount  int64     `json:"reply_count"`
+	LastReplyAt time.Time `json:"last_reply_at"`
+}
+
+func (q *Queries) GetThreadSummaries(ctx context.Context, rootIds []uuid.UUID) ([]GetThreadSummariesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getThreadSummaries, pq.Array(rootIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetThreadSummariesRow{}
+	for rows.Next() {
+		var i GetThreadSummariesRow
+		if err := rows.Scan(
+			&i.RootID,
+			&i.ReplyCount,
+			&i.LastReplyAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -51,6 +51,19 @@ func (q *Queries) AddMessageReaction(ctx context.Context, arg AddMessageReaction
 	return err
 }
 
+const countConversationMessages = `-- name: CountConversationMessages :one
+SELECT COUNT(*) FROM messages m
+WHERE m.conversation_id = $1
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+`
+
+func (q *Queries) CountConversationMessages(ctx context.Context, conversationID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countConversationMessages, conversationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createConversation = `-- name: CreateConversation :one
 
 INSERT INTO conversations (space_id, name, avatar, description, conversation_type, settings)
@@ -114,14 +127,22 @@ func (q *Queries) DeleteMessage(ctx context.Context, arg DeleteMessageParams) er
 const getConversationByID = `-- name: GetConversationByID :one
 SELECT 
     c.id, c.space_id, c.name, c.avatar, c.description, c.conversation_type, c.last_message_id, c.last_message_at, c.is_active, c.settings, c.created_at, c.updated_at,
-    (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.recipient_id = $1) as unread_count
+    (SELECT COUNT(*)
+     FROM conversation_participants cp
+     JOIN messages m ON m.conversation_id = cp.conversation_id
+     WHERE cp.conversation_id = c.id AND cp.user_id = $1 AND cp.is_active = true
+       AND m.sender_id <> cp.user_id
+       AND COALESCE(m.status, 'sent') <> 'deleted'
+       AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+       AND (cp.last_read_message_at IS NULL
+            OR (m.created_at, m.id) > (cp.last_read_message_at, cp.last_read_message_id))) as unread_count
 FROM conversations c
 WHERE c.id = $2 AND c.is_active = true
 `
 
 type GetConversationByIDParams struct {
-	RecipientID uuid.NullUUID `json:"recipient_id"`
-	ID          uuid.UUID     `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+	ID     uuid.UUID `json:"id"`
 }
 
 type GetConversationByIDRow struct {
@@ -141,7 +162,7 @@ type GetConversationByIDRow struct {
 }
 
 func (q *Queries) GetConversationByID(ctx context.Context, arg GetConversationByIDParams) (GetConversationByIDRow, error) {
-	row := q.db.QueryRowContext(ctx, getConversationByID, arg.RecipientID, arg.ID)
+	row := q.db.QueryRowContext(ctx, getConversationByID, arg.UserID, arg.ID)
 	var i GetConversationByIDRow
 	err := row.Scan(
 		&i.ID,
@@ -189,7 +210,7 @@ func (q *Queries) GetConversationByParticipants(ctx context.Context, arg GetConv
 
 const getConversationMessages = `-- name: GetConversationMessages :many
 SELECT 
-    m.id, m.conversation_id, m.sender_id, m.recipient_id, m.content, m.attachments, m.message_type, m.is_read, m.read_at, m.reactions, m.reply_to_id, m.status, m.created_at,
+    m.id, m.conversation_id, m.sender_id, m.recipient_id, m.content, m.attachments, m.message_type, m.is_read, m.read_at, m.reactions, m.reply_to_id, m.status, m.created_at, m.edited_at, m.thread_root_id, m.expires_at, m.shared_type, m.shared_id, m.shared_preview, m.is_forwarded, m.forwarded_from_id,
     u.username as sender_username,
     u.full_name as sender_full_name,
     u.avatar as sender_avatar,
@@ -200,39 +221,58 @@ JOIN users u ON m.sender_id = u.id
 LEFT JOIN messages reply_msg ON m.reply_to_id = reply_msg.id
 LEFT JOIN users reply_user ON reply_msg.sender_id = reply_user.id
 WHERE m.conversation_id = $1
-ORDER BY m.created_at ASC
-LIMIT $2 OFFSET $3
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND ($2::timestamptz IS NULL
+       OR (m.created_at, m.id) > ($2::timestamptz, $3::uuid))
+ORDER BY m.created_at ASC, m.id ASC
+LIMIT $4 OFFSET $5
 `
 
 type GetConversationMessagesParams struct {
-	ConversationID uuid.UUID `json:"conversation_id"`
-	Limit          int32     `json:"limit"`
-	Offset         int32     `json:"offset"`
+	ConversationID  uuid.UUID     `json:"conversation_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetConversationMessagesRow struct {
-	ID             uuid.UUID             `json:"id"`
-	ConversationID uuid.UUID             `json:"conversation_id"`
-	SenderID       uuid.UUID             `json:"sender_id"`
-	RecipientID    uuid.NullUUID         `json:"recipient_id"`
-	Content        sql.NullString        `json:"content"`
-	Attachments    pqtype.NullRawMessage `json:"attachments"`
-	MessageType    sql.NullString        `json:"message_type"`
-	IsRead         sql.NullBool          `json:"is_read"`
-	ReadAt         sql.NullTime          `json:"read_at"`
-	Reactions      pqtype.NullRawMessage `json:"reactions"`
-	ReplyToID      uuid.NullUUID         `json:"reply_to_id"`
-	Status         sql.NullString        `json:"status"`
-	CreatedAt      sql.NullTime          `json:"created_at"`
-	SenderUsername string                `json:"sender_username"`
-	SenderFullName string                `json:"sender_full_name"`
-	SenderAvatar   sql.NullString        `json:"sender_avatar"`
-	ReplyContent   sql.NullString        `json:"reply_content"`
-	ReplyUsername  sql.NullString        `json:"reply_username"`
+	ID              uuid.UUID             `json:"id"`
+	ConversationID  uuid.UUID             `json:"conversation_id"`
+	SenderID        uuid.UUID             `json:"sender_id"`
+	RecipientID     uuid.NullUUID         `json:"recipient_id"`
+	Content         sql.NullString        `json:"content"`
+	Attachments     pqtype.NullRawMessage `json:"attachments"`
+	MessageType     sql.NullString        `json:"message_type"`
+	IsRead          sql.NullBool          `json:"is_read"`
+	ReadAt          sql.NullTime          `json:"read_at"`
+	Reactions       pqtype.NullRawMessage `json:"reactions"`
+	ReplyToID       uuid.NullUUID         `json:"reply_to_id"`
+	Status          sql.NullString        `json:"status"`
+	CreatedAt       sql.NullTime          `json:"created_at"`
+	EditedAt        sql.NullTime          `json:"edited_at"`
+	ThreadRootID    uuid.NullUUID         `json:"thread_root_id"`
+	ExpiresAt       sql.NullTime          `json:"expires_at"`
+	SharedType      sql.NullString        `json:"shared_type"`
+	SharedID        uuid.NullUUID         `json:"shared_id"`
+	SharedPreview   pqtype.NullRawMessage `json:"shared_preview"`
+	IsForwarded     bool                  `json:"is_forwarded"`
+	ForwardedFromID uuid.NullUUID         `json:"forwarded_from_id"`
+	SenderUsername  string                `json:"sender_username"`
+	SenderFullName  string                `json:"sender_full_name"`
+	SenderAvatar    sql.NullString        `json:"sender_avatar"`
+	ReplyContent    sql.NullString        `json:"reply_content"`
+	ReplyUsername   sql.NullString        `json:"reply_username"`
 }
 
 func (q *Queries) GetConversationMessages(ctx context.Context, arg GetConversationMessagesParams) ([]GetConversationMessagesRow, error) {
-	rows, err := q.db.QueryContext(ctx, getConversationMessages, arg.ConversationID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getConversationMessages,
+		arg.ConversationID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -254,6 +294,14 @@ func (q *Queries) GetConversationMessages(ctx context.Context, arg GetConversati
 			&i.ReplyToID,
 			&i.Status,
 			&i.CreatedAt,
+			&i.EditedAt,
+			&i.ThreadRootID,
+			&i.ExpiresAt,
+			&i.SharedType,
+			&i.SharedID,
+			&i.SharedPreview,
+			&i.IsForwarded,
+			&i.ForwardedFromID,
 			&i.SenderUsername,
 			&i.SenderFullName,
 			&i.SenderAvatar,
@@ -337,7 +385,7 @@ func (q *Queries) GetConversationParticipants(ctx context.Context, conversationI
 
 const getMessageByID = `-- name: GetMessageByID :one
 SELECT 
-    m.id, m.conversation_id, m.sender_id, m.recipient_id, m.content, m.attachments, m.message_type, m.is_read, m.read_at, m.reactions, m.reply_to_id, m.status, m.created_at,
+    m.id, m.conversation_id, m.sender_id, m.recipient_id, m.content, m.attachments, m.message_type, m.is_read, m.read_at, m.reactions, m.reply_to_id, m.status, m.created_at, m.edited_at, m.thread_root_id, m.expires_at, m.shared_type, m.shared_id, m.shared_preview, m.is_forwarded, m.forwarded_from_id,
     u.username as sender_username,
     u.full_name as sender_full_name,
     u.avatar as sender_avatar
@@ -347,22 +395,30 @@ WHERE m.id = $1
 `
 
 type GetMessageByIDRow struct {
-	ID             uuid.UUID             `json:"id"`
-	ConversationID uuid.UUID             `json:"conversation_id"`
-	SenderID       uuid.UUID             `json:"sender_id"`
-	RecipientID    uuid.NullUUID         `json:"recipient_id"`
-	Content        sql.NullString        `json:"content"`
-	Attachments    pqtype.NullRawMessage `json:"attachments"`
-	MessageType    sql.NullString        `json:"message_type"`
-	IsRead         sql.NullBool          `json:"is_read"`
-	ReadAt         sql.NullTime          `json:"read_at"`
-	Reactions      pqtype.NullRawMessage `json:"reactions"`
-	ReplyToID      uuid.NullUUID         `json:"reply_to_id"`
-	Status         sql.NullString        `json:"status"`
-	CreatedAt      sql.NullTime          `json:"created_at"`
-	SenderUsername string                `json:"sender_username"`
-	SenderFullName string                `json:"sender_full_name"`
-	SenderAvatar   sql.NullString        `json:"sender_avatar"`
+	ID              uuid.UUID             `json:"id"`
+	ConversationID  uuid.UUID             `json:"conversation_id"`
+	SenderID        uuid.UUID             `json:"sender_id"`
+	RecipientID     uuid.NullUUID         `json:"recipient_id"`
+	Content         sql.NullString        `json:"content"`
+	Attachments     pqtype.NullRawMessage `json:"attachments"`
+	MessageType     sql.NullString        `json:"message_type"`
+	IsRead          sql.NullBool          `json:"is_read"`
+	ReadAt          sql.NullTime          `json:"read_at"`
+	Reactions       pqtype.NullRawMessage `json:"reactions"`
+	ReplyToID       uuid.NullUUID         `json:"reply_to_id"`
+	Status          sql.NullString        `json:"status"`
+	CreatedAt       sql.NullTime          `json:"created_at"`
+	EditedAt        sql.NullTime          `json:"edited_at"`
+	ThreadRootID    uuid.NullUUID         `json:"thread_root_id"`
+	ExpiresAt       sql.NullTime          `json:"expires_at"`
+	SharedType      sql.NullString        `json:"shared_type"`
+	SharedID        uuid.NullUUID         `json:"shared_id"`
+	SharedPreview   pqtype.NullRawMessage `json:"shared_preview"`
+	IsForwarded     bool                  `json:"is_forwarded"`
+	ForwardedFromID uuid.NullUUID         `json:"forwarded_from_id"`
+	SenderUsername  string                `json:"sender_username"`
+	SenderFullName  string                `json:"sender_full_name"`
+	SenderAvatar    sql.NullString        `json:"sender_avatar"`
 }
 
 func (q *Queries) GetMessageByID(ctx context.Context, id uuid.UUID) (GetMessageByIDRow, error) {
@@ -382,6 +438,14 @@ func (q *Queries) GetMessageByID(ctx context.Context, id uuid.UUID) (GetMessageB
 		&i.ReplyToID,
 		&i.Status,
 		&i.CreatedAt,
+		&i.EditedAt,
+		&i.ThreadRootID,
+		&i.ExpiresAt,
+		&i.SharedType,
+		&i.SharedID,
+		&i.SharedPreview,
+		&i.IsForwarded,
+		&i.ForwardedFromID,
 		&i.SenderUsername,
 		&i.SenderFullName,
 		&i.SenderAvatar,
@@ -427,20 +491,27 @@ func (q *Queries) GetOrCreateDirectConversation(ctx context.Context, arg GetOrCr
 }
 
 const getUnreadMessageCount = `-- name: GetUnreadMessageCount :one
-SELECT COUNT(*) FROM messages 
-WHERE conversation_id = $1 AND recipient_id = $2 AND is_read = false
+SELECT COUNT(m.id)::bigint AS unread_count
+FROM conversation_participants cp
+JOIN messages m ON m.conversation_id = cp.conversation_id
+WHERE cp.conversation_id = $1 AND cp.user_id = $2 AND cp.is_active = true
+  AND m.sender_id <> cp.user_id
+  AND COALESCE(m.status, 'sent') <> 'deleted'
+  AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+  AND (cp.last_read_message_at IS NULL
+       OR (m.created_at, m.id) > (cp.last_read_message_at, cp.last_read_message_id))
 `
 
 type GetUnreadMessageCountParams struct {
-	ConversationID uuid.UUID     `json:"conversation_id"`
-	RecipientID    uuid.NullUUID `json:"recipient_id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
 }
 
 func (q *Queries) GetUnreadMessageCount(ctx context.Context, arg GetUnreadMessageCountParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getUnreadMessageCount, arg.ConversationID, arg.RecipientID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+	row := q.db.QueryRowContext(ctx, getUnreadMessageCount, arg.ConversationID, arg.UserID)
+	var unread_count int64
+	err := row.Scan(&unread_count)
+	return unread_count, err
 }
 
 const getUserConversations = `-- name: GetUserConversations :many
@@ -449,13 +520,25 @@ SELECT
     cp.role as user_role,
     cp.notifications_enabled,
     cp.custom_settings,
-    (SELECT COUNT(*) FROM messages m WHERE m.conversation_id = c.id AND m.is_read = false AND m.recipient_id = $1) as unread_count,
+    cp.muted_until,
+    cp.archived_at,
+    unread.unread_count,
     last_msg.content as last_message_content,
     last_msg.created_at as last_message_time,
     last_sender.username as last_sender_username,
     last_sender.full_name as last_sender_full_name
 FROM conversations c
 JOIN conversation_participants cp ON c.id = cp.conversation_id
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS unread_count
+    FROM messages m
+    WHERE m.conversation_id = c.id
+      AND m.sender_id <> cp.user_id
+      AND COALESCE(m.status, 'sent') <> 'deleted'
+      AND m.created_at >= COALESCE(cp.joined_at, '-infinity'::timestamptz)
+      AND (cp.last_read_message_at IS NULL
+           OR (m.created_at, m.id) > (cp.last_read_message_at, cp.last_read_message_id))
+) unread
 LEFT JOIN LATERAL (
     SELECT m2.content, m2.created_at, m2.sender_id
     FROM messages m2
@@ -465,9 +548,21 @@ LEFT JOIN LATERAL (
 ) last_msg ON true
 LEFT JOIN users last_sender ON last_msg.sender_id = last_sender.id
 WHERE cp.user_id = $1 AND cp.is_active = true AND c.is_active = true
+  AND COALESCE(cp.request_status, 'accepted') = 'accepted'
+  AND ($2::bool IS NULL OR (cp.archived_at IS NOT NULL) = $2)
+  AND ($3::bool IS NULL
+       OR (COALESCE(cp.notifications_enabled, true) = false OR COALESCE(cp.muted_until > NOW(), false)) = $3)
+  AND ($4::bool IS NULL OR (unread.unread_count > 0) = $4)
 ORDER BY c.last_message_at DESC NULLS LAST
 `
 
+type GetUserConversationsParams struct {
+	UserID   uuid.UUID    `json:"user_id"`
+	Archived sql.NullBool `json:"archived"`
+	Muted    sql.NullBool `json:"muted"`
+	Unread   sql.NullBool `json:"unread"`
+}
+
 type GetUserConversationsRow struct {
 	ID                   uuid.UUID             `json:"id"`
 	SpaceID              uuid.UUID             `json:"space_id"`
@@ -484,6 +579,8 @@ type GetUserConversationsRow struct {
 	UserRole             sql.NullString        `json:"user_role"`
 	NotificationsEnabled sql.NullBool          `json:"notifications_enabled"`
 	CustomSettings       pqtype.NullRawMessage `json:"custom_settings"`
+	MutedUntil           sql.NullTime          `json:"muted_until"`
+	ArchivedAt           sql.NullTime          `json:"archived_at"`
 	UnreadCount          int64                 `json:"unread_count"`
 	LastMessageContent   sql.NullString        `json:"last_message_content"`
 	LastMessageTime      sql.NullTime          `json:"last_message_time"`
@@ -491,8 +588,13 @@ type GetUserConversationsRow struct {
 	LastSenderFullName   sql.NullString        `json:"last_sender_full_name"`
 }
 
-func (q *Queries) GetUserConversations(ctx context.Context, recipientID uuid.NullUUID) ([]GetUserConversationsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserConversations, recipientID)
+func (q *Queries) GetUserConversations(ctx context.Context, arg GetUserConversationsParams) ([]GetUserConversationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserConversations,
+		arg.UserID,
+		arg.Archived,
+		arg.Muted,
+		arg.Unread,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -516,6 +618,8 @@ func (q *Queries) GetUserConversations(ctx context.Context, recipientID uuid.Nul
 			&i.UserRole,
 			&i.NotificationsEnabled,
 			&i.CustomSettings,
+			&i.MutedUntil,
+			&i.ArchivedAt,
 			&i.UnreadCount,
 			&i.LastMessageContent,
 			&i.LastMessageTime,
@@ -551,20 +655,69 @@ func (q *Queries) LeaveConversation(ctx context.Context, arg LeaveConversationPa
 	return err
 }
 
-const markMessagesAsRead = `-- name: MarkMessagesAsRead :exec
-UPDATE messages 
-SET is_read = true, read_at = NOW()
-WHERE conversation_id = $1 AND recipient_id = $2 AND is_read = false
+const markMessagesAsRead = `-- name: MarkMessagesAsRead :one
+WITH target AS (
+    SELECT m.id, m.created_at
+    FROM messages m
+    WHERE m.conversation_id = $1
+      AND ($2::uuid IS NULL OR m.id = $2::uuid)
+    ORDER BY m.created_at DESC, m.id DESC
+    LIMIT 1
+), previous AS (
+    SELECT cp.id, cp.last_read_message_id, cp.last_read_message_at
+    FROM conversation_participants cp
+    WHERE cp.conversation_id = $1 AND cp.user_id = $3 AND cp.is_active = true
+)
+UPDATE conversation_participants cp
+SET last_read_message_id = target.id,
+    last_read_message_at = target.created_at,
+    last_read_at = NOW(),
+    last_delivered_message_id = CASE
+        WHEN cp.last_delivered_message_at IS NULL
+          OR (cp.last_delivered_message_at, cp.last_delivered_message_id) < (target.created_at, target.id)
+        THEN target.id ELSE cp.last_delivered_message_id END,
+    last_delivered_message_at = CASE
+        WHEN cp.last_delivered_message_at IS NULL
+          OR (cp.last_delivered_message_at, cp.last_delivered_message_id) < (target.created_at, target.id)
+        THEN target.created_at ELSE cp.last_delivered_message_at END,
+    last_delivered_at = CASE
+        WHEN cp.last_delivered_message_at IS NULL
+          OR (cp.last_delivered_message_at, cp.last_delivered_message_id) < (target.created_at, target.id)
+        THEN NOW() ELSE cp.last_delivered_at END
+FROM target, previous
+WHERE cp.id = previous.id
+  AND (previous.last_read_message_at IS NULL
+       OR (previous.last_read_message_at, previous.last_read_message_id) < (target.created_at, target.id))
+RETURNING
+    previous.last_read_message_id AS previous_message_id,
+    previous.last_read_message_at AS previous_message_at,
+    target.id AS message_id,
+    target.created_at AS message_at
 `
 
 type MarkMessagesAsReadParams struct {
 	ConversationID uuid.UUID     `json:"conversation_id"`
-	RecipientID    uuid.NullUUID `json:"recipient_id"`
+	MessageID      uuid.NullUUID `json:"message_id"`
+	UserID         uuid.UUID     `json:"user_id"`
 }
 
-func (q *Queries) MarkMessagesAsRead(ctx context.Context, arg MarkMessagesAsReadParams) error {
-	_, err := q.db.ExecContext(ctx, markMessagesAsRead, arg.ConversationID, arg.RecipientID)
-	return err
+type MarkMessagesAsReadRow struct {
+	PreviousMessageID uuid.NullUUID `json:"previous_message_id"`
+	PreviousMessageAt sql.NullTime  `json:"previous_message_at"`
+	MessageID         uuid.UUID     `json:"message_id"`
+	MessageAt         sql.NullTime  `json:"message_at"`
+}
+
+func (q *Queries) MarkMessagesAsRead(ctx context.Context, arg MarkMessagesAsReadParams) (MarkMessagesAsReadRow, error) {
+	row := q.db.QueryRowContext(ctx, markMessagesAsRead, arg.ConversationID, arg.MessageID, arg.UserID)
+	var i MarkMessagesAsReadRow
+	err := row.Scan(
+		&i.PreviousMessageID,
+		&i.PreviousMessageAt,
+		&i.MessageID,
+		&i.MessageAt,
+	)
+	return i, err
 }
 
 const removeMessageReaction = `-- name: RemoveMessageReaction :exec
@@ -584,19 +737,33 @@ func (q *Queries) RemoveMessageReaction(ctx context.Context, arg RemoveMessageRe
 }
 
 const sendMessage = `-- name: SendMessage :one
-INSERT INTO messages (conversation_id, sender_id, recipient_id, content, attachments, message_type, reply_to_id)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, conversation_id, sender_id, recipient_id, content, attachments, message_type, is_read, read_at, reactions, reply_to_id, status, created_at
+INSERT INTO messages (conversation_id, sender_id, recipient_id, content, attachments, message_type, reply_to_id, thread_root_id, expires_at,
+    shared_type, shared_id, shared_preview, is_forwarded, forwarded_from_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7,
+    (SELECT COALESCE(p.thread_root_id, p.id) FROM messages p WHERE p.id = $7 AND p.conversation_id = $1),
+    (SELECT NOW() + (c.settings->>'disappearing_messages_seconds')::int * INTERVAL '1 second'
+     FROM conversations c
+     WHERE c.id = $1 AND $6 IS DISTINCT FROM 'system'
+       AND CASE WHEN jsonb_typeof(c.settings->'disappearing_messages_seconds') = 'number'
+                THEN (c.settings->>'disappearing_messages_seconds')::int > 0
+                ELSE false END),
+    $8, $9, $10, $11, $12)
+RETURNING id, conversation_id, sender_id, recipient_id, content, attachments, message_type, is_read, read_at, reactions, reply_to_id, status, created_at, edited_at, thread_root_id, expires_at, shared_type, shared_id, shared_preview, is_forwarded, forwarded_from_id
 `
 
 type SendMessageParams struct {
-	ConversationID uuid.UUID             `json:"conversation_id"`
-	SenderID       uuid.UUID             `json:"sender_id"`
-	RecipientID    uuid.NullUUID         `json:"recipient_id"`
-	Content        sql.NullString        `json:"content"`
-	Attachments    pqtype.NullRawMessage `json:"attachments"`
-	MessageType    sql.NullString        `json:"message_type"`
-	ReplyToID      uuid.NullUUID         `json:"reply_to_id"`
+	ConversationID  uuid.UUID             `json:"conversation_id"`
+	SenderID        uuid.UUID             `json:"sender_id"`
+	RecipientID     uuid.NullUUID         `json:"recipient_id"`
+	Content         sql.NullString        `json:"content"`
+	Attachments     pqtype.NullRawMessage `json:"attachments"`
+	MessageType     sql.NullString        `json:"message_type"`
+	ReplyToID       uuid.NullUUID         `json:"reply_to_id"`
+	SharedType      sql.NullString        `json:"shared_type"`
+	SharedID        uuid.NullUUID         `json:"shared_id"`
+	SharedPreview   pqtype.NullRawMessage `json:"shared_preview"`
+	IsForwarded     bool                  `json:"is_forwarded"`
+	ForwardedFromID uuid.NullUUID         `json:"forwarded_from_id"`
 }
 
 func (q *Queries) SendMessage(ctx context.Context, arg SendMessageParams) (Message, error) {
@@ -608,6 +775,11 @@ func (q *Queries) SendMessage(ctx context.Context, arg SendMessageParams) (Messa
 		arg.Attachments,
 		arg.MessageType,
 		arg.ReplyToID,
+		arg.SharedType,
+		arg.SharedID,
+		arg.SharedPreview,
+		arg.IsForwarded,
+		arg.ForwardedFromID,
 	)
 	var i Message
 	err := row.Scan(
@@ -624,6 +796,14 @@ func (q *Queries) SendMessage(ctx context.Context, arg SendMessageParams) (Messa
 		&i.ReplyToID,
 		&i.Status,
 		&i.CreatedAt,
+		&i.EditedAt,
+		&i.ThreadRootID,
+		&i.ExpiresAt,
+		&i.SharedType,
+		&i.SharedID,
+		&i.SharedPreview,
+		&i.IsForwarded,
+		&i.ForwardedFromID,
 	)
 	return i, err
 }
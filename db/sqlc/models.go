@@ -44,6 +44,22 @@ type AuditLog struct {
 	CreatedAt    time.Time             `json:"created_at"`
 }
 
+type Bookmark struct {
+	ID           uuid.UUID     `json:"id"`
+	UserID       uuid.UUID     `json:"user_id"`
+	PostID       uuid.UUID     `json:"post_id"`
+	CollectionID uuid.NullUUID `json:"collection_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type BookmarkCollection struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type Comment struct {
 	ID              uuid.UUID      `json:"id"`
 	PostID          uuid.UUID      `json:"post_id"`
@@ -54,6 +70,8 @@ type Comment struct {
 	Status          sql.NullString `json:"status"`
 	CreatedAt       sql.NullTime   `json:"created_at"`
 	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	ContentWarning  sql.NullString `json:"content_warning"`
+	IsSensitive     bool           `json:"is_sensitive"`
 }
 
 type Community struct {
@@ -97,16 +115,51 @@ type Conversation struct {
 	UpdatedAt        sql.NullTime          `json:"updated_at"`
 }
 
+type ConversationExport struct {
+	ID             uuid.UUID      `json:"id"`
+	ConversationID uuid.UUID      `json:"conversation_id"`
+	RequestedBy    uuid.UUID      `json:"requested_by"`
+	Format         string         `json:"format"`
+	Status         string         `json:"status"`
+	MessageCount   int64          `json:"message_count"`
+	Content        []byte         `json:"content"`
+	Error          sql.NullString `json:"error"`
+	CreatedAt      time.Time      `json:"created_at"`
+	CompletedAt    sql.NullTime   `json:"completed_at"`
+	ExpiresAt      sql.NullTime   `json:"expires_at"`
+}
+
+type ConversationInvite struct {
+	ID             uuid.UUID     `json:"id"`
+	ConversationID uuid.UUID     `json:"conversation_id"`
+	Code           string        `json:"code"`
+	CreatedBy      uuid.UUID     `json:"created_by"`
+	ExpiresAt      sql.NullTime  `json:"expires_at"`
+	MaxUses        sql.NullInt32 `json:"max_uses"`
+	UseCount       int32         `json:"use_count"`
+	RevokedAt      sql.NullTime  `json:"revoked_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
 type ConversationParticipant struct {
-	ID                   uuid.UUID             `json:"id"`
-	ConversationID       uuid.UUID             `json:"conversation_id"`
-	UserID               uuid.UUID             `json:"user_id"`
-	Role                 sql.NullString        `json:"role"`
-	JoinedAt             sql.NullTime          `json:"joined_at"`
-	LeftAt               sql.NullTime          `json:"left_at"`
-	IsActive             sql.NullBool          `json:"is_active"`
-	NotificationsEnabled sql.NullBool          `json:"notifications_enabled"`
-	CustomSettings       pqtype.NullRawMessage `json:"custom_settings"`
+	ID                     uuid.UUID             `json:"id"`
+	ConversationID         uuid.UUID             `json:"conversation_id"`
+	UserID                 uuid.UUID             `json:"user_id"`
+	Role                   sql.NullString        `json:"role"`
+	JoinedAt               sql.NullTime          `json:"joined_at"`
+	LeftAt                 sql.NullTime          `json:"left_at"`
+	IsActive               sql.NullBool          `json:"is_active"`
+	NotificationsEnabled   sql.NullBool          `json:"notifications_enabled"`
+	CustomSettings         pqtype.NullRawMessage `json:"custom_settings"`
+	LastReadMessageID      uuid.NullUUID         `json:"last_read_message_id"`
+	LastReadMessageAt      sql.NullTime          `json:"last_read_message_at"`
+	LastReadAt             sql.NullTime          `json:"last_read_at"`
+	LastDeliveredMessageID uuid.NullUUID         `json:"last_delivered_message_id"`
+	LastDeliveredMessageAt sql.NullTime          `json:"last_delivered_message_at"`
+	LastDeliveredAt        sql.NullTime          `json:"last_delivered_at"`
+	RequestStatus          sql.NullString        `json:"request_status"`
+	MutedUntil             sql.NullTime          `json:"muted_until"`
+	ArchivedAt             sql.NullTime          `json:"archived_at"`
 }
 
 type EmailQueue struct {
@@ -181,6 +234,14 @@ type Follow struct {
 	CreatedAt   sql.NullTime `json:"created_at"`
 }
 
+type FollowRequest struct {
+	ID          uuid.UUID `json:"id"`
+	RequesterID uuid.UUID `json:"requester_id"`
+	TargetID    uuid.UUID `json:"target_id"`
+	SpaceID     uuid.UUID `json:"space_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 type Group struct {
 	ID               uuid.UUID             `json:"id"`
 	SpaceID          uuid.UUID             `json:"space_id"`
@@ -245,6 +306,7 @@ type Like struct {
 	PostID    uuid.NullUUID `json:"post_id"`
 	CommentID uuid.NullUUID `json:"comment_id"`
 	CreatedAt sql.NullTime  `json:"created_at"`
+	Reaction  string        `json:"reaction"`
 }
 
 
@@ -260,6 +322,17 @@ type LoginAttempt struct {
 	SessionID     uuid.NullUUID  `json:"session_id"`
 }
 
+type Mention struct {
+	ID              uuid.UUID `json:"id"`
+	SourceType      string    `json:"source_type"`
+	SourceID        uuid.UUID `json:"source_id"`
+	AuthorID        uuid.UUID `json:"author_id"`
+	MentionedUserID uuid.UUID `json:"mentioned_user_id"`
+	StartOffset     int32     `json:"start_offset"`
+	Length          int32     `json:"length"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
 type MentorApplication struct {
 	ID                   uuid.UUID       `json:"id"`
 	ApplicantID          uuid.UUID       `json:"applicant_id"`
@@ -322,19 +395,43 @@ type MentoringSession struct {
 }
 
 type Message struct {
-	ID             uuid.UUID             `json:"id"`
-	ConversationID uuid.UUID             `json:"conversation_id"`
-	SenderID       uuid.UUID             `json:"sender_id"`
-	RecipientID    uuid.NullUUID         `json:"recipient_id"`
-	Content        sql.NullString        `json:"content"`
-	Attachments    pqtype.NullRawMessage `json:"attachments"`
-	MessageType    sql.NullString        `json:"message_type"`
-	IsRead         sql.NullBool          `json:"is_read"`
-	ReadAt         sql.NullTime          `json:"read_at"`
-	Reactions      pqtype.NullRawMessage `json:"reactions"`
-	ReplyToID      uuid.NullUUID         `json:"reply_to_id"`
-	Status         sql.NullString        `json:"status"`
-	CreatedAt      sql.NullTime          `json:"created_at"`
+	ID              uuid.UUID             `json:"id"`
+	ConversationID  uuid.UUID             `json:"conversation_id"`
+	SenderID        uuid.UUID             `json:"sender_id"`
+	RecipientID     uuid.NullUUID         `json:"recipient_id"`
+	Content         sql.NullString        `json:"content"`
+	Attachments     pqtype.NullRawMessage `json:"attachments"`
+	MessageType     sql.NullString        `json:"message_type"`
+	IsRead          sql.NullBool          `json:"is_read"`
+	ReadAt          sql.NullTime          `json:"read_at"`
+	Reactions       pqtype.NullRawMessage `json:"reactions"`
+	ReplyToID       uuid.NullUUID         `json:"reply_to_id"`
+	Status          sql.NullString        `json:"status"`
+	CreatedAt       sql.NullTime          `json:"created_at"`
+	EditedAt        sql.NullTime          `json:"edited_at"`
+	ThreadRootID    uuid.NullUUID         `json:"thread_root_id"`
+	ExpiresAt       sql.NullTime          `json:"expires_at"`
+	SharedType      sql.NullString        `json:"shared_type"`
+	SharedID        uuid.NullUUID         `json:"shared_id"`
+	SharedPreview   pqtype.NullRawMessage `json:"shared_preview"`
+	IsForwarded     bool                  `json:"is_forwarded"`
+	ForwardedFromID uuid.NullUUID         `json:"forwarded_from_id"`
+}
+
+type MessagePin struct {
+	ID             uuid.UUID `json:"id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	MessageID      uuid.UUID `json:"message_id"`
+	PinnedBy       uuid.UUID `json:"pinned_by"`
+	PinnedAt       time.Time `json:"pinned_at"`
+}
+
+type MessageRevision struct {
+	ID        uuid.UUID      `json:"id"`
+	MessageID uuid.UUID      `json:"message_id"`
+	Content   sql.NullString `json:"content"`
+	EditedBy  uuid.UUID      `json:"edited_by"`
+	CreatedAt time.Time      `json:"created_at"`
 }
 
 type Notification struct {
@@ -373,27 +470,69 @@ type PastQuestion struct {
 	CreatedAt     sql.NullTime   `json:"created_at"`
 }
 
+type Poll struct {
+	ID            uuid.UUID    `json:"id"`
+	PostID        uuid.UUID    `json:"post_id"`
+	AllowMultiple bool         `json:"allow_multiple"`
+	IsAnonymous   bool         `json:"is_anonymous"`
+	ClosesAt      sql.NullTime `json:"closes_at"`
+	VotersCount   int32        `json:"voters_count"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type PollOption struct {
+	ID         uuid.UUID `json:"id"`
+	PollID     uuid.UUID `json:"poll_id"`
+	Position   int32     `json:"position"`
+	Text       string    `json:"text"`
+	VotesCount int32     `json:"votes_count"`
+}
+
+type PollVote struct {
+	PollID    uuid.UUID `json:"poll_id"`
+	OptionID  uuid.UUID `json:"option_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PollVoter struct {
+	PollID    uuid.UUID `json:"poll_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Post struct {
-	ID            uuid.UUID             `json:"id"`
-	AuthorID      uuid.UUID             `json:"author_id"`
-	SpaceID       uuid.UUID             `json:"space_id"`
-	CommunityID   uuid.NullUUID         `json:"community_id"`
-	GroupID       uuid.NullUUID         `json:"group_id"`
-	ParentPostID  uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID  uuid.NullUUID         `json:"quoted_post_id"`
-	Content       string                `json:"content"`
-	Media         pqtype.NullRawMessage `json:"media"`
-	Tags          []string              `json:"tags"`
-	LikesCount    sql.NullInt32         `json:"likes_count"`
-	CommentsCount sql.NullInt32         `json:"comments_count"`
-	RepostsCount  sql.NullInt32         `json:"reposts_count"`
-	QuotesCount   sql.NullInt32         `json:"quotes_count"`
-	ViewsCount    sql.NullInt32         `json:"views_count"`
-	IsPinned      sql.NullBool          `json:"is_pinned"`
-	Visibility    sql.NullString        `json:"visibility"`
-	Status        sql.NullString        `json:"status"`
-	CreatedAt     sql.NullTime          `json:"created_at"`
-	UpdatedAt     sql.NullTime          `json:"updated_at"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+}
+
+type PostTag struct {
+	PostID    uuid.UUID `json:"post_id"`
+	TagID     uuid.UUID `json:"tag_id"`
+	SpaceID   uuid.UUID `json:"space_id"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Report struct {
@@ -455,6 +594,19 @@ type SystemSetting struct {
 	UpdatedAt   time.Time       `json:"updated_at"`
 }
 
+type Tag struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TagSpaceUsage struct {
+	TagID      uuid.UUID `json:"tag_id"`
+	SpaceID    uuid.UUID `json:"space_id"`
+	UsageCount int32     `json:"usage_count"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
 type TrendingTopic struct {
 	ID         uuid.UUID       `json:"id"`
 	SpaceID    uuid.UUID       `json:"space_id"`
@@ -561,6 +713,20 @@ type User struct {
 	SuspendedUntil  sql.NullTime `json:"suspended_until"`
 }
 
+type UserBlock struct {
+	ID        uuid.UUID `json:"id"`
+	BlockerID uuid.UUID `json:"blocker_id"`
+	BlockedID uuid.UUID `json:"blocked_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserMute struct {
+	ID        uuid.UUID `json:"id"`
+	MuterID   uuid.UUID `json:"muter_id"`
+	MutedID   uuid.UUID `json:"muted_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type UserSession struct {
 	ID           uuid.UUID      `json:"id"`
 	UserID       uuid.UUID      `json:"user_id"`
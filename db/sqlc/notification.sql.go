@@ -13,6 +13,18 @@ import (
 	"github.com/sqlc-dev/pqtype"
 )
 
+const countUserNotifications = `-- name: CountUserNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE to_user_id = $1
+`
+
+func (q *Queries) CountUserNotifications(ctx context.Context, toUserID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserNotifications, toUserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createNotification = `-- name: CreateNotification :one
 INSERT INTO notifications (
     to_user_id, from_user_id, type, title, message, related_id, metadata, priority, action_required
@@ -209,14 +221,18 @@ SELECT n.id, n.to_user_id, n.from_user_id, n.type, n.title, n.message, n.related
 FROM notifications n
 LEFT JOIN users u ON n.from_user_id = u.id
 WHERE n.to_user_id = $1
-ORDER BY n.created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+       OR (n.created_at, n.id) < ($2::timestamptz, $3::uuid))
+ORDER BY n.created_at DESC, n.id DESC
+LIMIT $4 OFFSET $5
 `
 
 type GetUserNotificationsParams struct {
-	ToUserID uuid.UUID `json:"to_user_id"`
-	Limit    int32     `json:"limit"`
-	Offset   int32     `json:"offset"`
+	ToUserID        uuid.UUID     `json:"to_user_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetUserNotificationsRow struct {
@@ -238,7 +254,13 @@ type GetUserNotificationsRow struct {
 }
 
 func (q *Queries) GetUserNotifications(ctx context.Context, arg GetUserNotificationsParams) ([]GetUserNotificationsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserNotifications, arg.ToUserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getUserNotifications,
+		arg.ToUserID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,305 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const castPollVote = `-- name: CastPollVote :one
+WITH ballot AS (
+    INSERT INTO poll_voters (poll_id, user_id)
+    SELECT p.id, $1::uuid
+    FROM polls p
+    WHERE p.id = $2
+      AND (p.closes_at IS NULL OR p.closes_at > NOW())
+    ON CONFLICT (poll_id, user_id) DO NOTHING
+    RETURNING poll_id, user_id
+),
+votes AS (
+    INSERT INTO poll_votes (poll_id, option_id, user_id)
+    SELECT b.poll_id, o.id, b.user_id
+    FROM ballot b
+    JOIN poll_options o ON o.poll_id = b.poll_id
+    WHERE o.id = ANY($3::uuid[])
+    RETURNING option_id
+),
+bump_options AS (
+    UPDATE poll_options
+    SET votes_count = votes_count + 1
+    WHERE id IN (SELECT option_id FROM votes)
+    RETURNING id
+),
+bump_poll AS (
+    UPDATE polls
+    SET voters_count = voters_count + 1
+    WHERE id IN (SELECT poll_id FROM ballot)
+    RETURNING id
+)
+SELECT COUNT(*) FROM votes
+`
+
+type CastPollVoteParams struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	PollID    uuid.UUID   `json:"poll_id"`
+	OptionIds []uuid.UUID `json:"option_ids"`
+}
+
+func (q *Queries) CastPollVote(ctx context.Context, arg CastPollVoteParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, castPollVote, arg.UserID, arg.PollID, pq.Array(arg.OptionIds))
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createPoll = `-- name: CreatePoll :one
+INSERT INTO polls (post_id, allow_multiple, is_anonymous, closes_at)
+VALUES ($1, $2, $3, $4)
+RETURNING id, post_id, allow_multiple, is_anonymous, closes_at, voters_count, created_at
+`
+
+type CreatePollParams struct {
+	PostID        uuid.UUID    `json:"post_id"`
+	AllowMultiple bool         `json:"allow_multiple"`
+	IsAnonymous   bool         `json:"is_anonymous"`
+	ClosesAt      sql.NullTime `json:"closes_at"`
+}
+
+func (q *Queries) CreatePoll(ctx context.Context, arg CreatePollParams) (Poll, error) {
+	row := q.db.QueryRowContext(ctx, createPoll,
+		arg.PostID,
+		arg.AllowMultiple,
+		arg.IsAnonymous,
+		arg.ClosesAt,
+	)
+	var i Poll
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.AllowMultiple,
+		&i.IsAnonymous,
+		&i.ClosesAt,
+		&i.VotersCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPollOptions = `-- name: CreatePollOptions :many
+INSERT INTO poll_options (poll_id, position, text)
+SELECT $1::uuid, o.position::int, o.text
+FROM unnest($2::text[]) WITH ORDINALITY AS o(text, position)
+RETURNING id, poll_id, position, text, votes_count
+`
+
+type CreatePollOptionsParams struct {
+	PollID  uuid.UUID `json:"poll_id"`
+	Options []string  `json:"options"`
+}
+
+func (q *Queries) CreatePollOptions(ctx context.Context, arg CreatePollOptionsParams) ([]PollOption, error) {
+	rows, err := q.db.QueryContext(ctx, createPollOptions, arg.PollID, pq.Array(arg.Options))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PollOption{}
+	for rows.Next() {
+		var i PollOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.PollID,
+			&i.Position,
+			&i.Text,
+			&i.VotesCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollByPostID = `-- name: GetPollByPostID :one
+SELECT id, post_id, allow_multiple, is_anonymous, closes_at, voters_count, created_at FROM polls WHERE post_id = $1
+`
+
+func (q *Queries) GetPollByPostID(ctx context.Context, postID uuid.UUID) (Poll, error) {
+	row := q.db.QueryRowContext(ctx, getPollByPostID, postID)
+	var i Poll
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.AllowMultiple,
+		&i.IsAnonymous,
+		&i.ClosesAt,
+		&i.VotersCount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPollOptionVoters = `-- name: GetPollOptionVoters :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    v.created_at
+FROM poll_votes v
+JOIN users u ON v.user_id = u.id
+WHERE v.option_id = $1 AND u.status = 'active'
+ORDER BY v.created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type GetPollOptionVotersParams struct {
+	OptionID uuid.UUID `json:"option_id"`
+	Limit    int32     `json:"limit"`
+	Offset   int32     `json:"offset"`
+}
+
+type GetPollOptionVotersRow struct {
+	ID        uuid.UUID      `json:"id"`
+	Username  string         `json:"username"`
+	FullName  string         `json:"full_name"`
+	Avatar    sql.NullString `json:"avatar"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func (q *Queries) GetPollOptionVoters(ctx context.Context, arg GetPollOptionVotersParams) ([]GetPollOptionVotersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPollOptionVoters, arg.OptionID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollOptionVotersRow{}
+	for rows.Next() {
+		var i GetPollOptionVotersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollOptionsByPollIDs = `-- name: GetPollOptionsByPollIDs :many
+SELECT id, poll_id, position, text, votes_count FROM poll_options
+WHERE poll_id = ANY($1::uuid[])
+ORDER BY poll_id, position
+`
+
+func (q *Queries) GetPollOptionsByPollIDs(ctx context.Context, pollIds []uuid.UUID) ([]PollOption, error) {
+	rows, err := q.db.QueryContext(ctx, getPollOptionsByPollIDs, pq.Array(pollIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PollOption{}
+	for rows.Next() {
+		var i PollOption
+		if err := rows.Scan(
+			&i.ID,
+			&i.PollID,
+			&i.Position,
+			&i.Text,
+			&i.VotesCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPollsByPostIDs = `-- name: GetPollsByPostIDs :many
+SELECT
+    p.id, p.post_id, p.allow_multiple, p.is_anonymous, p.closes_at, p.voters_count, p.created_at,
+    COALESCE((
+        SELECT array_agg(v.option_id) FROM poll_votes v
+        WHERE v.poll_id = p.id AND v.user_id = $1
+    ), '{}')::uuid[] as viewer_option_ids
+FROM polls p
+WHERE p.post_id = ANY($2::uuid[])
+`
+
+type GetPollsByPostIDsParams struct {
+	ViewerID uuid.UUID   `json:"viewer_id"`
+	PostIds  []uuid.UUID `json:"post_ids"`
+}
+
+type GetPollsByPostIDsRow struct {
+	ID              uuid.UUID    `json:"id"`
+	PostID          uuid.UUID    `json:"post_id"`
+	AllowMultiple   bool         `json:"allow_multiple"`
+	IsAnonymous     bool         `json:"is_anonymous"`
+	ClosesAt        sql.NullTime `json:"closes_at"`
+	VotersCount     int32        `json:"voters_count"`
+	CreatedAt       time.Time    `json:"created_at"`
+	ViewerOptionIds []uuid.UUID  `json:"viewer_option_ids"`
+}
+
+func (q *Queries) GetPollsByPostIDs(ctx context.Context, arg GetPollsByPostIDsParams) ([]GetPollsByPostIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPollsByPostIDs, arg.ViewerID, pq.Array(arg.PostIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPollsByPostIDsRow{}
+	for rows.Next() {
+		var i GetPollsByPostIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.AllowMultiple,
+			&i.IsAnonymous,
+			&i.ClosesAt,
+			&i.VotersCount,
+			&i.CreatedAt,
+			pq.Array(&i.ViewerOptionIds),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
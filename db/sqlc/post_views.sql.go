@@ -0,0 +1,87 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const flushPostViews = `-- name: FlushPostViews :exec
+WITH batch AS (
+    SELECT
+        UNNEST($1::uuid[]) AS post_id,
+        UNNEST($2::text[])::date AS day,
+        UNNEST($3::int[]) AS views
+), daily AS (
+    INSERT INTO post_daily_views (post_id, day, views)
+    SELECT b.post_id, b.day, b.views
+    FROM batch b
+    JOIN posts p ON p.id = b.post_id
+    ON CONFLICT (post_id, day) DO UPDATE SET views = post_daily_views.views + EXCLUDED.views
+)
+UPDATE posts p
+SET views_count = COALESCE(p.views_count, 0) + t.views
+FROM (SELECT post_id, SUM(views)::int AS views FROM batch GROUP BY post_id) t
+WHERE p.id = t.post_id
+`
+
+type FlushPostViewsParams struct {
+	PostIds    []uuid.UUID `json:"post_ids"`
+	Days       []string    `json:"days"`
+	ViewCounts []int32     `json:"view_counts"`
+}
+
+func (q *Queries) FlushPostViews(ctx context.Context, arg FlushPostViewsParams) error {
+	_, err := q.db.ExecContext(ctx, flushPostViews, pq.Array(arg.PostIds), pq.Array(arg.Days), pq.Array(arg.ViewCounts))
+	return err
+}
+
+const getPostDailyViews = `-- name: GetPostDailyViews :many
+SELECT day, views
+FROM post_daily_views
+WHERE post_id = $1 AND day >= $2::date
+ORDER BY day
+`
+
+type GetPostDailyViewsParams struct {
+	PostID uuid.UUID `json:"post_id"`
+	Since  time.Time `json:"since"`
+}
+
+type GetPostDailyViewsRow struct {
+	Day   time.Time `json:"day"`
+	Views int32     `json:"views"`
+}
+
+func (q *Queries) GetPostDailyViews(ctx context.Context, arg GetPostDailyViewsParams) ([]GetPostDailyViewsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostDailyViews, arg.PostID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPostDailyViewsRow{}
+	for rows.Next() {
+		var i GetPostDailyViewsRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Views,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
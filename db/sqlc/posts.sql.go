@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -16,7 +17,7 @@ import (
 
 const advancedSearchPosts = `-- name: AdvancedSearchPosts :many
 SELECT 
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     u.username,
     u.full_name,
     u.avatar as author_avatar,
@@ -38,6 +39,8 @@ WHERE p.space_id = $2
     OR p.content ILIKE '%' || $1 || '%'
     OR p.tags @> ARRAY[$1]::text[]
   )
+  AND NOT is_hidden_from($5, p.author_id)
+  AND can_view_post($5, p.author_id, p.visibility, p.community_id, p.group_id)
 ORDER BY relevance_score DESC, p.created_at DESC
 LIMIT $3 OFFSET $4
 `
@@ -47,35 +50,40 @@ type AdvancedSearchPostsParams struct {
 	SpaceID        uuid.UUID `json:"space_id"`
 	Limit          int32     `json:"limit"`
 	Offset         int32     `json:"offset"`
+	ViewerID       uuid.UUID `json:"viewer_id"`
 }
 
 type AdvancedSearchPostsRow struct {
-	ID             uuid.UUID             `json:"id"`
-	AuthorID       uuid.UUID             `json:"author_id"`
-	SpaceID        uuid.UUID             `json:"space_id"`
-	CommunityID    uuid.NullUUID         `json:"community_id"`
-	GroupID        uuid.NullUUID         `json:"group_id"`
-	ParentPostID   uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID   uuid.NullUUID         `json:"quoted_post_id"`
-	Content        string                `json:"content"`
-	Media          pqtype.NullRawMessage `json:"media"`
-	Tags           []string              `json:"tags"`
-	LikesCount     sql.NullInt32         `json:"likes_count"`
-	CommentsCount  sql.NullInt32         `json:"comments_count"`
-	RepostsCount   sql.NullInt32         `json:"reposts_count"`
-	QuotesCount    sql.NullInt32         `json:"quotes_count"`
-	ViewsCount     sql.NullInt32         `json:"views_count"`
-	IsPinned       sql.NullBool          `json:"is_pinned"`
-	Visibility     sql.NullString        `json:"visibility"`
-	Status         sql.NullString        `json:"status"`
-	CreatedAt      sql.NullTime          `json:"created_at"`
-	UpdatedAt      sql.NullTime          `json:"updated_at"`
-	Username       string                `json:"username"`
-	FullName       string                `json:"full_name"`
-	AuthorAvatar   sql.NullString        `json:"author_avatar"`
-	CommunityName  sql.NullString        `json:"community_name"`
-	GroupName      sql.NullString        `json:"group_name"`
-	RelevanceScore float32               `json:"relevance_score"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           string                `json:"username"`
+	FullName           string                `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	CommunityName      sql.NullString        `json:"community_name"`
+	GroupName          sql.NullString        `json:"group_name"`
+	RelevanceScore     float32               `json:"relevance_score"`
 }
 
 func (q *Queries) AdvancedSearchPosts(ctx context.Context, arg AdvancedSearchPostsParams) ([]AdvancedSearchPostsRow, error) {
@@ -84,6 +92,7 @@ func (q *Queries) AdvancedSearchPosts(ctx context.Context, arg AdvancedSearchPos
 		arg.SpaceID,
 		arg.Limit,
 		arg.Offset,
+		arg.ViewerID,
 	)
 	if err != nil {
 		return nil, err
@@ -113,6 +122,10 @@ func (q *Queries) AdvancedSearchPosts(ctx context.Context, arg AdvancedSearchPos
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
@@ -133,17 +146,114 @@ func (q *Queries) AdvancedSearchPosts(ctx context.Context, arg AdvancedSearchPos
 	return items, nil
 }
 
+const countCommunityPosts = `-- name: CountCommunityPosts :one
+SELECT COUNT(*) FROM posts p
+WHERE p.community_id = $1 AND p.status = 'active'
+  AND NOT is_hidden_from($2, p.author_id)
+  AND can_view_post($2, p.author_id, p.visibility, p.community_id, p.group_id)
+`
+
+type CountCommunityPostsParams struct {
+	CommunityID uuid.NullUUID `json:"community_id"`
+	UserID      uuid.UUID     `json:"user_id"`
+}
+
+func (q *Queries) CountCommunityPosts(ctx context.Context, arg CountCommunityPostsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countCommunityPosts, arg.CommunityID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countGroupPosts = `-- name: CountGroupPosts :one
+SELECT COUNT(*) FROM posts p
+WHERE p.group_id = $1 AND p.status = 'active'
+  AND NOT is_hidden_from($2, p.author_id)
+  AND can_view_post($2, p.author_id, p.visibility, p.community_id, p.group_id)
+`
+
+type CountGroupPostsParams struct {
+	GroupID uuid.NullUUID `json:"group_id"`
+	UserID  uuid.UUID     `json:"user_id"`
+}
+
+func (q *Queries) CountGroupPosts(ctx context.Context, arg CountGroupPostsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countGroupPosts, arg.GroupID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTopLevelComments = `-- name: CountTopLevelComments :one
+SELECT COUNT(*) FROM comments c
+WHERE c.post_id = $1 AND c.parent_comment_id IS NULL AND c.status = 'active'
+  AND NOT is_hidden_from($2, c.author_id)
+`
+
+type CountTopLevelCommentsParams struct {
+	PostID   uuid.UUID `json:"post_id"`
+	ViewerID uuid.UUID `json:"viewer_id"`
+}
+
+func (q *Queries) CountTopLevelComments(ctx context.Context, arg CountTopLevelCommentsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTopLevelComments, arg.PostID, arg.ViewerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserFeed = `-- name: CountUserFeed :one
+SELECT COUNT(*) FROM posts p
+WHERE p.space_id = $1
+  AND p.status = 'active'
+  AND can_view_post($2, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from($2, p.author_id)
+`
+
+type CountUserFeedParams struct {
+	SpaceID uuid.UUID `json:"space_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) CountUserFeed(ctx context.Context, arg CountUserFeedParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserFeed, arg.SpaceID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserPosts = `-- name: CountUserPosts :one
+SELECT COUNT(*) FROM posts p
+WHERE p.author_id = $1 AND p.status = 'active'
+  AND can_view_post($2, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from($2, p.author_id)
+`
+
+type CountUserPostsParams struct {
+	AuthorID uuid.UUID `json:"author_id"`
+	ViewerID uuid.UUID `json:"viewer_id"`
+}
+
+func (q *Queries) CountUserPosts(ctx context.Context, arg CountUserPostsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserPosts, arg.AuthorID, arg.ViewerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createComment = `-- name: CreateComment :one
-INSERT INTO comments (post_id, author_id, parent_comment_id, content)
-VALUES ($1, $2, $3, $4)
-RETURNING id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at
+INSERT INTO comments (post_id, author_id, parent_comment_id, content, content_warning, is_sensitive)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at, content_warning, is_sensitive
 `
 
 type CreateCommentParams struct {
-	PostID          uuid.UUID     `json:"post_id"`
-	AuthorID        uuid.UUID     `json:"author_id"`
-	ParentCommentID uuid.NullUUID `json:"parent_comment_id"`
-	Content         string        `json:"content"`
+	PostID          uuid.UUID      `json:"post_id"`
+	AuthorID        uuid.UUID      `json:"author_id"`
+	ParentCommentID uuid.NullUUID  `json:"parent_comment_id"`
+	Content         string         `json:"content"`
+	ContentWarning  sql.NullString `json:"content_warning"`
+	IsSensitive     bool           `json:"is_sensitive"`
 }
 
 func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error) {
@@ -152,6 +262,8 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		arg.AuthorID,
 		arg.ParentCommentID,
 		arg.Content,
+		arg.ContentWarning,
+		arg.IsSensitive,
 	)
 	var i Comment
 	err := row.Scan(
@@ -164,6 +276,8 @@ func (q *Queries) CreateComment(ctx context.Context, arg CreateCommentParams) (C
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
 	)
 	return i, err
 }
@@ -172,22 +286,30 @@ const createPost = `-- name: CreatePost :one
 
 INSERT INTO posts (
     author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id,
-    content, media, tags, visibility
-) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at
+    content, media, tags, visibility, status, publish_at, content_warning, is_sensitive
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+    COALESCE($11::varchar, 'active'), $12::timestamptz,
+    $13, $14
+)
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
 `
 
 type CreatePostParams struct {
-	AuthorID     uuid.UUID             `json:"author_id"`
-	SpaceID      uuid.UUID             `json:"space_id"`
-	CommunityID  uuid.NullUUID         `json:"community_id"`
-	GroupID      uuid.NullUUID         `json:"group_id"`
-	ParentPostID uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID uuid.NullUUID         `json:"quoted_post_id"`
-	Content      string                `json:"content"`
-	Media        pqtype.NullRawMessage `json:"media"`
-	Tags         []string              `json:"tags"`
-	Visibility   sql.NullString        `json:"visibility"`
+	AuthorID       uuid.UUID             `json:"author_id"`
+	SpaceID        uuid.UUID             `json:"space_id"`
+	CommunityID    uuid.NullUUID         `json:"community_id"`
+	GroupID        uuid.NullUUID         `json:"group_id"`
+	ParentPostID   uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID   uuid.NullUUID         `json:"quoted_post_id"`
+	Content        string                `json:"content"`
+	Media          pqtype.NullRawMessage `json:"media"`
+	Tags           []string              `json:"tags"`
+	Visibility     sql.NullString        `json:"visibility"`
+	Status         sql.NullString        `json:"status"`
+	PublishAt      sql.NullTime          `json:"publish_at"`
+	ContentWarning sql.NullString        `json:"content_warning"`
+	IsSensitive    bool                  `json:"is_sensitive"`
 }
 
 
@@ -203,6 +325,10 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		arg.Media,
 		pq.Array(arg.Tags),
 		arg.Visibility,
+		arg.Status,
+		arg.PublishAt,
+		arg.ContentWarning,
+		arg.IsSensitive,
 	)
 	var i Post
 	err := row.Scan(
@@ -226,6 +352,10 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
 	)
 	return i, err
 }
@@ -233,7 +363,9 @@ func (q *Queries) CreatePost(ctx context.Context, arg CreatePostParams) (Post, e
 const createRepost = `-- name: CreateRepost :one
 INSERT INTO posts (author_id, space_id, quoted_post_id, content, visibility)
 VALUES ($1, $2, $3, $4, $5)
-RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at
+ON CONFLICT (author_id, quoted_post_id) WHERE quoted_post_id IS NOT NULL AND content = '' AND status = 'active'
+DO NOTHING
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
 `
 
 type CreateRepostParams struct {
@@ -274,6 +406,10 @@ func (q *Queries) CreateRepost(ctx context.Context, arg CreateRepostParams) (Pos
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
 	)
 	return i, err
 }
@@ -292,60 +428,193 @@ func (q *Queries) DeletePost(ctx context.Context, arg DeletePostParams) error {
 	return err
 }
 
+const getCommentByID = `-- name: GetCommentByID :one
+SELECT id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at, content_warning, is_sensitive FROM comments WHERE id = $1
+`
+
+func (q *Queries) GetCommentByID(ctx context.Context, id uuid.UUID) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, getCommentByID, id)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.AuthorID,
+		&i.ParentCommentID,
+		&i.Content,
+		&i.LikesCount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+	)
+	return i, err
+}
+
+const getCommentReplies = `-- name: GetCommentReplies :many
+SELECT
+    c.id, c.post_id, c.author_id, c.parent_comment_id, c.content, c.likes_count, c.status, c.created_at, c.updated_at, c.content_warning, c.is_sensitive,
+    u.username,
+    u.full_name,
+    u.avatar,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active') as reply_count,
+    EXISTS(SELECT 1 FROM likes l WHERE l.comment_id = c.id AND l.user_id = $1) as is_liked
+FROM comments c
+JOIN users u ON c.author_id = u.id
+WHERE c.parent_comment_id = $2::uuid
+  AND (c.status = 'active' OR EXISTS (
+      SELECT 1 FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active'
+  ))
+  AND ($3::timestamptz IS NULL
+       OR (c.created_at, c.id) > ($3::timestamptz, $4::uuid))
+  AND NOT is_hidden_from($1, c.author_id)
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT $5
+`
+
+type GetCommentRepliesParams struct {
+	ViewerID        uuid.UUID     `json:"viewer_id"`
+	ParentCommentID uuid.UUID     `json:"parent_comment_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+}
+
+type GetCommentRepliesRow struct {
+	ID              uuid.UUID      `json:"id"`
+	PostID          uuid.UUID      `json:"post_id"`
+	AuthorID        uuid.UUID      `json:"author_id"`
+	ParentCommentID uuid.NullUUID  `json:"parent_comment_id"`
+	Content         string         `json:"content"`
+	LikesCount      sql.NullInt32  `json:"likes_count"`
+	Status          sql.NullString `json:"status"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	ContentWarning  sql.NullString `json:"content_warning"`
+	IsSensitive     bool           `json:"is_sensitive"`
+	Username        string         `json:"username"`
+	FullName        string         `json:"full_name"`
+	Avatar          sql.NullString `json:"avatar"`
+	ReplyCount      int64          `json:"reply_count"`
+	IsLiked         bool           `json:"is_liked"`
+}
+
+func (q *Queries) GetCommentReplies(ctx context.Context, arg GetCommentRepliesParams) ([]GetCommentRepliesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCommentReplies,
+		arg.ViewerID,
+		arg.ParentCommentID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCommentRepliesRow{}
+	for rows.Next() {
+		var i GetCommentRepliesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.AuthorID,
+			&i.ParentCommentID,
+			&i.Content,
+			&i.LikesCount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.ReplyCount,
+			&i.IsLiked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCommunityPosts = `-- name: GetCommunityPosts :many
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
-    EXISTS(SELECT 1 FROM likes l6 WHERE l6.post_id = p.id AND l6.user_id = $1) as is_liked
+    EXISTS(SELECT 1 FROM likes l6 WHERE l6.post_id = p.id AND l6.user_id = $1) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked
 FROM posts p
 JOIN users u ON p.author_id = u.id
 WHERE p.community_id = $2 AND p.status = 'active'
-ORDER BY p.is_pinned DESC, p.created_at DESC
-LIMIT $3 OFFSET $4
+  AND NOT is_hidden_from($1, p.author_id)
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND ($3::timestamptz IS NULL
+       OR (p.created_at, p.id) < ($3::timestamptz, $4::uuid))
+ORDER BY ($5::bool AND COALESCE(p.is_pinned, false)) DESC, p.created_at DESC, p.id DESC
+LIMIT $6 OFFSET $7
 `
 
 type GetCommunityPostsParams struct {
-	UserID      uuid.UUID     `json:"user_id"`
-	CommunityID uuid.NullUUID `json:"community_id"`
-	Limit       int32         `json:"limit"`
-	Offset      int32         `json:"offset"`
+	UserID          uuid.UUID     `json:"user_id"`
+	CommunityID     uuid.NullUUID `json:"community_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PinnedFirst     bool          `json:"pinned_first"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetCommunityPostsRow struct {
-	ID            uuid.UUID             `json:"id"`
-	AuthorID      uuid.UUID             `json:"author_id"`
-	SpaceID       uuid.UUID             `json:"space_id"`
-	CommunityID   uuid.NullUUID         `json:"community_id"`
-	GroupID       uuid.NullUUID         `json:"group_id"`
-	ParentPostID  uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID  uuid.NullUUID         `json:"quoted_post_id"`
-	Content       string                `json:"content"`
-	Media         pqtype.NullRawMessage `json:"media"`
-	Tags          []string              `json:"tags"`
-	LikesCount    sql.NullInt32         `json:"likes_count"`
-	CommentsCount sql.NullInt32         `json:"comments_count"`
-	RepostsCount  sql.NullInt32         `json:"reposts_count"`
-	QuotesCount   sql.NullInt32         `json:"quotes_count"`
-	ViewsCount    sql.NullInt32         `json:"views_count"`
-	IsPinned      sql.NullBool          `json:"is_pinned"`
-	Visibility    sql.NullString        `json:"visibility"`
-	Status        sql.NullString        `json:"status"`
-	CreatedAt     sql.NullTime          `json:"created_at"`
-	UpdatedAt     sql.NullTime          `json:"updated_at"`
-	Username      interface{}           `json:"username"`
-	FullName      interface{}           `json:"full_name"`
-	AuthorAvatar  sql.NullString        `json:"author_avatar"`
-	IsLiked       bool                  `json:"is_liked"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	IsLiked            bool                  `json:"is_liked"`
+	IsBookmarked       bool                  `json:"is_bookmarked"`
 }
 
 func (q *Queries) GetCommunityPosts(ctx context.Context, arg GetCommunityPostsParams) ([]GetCommunityPostsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getCommunityPosts,
 		arg.UserID,
 		arg.CommunityID,
-		arg.Limit,
-		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PinnedFirst,
+		arg.PageSize,
+		arg.PageOffset,
 	)
 	if err != nil {
 		return nil, err
@@ -375,10 +644,15 @@ func (q *Queries) GetCommunityPosts(ctx context.Context, arg GetCommunityPostsPa
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
 			&i.IsLiked,
+			&i.IsBookmarked,
 		); err != nil {
 			return nil, err
 		}
@@ -395,58 +669,74 @@ func (q *Queries) GetCommunityPosts(ctx context.Context, arg GetCommunityPostsPa
 
 const getGroupPosts = `-- name: GetGroupPosts :many
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
-    EXISTS(SELECT 1 FROM likes l7 WHERE l7.post_id = p.id AND l7.user_id = $1) as is_liked
+    EXISTS(SELECT 1 FROM likes l7 WHERE l7.post_id = p.id AND l7.user_id = $1) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked
 FROM posts p
 JOIN users u ON p.author_id = u.id
 WHERE p.group_id = $2 AND p.status = 'active'
-ORDER BY p.is_pinned DESC, p.created_at DESC
-LIMIT $3 OFFSET $4
+  AND NOT is_hidden_from($1, p.author_id)
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND ($3::timestamptz IS NULL
+       OR (p.created_at, p.id) < ($3::timestamptz, $4::uuid))
+ORDER BY ($5::bool AND COALESCE(p.is_pinned, false)) DESC, p.created_at DESC, p.id DESC
+LIMIT $6 OFFSET $7
 `
 
 type GetGroupPostsParams struct {
-	UserID  uuid.UUID     `json:"user_id"`
-	GroupID uuid.NullUUID `json:"group_id"`
-	Limit   int32         `json:"limit"`
-	Offset  int32         `json:"offset"`
+	UserID          uuid.UUID     `json:"user_id"`
+	GroupID         uuid.NullUUID `json:"group_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PinnedFirst     bool          `json:"pinned_first"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetGroupPostsRow struct {
-	ID            uuid.UUID             `json:"id"`
-	AuthorID      uuid.UUID             `json:"author_id"`
-	SpaceID       uuid.UUID             `json:"space_id"`
-	CommunityID   uuid.NullUUID         `json:"community_id"`
-	GroupID       uuid.NullUUID         `json:"group_id"`
-	ParentPostID  uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID  uuid.NullUUID         `json:"quoted_post_id"`
-	Content       string                `json:"content"`
-	Media         pqtype.NullRawMessage `json:"media"`
-	Tags          []string              `json:"tags"`
-	LikesCount    sql.NullInt32         `json:"likes_count"`
-	CommentsCount sql.NullInt32         `json:"comments_count"`
-	RepostsCount  sql.NullInt32         `json:"reposts_count"`
-	QuotesCount   sql.NullInt32         `json:"quotes_count"`
-	ViewsCount    sql.NullInt32         `json:"views_count"`
-	IsPinned      sql.NullBool          `json:"is_pinned"`
-	Visibility    sql.NullString        `json:"visibility"`
-	Status        sql.NullString        `json:"status"`
-	CreatedAt     sql.NullTime          `json:"created_at"`
-	UpdatedAt     sql.NullTime          `json:"updated_at"`
-	Username      interface{}           `json:"username"`
-	FullName      interface{}           `json:"full_name"`
-	AuthorAvatar  sql.NullString        `json:"author_avatar"`
-	IsLiked       bool                  `json:"is_liked"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	IsLiked            bool                  `json:"is_liked"`
+	IsBookmarked       bool                  `json:"is_bookmarked"`
 }
 
 func (q *Queries) GetGroupPosts(ctx context.Context, arg GetGroupPostsParams) ([]GetGroupPostsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getGroupPosts,
 		arg.UserID,
 		arg.GroupID,
-		arg.Limit,
-		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PinnedFirst,
+		arg.PageSize,
+		arg.PageOffset,
 	)
 	if err != nil {
 		return nil, err
@@ -476,10 +766,126 @@ func (q *Queries) GetGroupPosts(ctx context.Context, arg GetGroupPostsParams) ([
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
 			&i.IsLiked,
+			&i.IsBookmarked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingPost = `-- name: GetPendingPost :one
+SELECT id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator FROM posts
+WHERE id = $1 AND author_id = $2 AND status IN ('draft', 'scheduled')
+`
+
+type GetPendingPostParams struct {
+	ID       uuid.UUID `json:"id"`
+	AuthorID uuid.UUID `json:"author_id"`
+}
+
+func (q *Queries) GetPendingPost(ctx context.Context, arg GetPendingPostParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, getPendingPost, arg.ID, arg.AuthorID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.SpaceID,
+		&i.CommunityID,
+		&i.GroupID,
+		&i.ParentPostID,
+		&i.QuotedPostID,
+		&i.Content,
+		&i.Media,
+		pq.Array(&i.Tags),
+		&i.LikesCount,
+		&i.CommentsCount,
+		&i.RepostsCount,
+		&i.QuotesCount,
+		&i.ViewsCount,
+		&i.IsPinned,
+		&i.Visibility,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
+	)
+	return i, err
+}
+
+const getPendingPosts = `-- name: GetPendingPosts :many
+SELECT id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator FROM posts
+WHERE author_id = $1
+  AND status IN ('draft', 'scheduled')
+  AND ($2::varchar IS NULL OR status = $2::varchar)
+ORDER BY publish_at ASC NULLS LAST, created_at DESC
+LIMIT $3 OFFSET $4
+`
+
+type GetPendingPostsParams struct {
+	AuthorID   uuid.UUID      `json:"author_id"`
+	Status     sql.NullString `json:"status"`
+	PageLimit  int32          `json:"page_limit"`
+	PageOffset int32          `json:"page_offset"`
+}
+
+func (q *Queries) GetPendingPosts(ctx context.Context, arg GetPendingPostsParams) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, getPendingPosts,
+		arg.AuthorID,
+		arg.Status,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Post{}
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.SpaceID,
+			&i.CommunityID,
+			&i.GroupID,
+			&i.ParentPostID,
+			&i.QuotedPostID,
+			&i.Content,
+			&i.Media,
+			pq.Array(&i.Tags),
+			&i.LikesCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+			&i.QuotesCount,
+			&i.ViewsCount,
+			&i.IsPinned,
+			&i.Visibility,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 		); err != nil {
 			return nil, err
 		}
@@ -496,7 +902,7 @@ func (q *Queries) GetGroupPosts(ctx context.Context, arg GetGroupPostsParams) ([
 
 const getPostByID = `-- name: GetPostByID :one
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
@@ -510,7 +916,9 @@ SELECT
     COALESCE(NULLIF(qu.username, ''), 'user_' || SUBSTRING(qu.id::text, 1, 8)) as quoted_username,
     COALESCE(NULLIF(qu.full_name, ''), 'User') as quoted_full_name,
     EXISTS(SELECT 1 FROM likes l2 WHERE l2.post_id = p.id AND l2.user_id = $1) as is_liked,
-    EXISTS(SELECT 1 FROM posts pq2 WHERE pq2.quoted_post_id = p.id AND pq2.author_id = $1) as is_quoted,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked,
+    EXISTS(SELECT 1 FROM posts pq2 WHERE pq2.quoted_post_id = p.id AND pq2.author_id = $1 AND pq2.status = 'active') as is_quoted,
+    EXISTS(SELECT 1 FROM posts pr WHERE pr.quoted_post_id = p.id AND pr.author_id = $1 AND pr.content = '' AND pr.status = 'active') as is_reposted,
     (SELECT COUNT(*) FROM likes l3 WHERE l3.post_id = p.id) as actual_likes_count,
     (SELECT COUNT(*) FROM comments c2 WHERE c2.post_id = p.id AND c2.status = 'active') as actual_comments_count,
     (SELECT COUNT(*) FROM posts p2 WHERE p2.quoted_post_id = p.id AND p2.status = 'active') as actual_quotes_count
@@ -518,9 +926,13 @@ FROM posts p
 JOIN users u ON p.author_id = u.id
 LEFT JOIN communities c ON p.community_id = c.id
 LEFT JOIN groups g ON p.group_id = g.id
-LEFT JOIN posts qp ON p.quoted_post_id = qp.id
+LEFT JOIN posts qp ON p.quoted_post_id = qp.id AND qp.status = 'active'
+    AND NOT is_hidden_from($1, qp.author_id)
+    AND can_view_post($1, qp.author_id, qp.visibility, qp.community_id, qp.group_id)
 LEFT JOIN users qu ON qp.author_id = qu.id
 WHERE p.id = $2 AND p.status = 'active'
+  AND NOT is_hidden_from($1, p.author_id)
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
 `
 
 type GetPostByIDParams struct {
@@ -549,6 +961,10 @@ type GetPostByIDRow struct {
 	Status              sql.NullString        `json:"status"`
 	CreatedAt           sql.NullTime          `json:"created_at"`
 	UpdatedAt           sql.NullTime          `json:"updated_at"`
+	PublishAt           sql.NullTime          `json:"publish_at"`
+	ContentWarning      sql.NullString        `json:"content_warning"`
+	IsSensitive         bool                  `json:"is_sensitive"`
+	WarningByModerator  bool                  `json:"warning_by_moderator"`
 	Username            interface{}           `json:"username"`
 	FullName            interface{}           `json:"full_name"`
 	AuthorAvatar        sql.NullString        `json:"author_avatar"`
@@ -562,7 +978,9 @@ type GetPostByIDRow struct {
 	QuotedUsername      interface{}           `json:"quoted_username"`
 	QuotedFullName      interface{}           `json:"quoted_full_name"`
 	IsLiked             bool                  `json:"is_liked"`
+	IsBookmarked        bool                  `json:"is_bookmarked"`
 	IsQuoted            bool                  `json:"is_quoted"`
+	IsReposted          bool                  `json:"is_reposted"`
 	ActualLikesCount    int64                 `json:"actual_likes_count"`
 	ActualCommentsCount int64                 `json:"actual_comments_count"`
 	ActualQuotesCount   int64                 `json:"actual_quotes_count"`
@@ -592,6 +1010,10 @@ func (q *Queries) GetPostByID(ctx context.Context, arg GetPostByIDParams) (GetPo
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
 		&i.Username,
 		&i.FullName,
 		&i.AuthorAvatar,
@@ -605,7 +1027,9 @@ func (q *Queries) GetPostByID(ctx context.Context, arg GetPostByIDParams) (GetPo
 		&i.QuotedUsername,
 		&i.QuotedFullName,
 		&i.IsLiked,
+		&i.IsBookmarked,
 		&i.IsQuoted,
+		&i.IsReposted,
 		&i.ActualLikesCount,
 		&i.ActualCommentsCount,
 		&i.ActualQuotesCount,
@@ -614,36 +1038,60 @@ func (q *Queries) GetPostByID(ctx context.Context, arg GetPostByIDParams) (GetPo
 }
 
 const getPostComments = `-- name: GetPostComments :many
-WITH RECURSIVE comment_tree AS (
+WITH RECURSIVE roots AS (
+    SELECT c.id
+    FROM comments c
+    WHERE c.post_id = $1 AND c.parent_comment_id IS NULL AND c.status = 'active'
+      AND NOT is_hidden_from($2, c.author_id)
+      AND ($3::timestamptz IS NULL
+           OR (c.created_at, c.id) > ($3::timestamptz, $4::uuid))
+    ORDER BY c.created_at ASC, c.id ASC
+    LIMIT $5 OFFSET $6
+),
+comment_tree AS (
     SELECT 
-        c.id, c.post_id, c.author_id, c.parent_comment_id, c.content, c.likes_count, c.status, c.created_at, c.updated_at, 
+        c.id, c.post_id, c.author_id, c.parent_comment_id, c.content, c.likes_count, c.status, c.created_at, c.updated_at, c.content_warning, c.is_sensitive, 
         u.username,
         u.full_name,
         u.avatar,
         0 as depth,
-        ARRAY[c.id] as path
+        ARRAY[c.id] as path,
+        c.id as root_id,
+        c.created_at as root_created_at
     FROM comments c
     JOIN users u ON c.author_id = u.id
-    WHERE c.post_id = $1 AND c.parent_comment_id IS NULL AND c.status = 'active'
+    WHERE c.id IN (SELECT id FROM roots)
     
     UNION ALL
     
     SELECT 
-        c.id, c.post_id, c.author_id, c.parent_comment_id, c.content, c.likes_count, c.status, c.created_at, c.updated_at,
+        c.id, c.post_id, c.author_id, c.parent_comment_id, c.content, c.likes_count, c.status, c.created_at, c.updated_at, c.content_warning, c.is_sensitive,
         u.username,
         u.full_name,
         u.avatar,
         ct.depth + 1 as depth,
-        ct.path || c.id as path
+        ct.path || c.id as path,
+        ct.root_id,
+        ct.root_created_at
     FROM comments c
     JOIN users u ON c.author_id = u.id
     JOIN comment_tree ct ON c.parent_comment_id = ct.id
     WHERE c.status = 'active'
+      AND NOT is_hidden_from($2, c.author_id)
 )
-SELECT id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at, username, full_name, avatar, depth, path FROM comment_tree
-ORDER BY path, created_at
+SELECT id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at, content_warning, is_sensitive, username, full_name, avatar, depth, path, root_id, root_created_at FROM comment_tree
+ORDER BY root_created_at, root_id, path, created_at
 `
 
+type GetPostCommentsParams struct {
+	PostID          uuid.UUID     `json:"post_id"`
+	ViewerID        uuid.UUID     `json:"viewer_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
+}
+
 type GetPostCommentsRow struct {
 	ID              uuid.UUID      `json:"id"`
 	PostID          uuid.UUID      `json:"post_id"`
@@ -654,15 +1102,26 @@ type GetPostCommentsRow struct {
 	Status          sql.NullString `json:"status"`
 	CreatedAt       sql.NullTime   `json:"created_at"`
 	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	ContentWarning  sql.NullString `json:"content_warning"`
+	IsSensitive     bool           `json:"is_sensitive"`
 	Username        string         `json:"username"`
 	FullName        string         `json:"full_name"`
 	Avatar          sql.NullString `json:"avatar"`
 	Depth           int32          `json:"depth"`
 	Path            interface{}    `json:"path"`
+	RootID          uuid.UUID      `json:"root_id"`
+	RootCreatedAt   sql.NullTime   `json:"root_created_at"`
 }
 
-func (q *Queries) GetPostComments(ctx context.Context, postID uuid.UUID) ([]GetPostCommentsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getPostComments, postID)
+func (q *Queries) GetPostComments(ctx context.Context, arg GetPostCommentsParams) ([]GetPostCommentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostComments,
+		arg.PostID,
+		arg.ViewerID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -680,11 +1139,15 @@ func (q *Queries) GetPostComments(ctx context.Context, postID uuid.UUID) ([]GetP
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
 			&i.Username,
 			&i.FullName,
 			&i.Avatar,
 			&i.Depth,
 			&i.Path,
+			&i.RootID,
+			&i.RootCreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -749,53 +1212,390 @@ func (q *Queries) GetPostLikes(ctx context.Context, postID uuid.NullUUID) ([]Get
 	return items, nil
 }
 
-const getTrendingPosts = `-- name: GetTrendingPosts :many
+const getRankedFeed = `-- name: GetRankedFeed :many
+WITH candidates AS (
+    SELECT
+        p.id,
+        p.author_id,
+        p.community_id,
+        p.group_id,
+        GREATEST(EXTRACT(EPOCH FROM ($1::timestamptz - p.created_at)) / 3600.0, 0)::float8 AS age_hours
+    FROM posts p
+    WHERE p.space_id = $2
+      AND p.status = 'active'
+      AND p.created_at <= $1::timestamptz
+      AND p.created_at > $1::timestamptz - make_interval(hours => $3::int)
+      AND can_view_post($4, p.author_id, p.visibility, p.community_id, p.group_id)
+      AND NOT is_hidden_from($4, p.author_id)
+),
+signals AS (
+    SELECT
+        c.*,
+        EXISTS(SELECT 1 FROM follows f WHERE f.follower_id = $4 AND f.following_id = c.author_id) AS is_following,
+        (
+            (SELECT COUNT(*) FROM likes l JOIN posts ap ON l.post_id = ap.id
+             WHERE l.user_id = $4 AND ap.author_id = c.author_id
+               AND l.created_at <= $1::timestamptz
+               AND l.created_at > $1::timestamptz - INTERVAL '30 days')
+            +
+            (SELECT COUNT(*) FROM comments cm JOIN posts ap ON cm.post_id = ap.id
+             WHERE cm.author_id = $4 AND ap.author_id = c.author_id AND cm.status = 'active'
+               AND cm.created_at <= $1::timestamptz
+               AND cm.created_at > $1::timestamptz - INTERVAL '30 days')
+        ) AS interaction_count,
+        (SELECT COUNT(*) FROM likes l WHERE l.post_id = c.id AND l.created_at <= $1::timestamptz) AS like_count,
+        (SELECT COUNT(*) FROM comments cm WHERE cm.post_id = c.id AND cm.status = 'active' AND cm.created_at <= $1::timestamptz) AS comment_count,
+        (SELECT COUNT(*) FROM posts rp WHERE rp.quoted_post_id = c.id AND rp.status = 'active' AND rp.created_at <= $1::timestamptz) AS repost_count,
+        (EXISTS(SELECT 1 FROM community_members cmm WHERE cmm.community_id = c.community_id AND cmm.user_id = $4)
+         OR EXISTS(SELECT 1 FROM group_members gm WHERE gm.group_id = c.group_id AND gm.user_id = $4)) AS is_member
+    FROM candidates c
+),
+scored AS (
+    SELECT
+        s.*,
+        POWER(0.5, s.age_hours / $5::float8)::float8 AS recency_score,
+        (CASE WHEN s.author_id = $4 THEN 0
+              ELSE (CASE WHEN s.is_following THEN 1 ELSE 0 END) + LN(1 + s.interaction_count) END)::float8 AS affinity_score,
+        LN(1 + (s.like_count + 2 * s.comment_count + 3 * s.repost_count) / GREATEST(s.age_hours, 1))::float8 AS velocity_score,
+        (CASE WHEN s.is_member THEN 1 ELSE 0 END)::float8 AS membership_score
+    FROM signals s
+),
+ranked AS (
+    SELECT
+        sc.*,
+        (sc.recency_score * (1
+            + $6::float8 * sc.affinity_score
+            + $7::float8 * sc.velocity_score
+            + $8::float8 * sc.membership_score))::float8 AS score
+    FROM scored sc
+)
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
-    (p.likes_count + (p.comments_count * 2) + p.views_count + (p.quotes_count * 3)) as engagement_score
-FROM posts p
+    u.verified as author_verified,
+    co.name as community_name,
+    g.name as group_name,
+    EXISTS(SELECT 1 FROM likes lk WHERE lk.post_id = p.id AND lk.user_id = $4) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $4) as is_bookmarked,
+    r.age_hours,
+    r.is_following,
+    r.interaction_count::int AS interaction_count,
+    r.like_count::int AS like_count,
+    r.comment_count::int AS comment_count,
+    r.repost_count::int AS repost_count,
+    r.is_member,
+    r.recency_score,
+    r.affinity_score,
+    r.velocity_score,
+    r.membership_score,
+    r.score
+FROM ranked r
+JOIN posts p ON p.id = r.id
 JOIN users u ON p.author_id = u.id
-WHERE p.space_id = $1
-  AND p.status = 'active'
-  AND p.created_at >= NOW() - INTERVAL '7 days'
-ORDER BY engagement_score DESC, p.created_at DESC
-LIMIT 20
+LEFT JOIN communities co ON p.community_id = co.id
+LEFT JOIN groups g ON p.group_id = g.id
+WHERE ($9::float8 IS NULL
+       OR (r.score, r.id) < ($9::float8, $10::uuid))
+ORDER BY r.score DESC, r.id DESC
+LIMIT $11
 `
 
-type GetTrendingPostsRow struct {
-	ID              uuid.UUID             `json:"id"`
-	AuthorID        uuid.UUID             `json:"author_id"`
-	SpaceID         uuid.UUID             `json:"space_id"`
-	CommunityID     uuid.NullUUID         `json:"community_id"`
-	GroupID         uuid.NullUUID         `json:"group_id"`
-	ParentPostID    uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID    uuid.NullUUID         `json:"quoted_post_id"`
-	Content         string                `json:"content"`
-	Media           pqtype.NullRawMessage `json:"media"`
-	Tags            []string              `json:"tags"`
-	LikesCount      sql.NullInt32         `json:"likes_count"`
-	CommentsCount   sql.NullInt32         `json:"comments_count"`
-	RepostsCount    sql.NullInt32         `json:"reposts_count"`
-	QuotesCount     sql.NullInt32         `json:"quotes_count"`
-	ViewsCount      sql.NullInt32         `json:"views_count"`
-	IsPinned        sql.NullBool          `json:"is_pinned"`
-	Visibility      sql.NullString        `json:"visibility"`
-	Status          sql.NullString        `json:"status"`
-	CreatedAt       sql.NullTime          `json:"created_at"`
-	UpdatedAt       sql.NullTime          `json:"updated_at"`
-	Username        interface{}           `json:"username"`
-	FullName        interface{}           `json:"full_name"`
-	AuthorAvatar    sql.NullString        `json:"author_avatar"`
-	EngagementScore int32                 `json:"engagement_score"`
-}
-
-func (q *Queries) GetTrendingPosts(ctx context.Context, spaceID uuid.UUID) ([]GetTrendingPostsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTrendingPosts, spaceID)
-	if err != nil {
-		return nil, err
+type GetRankedFeedParams struct {
+	SnapshotAt       time.Time       `json:"snapshot_at"`
+	SpaceID          uuid.UUID       `json:"space_id"`
+	WindowHours      int32           `json:"window_hours"`
+	ViewerID         uuid.UUID       `json:"viewer_id"`
+	HalfLifeHours    float64         `json:"half_life_hours"`
+	AffinityWeight   float64         `json:"affinity_weight"`
+	VelocityWeight   float64         `json:"velocity_weight"`
+	MembershipWeight float64         `json:"membership_weight"`
+	CursorScore      sql.NullFloat64 `json:"cursor_score"`
+	CursorID         uuid.NullUUID   `json:"cursor_id"`
+	PageSize         int32           `json:"page_size"`
+}
+
+type GetRankedFeedRow struct {
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	AuthorVerified     sql.NullBool          `json:"author_verified"`
+	CommunityName      sql.NullString        `json:"community_name"`
+	GroupName          sql.NullString        `json:"group_name"`
+	IsLiked            bool                  `json:"is_liked"`
+	IsBookmarked       bool                  `json:"is_bookmarked"`
+	AgeHours           float64               `json:"age_hours"`
+	IsFollowing        bool                  `json:"is_following"`
+	InteractionCount   int32                 `json:"interaction_count"`
+	LikeCount          int32                 `json:"like_count"`
+	CommentCount       int32                 `json:"comment_count"`
+	RepostCount        int32                 `json:"repost_count"`
+	IsMember           bool                  `json:"is_member"`
+	RecencyScore       float64               `json:"recency_score"`
+	AffinityScore      float64               `json:"affinity_score"`
+	VelocityScore      float64               `json:"velocity_score"`
+	MembershipScore    float64               `json:"membership_score"`
+	Score              float64               `json:"score"`
+}
+
+func (q *Queries) GetRankedFeed(ctx context.Context, arg GetRankedFeedParams) ([]GetRankedFeedRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRankedFeed,
+		arg.SnapshotAt,
+		arg.SpaceID,
+		arg.WindowHours,
+		arg.ViewerID,
+		arg.HalfLifeHours,
+		arg.AffinityWeight,
+		arg.VelocityWeight,
+		arg.MembershipWeight,
+		arg.CursorScore,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetRankedFeedRow{}
+	for rows.Next() {
+		var i GetRankedFeedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.SpaceID,
+			&i.CommunityID,
+			&i.GroupID,
+			&i.ParentPostID,
+			&i.QuotedPostID,
+			&i.Content,
+			&i.Media,
+			pq.Array(&i.Tags),
+			&i.LikesCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+			&i.QuotesCount,
+			&i.ViewsCount,
+			&i.IsPinned,
+			&i.Visibility,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
+			&i.Username,
+			&i.FullName,
+			&i.AuthorAvatar,
+			&i.AuthorVerified,
+			&i.CommunityName,
+			&i.GroupName,
+			&i.IsLiked,
+			&i.IsBookmarked,
+			&i.AgeHours,
+			&i.IsFollowing,
+			&i.InteractionCount,
+			&i.LikeCount,
+			&i.CommentCount,
+			&i.RepostCount,
+			&i.IsMember,
+			&i.RecencyScore,
+			&i.AffinityScore,
+			&i.VelocityScore,
+			&i.MembershipScore,
+			&i.Score,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopLevelComments = `-- name: GetTopLevelComments :many
+SELECT
+    c.id, c.post_id, c.author_id, c.parent_comment_id, c.content, c.likes_count, c.status, c.created_at, c.updated_at, c.content_warning, c.is_sensitive,
+    u.username,
+    u.full_name,
+    u.avatar,
+    (SELECT COUNT(*) FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active') as reply_count,
+    EXISTS(SELECT 1 FROM likes l WHERE l.comment_id = c.id AND l.user_id = $1) as is_liked
+FROM comments c
+JOIN users u ON c.author_id = u.id
+WHERE c.post_id = $2
+  AND c.parent_comment_id IS NULL
+  AND (c.status = 'active' OR EXISTS (
+      SELECT 1 FROM comments r WHERE r.parent_comment_id = c.id AND r.status = 'active'
+  ))
+  AND ($3::timestamptz IS NULL
+       OR (c.created_at, c.id) > ($3::timestamptz, $4::uuid))
+  AND NOT is_hidden_from($1, c.author_id)
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT $5
+`
+
+type GetTopLevelCommentsParams struct {
+	ViewerID        uuid.UUID     `json:"viewer_id"`
+	PostID          uuid.UUID     `json:"post_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+}
+
+type GetTopLevelCommentsRow struct {
+	ID              uuid.UUID      `json:"id"`
+	PostID          uuid.UUID      `json:"post_id"`
+	AuthorID        uuid.UUID      `json:"author_id"`
+	ParentCommentID uuid.NullUUID  `json:"parent_comment_id"`
+	Content         string         `json:"content"`
+	LikesCount      sql.NullInt32  `json:"likes_count"`
+	Status          sql.NullString `json:"status"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	ContentWarning  sql.NullString `json:"content_warning"`
+	IsSensitive     bool           `json:"is_sensitive"`
+	Username        string         `json:"username"`
+	FullName        string         `json:"full_name"`
+	Avatar          sql.NullString `json:"avatar"`
+	ReplyCount      int64          `json:"reply_count"`
+	IsLiked         bool           `json:"is_liked"`
+}
+
+func (q *Queries) GetTopLevelComments(ctx context.Context, arg GetTopLevelCommentsParams) ([]GetTopLevelCommentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTopLevelComments,
+		arg.ViewerID,
+		arg.PostID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTopLevelCommentsRow{}
+	for rows.Next() {
+		var i GetTopLevelCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.PostID,
+			&i.AuthorID,
+			&i.ParentCommentID,
+			&i.Content,
+			&i.LikesCount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.ReplyCount,
+			&i.IsLiked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTrendingPosts = `-- name: GetTrendingPosts :many
+SELECT
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    (p.likes_count + (p.comments_count * 2) + p.views_count + (p.quotes_count * 3)) as engagement_score
+FROM posts p
+JOIN users u ON p.author_id = u.id
+WHERE p.space_id = $1
+  AND p.status = 'active'
+  AND p.created_at >= NOW() - INTERVAL '7 days'
+  AND NOT is_hidden_from($2, p.author_id)
+  AND can_view_post($2, p.author_id, p.visibility, p.community_id, p.group_id)
+ORDER BY engagement_score DESC, p.created_at DESC
+LIMIT 20
+`
+
+type GetTrendingPostsParams struct {
+	SpaceID  uuid.UUID `json:"space_id"`
+	ViewerID uuid.UUID `json:"viewer_id"`
+}
+
+type GetTrendingPostsRow struct {
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	EngagementScore    int32                 `json:"engagement_score"`
+}
+
+func (q *Queries) GetTrendingPosts(ctx context.Context, arg GetTrendingPostsParams) ([]GetTrendingPostsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTrendingPosts, arg.SpaceID, arg.ViewerID)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 	items := []GetTrendingPostsRow{}
@@ -822,6 +1622,10 @@ func (q *Queries) GetTrendingPosts(ctx context.Context, spaceID uuid.UUID) ([]Ge
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
@@ -841,46 +1645,42 @@ func (q *Queries) GetTrendingPosts(ctx context.Context, spaceID uuid.UUID) ([]Ge
 }
 
 const getTrendingTopics = `-- name: GetTrendingTopics :many
-WITH hashtags AS (
-  SELECT
-    unnest(regexp_matches(content, '#([a-zA-Z0-9_]+)', 'g')) AS tag,
-    p.id,
-    p.created_at,
-    COALESCE(p.likes_count, 0) + COALESCE(p.comments_count, 0) + COALESCE(p.reposts_count, 0) AS engagement
-  FROM posts p
-  WHERE p.space_id = $1
-    AND p.status = 'active'
-    AND p.created_at > NOW() - INTERVAL '24 hours'
-)
 SELECT
-  lower(tag) AS id,
-  '#' || tag AS name,
-  'General' AS category,
-  COUNT(DISTINCT id) AS posts_count,
-  -- Trend score: posts * avg_engagement * recency_factor
-  COUNT(*) * AVG(engagement)::int AS trend_score
-FROM hashtags
-GROUP BY lower(tag), tag
-ORDER BY trend_score DESC
-LIMIT $2 OFFSET $3
+    tt.name::varchar AS id,
+    '#' || tt.name AS name,
+    COALESCE(tt.category, 'hashtag')::varchar AS category,
+    COALESCE(tt.post_count, 0)::bigint AS posts_count,
+    COALESCE(tt.trend_score, 0)::float8 AS trend_score,
+    tt.recorded_at
+FROM trending_topics tt
+WHERE tt.space_id = $1 AND tt.period = $2
+ORDER BY tt.trend_score DESC, tt.name ASC
+LIMIT $3 OFFSET $4
 `
 
 type GetTrendingTopicsParams struct {
-	SpaceID uuid.UUID `json:"space_id"`
-	Limit   int32     `json:"limit"`
-	Offset  int32     `json:"offset"`
+	SpaceID uuid.UUID      `json:"space_id"`
+	Period  sql.NullString `json:"period"`
+	Limit   int32          `json:"limit"`
+	Offset  int32          `json:"offset"`
 }
 
 type GetTrendingTopicsRow struct {
-	ID         string      `json:"id"`
-	Name       interface{} `json:"name"`
-	Category   string      `json:"category"`
-	PostsCount int64       `json:"posts_count"`
-	TrendScore int32       `json:"trend_score"`
+	ID         string       `json:"id"`
+	Name       interface{}  `json:"name"`
+	Category   string       `json:"category"`
+	PostsCount int64        `json:"posts_count"`
+	TrendScore float64      `json:"trend_score"`
+	RecordedAt sql.NullTime `json:"recorded_at"`
 }
 
 func (q *Queries) GetTrendingTopics(ctx context.Context, arg GetTrendingTopicsParams) ([]GetTrendingTopicsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTrendingTopics, arg.SpaceID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getTrendingTopics,
+		arg.SpaceID,
+		arg.Period,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -894,6 +1694,7 @@ func (q *Queries) GetTrendingTopics(ctx context.Context, arg GetTrendingTopicsPa
 			&i.Category,
 			&i.PostsCount,
 			&i.TrendScore,
+			&i.RecordedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -910,7 +1711,7 @@ func (q *Queries) GetTrendingTopics(ctx context.Context, arg GetTrendingTopicsPa
 
 const getUserFeed = `-- name: GetUserFeed :many
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
@@ -918,6 +1719,7 @@ SELECT
     c.name as community_name,
     g.name as group_name,
     EXISTS(SELECT 1 FROM likes l4 WHERE l4.post_id = p.id AND l4.user_id = $1) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked,
     EXISTS(SELECT 1 FROM posts pq3 WHERE pq3.quoted_post_id = p.id AND pq3.author_id = $1) as is_quoted
 FROM posts p
 JOIN users u ON p.author_id = u.id
@@ -925,59 +1727,67 @@ LEFT JOIN communities c ON p.community_id = c.id
 LEFT JOIN groups g ON p.group_id = g.id
 WHERE p.space_id = $2
   AND p.status = 'active'
-  AND (p.visibility = 'public'
-       OR p.author_id = $1
-       OR p.author_id IN (SELECT following_id FROM follows WHERE follower_id = $1)
-       OR p.community_id IN (SELECT community_id FROM community_members WHERE user_id = $1)
-       OR p.group_id IN (SELECT group_id FROM group_members WHERE user_id = $1))
-ORDER BY p.created_at DESC
-LIMIT $3 OFFSET $4
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from($1, p.author_id)
+  AND ($3::timestamptz IS NULL
+       OR (p.created_at, p.id) < ($3::timestamptz, $4::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT $5 OFFSET $6
 `
 
 type GetUserFeedParams struct {
-	UserID  uuid.UUID `json:"user_id"`
-	SpaceID uuid.UUID `json:"space_id"`
-	Limit   int32     `json:"limit"`
-	Offset  int32     `json:"offset"`
+	UserID          uuid.UUID     `json:"user_id"`
+	SpaceID         uuid.UUID     `json:"space_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetUserFeedRow struct {
-	ID             uuid.UUID             `json:"id"`
-	AuthorID       uuid.UUID             `json:"author_id"`
-	SpaceID        uuid.UUID             `json:"space_id"`
-	CommunityID    uuid.NullUUID         `json:"community_id"`
-	GroupID        uuid.NullUUID         `json:"group_id"`
-	ParentPostID   uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID   uuid.NullUUID         `json:"quoted_post_id"`
-	Content        string                `json:"content"`
-	Media          pqtype.NullRawMessage `json:"media"`
-	Tags           []string              `json:"tags"`
-	LikesCount     sql.NullInt32         `json:"likes_count"`
-	CommentsCount  sql.NullInt32         `json:"comments_count"`
-	RepostsCount   sql.NullInt32         `json:"reposts_count"`
-	QuotesCount    sql.NullInt32         `json:"quotes_count"`
-	ViewsCount     sql.NullInt32         `json:"views_count"`
-	IsPinned       sql.NullBool          `json:"is_pinned"`
-	Visibility     sql.NullString        `json:"visibility"`
-	Status         sql.NullString        `json:"status"`
-	CreatedAt      sql.NullTime          `json:"created_at"`
-	UpdatedAt      sql.NullTime          `json:"updated_at"`
-	Username       interface{}           `json:"username"`
-	FullName       interface{}           `json:"full_name"`
-	AuthorAvatar   sql.NullString        `json:"author_avatar"`
-	AuthorVerified sql.NullBool          `json:"author_verified"`
-	CommunityName  sql.NullString        `json:"community_name"`
-	GroupName      sql.NullString        `json:"group_name"`
-	IsLiked        bool                  `json:"is_liked"`
-	IsQuoted       bool                  `json:"is_quoted"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	AuthorVerified     sql.NullBool          `json:"author_verified"`
+	CommunityName      sql.NullString        `json:"community_name"`
+	GroupName          sql.NullString        `json:"group_name"`
+	IsLiked            bool                  `json:"is_liked"`
+	IsBookmarked       bool                  `json:"is_bookmarked"`
+	IsQuoted           bool                  `json:"is_quoted"`
 }
 
 func (q *Queries) GetUserFeed(ctx context.Context, arg GetUserFeedParams) ([]GetUserFeedRow, error) {
 	rows, err := q.db.QueryContext(ctx, getUserFeed,
 		arg.UserID,
 		arg.SpaceID,
-		arg.Limit,
-		arg.Offset,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
 	)
 	if err != nil {
 		return nil, err
@@ -1007,6 +1817,10 @@ func (q *Queries) GetUserFeed(ctx context.Context, arg GetUserFeedParams) ([]Get
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
@@ -1014,6 +1828,7 @@ func (q *Queries) GetUserFeed(ctx context.Context, arg GetUserFeedParams) ([]Get
 			&i.CommunityName,
 			&i.GroupName,
 			&i.IsLiked,
+			&i.IsBookmarked,
 			&i.IsQuoted,
 		); err != nil {
 			return nil, err
@@ -1031,7 +1846,7 @@ func (q *Queries) GetUserFeed(ctx context.Context, arg GetUserFeedParams) ([]Get
 
 const getUserLikedPosts = `-- name: GetUserLikedPosts :many
 SELECT 
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     u.username,
     u.full_name,
     u.avatar as author_avatar
@@ -1050,29 +1865,33 @@ type GetUserLikedPostsParams struct {
 }
 
 type GetUserLikedPostsRow struct {
-	ID            uuid.UUID             `json:"id"`
-	AuthorID      uuid.UUID             `json:"author_id"`
-	SpaceID       uuid.UUID             `json:"space_id"`
-	CommunityID   uuid.NullUUID         `json:"community_id"`
-	GroupID       uuid.NullUUID         `json:"group_id"`
-	ParentPostID  uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID  uuid.NullUUID         `json:"quoted_post_id"`
-	Content       string                `json:"content"`
-	Media         pqtype.NullRawMessage `json:"media"`
-	Tags          []string              `json:"tags"`
-	LikesCount    sql.NullInt32         `json:"likes_count"`
-	CommentsCount sql.NullInt32         `json:"comments_count"`
-	RepostsCount  sql.NullInt32         `json:"reposts_count"`
-	QuotesCount   sql.NullInt32         `json:"quotes_count"`
-	ViewsCount    sql.NullInt32         `json:"views_count"`
-	IsPinned      sql.NullBool          `json:"is_pinned"`
-	Visibility    sql.NullString        `json:"visibility"`
-	Status        sql.NullString        `json:"status"`
-	CreatedAt     sql.NullTime          `json:"created_at"`
-	UpdatedAt     sql.NullTime          `json:"updated_at"`
-	Username      string                `json:"username"`
-	FullName      string                `json:"full_name"`
-	AuthorAvatar  sql.NullString        `json:"author_avatar"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           string                `json:"username"`
+	FullName           string                `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
 }
 
 func (q *Queries) GetUserLikedPosts(ctx context.Context, arg GetUserLikedPostsParams) ([]GetUserLikedPostsRow, error) {
@@ -1105,6 +1924,10 @@ func (q *Queries) GetUserLikedPosts(ctx context.Context, arg GetUserLikedPostsPa
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
@@ -1124,59 +1947,79 @@ func (q *Queries) GetUserLikedPosts(ctx context.Context, arg GetUserLikedPostsPa
 
 const getUserPosts = `-- name: GetUserPosts :many
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
     c.name as community_name,
     g.name as group_name,
-    EXISTS(SELECT 1 FROM likes l5 WHERE l5.post_id = p.id AND l5.user_id = $1) as is_liked
+    EXISTS(SELECT 1 FROM likes l5 WHERE l5.post_id = p.id AND l5.user_id = $1) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked
 FROM posts p
 JOIN users u ON p.author_id = u.id
 LEFT JOIN communities c ON p.community_id = c.id
 LEFT JOIN groups g ON p.group_id = g.id
-WHERE p.author_id = $1 AND p.status = 'active'
-ORDER BY p.created_at DESC
-LIMIT $2 OFFSET $3
+WHERE p.author_id = $2 AND p.status = 'active'
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from($1, p.author_id)
+  AND ($3::timestamptz IS NULL
+       OR (p.created_at, p.id) < ($3::timestamptz, $4::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT $5 OFFSET $6
 `
 
 type GetUserPostsParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Limit  int32     `json:"limit"`
-	Offset int32     `json:"offset"`
+	ViewerID        uuid.UUID     `json:"viewer_id"`
+	AuthorID        uuid.UUID     `json:"author_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetUserPostsRow struct {
-	ID            uuid.UUID             `json:"id"`
-	AuthorID      uuid.UUID             `json:"author_id"`
-	SpaceID       uuid.UUID             `json:"space_id"`
-	CommunityID   uuid.NullUUID         `json:"community_id"`
-	GroupID       uuid.NullUUID         `json:"group_id"`
-	ParentPostID  uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID  uuid.NullUUID         `json:"quoted_post_id"`
-	Content       string                `json:"content"`
-	Media         pqtype.NullRawMessage `json:"media"`
-	Tags          []string              `json:"tags"`
-	LikesCount    sql.NullInt32         `json:"likes_count"`
-	CommentsCount sql.NullInt32         `json:"comments_count"`
-	RepostsCount  sql.NullInt32         `json:"reposts_count"`
-	QuotesCount   sql.NullInt32         `json:"quotes_count"`
-	ViewsCount    sql.NullInt32         `json:"views_count"`
-	IsPinned      sql.NullBool          `json:"is_pinned"`
-	Visibility    sql.NullString        `json:"visibility"`
-	Status        sql.NullString        `json:"status"`
-	CreatedAt     sql.NullTime          `json:"created_at"`
-	UpdatedAt     sql.NullTime          `json:"updated_at"`
-	Username      interface{}           `json:"username"`
-	FullName      interface{}           `json:"full_name"`
-	AuthorAvatar  sql.NullString        `json:"author_avatar"`
-	CommunityName sql.NullString        `json:"community_name"`
-	GroupName     sql.NullString        `json:"group_name"`
-	IsLiked       bool                  `json:"is_liked"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	CommunityName      sql.NullString        `json:"community_name"`
+	GroupName          sql.NullString        `json:"group_name"`
+	IsLiked            bool                  `json:"is_liked"`
+	IsBookmarked       bool                  `json:"is_bookmarked"`
 }
 
 func (q *Queries) GetUserPosts(ctx context.Context, arg GetUserPostsParams) ([]GetUserPostsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserPosts, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getUserPosts,
+		arg.ViewerID,
+		arg.AuthorID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1205,12 +2048,17 @@ func (q *Queries) GetUserPosts(ctx context.Context, arg GetUserPostsParams) ([]G
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
 			&i.CommunityName,
 			&i.GroupName,
 			&i.IsLiked,
+			&i.IsBookmarked,
 		); err != nil {
 			return nil, err
 		}
@@ -1225,15 +2073,6 @@ func (q *Queries) GetUserPosts(ctx context.Context, arg GetUserPostsParams) ([]G
 	return items, nil
 }
 
-const incrementPostViews = `-- name: IncrementPostViews :exec
-UPDATE posts SET views_count = views_count + 1 WHERE id = $1
-`
-
-func (q *Queries) IncrementPostViews(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, incrementPostViews, id)
-	return err
-}
-
 const pinPost = `-- name: PinPost :exec
 UPDATE posts SET is_pinned = $1, updated_at = NOW() WHERE id = $2
 `
@@ -1248,9 +2087,114 @@ func (q *Queries) PinPost(ctx context.Context, arg PinPostParams) error {
 	return err
 }
 
+const publishDuePosts = `-- name: PublishDuePosts :many
+UPDATE posts
+SET status = 'active', created_at = NOW()
+WHERE id IN (
+    SELECT sp.id FROM posts sp
+    WHERE sp.status = 'scheduled' AND sp.publish_at <= NOW()
+    ORDER BY sp.publish_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
+`
+
+func (q *Queries) PublishDuePosts(ctx context.Context, limit int32) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, publishDuePosts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Post{}
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.SpaceID,
+			&i.CommunityID,
+			&i.GroupID,
+			&i.ParentPostID,
+			&i.QuotedPostID,
+			&i.Content,
+			&i.Media,
+			pq.Array(&i.Tags),
+			&i.LikesCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+			&i.QuotesCount,
+			&i.ViewsCount,
+			&i.IsPinned,
+			&i.Visibility,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishPendingPost = `-- name: PublishPendingPost :one
+UPDATE posts
+SET status = 'active', created_at = NOW()
+WHERE id = $1 AND author_id = $2 AND status IN ('draft', 'scheduled')
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
+`
+
+type PublishPendingPostParams struct {
+	ID       uuid.UUID `json:"id"`
+	AuthorID uuid.UUID `json:"author_id"`
+}
+
+func (q *Queries) PublishPendingPost(ctx context.Context, arg PublishPendingPostParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, publishPendingPost, arg.ID, arg.AuthorID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.SpaceID,
+		&i.CommunityID,
+		&i.GroupID,
+		&i.ParentPostID,
+		&i.QuotedPostID,
+		&i.Content,
+		&i.Media,
+		pq.Array(&i.Tags),
+		&i.LikesCount,
+		&i.CommentsCount,
+		&i.RepostsCount,
+		&i.QuotesCount,
+		&i.ViewsCount,
+		&i.IsPinned,
+		&i.Visibility,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
+	)
+	return i, err
+}
+
 const searchPosts = `-- name: SearchPosts :many
 SELECT
-    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at,
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
     COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
     COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
     u.avatar as author_avatar,
@@ -1264,6 +2208,8 @@ LEFT JOIN groups g ON p.group_id = g.id
 WHERE p.space_id = $1
   AND p.status = 'active'
   AND (p.content ILIKE $3 OR p.tags @> ARRAY[$2]::text[] OR to_tsvector('english', p.content) @@ plainto_tsquery('english', $2))
+  AND NOT is_hidden_from($6, p.author_id)
+  AND can_view_post($6, p.author_id, p.visibility, p.community_id, p.group_id)
 ORDER BY rank DESC, p.created_at DESC
 LIMIT $4 OFFSET $5
 `
@@ -1274,35 +2220,40 @@ type SearchPostsParams struct {
 	Content        string    `json:"content"`
 	Limit          int32     `json:"limit"`
 	Offset         int32     `json:"offset"`
+	ViewerID       uuid.UUID `json:"viewer_id"`
 }
 
 type SearchPostsRow struct {
-	ID            uuid.UUID             `json:"id"`
-	AuthorID      uuid.UUID             `json:"author_id"`
-	SpaceID       uuid.UUID             `json:"space_id"`
-	CommunityID   uuid.NullUUID         `json:"community_id"`
-	GroupID       uuid.NullUUID         `json:"group_id"`
-	ParentPostID  uuid.NullUUID         `json:"parent_post_id"`
-	QuotedPostID  uuid.NullUUID         `json:"quoted_post_id"`
-	Content       string                `json:"content"`
-	Media         pqtype.NullRawMessage `json:"media"`
-	Tags          []string              `json:"tags"`
-	LikesCount    sql.NullInt32         `json:"likes_count"`
-	CommentsCount sql.NullInt32         `json:"comments_count"`
-	RepostsCount  sql.NullInt32         `json:"reposts_count"`
-	QuotesCount   sql.NullInt32         `json:"quotes_count"`
-	ViewsCount    sql.NullInt32         `json:"views_count"`
-	IsPinned      sql.NullBool          `json:"is_pinned"`
-	Visibility    sql.NullString        `json:"visibility"`
-	Status        sql.NullString        `json:"status"`
-	CreatedAt     sql.NullTime          `json:"created_at"`
-	UpdatedAt     sql.NullTime          `json:"updated_at"`
-	Username      interface{}           `json:"username"`
-	FullName      interface{}           `json:"full_name"`
-	AuthorAvatar  sql.NullString        `json:"author_avatar"`
-	CommunityName sql.NullString        `json:"community_name"`
-	GroupName     sql.NullString        `json:"group_name"`
-	Rank          float32               `json:"rank"`
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	CommunityName      sql.NullString        `json:"community_name"`
+	GroupName          sql.NullString        `json:"group_name"`
+	Rank               float32               `json:"rank"`
 }
 
 func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]SearchPostsRow, error) {
@@ -1312,6 +2263,7 @@ func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]Sea
 		arg.Content,
 		arg.Limit,
 		arg.Offset,
+		arg.ViewerID,
 	)
 	if err != nil {
 		return nil, err
@@ -1341,6 +2293,10 @@ func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]Sea
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
 			&i.Username,
 			&i.FullName,
 			&i.AuthorAvatar,
@@ -1361,6 +2317,37 @@ func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]Sea
 	return items, nil
 }
 
+const softDeleteComment = `-- name: SoftDeleteComment :one
+UPDATE comments
+SET status = 'deleted', content = '', updated_at = NOW()
+WHERE id = $1 AND author_id = $2 AND status = 'active'
+RETURNING id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at, content_warning, is_sensitive
+`
+
+type SoftDeleteCommentParams struct {
+	ID       uuid.UUID `json:"id"`
+	AuthorID uuid.UUID `json:"author_id"`
+}
+
+func (q *Queries) SoftDeleteComment(ctx context.Context, arg SoftDeleteCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, softDeleteComment, arg.ID, arg.AuthorID)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.AuthorID,
+		&i.ParentCommentID,
+		&i.Content,
+		&i.LikesCount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+	)
+	return i, err
+}
+
 const toggleCommentLike = `-- name: ToggleCommentLike :one
 INSERT INTO likes (user_id, comment_id) 
 VALUES ($1, $2)
@@ -1410,3 +2397,92 @@ func (q *Queries) TogglePostLike(ctx context.Context, arg TogglePostLikeParams)
 	err := row.Scan(&likes_count)
 	return likes_count, err
 }
+
+const updateComment = `-- name: UpdateComment :one
+UPDATE comments
+SET content = $1, updated_at = NOW()
+WHERE id = $2 AND author_id = $3 AND status = 'active'
+RETURNING id, post_id, author_id, parent_comment_id, content, likes_count, status, created_at, updated_at, content_warning, is_sensitive
+`
+
+type UpdateCommentParams struct {
+	Content  string    `json:"content"`
+	ID       uuid.UUID `json:"id"`
+	AuthorID uuid.UUID `json:"author_id"`
+}
+
+func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error) {
+	row := q.db.QueryRowContext(ctx, updateComment, arg.Content, arg.ID, arg.AuthorID)
+	var i Comment
+	err := row.Scan(
+		&i.ID,
+		&i.PostID,
+		&i.AuthorID,
+		&i.ParentCommentID,
+		&i.Content,
+		&i.LikesCount,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+	)
+	return i, err
+}
+
+const updatePendingPost = `-- name: UpdatePendingPost :one
+UPDATE posts
+SET content = $1, tags = $2, visibility = $3, status = $4, publish_at = $5, updated_at = NOW()
+WHERE id = $6 AND author_id = $7 AND status IN ('draft', 'scheduled')
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
+`
+
+type UpdatePendingPostParams struct {
+	Content    string         `json:"content"`
+	Tags       []string       `json:"tags"`
+	Visibility sql.NullString `json:"visibility"`
+	Status     sql.NullString `json:"status"`
+	PublishAt  sql.NullTime   `json:"publish_at"`
+	ID         uuid.UUID      `json:"id"`
+	AuthorID   uuid.UUID      `json:"author_id"`
+}
+
+func (q *Queries) UpdatePendingPost(ctx context.Context, arg UpdatePendingPostParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, updatePendingPost,
+		arg.Content,
+		pq.Array(arg.Tags),
+		arg.Visibility,
+		arg.Status,
+		arg.PublishAt,
+		arg.ID,
+		arg.AuthorID,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.SpaceID,
+		&i.CommunityID,
+		&i.GroupID,
+		&i.ParentPostID,
+		&i.QuotedPostID,
+		&i.Content,
+		&i.Media,
+		pq.Array(&i.Tags),
+		&i.LikesCount,
+		&i.CommentsCount,
+		&i.RepostsCount,
+		&i.QuotesCount,
+		&i.ViewsCount,
+		&i.IsPinned,
+		&i.Visibility,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
+	)
+	return i, err
+}
@@ -16,9 +16,11 @@ import (
 type Querier interface {
 	AddCommunityModerator(ctx context.Context, arg AddCommunityModeratorParams) (CommunityMember, error)
 	AddConversationParticipants(ctx context.Context, arg AddConversationParticipantsParams) error
+	AddDirectParticipant(ctx context.Context, arg AddDirectParticipantParams) error
 	AddEventCoOrganizer(ctx context.Context, arg AddEventCoOrganizerParams) (EventAttendee, error)
 	AddGroupAdmin(ctx context.Context, arg AddGroupAdminParams) (GroupMember, error)
 	AddGroupModerator(ctx context.Context, arg AddGroupModeratorParams) (GroupMember, error)
+	AddGroupParticipants(ctx context.Context, arg AddGroupParticipantsParams) ([]uuid.UUID, error)
 	AddMentoringSessionMeetingLink(ctx context.Context, arg AddMentoringSessionMeetingLinkParams) error
 	AddMessageReaction(ctx context.Context, arg AddMessageReactionParams) error
 	AddSessionMeetingLink(ctx context.Context, arg AddSessionMeetingLinkParams) error
@@ -28,14 +30,32 @@ type Querier interface {
 	AdvancedSearchPosts(ctx context.Context, arg AdvancedSearchPostsParams) ([]AdvancedSearchPostsRow, error)
 	AdvancedSearchUsers(ctx context.Context, arg AdvancedSearchUsersParams) ([]AdvancedSearchUsersRow, error)
 	ApplyForProjectRole(ctx context.Context, arg ApplyForProjectRoleParams) (GroupApplication, error)
+	BlockUser(ctx context.Context, arg BlockUserParams) (UserBlock, error)
+	CanModeratePost(ctx context.Context, arg CanModeratePostParams) (bool, error)
+	CanViewSharedContent(ctx context.Context, arg CanViewSharedContentParams) (bool, error)
+	CastPollVote(ctx context.Context, arg CastPollVoteParams) (int64, error)
 	CheckAdminPermission(ctx context.Context, id uuid.UUID) (bool, error)
 	CheckIfFollowing(ctx context.Context, arg CheckIfFollowingParams) (bool, error)
 	CleanupOldLoginAttempts(ctx context.Context, attemptedAt time.Time) error
+	CompleteConversationExport(ctx context.Context, arg CompleteConversationExportParams) error
+	CountCommunityMembers(ctx context.Context, communityID uuid.UUID) (int64, error)
+	CountCommunityPosts(ctx context.Context, arg CountCommunityPostsParams) (int64, error)
+	CountConversationMessages(ctx context.Context, conversationID uuid.UUID) (int64, error)
+	CountExportMessages(ctx context.Context, arg CountExportMessagesParams) (int64, error)
+	CountGroupPosts(ctx context.Context, arg CountGroupPostsParams) (int64, error)
+	CountPinnedMessages(ctx context.Context, conversationID uuid.UUID) (int64, error)
 	CountRecentFailedLoginAttemptsByIP(ctx context.Context, arg CountRecentFailedLoginAttemptsByIPParams) (int64, error)
 	CountRecentFailedLoginAttemptsByUsername(ctx context.Context, arg CountRecentFailedLoginAttemptsByUsernameParams) (int64, error)
+	CountTopLevelComments(ctx context.Context, arg CountTopLevelCommentsParams) (int64, error)
+	CountUserFeed(ctx context.Context, arg CountUserFeedParams) (int64, error)
+	CountUserFollowers(ctx context.Context, followingID uuid.UUID) (int64, error)
+	CountUserFollowing(ctx context.Context, followerID uuid.UUID) (int64, error)
+	CountUserNotifications(ctx context.Context, toUserID uuid.UUID) (int64, error)
+	CountUserPosts(ctx context.Context, arg CountUserPostsParams) (int64, error)
 	CreateAnnouncement(ctx context.Context, arg CreateAnnouncementParams) (Announcement, error)
 	
 	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
+	CreateBookmarkCollection(ctx context.Context, arg CreateBookmarkCollectionParams) (BookmarkCollection, error)
 	CreateComment(ctx context.Context, arg CreateCommentParams) (Comment, error)
 	
 	CreateCommunity(ctx context.Context, arg CreateCommunityParams) (Community, error)
@@ -44,15 +64,21 @@ type Querier interface {
 	CreateContentReport(ctx context.Context, arg CreateContentReportParams) (Report, error)
 	
 	CreateConversation(ctx context.Context, arg CreateConversationParams) (Conversation, error)
+	CreateConversationExport(ctx context.Context, arg CreateConversationExportParams) (ConversationExport, error)
+	CreateConversationInvite(ctx context.Context, arg CreateConversationInviteParams) (ConversationInvite, error)
 	
 	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
+	CreateFollowRequest(ctx context.Context, arg CreateFollowRequestParams) (FollowRequest, error)
 	
 	CreateGroup(ctx context.Context, arg CreateGroupParams) (Group, error)
 	CreateLoginAttempt(ctx context.Context, arg CreateLoginAttemptParams) (LoginAttempt, error)
+	CreateMentions(ctx context.Context, arg CreateMentionsParams) ([]Mention, error)
 	CreateMentorApplication(ctx context.Context, arg CreateMentorApplicationParams) (MentorApplication, error)
 	CreateMentorProfile(ctx context.Context, arg CreateMentorProfileParams) (MentorProfile, error)
 	CreateMentoringSession(ctx context.Context, arg CreateMentoringSessionParams) (MentoringSession, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
+	CreatePoll(ctx context.Context, arg CreatePollParams) (Poll, error)
+	CreatePollOptions(ctx context.Context, arg CreatePollOptionsParams) ([]PollOption, error)
 	
 	CreatePost(ctx context.Context, arg CreatePostParams) (Post, error)
 	CreateProjectRole(ctx context.Context, arg CreateProjectRoleParams) (GroupRole, error)
@@ -74,15 +100,28 @@ type Querier interface {
 	DecrementFollowersCount(ctx context.Context, id uuid.UUID) error
 	DecrementFollowingCount(ctx context.Context, id uuid.UUID) error
 	DeleteAnnouncement(ctx context.Context, id uuid.UUID) error
+	DeleteBookmark(ctx context.Context, arg DeleteBookmarkParams) (int64, error)
+	DeleteBookmarkCollection(ctx context.Context, arg DeleteBookmarkCollectionParams) (int64, error)
 	DeleteCommunity(ctx context.Context, id uuid.UUID) error
 	DeleteEvent(ctx context.Context, id uuid.UUID) error
+	DeleteExpiredConversationExports(ctx context.Context) (int64, error)
+	DeleteExpiredMessages(ctx context.Context, batchSize int32) ([]DeleteExpiredMessagesRow, error)
+	DeleteFollowRequest(ctx context.Context, arg DeleteFollowRequestParams) (FollowRequest, error)
+	DeleteFollowRequestsBetween(ctx context.Context, arg DeleteFollowRequestsBetweenParams) error
+	DeleteFollowsBetween(ctx context.Context, arg DeleteFollowsBetweenParams) ([]DeleteFollowsBetweenRow, error)
 	DeleteGroup(ctx context.Context, id uuid.UUID) error
+	DeleteMentionsBySource(ctx context.Context, arg DeleteMentionsBySourceParams) error
 	DeleteMessage(ctx context.Context, arg DeleteMessageParams) error
 	DeleteNotification(ctx context.Context, id uuid.UUID) error
 	DeletePost(ctx context.Context, arg DeletePostParams) error
 	DeleteSpace(ctx context.Context, id uuid.UUID) error
 	DeleteSystemSetting(ctx context.Context, key string) error
+	DeleteTrendingTopicsByPeriod(ctx context.Context, period sql.NullString) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	EditMessage(ctx context.Context, arg EditMessageParams) (Message, error)
+	FailConversationExport(ctx context.Context, arg FailConversationExportParams) error
+	FailStaleConversationExports(ctx context.Context, arg FailStaleConversationExportsParams) (int64, error)
+	FlushPostViews(ctx context.Context, arg FlushPostViewsParams) error
 	
 	FollowUser(ctx context.Context, arg FollowUserParams) (Follow, error)
 	GetActiveSuspension(ctx context.Context, userID uuid.UUID) (UserSuspension, error)
@@ -102,11 +141,19 @@ type Querier interface {
 	GetAllTutorApplications(ctx context.Context, arg GetAllTutorApplicationsParams) ([]GetAllTutorApplicationsRow, error)
 	GetAnnouncementByID(ctx context.Context, id uuid.UUID) (GetAnnouncementByIDRow, error)
 	GetAuditLogs(ctx context.Context, arg GetAuditLogsParams) ([]GetAuditLogsRow, error)
+	GetAutoExpandContentWarnings(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetBlockedUsers(ctx context.Context, arg GetBlockedUsersParams) ([]GetBlockedUsersRow, error)
+	GetBookmarkCollection(ctx context.Context, arg GetBookmarkCollectionParams) (BookmarkCollection, error)
+	GetBookmarkCollections(ctx context.Context, userID uuid.UUID) ([]GetBookmarkCollectionsRow, error)
+	GetCommentByID(ctx context.Context, id uuid.UUID) (Comment, error)
+	GetCommentReactionCounts(ctx context.Context, arg GetCommentReactionCountsParams) ([]GetCommentReactionCountsRow, error)
+	GetCommentReactors(ctx context.Context, arg GetCommentReactorsParams) ([]GetCommentReactorsRow, error)
+	GetCommentReplies(ctx context.Context, arg GetCommentRepliesParams) ([]GetCommentRepliesRow, error)
 	GetCommunityAdmins(ctx context.Context, communityID uuid.UUID) ([]GetCommunityAdminsRow, error)
 	GetCommunityByID(ctx context.Context, arg GetCommunityByIDParams) (GetCommunityByIDRow, error)
 	GetCommunityBySlug(ctx context.Context, arg GetCommunityBySlugParams) (GetCommunityBySlugRow, error)
 	GetCommunityCategories(ctx context.Context, spaceID uuid.UUID) ([]string, error)
-	GetCommunityMembers(ctx context.Context, communityID uuid.UUID) ([]GetCommunityMembersRow, error)
+	GetCommunityMembers(ctx context.Context, arg GetCommunityMembersParams) ([]GetCommunityMembersRow, error)
 	GetCommunityModerators(ctx context.Context, communityID uuid.UUID) ([]GetCommunityModeratorsRow, error)
 	GetCommunityPosts(ctx context.Context, arg GetCommunityPostsParams) ([]GetCommunityPostsRow, error)
 	GetContentGrowthData(ctx context.Context, arg GetContentGrowthDataParams) ([]GetContentGrowthDataRow, error)
@@ -115,40 +162,76 @@ type Querier interface {
 	GetContentReports(ctx context.Context, arg GetContentReportsParams) ([]GetContentReportsRow, error)
 	GetConversationByID(ctx context.Context, arg GetConversationByIDParams) (GetConversationByIDRow, error)
 	GetConversationByParticipants(ctx context.Context, arg GetConversationByParticipantsParams) (uuid.UUID, error)
+	GetConversationExport(ctx context.Context, arg GetConversationExportParams) (ConversationExport, error)
+	GetConversationInviteByCode(ctx context.Context, code string) (ConversationInvite, error)
+	GetConversationInvites(ctx context.Context, conversationID uuid.UUID) ([]ConversationInvite, error)
 	GetConversationMessages(ctx context.Context, arg GetConversationMessagesParams) ([]GetConversationMessagesRow, error)
+	GetConversationParticipantRole(ctx context.Context, arg GetConversationParticipantRoleParams) (string, error)
 	GetConversationParticipants(ctx context.Context, conversationID uuid.UUID) ([]GetConversationParticipantsRow, error)
+	GetConversationPeer(ctx context.Context, arg GetConversationPeerParams) (uuid.UUID, error)
+	GetDirectMessagePolicy(ctx context.Context, arg GetDirectMessagePolicyParams) (GetDirectMessagePolicyRow, error)
 	GetEngagementMetrics(ctx context.Context, spaceID uuid.UUID) ([]GetEngagementMetricsRow, error)
 	GetEventAttendees(ctx context.Context, eventID uuid.UUID) ([]GetEventAttendeesRow, error)
 	GetEventByID(ctx context.Context, arg GetEventByIDParams) (GetEventByIDRow, error)
 	GetEventCategories(ctx context.Context, spaceID uuid.UUID) ([]string, error)
 	GetEventCoOrganizers(ctx context.Context, eventID uuid.UUID) ([]GetEventCoOrganizersRow, error)
 	GetEventWithRegistrations(ctx context.Context, id uuid.UUID) (GetEventWithRegistrationsRow, error)
+	GetExportMessages(ctx context.Context, arg GetExportMessagesParams) ([]GetExportMessagesRow, error)
+	GetExportWindow(ctx context.Context, arg GetExportWindowParams) (GetExportWindowRow, error)
 	GetGroupByID(ctx context.Context, arg GetGroupByIDParams) (GetGroupByIDRow, error)
 	GetGroupJoinRequests(ctx context.Context, groupID uuid.UUID) ([]GetGroupJoinRequestsRow, error)
 	GetGroupPosts(ctx context.Context, arg GetGroupPostsParams) ([]GetGroupPostsRow, error)
 	
 	GetGroupsBySpaceID(ctx context.Context, arg GetGroupsBySpaceIDParams) ([]Group, error)
 	GetGroupsByStatus(ctx context.Context, arg GetGroupsByStatusParams) ([]Group, error)
+	GetHiddenAudience(ctx context.Context, actorID uuid.UUID) ([]uuid.UUID, error)
+	GetIncomingFollowRequests(ctx context.Context, arg GetIncomingFollowRequestsParams) ([]GetIncomingFollowRequestsRow, error)
 	GetLockedUsers(ctx context.Context) ([]GetLockedUsersRow, error)
 	GetLoginAttemptsWithSessions(ctx context.Context, arg GetLoginAttemptsWithSessionsParams) ([]GetLoginAttemptsWithSessionsRow, error)
+	GetMentionsBySources(ctx context.Context, arg GetMentionsBySourcesParams) ([]GetMentionsBySourcesRow, error)
 	GetMentorApplication(ctx context.Context, id uuid.UUID) (GetMentorApplicationRow, error)
 	GetMentorProfile(ctx context.Context, userID uuid.UUID) (GetMentorProfileRow, error)
 	GetMentorReviews(ctx context.Context, arg GetMentorReviewsParams) ([]GetMentorReviewsRow, error)
 	GetMentoringSession(ctx context.Context, id uuid.UUID) (GetMentoringSessionRow, error)
 	GetMentoringStats(ctx context.Context, spaceID uuid.UUID) (GetMentoringStatsRow, error)
 	GetMessageByID(ctx context.Context, id uuid.UUID) (GetMessageByIDRow, error)
+	GetMessageIDsInCursorRange(ctx context.Context, arg GetMessageIDsInCursorRangeParams) ([]uuid.UUID, error)
+	GetMessageReceiptCounts(ctx context.Context, messageIds []uuid.UUID) ([]GetMessageReceiptCountsRow, error)
+	GetMessageRequests(ctx context.Context, arg GetMessageRequestsParams) ([]GetMessageRequestsRow, error)
+	GetMessageRevisions(ctx context.Context, messageID uuid.UUID) ([]GetMessageRevisionsRow, error)
+	GetMessageSearchContext(ctx context.Context, arg GetMessageSearchContextParams) ([]GetMessageSearchContextRow, error)
+	GetMessageSeenBy(ctx context.Context, messageID uuid.UUID) ([]GetMessageSeenByRow, error)
 	GetModerationQueue(ctx context.Context, arg GetModerationQueueParams) ([]GetModerationQueueRow, error)
+	GetMutedUsers(ctx context.Context, arg GetMutedUsersParams) ([]GetMutedUsersRow, error)
 	GetNotification(ctx context.Context, id uuid.UUID) (Notification, error)
 	GetOrCreateDirectConversation(ctx context.Context, arg GetOrCreateDirectConversationParams) (uuid.UUID, error)
+	GetOutgoingFollowRequests(ctx context.Context, arg GetOutgoingFollowRequestsParams) ([]GetOutgoingFollowRequestsRow, error)
+	GetOwnershipSuccessor(ctx context.Context, arg GetOwnershipSuccessorParams) (uuid.UUID, error)
+	GetParticipantRequestStatus(ctx context.Context, arg GetParticipantRequestStatusParams) (string, error)
+	GetParticipantsWithoutAccess(ctx context.Context, arg GetParticipantsWithoutAccessParams) ([]uuid.UUID, error)
 	GetPendingMentorApplications(ctx context.Context, spaceID uuid.UUID) ([]GetPendingMentorApplicationsRow, error)
+	GetPendingPost(ctx context.Context, arg GetPendingPostParams) (Post, error)
+	GetPendingPosts(ctx context.Context, arg GetPendingPostsParams) ([]Post, error)
 	GetPendingReports(ctx context.Context, spaceID uuid.UUID) ([]GetPendingReportsRow, error)
 	GetPendingTutorApplications(ctx context.Context, spaceID uuid.UUID) ([]GetPendingTutorApplicationsRow, error)
+	GetPinnedMessages(ctx context.Context, conversationID uuid.UUID) ([]GetPinnedMessagesRow, error)
+	GetPollByPostID(ctx context.Context, postID uuid.UUID) (Poll, error)
+	GetPollOptionVoters(ctx context.Context, arg GetPollOptionVotersParams) ([]GetPollOptionVotersRow, error)
+	GetPollOptionsByPollIDs(ctx context.Context, pollIds []uuid.UUID) ([]PollOption, error)
+	GetPollsByPostIDs(ctx context.Context, arg GetPollsByPostIDsParams) ([]GetPollsByPostIDsRow, error)
 	GetPopularIndustries(ctx context.Context, spaceID uuid.UUID) ([]GetPopularIndustriesRow, error)
 	GetPopularSubjects(ctx context.Context, spaceID uuid.UUID) ([]GetPopularSubjectsRow, error)
 	GetPostByID(ctx context.Context, arg GetPostByIDParams) (GetPostByIDRow, error)
-	GetPostComments(ctx context.Context, postID uuid.UUID) ([]GetPostCommentsRow, error)
+	GetPostComments(ctx context.Context, arg GetPostCommentsParams) ([]GetPostCommentsRow, error)
+	GetPostDailyViews(ctx context.Context, arg GetPostDailyViewsParams) ([]GetPostDailyViewsRow, error)
 	GetPostLikes(ctx context.Context, postID uuid.NullUUID) ([]GetPostLikesRow, error)
+	GetPostQuotes(ctx context.Context, arg GetPostQuotesParams) ([]GetPostQuotesRow, error)
+	GetPostReactionCounts(ctx context.Context, arg GetPostReactionCountsParams) ([]GetPostReactionCountsRow, error)
+	GetPostReactors(ctx context.Context, arg GetPostReactorsParams) ([]GetPostReactorsRow, error)
+	GetPostsByTag(ctx context.Context, arg GetPostsByTagParams) ([]GetPostsByTagRow, error)
 	GetProjectRoles(ctx context.Context, groupID uuid.UUID) ([]GroupRole, error)
+	GetQuotedPostPreviews(ctx context.Context, arg GetQuotedPostPreviewsParams) ([]GetQuotedPostPreviewsRow, error)
+	GetRankedFeed(ctx context.Context, arg GetRankedFeedParams) ([]GetRankedFeedRow, error)
 	GetRecentFailedLoginAttemptsByIP(ctx context.Context, arg GetRecentFailedLoginAttemptsByIPParams) ([]LoginAttempt, error)
 	GetRecentFailedLoginAttemptsByUsername(ctx context.Context, arg GetRecentFailedLoginAttemptsByUsernameParams) ([]LoginAttempt, error)
 	GetRecentLoginAttemptsByIP(ctx context.Context, arg GetRecentLoginAttemptsByIPParams) ([]LoginAttempt, error)
@@ -156,11 +239,16 @@ type Querier interface {
 	GetRecommendedMentors(ctx context.Context, arg GetRecommendedMentorsParams) ([]GetRecommendedMentorsRow, error)
 	
 	GetRecommendedTutors(ctx context.Context, arg GetRecommendedTutorsParams) ([]GetRecommendedTutorsRow, error)
+	GetRelationshipStatus(ctx context.Context, arg GetRelationshipStatusParams) (GetRelationshipStatusRow, error)
 	GetReport(ctx context.Context, id uuid.UUID) (GetReportRow, error)
 	GetReportStats(ctx context.Context, spaceID uuid.UUID) (GetReportStatsRow, error)
 	GetReportsByContent(ctx context.Context, arg GetReportsByContentParams) ([]Report, error)
 	GetRoleApplications(ctx context.Context, groupID uuid.UUID) ([]GetRoleApplicationsRow, error)
+	GetSearchFacets(ctx context.Context, arg GetSearchFacetsParams) ([]GetSearchFacetsRow, error)
 	GetSession(ctx context.Context, id uuid.UUID) (UserSession, error)
+	GetSharedCommunity(ctx context.Context, id uuid.UUID) (Community, error)
+	GetSharedEvent(ctx context.Context, id uuid.UUID) (Event, error)
+	GetSharedPost(ctx context.Context, id uuid.UUID) (GetSharedPostRow, error)
 	GetSpace(ctx context.Context, id uuid.UUID) (Space, error)
 	GetSpaceActivities(ctx context.Context, arg GetSpaceActivitiesParams) ([]GetSpaceActivitiesRow, error)
 	GetSpaceBySlug(ctx context.Context, slug string) (Space, error)
@@ -171,10 +259,16 @@ type Querier interface {
 	GetSystemMetrics(ctx context.Context, spaceID uuid.UUID) (GetSystemMetricsRow, error)
 	
 	GetSystemSetting(ctx context.Context, key string) (SystemSetting, error)
+	GetTagByName(ctx context.Context, name string) (Tag, error)
+	GetThreadLatestRepliers(ctx context.Context, arg GetThreadLatestRepliersParams) ([]GetThreadLatestRepliersRow, error)
+	GetThreadParticipantsToNotify(ctx context.Context, arg GetThreadParticipantsToNotifyParams) ([]uuid.UUID, error)
+	GetThreadReplies(ctx context.Context, arg GetThreadRepliesParams) ([]GetThreadRepliesRow, error)
+	GetThreadSummaries(ctx context.Context, rootIds []uuid.UUID) ([]GetThreadSummariesRow, error)
 	GetTopCommunities(ctx context.Context, spaceID uuid.UUID) ([]GetTopCommunitiesRow, error)
 	GetTopGroups(ctx context.Context, spaceID uuid.UUID) ([]GetTopGroupsRow, error)
+	GetTopLevelComments(ctx context.Context, arg GetTopLevelCommentsParams) ([]GetTopLevelCommentsRow, error)
 	GetTopPosts(ctx context.Context, spaceID uuid.UUID) ([]GetTopPostsRow, error)
-	GetTrendingPosts(ctx context.Context, spaceID uuid.UUID) ([]GetTrendingPostsRow, error)
+	GetTrendingPosts(ctx context.Context, arg GetTrendingPostsParams) ([]GetTrendingPostsRow, error)
 	GetTrendingTopics(ctx context.Context, arg GetTrendingTopicsParams) ([]GetTrendingTopicsRow, error)
 	GetTutorApplication(ctx context.Context, id uuid.UUID) (GetTutorApplicationRow, error)
 	GetTutorApplicationsByStatus(ctx context.Context, arg GetTutorApplicationsByStatusParams) ([]TutorApplication, error)
@@ -188,11 +282,12 @@ type Querier interface {
 	GetUpcomingEvents(ctx context.Context, spaceID uuid.UUID) ([]GetUpcomingEventsRow, error)
 	
 	GetUserActivityStats(ctx context.Context, spaceID uuid.UUID) ([]GetUserActivityStatsRow, error)
+	GetUserBookmarks(ctx context.Context, arg GetUserBookmarksParams) ([]GetUserBookmarksRow, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow, error)
 	GetUserByUsername(ctx context.Context, username string) (User, error)
 	GetUserCommunities(ctx context.Context, arg GetUserCommunitiesParams) ([]GetUserCommunitiesRow, error)
-	GetUserConversations(ctx context.Context, recipientID uuid.NullUUID) ([]GetUserConversationsRow, error)
+	GetUserConversations(ctx context.Context, arg GetUserConversationsParams) ([]GetUserConversationsRow, error)
 	GetUserDetails(ctx context.Context, id uuid.UUID) (GetUserDetailsRow, error)
 	GetUserEngagementAnalytics(ctx context.Context, authorID uuid.UUID) (GetUserEngagementAnalyticsRow, error)
 	GetUserEngagementRanking(ctx context.Context, spaceID uuid.UUID) ([]GetUserEngagementRankingRow, error)
@@ -223,11 +318,14 @@ type Querier interface {
 	IncrementFailedLoginAttempts(ctx context.Context, id uuid.UUID) (User, error)
 	IncrementFollowersCount(ctx context.Context, id uuid.UUID) error
 	IncrementFollowingCount(ctx context.Context, id uuid.UUID) error
-	IncrementPostViews(ctx context.Context, id uuid.UUID) error
+	InsertTrendingTopics(ctx context.Context, arg InsertTrendingTopicsParams) (int64, error)
+	IsBlockedBetween(ctx context.Context, arg IsBlockedBetweenParams) (bool, error)
 	IsCommunityAdmin(ctx context.Context, arg IsCommunityAdminParams) (bool, error)
 	IsCommunityModerator(ctx context.Context, arg IsCommunityModeratorParams) (bool, error)
+	IsDirectConversationBlocked(ctx context.Context, arg IsDirectConversationBlockedParams) (bool, error)
 	IsGroupAdmin(ctx context.Context, arg IsGroupAdminParams) (bool, error)
 	IsGroupModerator(ctx context.Context, arg IsGroupModeratorParams) (bool, error)
+	IsPrivateAccount(ctx context.Context, userID uuid.UUID) (bool, error)
 	IsUserSuperAdmin(ctx context.Context, id uuid.UUID) (bool, error)
 	JoinCommunity(ctx context.Context, arg JoinCommunityParams) (CommunityMember, error)
 	JoinGroup(ctx context.Context, arg JoinGroupParams) (GroupMember, error)
@@ -251,7 +349,8 @@ type Querier interface {
 	MarkAllAsRead(ctx context.Context, toUserID uuid.UUID) error
 	MarkAsRead(ctx context.Context, id uuid.UUID) error
 	MarkEventAttendance(ctx context.Context, arg MarkEventAttendanceParams) error
-	MarkMessagesAsRead(ctx context.Context, arg MarkMessagesAsReadParams) error
+	MarkMessagesAsDelivered(ctx context.Context, arg MarkMessagesAsDeliveredParams) (MarkMessagesAsDeliveredRow, error)
+	MarkMessagesAsRead(ctx context.Context, arg MarkMessagesAsReadParams) (MarkMessagesAsReadRow, error)
 	
 	
 	
@@ -259,40 +358,72 @@ type Querier interface {
 	
 	
 	MarkNotificationsAsRead(ctx context.Context, toUserID uuid.UUID) error
+	MoveBookmark(ctx context.Context, arg MoveBookmarkParams) (Bookmark, error)
+	MuteUser(ctx context.Context, arg MuteUserParams) (UserMute, error)
+	PinMessage(ctx context.Context, arg PinMessageParams) (MessagePin, error)
 	PinPost(ctx context.Context, arg PinPostParams) error
+	PublishDuePosts(ctx context.Context, limit int32) ([]Post, error)
+	PublishPendingPost(ctx context.Context, arg PublishPendingPostParams) (Post, error)
 	RateMentoringSession(ctx context.Context, arg RateMentoringSessionParams) (MentoringSession, error)
 	RateTutoringSession(ctx context.Context, arg RateTutoringSessionParams) (TutoringSession, error)
+	RedeemConversationInvite(ctx context.Context, id uuid.UUID) (int64, error)
 	RegisterForEvent(ctx context.Context, arg RegisterForEventParams) (EventAttendee, error)
+	RemoveCommentReaction(ctx context.Context, arg RemoveCommentReactionParams) (RemoveCommentReactionRow, error)
 	RemoveCommunityModerator(ctx context.Context, arg RemoveCommunityModeratorParams) error
+	RemoveConversationParticipant(ctx context.Context, arg RemoveConversationParticipantParams) (int64, error)
 	RemoveEventCoOrganizer(ctx context.Context, arg RemoveEventCoOrganizerParams) error
 	RemoveGroupAdmin(ctx context.Context, arg RemoveGroupAdminParams) error
 	RemoveGroupModerator(ctx context.Context, arg RemoveGroupModeratorParams) error
 	RemoveMessageReaction(ctx context.Context, arg RemoveMessageReactionParams) error
+	RemovePostReaction(ctx context.Context, arg RemovePostReactionParams) (RemovePostReactionRow, error)
+	RemoveRepost(ctx context.Context, arg RemoveRepostParams) (Post, error)
+	RenameBookmarkCollection(ctx context.Context, arg RenameBookmarkCollectionParams) (BookmarkCollection, error)
 	ResetFailedLoginAttempts(ctx context.Context, id uuid.UUID) (User, error)
 	ResetUserPassword(ctx context.Context, arg ResetUserPasswordParams) error
+	ResolveMentionedUsers(ctx context.Context, arg ResolveMentionedUsersParams) ([]ResolveMentionedUsersRow, error)
+	RevokeConversationInvite(ctx context.Context, arg RevokeConversationInviteParams) (int64, error)
 	SearchCommunities(ctx context.Context, arg SearchCommunitiesParams) ([]SearchCommunitiesRow, error)
+	SearchDocuments(ctx context.Context, arg SearchDocumentsParams) ([]SearchDocumentsRow, error)
 	SearchEvents(ctx context.Context, arg SearchEventsParams) ([]SearchEventsRow, error)
 	SearchGroups(ctx context.Context, arg SearchGroupsParams) ([]SearchGroupsRow, error)
+	SearchMentionCandidates(ctx context.Context, arg SearchMentionCandidatesParams) ([]SearchMentionCandidatesRow, error)
 	SearchMentors(ctx context.Context, arg SearchMentorsParams) ([]SearchMentorsRow, error)
+	SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error)
 	SearchPosts(ctx context.Context, arg SearchPostsParams) ([]SearchPostsRow, error)
+	SearchTags(ctx context.Context, arg SearchTagsParams) ([]SearchTagsRow, error)
 	SearchTutors(ctx context.Context, arg SearchTutorsParams) ([]SearchTutorsRow, error)
 	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
 	
 	SearchUsersAdmin(ctx context.Context, arg SearchUsersAdminParams) ([]SearchUsersAdminRow, error)
 	SendMessage(ctx context.Context, arg SendMessageParams) (Message, error)
+	SetAccountPrivacy(ctx context.Context, arg SetAccountPrivacyParams) (User, error)
+	SetAllowMessagesFrom(ctx context.Context, arg SetAllowMessagesFromParams) (User, error)
+	SetAutoExpandContentWarnings(ctx context.Context, arg SetAutoExpandContentWarningsParams) (User, error)
+	SetConversationArchived(ctx context.Context, arg SetConversationArchivedParams) (int64, error)
+	SetConversationMute(ctx context.Context, arg SetConversationMuteParams) (int64, error)
+	SetDisappearingMessagesTimer(ctx context.Context, arg SetDisappearingMessagesTimerParams) (int64, error)
+	SetParticipantRole(ctx context.Context, arg SetParticipantRoleParams) (int64, error)
+	SetPostContentWarning(ctx context.Context, arg SetPostContentWarningParams) (Post, error)
+	SoftDeleteComment(ctx context.Context, arg SoftDeleteCommentParams) (Comment, error)
+	SyncPostTags(ctx context.Context, arg SyncPostTagsParams) error
 	ToggleCommentLike(ctx context.Context, arg ToggleCommentLikeParams) (bool, error)
 	TogglePostLike(ctx context.Context, arg TogglePostLikeParams) (sql.NullInt32, error)
+	UnblockUser(ctx context.Context, arg UnblockUserParams) (int64, error)
 	UnfollowUser(ctx context.Context, arg UnfollowUserParams) error
 	UnlockExpiredAccounts(ctx context.Context) error
+	UnmuteUser(ctx context.Context, arg UnmuteUserParams) (int64, error)
+	UnpinMessage(ctx context.Context, arg UnpinMessageParams) (int64, error)
 	UnregisterFromEvent(ctx context.Context, arg UnregisterFromEventParams) error
 	UpdateAnnouncement(ctx context.Context, arg UpdateAnnouncementParams) (Announcement, error)
 	UpdateAnnouncementStatus(ctx context.Context, arg UpdateAnnouncementStatusParams) (Announcement, error)
+	UpdateComment(ctx context.Context, arg UpdateCommentParams) (Comment, error)
 	UpdateCommunity(ctx context.Context, arg UpdateCommunityParams) (Community, error)
 	UpdateCommunityStats(ctx context.Context, communityID uuid.UUID) error
 	UpdateCommunityStatus(ctx context.Context, arg UpdateCommunityStatusParams) (Community, error)
 	UpdateContentReportPriority(ctx context.Context, arg UpdateContentReportPriorityParams) (Report, error)
 	UpdateContentReportStatus(ctx context.Context, arg UpdateContentReportStatusParams) (Report, error)
 	UpdateContentReportWithAction(ctx context.Context, arg UpdateContentReportWithActionParams) (Report, error)
+	UpdateConversationDetails(ctx context.Context, arg UpdateConversationDetailsParams) (Conversation, error)
 	UpdateConversationLastMessage(ctx context.Context, arg UpdateConversationLastMessageParams) error
 	UpdateConversationSettings(ctx context.Context, arg UpdateConversationSettingsParams) error
 	UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error)
@@ -306,7 +437,9 @@ type Querier interface {
 	UpdateMentorAvailability(ctx context.Context, arg UpdateMentorAvailabilityParams) (MentorProfile, error)
 	UpdateMentorStatus(ctx context.Context, arg UpdateMentorStatusParams) (User, error)
 	UpdateMentoringSessionStatus(ctx context.Context, arg UpdateMentoringSessionStatusParams) (MentoringSession, error)
+	UpdateMessageRequestStatus(ctx context.Context, arg UpdateMessageRequestStatusParams) (int64, error)
 	UpdateParticipantSettings(ctx context.Context, arg UpdateParticipantSettingsParams) error
+	UpdatePendingPost(ctx context.Context, arg UpdatePendingPostParams) (Post, error)
 	UpdateReport(ctx context.Context, arg UpdateReportParams) (Report, error)
 	UpdateSessionStatus(ctx context.Context, arg UpdateSessionStatusParams) (TutoringSession, error)
 	UpdateSpace(ctx context.Context, arg UpdateSpaceParams) (Space, error)
@@ -322,6 +455,9 @@ type Querier interface {
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 	UpdateUserSettings(ctx context.Context, arg UpdateUserSettingsParams) (User, error)
+	UpsertBookmark(ctx context.Context, arg UpsertBookmarkParams) (Bookmark, error)
+	UpsertCommentReaction(ctx context.Context, arg UpsertCommentReactionParams) (UpsertCommentReactionRow, error)
+	UpsertPostReaction(ctx context.Context, arg UpsertPostReactionParams) (UpsertPostReactionRow, error)
 	UpsertSystemSetting(ctx context.Context, arg UpsertSystemSettingParams) (SystemSetting, error)
 }
 
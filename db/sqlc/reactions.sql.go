@@ -0,0 +1,394 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const getCommentReactionCounts = `-- name: GetCommentReactionCounts :many
+SELECT
+    l.comment_id::uuid AS comment_id,
+    l.reaction,
+    COUNT(*) AS reaction_count,
+    BOOL_OR(l.user_id = $1)::bool AS viewer_reacted
+FROM likes l
+WHERE l.comment_id = ANY($2::uuid[])
+GROUP BY l.comment_id, l.reaction
+ORDER BY l.comment_id, reaction_count DESC, l.reaction
+`
+
+type GetCommentReactionCountsParams struct {
+	ViewerID   uuid.UUID   `json:"viewer_id"`
+	CommentIds []uuid.UUID `json:"comment_ids"`
+}
+
+type GetCommentReactionCountsRow struct {
+	CommentID     uuid.UUID `json:"comment_id"`
+	Reaction      string    `json:"reaction"`
+	ReactionCount int64     `json:"reaction_count"`
+	ViewerReacted bool      `json:"viewer_reacted"`
+}
+
+func (q *Queries) GetCommentReactionCounts(ctx context.Context, arg GetCommentReactionCountsParams) ([]GetCommentReactionCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCommentReactionCounts, arg.ViewerID, pq.Array(arg.CommentIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCommentReactionCountsRow{}
+	for rows.Next() {
+		var i GetCommentReactionCountsRow
+		if err := rows.Scan(
+			&i.CommentID,
+			&i.Reaction,
+			&i.ReactionCount,
+			&i.ViewerReacted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCommentReactors = `-- name: GetCommentReactors :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    l.reaction,
+    l.created_at AS reacted_at
+FROM likes l
+JOIN users u ON l.user_id = u.id
+WHERE l.comment_id = $1
+  AND ($2::text IS NULL OR l.reaction = $2::text)
+  AND NOT is_hidden_from($3, u.id)
+ORDER BY l.created_at DESC, l.id DESC
+LIMIT $4 OFFSET $5
+`
+
+type GetCommentReactorsParams struct {
+	CommentID  uuid.UUID      `json:"comment_id"`
+	Reaction   sql.NullString `json:"reaction"`
+	ViewerID   uuid.UUID      `json:"viewer_id"`
+	PageSize   int32          `json:"page_size"`
+	PageOffset int32          `json:"page_offset"`
+}
+
+type GetCommentReactorsRow struct {
+	ID        uuid.UUID      `json:"id"`
+	Username  string         `json:"username"`
+	FullName  string         `json:"full_name"`
+	Avatar    sql.NullString `json:"avatar"`
+	Reaction  string         `json:"reaction"`
+	ReactedAt sql.NullTime   `json:"reacted_at"`
+}
+
+func (q *Queries) GetCommentReactors(ctx context.Context, arg GetCommentReactorsParams) ([]GetCommentReactorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCommentReactors,
+		arg.CommentID,
+		arg.Reaction,
+		arg.ViewerID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCommentReactorsRow{}
+	for rows.Next() {
+		var i GetCommentReactorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.Reaction,
+			&i.ReactedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPostReactionCounts = `-- name: GetPostReactionCounts :many
+SELECT
+    l.post_id::uuid AS post_id,
+    l.reaction,
+    COUNT(*) AS reaction_count,
+    BOOL_OR(l.user_id = $1)::bool AS viewer_reacted
+FROM likes l
+WHERE l.post_id = ANY($2::uuid[])
+GROUP BY l.post_id, l.reaction
+ORDER BY l.post_id, reaction_count DESC, l.reaction
+`
+
+type GetPostReactionCountsParams struct {
+	ViewerID uuid.UUID   `json:"viewer_id"`
+	PostIds  []uuid.UUID `json:"post_ids"`
+}
+
+type GetPostReactionCountsRow struct {
+	PostID        uuid.UUID `json:"post_id"`
+	Reaction      string    `json:"reaction"`
+	ReactionCount int64     `json:"reaction_count"`
+	ViewerReacted bool      `json:"viewer_reacted"`
+}
+
+func (q *Queries) GetPostReactionCounts(ctx context.Context, arg GetPostReactionCountsParams) ([]GetPostReactionCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostReactionCounts, arg.ViewerID, pq.Array(arg.PostIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPostReactionCountsRow{}
+	for rows.Next() {
+		var i GetPostReactionCountsRow
+		if err := rows.Scan(
+			&i.PostID,
+			&i.Reaction,
+			&i.ReactionCount,
+			&i.ViewerReacted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPostReactors = `-- name: GetPostReactors :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    l.reaction,
+    l.created_at AS reacted_at
+FROM likes l
+JOIN users u ON l.user_id = u.id
+WHERE l.post_id = $1
+  AND ($2::text IS NULL OR l.reaction = $2::text)
+  AND NOT is_hidden_from($3, u.id)
+ORDER BY l.created_at DESC, l.id DESC
+LIMIT $4 OFFSET $5
+`
+
+type GetPostReactorsParams struct {
+	PostID     uuid.UUID      `json:"post_id"`
+	Reaction   sql.NullString `json:"reaction"`
+	ViewerID   uuid.UUID      `json:"viewer_id"`
+	PageSize   int32          `json:"page_size"`
+	PageOffset int32          `json:"page_offset"`
+}
+
+type GetPostReactorsRow struct {
+	ID        uuid.UUID      `json:"id"`
+	Username  string         `json:"username"`
+	FullName  string         `json:"full_name"`
+	Avatar    sql.NullString `json:"avatar"`
+	Reaction  string         `json:"reaction"`
+	ReactedAt sql.NullTime   `json:"reacted_at"`
+}
+
+func (q *Queries) GetPostReactors(ctx context.Context, arg GetPostReactorsParams) ([]GetPostReactorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostReactors,
+		arg.PostID,
+		arg.Reaction,
+		arg.ViewerID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPostReactorsRow{}
+	for rows.Next() {
+		var i GetPostReactorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.Reaction,
+			&i.ReactedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeCommentReaction = `-- name: RemoveCommentReaction :one
+WITH removed AS (
+    DELETE FROM likes
+    WHERE user_id = $1 AND comment_id = $2
+    RETURNING reaction
+)
+UPDATE comments
+SET likes_count = GREATEST(COALESCE(likes_count, 0) - (SELECT COUNT(*) FROM removed), 0)
+WHERE comments.id = $2
+RETURNING likes_count, (SELECT reaction FROM removed)::text AS removed_reaction
+`
+
+type RemoveCommentReactionParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	CommentID uuid.UUID `json:"comment_id"`
+}
+
+type RemoveCommentReactionRow struct {
+	LikesCount      sql.NullInt32  `json:"likes_count"`
+	RemovedReaction sql.NullString `json:"removed_reaction"`
+}
+
+func (q *Queries) RemoveCommentReaction(ctx context.Context, arg RemoveCommentReactionParams) (RemoveCommentReactionRow, error) {
+	row := q.db.QueryRowContext(ctx, removeCommentReaction, arg.UserID, arg.CommentID)
+	var i RemoveCommentReactionRow
+	err := row.Scan(
+		&i.LikesCount,
+		&i.RemovedReaction,
+	)
+	return i, err
+}
+
+const removePostReaction = `-- name: RemovePostReaction :one
+WITH removed AS (
+    DELETE FROM likes
+    WHERE user_id = $1 AND post_id = $2
+    RETURNING reaction
+)
+UPDATE posts
+SET likes_count = GREATEST(COALESCE(likes_count, 0) - (SELECT COUNT(*) FROM removed), 0)
+WHERE posts.id = $2
+RETURNING likes_count, (SELECT reaction FROM removed)::text AS removed_reaction
+`
+
+type RemovePostReactionParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	PostID uuid.UUID `json:"post_id"`
+}
+
+type RemovePostReactionRow struct {
+	LikesCount      sql.NullInt32  `json:"likes_count"`
+	RemovedReaction sql.NullString `json:"removed_reaction"`
+}
+
+func (q *Queries) RemovePostReaction(ctx context.Context, arg RemovePostReactionParams) (RemovePostReactionRow, error) {
+	row := q.db.QueryRowContext(ctx, removePostReaction, arg.UserID, arg.PostID)
+	var i RemovePostReactionRow
+	err := row.Scan(
+		&i.LikesCount,
+		&i.RemovedReaction,
+	)
+	return i, err
+}
+
+const upsertCommentReaction = `-- name: UpsertCommentReaction :one
+WITH previous AS (
+    SELECT reaction FROM likes WHERE user_id = $1 AND comment_id = $2
+), upserted AS (
+    INSERT INTO likes (user_id, comment_id, reaction)
+    VALUES ($1, $2, $3)
+    ON CONFLICT (user_id, comment_id) DO UPDATE SET reaction = EXCLUDED.reaction
+    RETURNING (xmax = 0) AS inserted
+)
+UPDATE comments
+SET likes_count = COALESCE(likes_count, 0) + CASE WHEN (SELECT inserted FROM upserted) THEN 1 ELSE 0 END
+WHERE comments.id = $2
+RETURNING likes_count, (SELECT reaction FROM previous)::text AS previous_reaction
+`
+
+type UpsertCommentReactionParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	CommentID uuid.UUID `json:"comment_id"`
+	Reaction  string    `json:"reaction"`
+}
+
+type UpsertCommentReactionRow struct {
+	LikesCount       sql.NullInt32  `json:"likes_count"`
+	PreviousReaction sql.NullString `json:"previous_reaction"`
+}
+
+func (q *Queries) UpsertCommentReaction(ctx context.Context, arg UpsertCommentReactionParams) (UpsertCommentReactionRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertCommentReaction, arg.UserID, arg.CommentID, arg.Reaction)
+	var i UpsertCommentReactionRow
+	err := row.Scan(
+		&i.LikesCount,
+		&i.PreviousReaction,
+	)
+	return i, err
+}
+
+const upsertPostReaction = `-- name: UpsertPostReaction :one
+WITH previous AS (
+    SELECT reaction FROM likes WHERE user_id = $1 AND post_id = $2
+), upserted AS (
+    INSERT INTO likes (user_id, post_id, reaction)
+    VALUES ($1, $2, $3)
+    ON CONFLICT (user_id, post_id) DO UPDATE SET reaction = EXCLUDED.reaction
+    RETURNING (xmax = 0) AS inserted
+)
+UPDATE posts
+SET likes_count = COALESCE(likes_count, 0) + CASE WHEN (SELECT inserted FROM upserted) THEN 1 ELSE 0 END
+WHERE posts.id = $2
+RETURNING likes_count, (SELECT reaction FROM previous)::text AS previous_reaction
+`
+
+type UpsertPostReactionParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	PostID   uuid.UUID `json:"post_id"`
+	Reaction string    `json:"reaction"`
+}
+
+type UpsertPostReactionRow struct {
+	LikesCount       sql.NullInt32  `json:"likes_count"`
+	PreviousReaction sql.NullString `json:"previous_reaction"`
+}
+
+func (q *Queries) UpsertPostReaction(ctx context.Context, arg UpsertPostReactionParams) (UpsertPostReactionRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertPostReaction, arg.UserID, arg.PostID, arg.Reaction)
+	var i UpsertPostReactionRow
+	err := row.Scan(
+		&i.LikesCount,
+		&i.PreviousReaction,
+	)
+	return i, err
+}
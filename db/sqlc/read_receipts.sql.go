@@ -0,0 +1,230 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const getMessageIDsInCursorRange = `-- name: GetMessageIDsInCursorRange :many
+SELECT m.id
+FROM messages m
+WHERE m.conversation_id = $1
+  AND m.sender_id <> $2
+  AND ($3::timestamptz IS NULL
+       OR (m.created_at, m.id) > ($3::timestamptz, $4::uuid))
+  AND (m.created_at, m.id) <= ($5::timestamptz, $6::uuid)
+ORDER BY m.created_at DESC, m.id DESC
+LIMIT 100
+`
+
+type GetMessageIDsInCursorRangeParams struct {
+	ConversationID uuid.UUID     `json:"conversation_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	AfterAt        sql.NullTime  `json:"after_at"`
+	AfterID        uuid.NullUUID `json:"after_id"`
+	UptoAt         time.Time     `json:"upto_at"`
+	UptoID         uuid.UUID     `json:"upto_id"`
+}
+
+func (q *Queries) GetMessageIDsInCursorRange(ctx context.Context, arg GetMessageIDsInCursorRangeParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageIDsInCursorRange,
+		arg.ConversationID,
+		arg.UserID,
+		arg.AfterAt,
+		arg.AfterID,
+		arg.UptoAt,
+		arg.UptoID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessageReceiptCounts = `-- name: GetMessageReceiptCounts :many
+SELECT
+    m.id AS message_id,
+    COUNT(cp.user_id)::bigint AS recipient_count,
+    COUNT(cp.user_id) FILTER (
+        WHERE (cp.last_delivered_message_at, cp.last_delivered_message_id) >= (m.created_at, m.id)
+    )::bigint AS delivered_count,
+    COUNT(cp.user_id) FILTER (
+        WHERE (cp.last_read_message_at, cp.last_read_message_id) >= (m.created_at, m.id)
+          AND COALESCE(cp.request_status, 'accepted') = 'accepted'
+    )::bigint AS read_count
+FROM messages m
+LEFT JOIN conversation_participants cp
+    ON cp.conversation_id = m.conversation_id
+   AND cp.user_id <> m.sender_id
+   AND cp.is_active = true
+   AND COALESCE(cp.joined_at, '-infinity'::timestamptz) <= m.created_at
+WHERE m.id = ANY($1::uuid[])
+GROUP BY m.id
+`
+
+type GetMessageReceiptCountsRow struct {
+	MessageID      uuid.UUID `json:"message_id"`
+	RecipientCount int64     `json:"recipient_count"`
+	DeliveredCount int64     `json:"delivered_count"`
+	ReadCount      int64     `json:"read_count"`
+}
+
+func (q *Queries) GetMessageReceiptCounts(ctx context.Context, messageIds []uuid.UUID) ([]GetMessageReceiptCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageReceiptCounts, pq.Array(messageIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageReceiptCountsRow{}
+	for rows.Next() {
+		var i GetMessageReceiptCountsRow
+		if err := rows.Scan(
+			&i.MessageID,
+			&i.RecipientCount,
+			&i.DeliveredCount,
+			&i.ReadCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessageSeenBy = `-- name: GetMessageSeenBy :many
+SELECT
+    u.id,
+    u.username,
+    u.full_name,
+    u.avatar,
+    cp.last_read_at
+FROM messages m
+JOIN conversation_participants cp ON cp.conversation_id = m.conversation_id
+JOIN users u ON cp.user_id = u.id
+WHERE m.id = $1
+  AND cp.user_id <> m.sender_id
+  AND cp.is_active = true
+  AND COALESCE(cp.request_status, 'accepted') = 'accepted'
+  AND (cp.last_read_message_at, cp.last_read_message_id) >= (m.created_at, m.id)
+ORDER BY cp.last_read_at DESC
+`
+
+type GetMessageSeenByRow struct {
+	ID         uuid.UUID      `json:"id"`
+	Username   string         `json:"username"`
+	FullName   string         `json:"full_name"`
+	Avatar     sql.NullString `json:"avatar"`
+	LastReadAt sql.NullTime   `json:"last_read_at"`
+}
+
+func (q *Queries) GetMessageSeenBy(ctx context.Context, messageID uuid.UUID) ([]GetMessageSeenByRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessageSeenBy, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetMessageSeenByRow{}
+	for rows.Next() {
+		var i GetMessageSeenByRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Avatar,
+			&i.LastReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markMessagesAsDelivered = `-- name: MarkMessagesAsDelivered :one
+WITH target AS (
+    SELECT m.id, m.created_at
+    FROM messages m
+    WHERE m.conversation_id = $1
+      AND ($2::uuid IS NULL OR m.id = $2::uuid)
+    ORDER BY m.created_at DESC, m.id DESC
+    LIMIT 1
+), previous AS (
+    SELECT cp.id, cp.last_delivered_message_id, cp.last_delivered_message_at
+    FROM conversation_participants cp
+    WHERE cp.conversation_id = $1 AND cp.user_id = $3 AND cp.is_active = true
+)
+UPDATE conversation_participants cp
+SET last_delivered_message_id = target.id,
+    last_delivered_message_at = target.created_at,
+    last_delivered_at = NOW()
+FROM target, previous
+WHERE cp.id = previous.id
+  AND (previous.last_delivered_message_at IS NULL
+       OR (previous.last_delivered_message_at, previous.last_delivered_message_id) < (target.created_at, target.id))
+RETURNING
+    previous.last_delivered_message_id AS previous_message_id,
+    previous.last_delivered_message_at AS previous_message_at,
+    target.id AS message_id,
+    target.created_at AS message_at
+`
+
+type MarkMessagesAsDeliveredParams struct {
+	ConversationID uuid.UUID     `json:"conversation_id"`
+	MessageID      uuid.NullUUID `json:"message_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+}
+
+type MarkMessagesAsDeliveredRow struct {
+	PreviousMessageID uuid.NullUUID `json:"previous_message_id"`
+	PreviousMessageAt sql.NullTime  `json:"previous_message_at"`
+	MessageID         uuid.UUID     `json:"message_id"`
+	MessageAt         sql.NullTime  `json:"message_at"`
+}
+
+func (q *Queries) MarkMessagesAsDelivered(ctx context.Context, arg MarkMessagesAsDeliveredParams) (MarkMessagesAsDeliveredRow, error) {
+	row := q.db.QueryRowContext(ctx, markMessagesAsDelivered, arg.ConversationID, arg.MessageID, arg.UserID)
+	var i MarkMessagesAsDeliveredRow
+	err := row.Scan(
+		&i.PreviousMessageID,
+		&i.PreviousMessageAt,
+		&i.MessageID,
+		&i.MessageAt,
+	)
+	return i, err
+}
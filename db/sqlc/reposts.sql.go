@@ -0,0 +1,263 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const getPostQuotes = `-- name: GetPostQuotes :many
+SELECT
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    c.name as community_name,
+    g.name as group_name,
+    EXISTS(SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $1) as is_liked
+FROM posts p
+JOIN users u ON p.author_id = u.id
+LEFT JOIN communities c ON p.community_id = c.id
+LEFT JOIN groups g ON p.group_id = g.id
+WHERE p.quoted_post_id = $2::uuid
+  AND p.content <> ''
+  AND p.status = 'active'
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND NOT is_hidden_from($1, p.author_id)
+  AND ($3::timestamptz IS NULL
+       OR (p.created_at, p.id) < ($3::timestamptz, $4::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT $5 OFFSET $6
+`
+
+type GetPostQuotesParams struct {
+	ViewerID        uuid.UUID     `json:"viewer_id"`
+	QuotedPostID    uuid.UUID     `json:"quoted_post_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
+}
+
+type GetPostQuotesRow struct {
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	CommunityName      sql.NullString        `json:"community_name"`
+	GroupName          sql.NullString        `json:"group_name"`
+	IsLiked            bool                  `json:"is_liked"`
+}
+
+func (q *Queries) GetPostQuotes(ctx context.Context, arg GetPostQuotesParams) ([]GetPostQuotesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostQuotes,
+		arg.ViewerID,
+		arg.QuotedPostID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPostQuotesRow{}
+	for rows.Next() {
+		var i GetPostQuotesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.SpaceID,
+			&i.CommunityID,
+			&i.GroupID,
+			&i.ParentPostID,
+			&i.QuotedPostID,
+			&i.Content,
+			&i.Media,
+			pq.Array(&i.Tags),
+			&i.LikesCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+			&i.QuotesCount,
+			&i.ViewsCount,
+			&i.IsPinned,
+			&i.Visibility,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
+			&i.Username,
+			&i.FullName,
+			&i.AuthorAvatar,
+			&i.CommunityName,
+			&i.GroupName,
+			&i.IsLiked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuotedPostPreviews = `-- name: GetQuotedPostPreviews :many
+SELECT
+    qp.id,
+    qp.author_id,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8))::text as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User')::text as full_name,
+    u.avatar as author_avatar,
+    u.verified as author_verified,
+    qp.content,
+    qp.media,
+    qp.created_at,
+    qp.content_warning,
+    qp.is_sensitive
+FROM posts qp
+JOIN users u ON qp.author_id = u.id
+WHERE qp.id = ANY($1::uuid[])
+  AND qp.status = 'active'
+  AND NOT is_hidden_from($2, qp.author_id)
+  AND can_view_post($2, qp.author_id, qp.visibility, qp.community_id, qp.group_id)
+`
+
+type GetQuotedPostPreviewsParams struct {
+	PostIds  []uuid.UUID `json:"post_ids"`
+	ViewerID uuid.UUID   `json:"viewer_id"`
+}
+
+type GetQuotedPostPreviewsRow struct {
+	ID             uuid.UUID             `json:"id"`
+	AuthorID       uuid.UUID             `json:"author_id"`
+	Username       string                `json:"username"`
+	FullName       string                `json:"full_name"`
+	AuthorAvatar   sql.NullString        `json:"author_avatar"`
+	AuthorVerified sql.NullBool          `json:"author_verified"`
+	Content        string                `json:"content"`
+	Media          pqtype.NullRawMessage `json:"media"`
+	CreatedAt      sql.NullTime          `json:"created_at"`
+	ContentWarning sql.NullString        `json:"content_warning"`
+	IsSensitive    bool                  `json:"is_sensitive"`
+}
+
+func (q *Queries) GetQuotedPostPreviews(ctx context.Context, arg GetQuotedPostPreviewsParams) ([]GetQuotedPostPreviewsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getQuotedPostPreviews, pq.Array(arg.PostIds), arg.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuotedPostPreviewsRow{}
+	for rows.Next() {
+		var i GetQuotedPostPreviewsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.Username,
+			&i.FullName,
+			&i.AuthorAvatar,
+			&i.AuthorVerified,
+			&i.Content,
+			&i.Media,
+			&i.CreatedAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeRepost = `-- name: RemoveRepost :one
+UPDATE posts SET status = 'removed', updated_at = NOW()
+WHERE author_id = $1
+  AND quoted_post_id = $2::uuid
+  AND content = ''
+  AND status = 'active'
+RETURNING id, author_id, space_id, community_id, group_id, parent_post_id, quoted_post_id, content, media, tags, likes_count, comments_count, reposts_count, quotes_count, views_count, is_pinned, visibility, status, created_at, updated_at, publish_at, content_warning, is_sensitive, warning_by_moderator
+`
+
+type RemoveRepostParams struct {
+	AuthorID     uuid.UUID `json:"author_id"`
+	QuotedPostID uuid.UUID `json:"quoted_post_id"`
+}
+
+func (q *Queries) RemoveRepost(ctx context.Context, arg RemoveRepostParams) (Post, error) {
+	row := q.db.QueryRowContext(ctx, removeRepost, arg.AuthorID, arg.QuotedPostID)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.AuthorID,
+		&i.SpaceID,
+		&i.CommunityID,
+		&i.GroupID,
+		&i.ParentPostID,
+		&i.QuotedPostID,
+		&i.Content,
+		&i.Media,
+		pq.Array(&i.Tags),
+		&i.LikesCount,
+		&i.CommentsCount,
+		&i.RepostsCount,
+		&i.QuotesCount,
+		&i.ViewsCount,
+		&i.IsPinned,
+		&i.Visibility,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishAt,
+		&i.ContentWarning,
+		&i.IsSensitive,
+		&i.WarningByModerator,
+	)
+	return i, err
+}
@@ -0,0 +1,181 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const getSearchFacets = `-- name: GetSearchFacets :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', $1::text) AS tsq
+)
+SELECT d.entity_type, COUNT(*) AS result_count
+FROM search_documents d, q
+WHERE d.space_id = $2
+  AND (d.search_vector @@ q.tsq OR d.title % $1::text OR $1::text <% d.title)
+  AND can_view_search_document($3, d.entity_type, d.entity_id)
+GROUP BY d.entity_type
+ORDER BY d.entity_type
+`
+
+type GetSearchFacetsParams struct {
+	Query    string    `json:"query"`
+	SpaceID  uuid.UUID `json:"space_id"`
+	ViewerID uuid.UUID `json:"viewer_id"`
+}
+
+type GetSearchFacetsRow struct {
+	EntityType  string `json:"entity_type"`
+	ResultCount int64  `json:"result_count"`
+}
+
+func (q *Queries) GetSearchFacets(ctx context.Context, arg GetSearchFacetsParams) ([]GetSearchFacetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSearchFacets, arg.Query, arg.SpaceID, arg.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSearchFacetsRow{}
+	for rows.Next() {
+		var i GetSearchFacetsRow
+		if err := rows.Scan(
+			&i.EntityType,
+			&i.ResultCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchDocuments = `-- name: SearchDocuments :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', $1::text) AS tsq
+),
+matches AS (
+    SELECT
+        d.entity_type,
+        d.entity_id,
+        d.title,
+        d.subtitle,
+        d.image,
+        d.body,
+        d.created_at,
+        COALESCE(d.created_at, '-infinity'::timestamptz) AS sort_at,
+        (ts_rank_cd(d.search_vector, q.tsq, 32) + word_similarity($1::text, d.title))::float8 AS score,
+        wp.content_warning,
+        COALESCE(wp.is_sensitive, false)::bool AS is_sensitive
+    FROM search_documents d
+    CROSS JOIN q
+    LEFT JOIN posts wp ON d.entity_type = 'post' AND wp.id = d.entity_id
+    WHERE d.space_id = $2
+      AND (cardinality($3::text[]) = 0 OR d.entity_type = ANY($3::text[]))
+      AND (d.search_vector @@ q.tsq OR d.title % $1::text OR $1::text <% d.title)
+      AND can_view_search_document($4, d.entity_type, d.entity_id)
+)
+SELECT
+    m.entity_type,
+    m.entity_id,
+    m.title,
+    m.subtitle,
+    m.image,
+    m.created_at,
+    ts_headline('english', html_escape(m.title), q.tsq, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true')::text AS title_highlight,
+    ts_headline('english', html_escape(m.body), q.tsq, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=24, MinWords=8, FragmentDelimiter=" ... "')::text AS snippet,
+    m.score,
+    m.content_warning,
+    m.is_sensitive
+FROM matches m
+CROSS JOIN q
+WHERE $5::uuid IS NULL
+   OR (m.score, m.sort_at, m.entity_id) < (
+       SELECT c.score, c.sort_at, c.entity_id
+       FROM matches c
+       WHERE c.entity_id = $5::uuid
+       LIMIT 1
+   )
+ORDER BY m.score DESC, m.sort_at DESC, m.entity_id DESC
+LIMIT $6 OFFSET $7
+`
+
+type SearchDocumentsParams struct {
+	Query       string    `json:"query"`
+	SpaceID     uuid.UUID `json:"space_id"`
+	EntityTypes []string  `json:"entity_types"`
+	ViewerID    uuid.UUID     `json:"viewer_id"`
+	CursorID    uuid.NullUUID `json:"cursor_id"`
+	PageSize    int32         `json:"page_size"`
+	PageOffset  int32         `json:"page_offset"`
+}
+
+type SearchDocumentsRow struct {
+	EntityType     string         `json:"entity_type"`
+	EntityID       uuid.UUID      `json:"entity_id"`
+	Title          string         `json:"title"`
+	Subtitle       sql.NullString `json:"subtitle"`
+	Image          sql.NullString `json:"image"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	TitleHighlight string         `json:"title_highlight"`
+	Snippet        string         `json:"snippet"`
+	Score          float64        `json:"score"`
+	ContentWarning sql.NullString `json:"content_warning"`
+	IsSensitive    bool           `json:"is_sensitive"`
+}
+
+func (q *Queries) SearchDocuments(ctx context.Context, arg SearchDocumentsParams) ([]SearchDocumentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchDocuments,
+		arg.Query,
+		arg.SpaceID,
+		pq.Array(arg.EntityTypes),
+		arg.ViewerID,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchDocumentsRow{}
+	for rows.Next() {
+		var i SearchDocumentsRow
+		if err := rows.Scan(
+			&i.EntityType,
+			&i.EntityID,
+			&i.Title,
+			&i.Subtitle,
+			&i.Image,
+			&i.CreatedAt,
+			&i.TitleHighlight,
+			&i.Snippet,
+			&i.Score,
+			&i.ContentWarning,
+			&i.IsSensitive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,13 +1,28 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
 )
 
 type Store interface {
 	Querier
+	CreatePostWithPollTx(ctx context.Context, arg CreatePostWithPollTxParams) (CreatePostWithPollTxResult, error)
+	RefreshTrendingTopicsTx(ctx context.Context, arg InsertTrendingTopicsParams) (int64, error)
+	BlockUserTx(ctx context.Context, arg BlockUserParams) (BlockUserTxResult, error)
+	ApproveFollowRequestTx(ctx context.Context, arg DeleteFollowRequestParams) (Follow, error)
+	TransferConversationOwnershipTx(ctx context.Context, arg TransferConversationOwnershipTxParams) error
+	SetPostContentWarningTx(ctx context.Context, arg SetPostContentWarningTxParams) (Post, error)
+	JoinConversationByInviteTx(ctx context.Context, arg JoinConversationByInviteTxParams) error
 }
 
+
+var ErrJoinRefused = errors.New("join refused")
+
 type SQLStore struct {
 	*Queries
 	db *sql.DB
@@ -26,20 +41,266 @@ func (store *SQLStore) DB() *sql.DB {
 }
 
 
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
 
+	q := New(tx)
+	if err := fn(q); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
 
+	return tx.Commit()
+}
 
 
+type CreatePostWithPollTxParams struct {
+	Post          CreatePostParams `json:"post"`
+	Options       []string         `json:"options"`
+	AllowMultiple bool             `json:"allow_multiple"`
+	IsAnonymous   bool             `json:"is_anonymous"`
+	ClosesAt      sql.NullTime     `json:"closes_at"`
+}
 
 
+type CreatePostWithPollTxResult struct {
+	Post    Post         `json:"post"`
+	Poll    Poll         `json:"poll"`
+	Options []PollOption `json:"options"`
+}
 
 
+func (store *SQLStore) CreatePostWithPollTx(ctx context.Context, arg CreatePostWithPollTxParams) (CreatePostWithPollTxResult, error) {
+	var result CreatePostWithPollTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Post, err = q.CreatePost(ctx, arg.Post)
+		if err != nil {
+			return err
+		}
+
+		result.Poll, err = q.CreatePoll(ctx, CreatePollParams{
+			PostID:        result.Post.ID,
+			AllowMultiple: arg.AllowMultiple,
+			IsAnonymous:   arg.IsAnonymous,
+			ClosesAt:      arg.ClosesAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Options, err = q.CreatePollOptions(ctx, CreatePollOptionsParams{
+			PollID:  result.Poll.ID,
+			Options: arg.Options,
+		})
+		return err
+	})
+
+	return result, err
+}
+
 
+func (store *SQLStore) RefreshTrendingTopicsTx(ctx context.Context, arg InsertTrendingTopicsParams) (int64, error) {
+	var inserted int64
 
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.DeleteTrendingTopicsByPeriod(ctx, sql.NullString{String: arg.Period, Valid: true})
+		if err != nil {
+			return err
+		}
 
+		inserted, err = q.InsertTrendingTopics(ctx, arg)
+		return err
+	})
 
+	return inserted, err
+}
+
+
+type BlockUserTxResult struct {
+	Block          UserBlock `json:"block"`
+	RemovedFollows int       `json:"removed_follows"`
+}
+
+
+func (store *SQLStore) BlockUserTx(ctx context.Context, arg BlockUserParams) (BlockUserTxResult, error) {
+	var result BlockUserTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Block, err = q.BlockUser(ctx, arg)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if err := q.DeleteFollowRequestsBetween(ctx, DeleteFollowRequestsBetweenParams{
+			UserA: arg.BlockerID,
+			UserB: arg.BlockedID,
+		}); err != nil {
+			return err
+		}
+
+		removed, err := q.DeleteFollowsBetween(ctx, DeleteFollowsBetweenParams{
+			UserA: arg.BlockerID,
+			UserB: arg.BlockedID,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, follow := range removed {
+			if err := q.DecrementFollowingCount(ctx, follow.FollowerID); err != nil {
+				return err
+			}
+			if err := q.DecrementFollowersCount(ctx, follow.FollowingID); err != nil {
+				return err
+			}
+		}
+		result.RemovedFollows = len(removed)
+
+		return nil
+	})
+
+	return result, err
+}
 
 
+func (store *SQLStore) ApproveFollowRequestTx(ctx context.Context, arg DeleteFollowRequestParams) (Follow, error) {
+	var follow Follow
 
+	err := store.execTx(ctx, func(q *Queries) error {
+		request, err := q.DeleteFollowRequest(ctx, arg)
+		if err != nil {
+			return err
+		}
 
+		follow, err = q.FollowUser(ctx, FollowUserParams{
+			FollowerID:  request.RequesterID,
+			FollowingID: request.TargetID,
+			SpaceID:     request.SpaceID,
+		})
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
 
+		if err := q.IncrementFollowingCount(ctx, request.RequesterID); err != nil {
+			return err
+		}
+		return q.IncrementFollowersCount(ctx, request.TargetID)
+	})
+
+	return follow, err
+}
+
+
+type TransferConversationOwnershipTxParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	OwnerID        uuid.UUID `json:"owner_id"`
+	NewOwnerID     uuid.UUID `json:"new_owner_id"`
+}
+
+
+func (store *SQLStore) TransferConversationOwnershipTx(ctx context.Context, arg TransferConversationOwnershipTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		
+		demoted, err := q.SetParticipantRole(ctx, SetParticipantRoleParams{
+			Role:           sql.NullString{String: "admin", Valid: true},
+			ConversationID: arg.ConversationID,
+			UserID:         arg.OwnerID,
+		})
+		if err != nil {
+			return err
+		}
+		if demoted == 0 {
+			return sql.ErrNoRows
+		}
+
+		promoted, err := q.SetParticipantRole(ctx, SetParticipantRoleParams{
+			Role:           sql.NullString{String: "owner", Valid: true},
+			ConversationID: arg.ConversationID,
+			UserID:         arg.NewOwnerID,
+		})
+		if err != nil {
+			return err
+		}
+		if promoted == 0 {
+			return sql.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+
+type SetPostContentWarningTxParams struct {
+	Warning  SetPostContentWarningParams `json:"warning"`
+	AuditLog *CreateAuditLogParams       `json:"audit_log"`
+}
+
+
+func (store *SQLStore) SetPostContentWarningTx(ctx context.Context, arg SetPostContentWarningTxParams) (Post, error) {
+	var post Post
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		post, err = q.SetPostContentWarning(ctx, arg.Warning)
+		if err != nil {
+			return err
+		}
+
+		if arg.AuditLog == nil {
+			return nil
+		}
+		_, err = q.CreateAuditLog(ctx, *arg.AuditLog)
+		return err
+	})
+
+	return post, err
+}
+
+
+type JoinConversationByInviteTxParams struct {
+	InviteID       uuid.UUID `json:"invite_id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	ActorID        uuid.UUID `json:"actor_id"`
+}
+
+
+func (store *SQLStore) JoinConversationByInviteTx(ctx context.Context, arg JoinConversationByInviteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		redeemed, err := q.RedeemConversationInvite(ctx, arg.InviteID)
+		if err != nil {
+			return err
+		}
+		if redeemed == 0 {
+			return sql.ErrNoRows
+		}
+
+		added, err := q.AddGroupParticipants(ctx, AddGroupParticipantsParams{
+			ConversationID: arg.ConversationID,
+			UserIds:        []uuid.UUID{arg.UserID},
+			ActorID:        arg.ActorID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(added) == 0 {
+			return ErrJoinRefused
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,295 @@
+
+
+
+
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
+)
+
+const deleteTrendingTopicsByPeriod = `-- name: DeleteTrendingTopicsByPeriod :exec
+DELETE FROM trending_topics WHERE period = $1
+`
+
+func (q *Queries) DeleteTrendingTopicsByPeriod(ctx context.Context, period sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, deleteTrendingTopicsByPeriod, period)
+	return err
+}
+
+const getPostsByTag = `-- name: GetPostsByTag :many
+SELECT
+    p.id, p.author_id, p.space_id, p.community_id, p.group_id, p.parent_post_id, p.quoted_post_id, p.content, p.media, p.tags, p.likes_count, p.comments_count, p.reposts_count, p.quotes_count, p.views_count, p.is_pinned, p.visibility, p.status, p.created_at, p.updated_at, p.publish_at, p.content_warning, p.is_sensitive, p.warning_by_moderator,
+    COALESCE(NULLIF(u.username, ''), 'user_' || SUBSTRING(u.id::text, 1, 8)) as username,
+    COALESCE(NULLIF(u.full_name, ''), 'User') as full_name,
+    u.avatar as author_avatar,
+    EXISTS(SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $1) as is_liked,
+    EXISTS(SELECT 1 FROM bookmarks b WHERE b.post_id = p.id AND b.user_id = $1) as is_bookmarked
+FROM post_tags pt
+JOIN posts p ON pt.post_id = p.id
+JOIN users u ON p.author_id = u.id
+WHERE pt.tag_id = $2
+  AND pt.space_id = $3
+  AND p.status = 'active'
+  AND can_view_post($1, p.author_id, p.visibility, p.community_id, p.group_id)
+  AND ($4::timestamptz IS NULL
+       OR (p.created_at, p.id) < ($4::timestamptz, $5::uuid))
+  AND NOT is_hidden_from($1, p.author_id)
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT $6
+`
+
+type GetPostsByTagParams struct {
+	ViewerID        uuid.UUID     `json:"viewer_id"`
+	TagID           uuid.UUID     `json:"tag_id"`
+	SpaceID         uuid.UUID     `json:"space_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+}
+
+type GetPostsByTagRow struct {
+	ID                 uuid.UUID             `json:"id"`
+	AuthorID           uuid.UUID             `json:"author_id"`
+	SpaceID            uuid.UUID             `json:"space_id"`
+	CommunityID        uuid.NullUUID         `json:"community_id"`
+	GroupID            uuid.NullUUID         `json:"group_id"`
+	ParentPostID       uuid.NullUUID         `json:"parent_post_id"`
+	QuotedPostID       uuid.NullUUID         `json:"quoted_post_id"`
+	Content            string                `json:"content"`
+	Media              pqtype.NullRawMessage `json:"media"`
+	Tags               []string              `json:"tags"`
+	LikesCount         sql.NullInt32         `json:"likes_count"`
+	CommentsCount      sql.NullInt32         `json:"comments_count"`
+	RepostsCount       sql.NullInt32         `json:"reposts_count"`
+	QuotesCount        sql.NullInt32         `json:"quotes_count"`
+	ViewsCount         sql.NullInt32         `json:"views_count"`
+	IsPinned           sql.NullBool          `json:"is_pinned"`
+	Visibility         sql.NullString        `json:"visibility"`
+	Status             sql.NullString        `json:"status"`
+	CreatedAt          sql.NullTime          `json:"created_at"`
+	UpdatedAt          sql.NullTime          `json:"updated_at"`
+	PublishAt          sql.NullTime          `json:"publish_at"`
+	ContentWarning     sql.NullString        `json:"content_warning"`
+	IsSensitive        bool                  `json:"is_sensitive"`
+	WarningByModerator bool                  `json:"warning_by_moderator"`
+	Username           interface{}           `json:"username"`
+	FullName           interface{}           `json:"full_name"`
+	AuthorAvatar       sql.NullString        `json:"author_avatar"`
+	IsLiked            bool                  `json:"is_liked"`
+	IsBookmarked       bool                  `json:"is_bookmarked"`
+}
+
+func (q *Queries) GetPostsByTag(ctx context.Context, arg GetPostsByTagParams) ([]GetPostsByTagRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPostsByTag,
+		arg.ViewerID,
+		arg.TagID,
+		arg.SpaceID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPostsByTagRow{}
+	for rows.Next() {
+		var i GetPostsByTagRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.SpaceID,
+			&i.CommunityID,
+			&i.GroupID,
+			&i.ParentPostID,
+			&i.QuotedPostID,
+			&i.Content,
+			&i.Media,
+			pq.Array(&i.Tags),
+			&i.LikesCount,
+			&i.CommentsCount,
+			&i.RepostsCount,
+			&i.QuotesCount,
+			&i.ViewsCount,
+			&i.IsPinned,
+			&i.Visibility,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishAt,
+			&i.ContentWarning,
+			&i.IsSensitive,
+			&i.WarningByModerator,
+			&i.Username,
+			&i.FullName,
+			&i.AuthorAvatar,
+			&i.IsLiked,
+			&i.IsBookmarked,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTagByName = `-- name: GetTagByName :one
+SELECT id, name, created_at FROM tags WHERE name = $1
+`
+
+func (q *Queries) GetTagByName(ctx context.Context, name string) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, getTagByName, name)
+	var i Tag
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const insertTrendingTopics = `-- name: InsertTrendingTopics :execrows
+WITH scored AS (
+    SELECT
+        pt.space_id,
+        t.name,
+        COUNT(DISTINCT pt.post_id)::int AS post_count,
+        SUM(
+            (1 + LN(1 + COALESCE(p.likes_count, 0) + COALESCE(p.comments_count, 0) + COALESCE(p.reposts_count, 0)))
+            * POWER(0.5, EXTRACT(EPOCH FROM (NOW() - pt.created_at)) / 3600.0 / $1::float8)
+        )::float8 AS trend_score
+    FROM post_tags pt
+    JOIN tags t ON pt.tag_id = t.id
+    JOIN posts p ON pt.post_id = p.id
+    WHERE p.status = 'active'
+      AND pt.created_at > NOW() - make_interval(hours => $2::int)
+    GROUP BY pt.space_id, t.name
+),
+ranked AS (
+    SELECT *, ROW_NUMBER() OVER (PARTITION BY space_id ORDER BY trend_score DESC, name ASC) AS rank
+    FROM scored
+)
+INSERT INTO trending_topics (space_id, name, category, post_count, trend_score, period, recorded_at)
+SELECT space_id, name, 'hashtag', post_count, trend_score, $3::varchar, NOW()
+FROM ranked
+WHERE rank <= $4::int
+`
+
+type InsertTrendingTopicsParams struct {
+	HalfLifeHours float64 `json:"half_life_hours"`
+	WindowHours   int32   `json:"window_hours"`
+	Period        string  `json:"period"`
+	PerSpaceLimit int32   `json:"per_space_limit"`
+}
+
+func (q *Queries) InsertTrendingTopics(ctx context.Context, arg InsertTrendingTopicsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertTrendingTopics,
+		arg.HalfLifeHours,
+		arg.WindowHours,
+		arg.Period,
+		arg.PerSpaceLimit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchTags = `-- name: SearchTags :many
+SELECT
+    t.id,
+    t.name,
+    COALESCE(u.usage_count, 0)::int AS usage_count
+FROM tags t
+LEFT JOIN tag_space_usage u ON u.tag_id = t.id AND u.space_id = $1
+WHERE t.name LIKE $2::text || '%'
+ORDER BY COALESCE(u.usage_count, 0) DESC, t.name ASC
+LIMIT $3
+`
+
+type SearchTagsParams struct {
+	SpaceID   uuid.UUID `json:"space_id"`
+	Prefix    string    `json:"prefix"`
+	PageLimit int32     `json:"page_limit"`
+}
+
+type SearchTagsRow struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	UsageCount int32     `json:"usage_count"`
+}
+
+func (q *Queries) SearchTags(ctx context.Context, arg SearchTagsParams) ([]SearchTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchTags, arg.SpaceID, arg.Prefix, arg.PageLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchTagsRow{}
+	for rows.Next() {
+		var i SearchTagsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.UsageCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const syncPostTags = `-- name: SyncPostTags :exec
+WITH input AS (
+    SELECT DISTINCT unnest($1::text[]) AS name
+),
+inserted AS (
+    INSERT INTO tags (name)
+    SELECT name FROM input
+    ON CONFLICT (name) DO NOTHING
+    RETURNING id
+),
+wanted AS (
+    SELECT id FROM inserted
+    UNION
+    SELECT t.id FROM tags t JOIN input i ON t.name = i.name
+),
+removed AS (
+    DELETE FROM post_tags
+    WHERE post_id = $2::uuid AND tag_id NOT IN (SELECT id FROM wanted)
+)
+INSERT INTO post_tags (post_id, tag_id, space_id)
+SELECT $2::uuid, id, $3::uuid FROM wanted
+ON CONFLICT (post_id, tag_id) DO NOTHING
+`
+
+type SyncPostTagsParams struct {
+	Names   []string  `json:"names"`
+	PostID  uuid.UUID `json:"post_id"`
+	SpaceID uuid.UUID `json:"space_id"`
+}
+
+func (q *Queries) SyncPostTags(ctx context.Context, arg SyncPostTagsParams) error {
+	_, err := q.db.ExecContext(ctx, syncPostTags, pq.Array(arg.Names), arg.PostID, arg.SpaceID)
+	return err
+}
@@ -162,6 +162,32 @@ func (q *Queries) CheckIfFollowing(ctx context.Context, arg CheckIfFollowingPara
 	return is_following, err
 }
 
+const countUserFollowers = `-- name: CountUserFollowers :one
+SELECT COUNT(*) FROM follows f
+JOIN users u ON f.follower_id = u.id
+WHERE f.following_id = $1 AND u.status = 'active'
+`
+
+func (q *Queries) CountUserFollowers(ctx context.Context, followingID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserFollowers, followingID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUserFollowing = `-- name: CountUserFollowing :one
+SELECT COUNT(*) FROM follows f
+JOIN users u ON f.following_id = u.id
+WHERE f.follower_id = $1 AND u.status = 'active'
+`
+
+func (q *Queries) CountUserFollowing(ctx context.Context, followerID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserFollowing, followerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 
 INSERT INTO users (
@@ -653,14 +679,18 @@ FROM follows f
 JOIN users u ON f.follower_id = u.id
 WHERE f.following_id = $1
   AND u.status = 'active'
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+       OR (f.created_at, u.id) < ($2::timestamptz, $3::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT $4 OFFSET $5
 `
 
 type GetUserFollowersParams struct {
-	FollowingID uuid.UUID `json:"following_id"`
-	Limit       int32     `json:"limit"`
-	Offset      int32     `json:"offset"`
+	FollowingID     uuid.UUID     `json:"following_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetUserFollowersRow struct {
@@ -676,7 +706,13 @@ type GetUserFollowersRow struct {
 }
 
 func (q *Queries) GetUserFollowers(ctx context.Context, arg GetUserFollowersParams) ([]GetUserFollowersRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserFollowers, arg.FollowingID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getUserFollowers,
+		arg.FollowingID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -723,14 +759,18 @@ FROM follows f
 JOIN users u ON f.following_id = u.id
 WHERE f.follower_id = $1
   AND u.status = 'active'
-ORDER BY f.created_at DESC
-LIMIT $2 OFFSET $3
+  AND ($2::timestamptz IS NULL
+       OR (f.created_at, u.id) < ($2::timestamptz, $3::uuid))
+ORDER BY f.created_at DESC, u.id DESC
+LIMIT $4 OFFSET $5
 `
 
 type GetUserFollowingParams struct {
-	FollowerID uuid.UUID `json:"follower_id"`
-	Limit      int32     `json:"limit"`
-	Offset     int32     `json:"offset"`
+	FollowerID      uuid.UUID     `json:"follower_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	PageSize        int32         `json:"page_size"`
+	PageOffset      int32         `json:"page_offset"`
 }
 
 type GetUserFollowingRow struct {
@@ -746,7 +786,13 @@ type GetUserFollowingRow struct {
 }
 
 func (q *Queries) GetUserFollowing(ctx context.Context, arg GetUserFollowingParams) ([]GetUserFollowingRow, error) {
-	rows, err := q.db.QueryContext(ctx, getUserFollowing, arg.FollowerID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getUserFollowing,
+		arg.FollowerID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageSize,
+		arg.PageOffset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -1250,6 +1296,159 @@ func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]Sea
 	return items, nil
 }
 
+const setAccountPrivacy = `-- name: SetAccountPrivacy :one
+UPDATE users
+SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{private_account}', to_jsonb($1::bool)),
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, space_id, username, email, password, full_name, avatar, bio, verified, roles, level, department, major, year, interests, followers_count, following_count, mentor_status, tutor_status, status, settings, phone_number, additional_phone_number, created_at, updated_at, is_locked, locked_until, failed_login_attempts, last_failed_login, suspended_until
+`
+
+type SetAccountPrivacyParams struct {
+	PrivateAccount bool      `json:"private_account"`
+	ID             uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetAccountPrivacy(ctx context.Context, arg SetAccountPrivacyParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setAccountPrivacy, arg.PrivateAccount, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.SpaceID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.FullName,
+		&i.Avatar,
+		&i.Bio,
+		&i.Verified,
+		pq.Array(&i.Roles),
+		&i.Level,
+		&i.Department,
+		&i.Major,
+		&i.Year,
+		pq.Array(&i.Interests),
+		&i.FollowersCount,
+		&i.FollowingCount,
+		&i.MentorStatus,
+		&i.TutorStatus,
+		&i.Status,
+		&i.Settings,
+		&i.PhoneNumber,
+		&i.AdditionalPhoneNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsLocked,
+		&i.LockedUntil,
+		&i.FailedLoginAttempts,
+		&i.LastFailedLogin,
+		&i.SuspendedUntil,
+	)
+	return i, err
+}
+
+const setAllowMessagesFrom = `-- name: SetAllowMessagesFrom :one
+UPDATE users
+SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{allow_messages_from}', to_jsonb($1::text)),
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, space_id, username, email, password, full_name, avatar, bio, verified, roles, level, department, major, year, interests, followers_count, following_count, mentor_status, tutor_status, status, settings, phone_number, additional_phone_number, created_at, updated_at, is_locked, locked_until, failed_login_attempts, last_failed_login, suspended_until
+`
+
+type SetAllowMessagesFromParams struct {
+	AllowMessagesFrom string    `json:"allow_messages_from"`
+	ID                uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetAllowMessagesFrom(ctx context.Context, arg SetAllowMessagesFromParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setAllowMessagesFrom, arg.AllowMessagesFrom, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.SpaceID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.FullName,
+		&i.Avatar,
+		&i.Bio,
+		&i.Verified,
+		pq.Array(&i.Roles),
+		&i.Level,
+		&i.Department,
+		&i.Major,
+		&i.Year,
+		pq.Array(&i.Interests),
+		&i.FollowersCount,
+		&i.FollowingCount,
+		&i.MentorStatus,
+		&i.TutorStatus,
+		&i.Status,
+		&i.Settings,
+		&i.PhoneNumber,
+		&i.AdditionalPhoneNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsLocked,
+		&i.LockedUntil,
+		&i.FailedLoginAttempts,
+		&i.LastFailedLogin,
+		&i.SuspendedUntil,
+	)
+	return i, err
+}
+
+const setAutoExpandContentWarnings = `-- name: SetAutoExpandContentWarnings :one
+UPDATE users
+SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{auto_expand_content_warnings}', to_jsonb($1::bool)),
+    updated_at = NOW()
+WHERE id = $2
+RETURNING id, space_id, username, email, password, full_name, avatar, bio, verified, roles, level, department, major, year, interests, followers_count, following_count, mentor_status, tutor_status, status, settings, phone_number, additional_phone_number, created_at, updated_at, is_locked, locked_until, failed_login_attempts, last_failed_login, suspended_until
+`
+
+type SetAutoExpandContentWarningsParams struct {
+	AutoExpand bool      `json:"auto_expand"`
+	ID         uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetAutoExpandContentWarnings(ctx context.Context, arg SetAutoExpandContentWarningsParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, setAutoExpandContentWarnings, arg.AutoExpand, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.SpaceID,
+		&i.Username,
+		&i.Email,
+		&i.Password,
+		&i.FullName,
+		&i.Avatar,
+		&i.Bio,
+		&i.Verified,
+		pq.Array(&i.Roles),
+		&i.Level,
+		&i.Department,
+		&i.Major,
+		&i.Year,
+		pq.Array(&i.Interests),
+		&i.FollowersCount,
+		&i.FollowingCount,
+		&i.MentorStatus,
+		&i.TutorStatus,
+		&i.Status,
+		&i.Settings,
+		&i.PhoneNumber,
+		&i.AdditionalPhoneNumber,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsLocked,
+		&i.LockedUntil,
+		&i.FailedLoginAttempts,
+		&i.LastFailedLogin,
+		&i.SuspendedUntil,
+	)
+	return i, err
+}
+
 const unfollowUser = `-- name: UnfollowUser :exec
 DELETE FROM follows
 WHERE follower_id = $1 AND following_id = $2
@@ -210,13 +210,20 @@ func (h *CommunityHandler) GetCommunityMembers(c *gin.Context) {
 		return
 	}
 	
-	members, err := h.communityService.GetCommunityMembers(c.Request.Context(), communityID)
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(members))
+	members, err := h.communityService.GetCommunityMembers(c.Request.Context(), communityID, page)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(members.Response()))
 }
 
 
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/connect-univyn/connect-server/internal/util/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+
+type MentionHandler struct {
+	mentionService *mentions.Service
+}
+
+
+func NewMentionHandler(mentionService *mentions.Service) *MentionHandler {
+	return &MentionHandler{
+		mentionService: mentionService,
+	}
+}
+
+
+func (h *MentionHandler) Suggest(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
+			limit = l
+		}
+	}
+
+	suggestions, err := h.mentionService.Suggest(c.Request.Context(), userID, c.Query("q"), int32(limit))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(suggestions))
+}
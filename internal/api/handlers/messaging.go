@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/connect-univyn/connect-server/internal/util/auth"
 	"github.com/connect-univyn/connect-server/internal/service/messaging"
@@ -50,6 +54,7 @@ func (h *MessagingHandler) CreateConversation(c *gin.Context) {
 	if !hasCreator {
 		req.ParticipantIDs = append(req.ParticipantIDs, creatorID)
 	}
+	req.CreatorID = creatorID
 	
 	conversation, err := h.messagingService.CreateConversation(c.Request.Context(), req)
 	if err != nil {
@@ -95,9 +100,31 @@ func (h *MessagingHandler) GetUserConversations(c *gin.Context) {
 	}
 	authPayload := payload.(*auth.Payload)
 	
-	userID, _ := uuid.Parse(authPayload.UserID)
+	params := messaging.GetUserConversationsParams{}
+	params.UserID, _ = uuid.Parse(authPayload.UserID)
+	
+	filters := []struct {
+		name   string
+		target **bool
+	}{
+		{"archived", &params.Archived},
+		{"muted", &params.Muted},
+		{"unread", &params.Unread},
+	}
+	for _, filter := range filters {
+		raw := c.Query(filter.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", filter.name+" must be true or false"))
+			return
+		}
+		*filter.target = &value
+	}
 	
-	conversations, err := h.messagingService.GetUserConversations(c.Request.Context(), userID)
+	conversations, err := h.messagingService.GetUserConversations(c.Request.Context(), params)
 	if err != nil {
 		util.HandleError(c, err)
 		return
@@ -107,6 +134,102 @@ func (h *MessagingHandler) GetUserConversations(c *gin.Context) {
 }
 
 
+func (h *MessagingHandler) GetMessageRequests(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	limit, offset := parsePagination(c)
+	
+	requests, err := h.messagingService.GetMessageRequests(c.Request.Context(), userID, int32(limit), int32(offset))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(requests))
+}
+
+
+func (h *MessagingHandler) AcceptMessageRequest(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.AcceptMessageRequest(c.Request.Context(), conversationID, userID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Message request accepted"}))
+}
+
+
+func (h *MessagingHandler) DeclineMessageRequest(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.DeclineMessageRequest(c.Request.Context(), conversationID, userID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Message request declined"}))
+}
+
+
+func (h *MessagingHandler) BlockMessageRequest(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.BlockMessageRequest(c.Request.Context(), conversationID, userID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Message request declined and sender blocked"}))
+}
+
+
 func (h *MessagingHandler) GetOrCreateDirectConversation(c *gin.Context) {
 	payload, exists := c.Get("authorization_payload")
 	if !exists {
@@ -176,18 +299,31 @@ func (h *MessagingHandler) SendMessage(c *gin.Context) {
 
 
 func (h *MessagingHandler) GetConversationMessages(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
 	conversationID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
 		return
 	}
 	
-	page, limit := parsePagination(c)
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
 	
+	userID, _ := uuid.Parse(authPayload.UserID)
 	params := messaging.GetConversationMessagesParams{
 		ConversationID: conversationID,
-		Page:           int32(page),
-		Limit:          int32(limit),
+		UserID:         userID,
+		Pagination:     page,
 	}
 
 	messages, err := h.messagingService.GetConversationMessages(c.Request.Context(), params)
@@ -196,18 +332,137 @@ func (h *MessagingHandler) GetConversationMessages(c *gin.Context) {
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(messages))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(messages.Response()))
+}
+
+
+func (h *MessagingHandler) SearchMessages(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("missing_query", "Search query (q) is required"))
+		return
+	}
+	
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	req := messaging.SearchMessagesRequest{
+		Query:  query,
+		UserID: userID,
+		Page:   page,
+	}
+	
+	if idStr := c.Query("conversation_id"); idStr != "" {
+		conversationID, err := uuid.Parse(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+			return
+		}
+		req.ConversationID = &conversationID
+	}
+	if idStr := c.Query("sender_id"); idStr != "" {
+		senderID, err := uuid.Parse(idStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid sender ID format"))
+			return
+		}
+		req.SenderID = &senderID
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_date", "from must be an RFC3339 timestamp"))
+			return
+		}
+		req.After = &from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_date", "to must be an RFC3339 timestamp"))
+			return
+		}
+		req.Before = &to
+	}
+	if attachmentStr := c.Query("has_attachment"); attachmentStr != "" {
+		hasAttachment, err := strconv.ParseBool(attachmentStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "has_attachment must be true or false"))
+			return
+		}
+		req.HasAttachment = &hasAttachment
+	}
+	
+	results, err := h.messagingService.SearchMessages(c.Request.Context(), req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(results))
+}
+
+
+func (h *MessagingHandler) ForwardMessage(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
+		return
+	}
+	
+	var req messaging.ForwardMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	messages, err := h.messagingService.ForwardMessage(c.Request.Context(), messageID, userID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusCreated, util.NewSuccessResponse(messages))
 }
 
 
 func (h *MessagingHandler) GetMessage(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
 	messageID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
 		return
 	}
 	
-	message, err := h.messagingService.GetMessageByID(c.Request.Context(), messageID)
+	userID, _ := uuid.Parse(authPayload.UserID)
+	message, err := h.messagingService.GetMessageByID(c.Request.Context(), messageID, userID)
 	if err != nil {
 		util.HandleError(c, err)
 		return
@@ -243,7 +498,7 @@ func (h *MessagingHandler) DeleteMessage(c *gin.Context) {
 }
 
 
-func (h *MessagingHandler) MarkMessagesAsRead(c *gin.Context) {
+func (h *MessagingHandler) EditMessage(c *gin.Context) {
 	payload, exists := c.Get("authorization_payload")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
@@ -251,25 +506,31 @@ func (h *MessagingHandler) MarkMessagesAsRead(c *gin.Context) {
 	}
 	authPayload := payload.(*auth.Payload)
 	
-	conversationID, err := uuid.Parse(c.Param("id"))
+	messageID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
 		return
 	}
 	
-	userID, _ := uuid.Parse(authPayload.UserID)
+	var req messaging.EditMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
 	
-	err = h.messagingService.MarkMessagesAsRead(c.Request.Context(), conversationID, userID)
+	editorID, _ := uuid.Parse(authPayload.UserID)
+	
+	message, err := h.messagingService.EditMessage(c.Request.Context(), messageID, editorID, req)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Messages marked as read"}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(message))
 }
 
 
-func (h *MessagingHandler) GetUnreadCount(c *gin.Context) {
+func (h *MessagingHandler) GetMessageRevisions(c *gin.Context) {
 	payload, exists := c.Get("authorization_payload")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
@@ -277,67 +538,88 @@ func (h *MessagingHandler) GetUnreadCount(c *gin.Context) {
 	}
 	authPayload := payload.(*auth.Payload)
 	
-	conversationID, err := uuid.Parse(c.Param("id"))
+	messageID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
 		return
 	}
 	
 	userID, _ := uuid.Parse(authPayload.UserID)
 	
-	count, err := h.messagingService.GetUnreadMessageCount(c.Request.Context(), conversationID, userID)
+	revisions, err := h.messagingService.GetMessageRevisions(c.Request.Context(), messageID, userID)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"unread_count": count}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(revisions))
 }
 
 
-func (h *MessagingHandler) GetConversationParticipants(c *gin.Context) {
-	conversationID, err := uuid.Parse(c.Param("id"))
+func (h *MessagingHandler) GetThreadReplies(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	messageID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
 		return
 	}
 	
-	participants, err := h.messagingService.GetConversationParticipants(c.Request.Context(), conversationID)
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(participants))
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	replies, err := h.messagingService.GetThreadReplies(c.Request.Context(), messaging.GetThreadRepliesParams{
+		RootID:     messageID,
+		UserID:     userID,
+		Pagination: page,
+	})
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(replies.Response()))
 }
 
 
-func (h *MessagingHandler) AddConversationParticipants(c *gin.Context) {
-	conversationID, err := uuid.Parse(c.Param("id"))
-	if err != nil {
-		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+func (h *MessagingHandler) GetMessageSeenBy(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
 		return
 	}
+	authPayload := payload.(*auth.Payload)
 	
-	var req struct {
-		UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
 		return
 	}
 	
-	err = h.messagingService.AddConversationParticipants(c.Request.Context(), conversationID, req.UserIDs)
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	readers, err := h.messagingService.GetMessageSeenBy(c.Request.Context(), messageID, userID)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Participants added successfully"}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(readers))
 }
 
 
-func (h *MessagingHandler) LeaveConversation(c *gin.Context) {
+func (h *MessagingHandler) MarkMessagesAsRead(c *gin.Context) {
 	payload, exists := c.Get("authorization_payload")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
@@ -351,19 +633,25 @@ func (h *MessagingHandler) LeaveConversation(c *gin.Context) {
 		return
 	}
 	
+	var req messaging.MarkMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
 	userID, _ := uuid.Parse(authPayload.UserID)
 	
-	err = h.messagingService.LeaveConversation(c.Request.Context(), conversationID, userID)
+	err = h.messagingService.MarkMessagesAsRead(c.Request.Context(), conversationID, userID, req)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Left conversation successfully"}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Messages marked as read"}))
 }
 
 
-func (h *MessagingHandler) UpdateParticipantSettings(c *gin.Context) {
+func (h *MessagingHandler) MarkMessagesAsDelivered(c *gin.Context) {
 	payload, exists := c.Get("authorization_payload")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
@@ -377,21 +665,700 @@ func (h *MessagingHandler) UpdateParticipantSettings(c *gin.Context) {
 		return
 	}
 	
-	var req messaging.UpdateParticipantSettingsRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var req messaging.MarkMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
 		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
 		return
 	}
 	
 	userID, _ := uuid.Parse(authPayload.UserID)
 	
-	err = h.messagingService.UpdateParticipantSettings(c.Request.Context(), conversationID, userID, req)
+	err = h.messagingService.MarkMessagesAsDelivered(c.Request.Context(), conversationID, userID, req)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Settings updated successfully"}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Messages marked as delivered"}))
+}
+
+
+func (h *MessagingHandler) GetUnreadCount(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	count, err := h.messagingService.GetUnreadMessageCount(c.Request.Context(), conversationID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"unread_count": count}))
+}
+
+
+func (h *MessagingHandler) GetConversationParticipants(c *gin.Context) {
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	participants, err := h.messagingService.GetConversationParticipants(c.Request.Context(), conversationID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(participants))
+}
+
+
+func (h *MessagingHandler) AddConversationParticipants(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req struct {
+		UserIDs []uuid.UUID `json:"user_ids" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.AddConversationParticipants(c.Request.Context(), conversationID, actorID, req.UserIDs)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Participants added successfully"}))
+}
+
+
+func (h *MessagingHandler) UpdateConversation(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req messaging.UpdateConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	conversation, err := h.messagingService.UpdateConversation(c.Request.Context(), conversationID, actorID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(conversation))
+}
+
+
+func (h *MessagingHandler) RemoveConversationParticipant(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	targetID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.RemoveConversationParticipant(c.Request.Context(), conversationID, actorID, targetID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Participant removed successfully"}))
+}
+
+
+func (h *MessagingHandler) UpdateParticipantRole(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	targetID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+	
+	var req messaging.UpdateParticipantRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.UpdateParticipantRole(c.Request.Context(), conversationID, actorID, targetID, req); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Participant role updated successfully"}))
+}
+
+
+func (h *MessagingHandler) TransferConversationOwnership(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req messaging.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.TransferOwnership(c.Request.Context(), conversationID, actorID, req); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Ownership transferred successfully"}))
+}
+
+
+func (h *MessagingHandler) CreateConversationInvite(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req messaging.CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	invite, err := h.messagingService.CreateConversationInvite(c.Request.Context(), conversationID, actorID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusCreated, util.NewSuccessResponse(invite))
+}
+
+
+func (h *MessagingHandler) GetConversationInvites(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	invites, err := h.messagingService.GetConversationInvites(c.Request.Context(), conversationID, actorID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(invites))
+}
+
+
+func (h *MessagingHandler) RevokeConversationInvite(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	inviteID, err := uuid.Parse(c.Param("invite_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid invite ID format"))
+		return
+	}
+	
+	actorID, _ := uuid.Parse(authPayload.UserID)
+	
+	if err := h.messagingService.RevokeConversationInvite(c.Request.Context(), conversationID, inviteID, actorID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Invite revoked successfully"}))
+}
+
+
+func (h *MessagingHandler) JoinConversationByInvite(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	conversation, err := h.messagingService.JoinConversationByInvite(c.Request.Context(), c.Param("code"), userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(conversation))
+}
+
+
+func (h *MessagingHandler) LeaveConversation(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.LeaveConversation(c.Request.Context(), conversationID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Left conversation successfully"}))
+}
+
+
+func (h *MessagingHandler) UpdateParticipantSettings(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req messaging.UpdateParticipantSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.UpdateParticipantSettings(c.Request.Context(), conversationID, userID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Settings updated successfully"}))
+}
+
+
+func (h *MessagingHandler) MuteConversation(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req messaging.MuteConversationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.MuteConversation(c.Request.Context(), conversationID, userID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Conversation muted successfully"}))
+}
+
+
+func (h *MessagingHandler) UnmuteConversation(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.UnmuteConversation(c.Request.Context(), conversationID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Conversation unmuted successfully"}))
+}
+
+
+func (h *MessagingHandler) ArchiveConversation(c *gin.Context) {
+	h.setConversationArchived(c, true, "Conversation archived successfully")
+}
+
+
+func (h *MessagingHandler) UnarchiveConversation(c *gin.Context) {
+	h.setConversationArchived(c, false, "Conversation unarchived successfully")
+}
+
+
+func (h *MessagingHandler) setConversationArchived(c *gin.Context, archived bool, message string) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.SetConversationArchived(c.Request.Context(), conversationID, userID, archived)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": message}))
+}
+
+
+func (h *MessagingHandler) SetDisappearingMessages(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	var req messaging.DisappearingMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.SetDisappearingMessages(c.Request.Context(), conversationID, userID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"disappearing_messages_seconds": *req.Seconds}))
+}
+
+
+func (h *MessagingHandler) GetPinnedMessages(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	pins, err := h.messagingService.GetPinnedMessages(c.Request.Context(), conversationID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(pins))
+}
+
+
+func (h *MessagingHandler) PinMessage(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	pin, err := h.messagingService.PinMessage(c.Request.Context(), messageID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(pin))
+}
+
+
+func (h *MessagingHandler) UnpinMessage(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid message ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	err = h.messagingService.UnpinMessage(c.Request.Context(), messageID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Message unpinned successfully"}))
+}
+
+
+func (h *MessagingHandler) ExportConversation(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	conversationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid conversation ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	format := strings.ToLower(c.DefaultQuery("format", messaging.ExportFormatJSON))
+	
+	export, err := h.messagingService.RequestConversationExport(c.Request.Context(), messaging.ExportConversationRequest{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Format:         format,
+		IPAddress:      c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+	})
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	if export != nil {
+		c.JSON(http.StatusAccepted, util.NewSuccessResponse(export))
+		return
+	}
+	
+	c.Header("Content-Type", messaging.ExportContentType(format))
+	c.Header("Content-Disposition", "attachment; filename="+messaging.ExportFilename(conversationID, format))
+	c.Status(http.StatusOK)
+	
+	if _, err := h.messagingService.WriteConversationExport(c.Request.Context(), conversationID, userID, format, c.Writer); err != nil {
+		if !c.Writer.Written() {
+			util.HandleError(c, err)
+			return
+		}
+		_ = c.Error(err)
+	}
+}
+
+
+func (h *MessagingHandler) GetConversationExport(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	exportID, err := uuid.Parse(c.Param("export_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid export ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	export, err := h.messagingService.GetConversationExport(c.Request.Context(), exportID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.JSON(http.StatusOK, util.NewSuccessResponse(export))
+}
+
+
+func (h *MessagingHandler) DownloadConversationExport(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	
+	exportID, err := uuid.Parse(c.Param("export_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid export ID format"))
+		return
+	}
+	
+	userID, _ := uuid.Parse(authPayload.UserID)
+	
+	file, err := h.messagingService.DownloadConversationExport(c.Request.Context(), exportID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+	
+	c.Header("Content-Disposition", "attachment; filename="+messaging.ExportFilename(file.ConversationID, file.Format))
+	c.Data(http.StatusOK, messaging.ExportContentType(file.Format), file.Content)
 }
 
 
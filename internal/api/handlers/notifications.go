@@ -77,17 +77,22 @@ func (h *NotificationHandler) GetUserNotifications(c *gin.Context) {
 		}
 	}
 
+	page, err := parsePageRequest(c, int(limit), int(offset))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
 	notifs, err := h.notificationService.GetUserNotifications(c.Request.Context(), notifications.GetNotificationsParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
+		UserID:     userID,
+		Pagination: page,
 	})
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(notifs))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(notifs.Response()))
 }
 
 
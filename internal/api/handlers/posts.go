@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -77,8 +79,9 @@ func (h *PostHandler) GetPost(c *gin.Context) {
 		return
 	}
 
-	
-	go h.postService.IncrementPostViews(c.Request.Context(), postID)
+	if post.AuthorID != userID {
+		h.postService.RecordPostView(c.Request.Context(), postID, userID, c.ClientIP())
+	}
 
 	c.JSON(http.StatusOK, util.NewSuccessResponse(post))
 }
@@ -119,14 +122,26 @@ func (h *PostHandler) GetUserPosts(c *gin.Context) {
 
 	
 	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	
+	var viewerID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		viewerID, _ = uuid.Parse(authPayload.UserID)
+	}
 
-	posts, err := h.postService.GetUserPosts(c.Request.Context(), userID, int32(limit), int32(offset))
+	posts, err := h.postService.GetUserPosts(c.Request.Context(), userID, viewerID, page)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(posts))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(posts.Response()))
 }
 
 
@@ -141,16 +156,61 @@ func (h *PostHandler) GetUserFeed(c *gin.Context) {
 	userID, _ := uuid.Parse(authPayload.UserID)
 	spaceID, _ := uuid.Parse(authPayload.SpaceID)
 
+	switch c.DefaultQuery("mode", "chronological") {
+	case "chronological":
+	case "for_you", "ranked":
+		limit, _ := parsePagination(c)
+
+		page, err := h.postService.GetRankedFeed(c.Request.Context(), userID, spaceID, c.Query("cursor"), int32(limit))
+		if err != nil {
+			util.HandleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, util.NewSuccessResponse(page))
+		return
+	default:
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_mode", "mode must be 'chronological' or 'for_you'"))
+		return
+	}
+
 	
 	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
 
-	posts, err := h.postService.GetUserFeed(c.Request.Context(), userID, spaceID, int32(limit), int32(offset))
+	posts, err := h.postService.GetUserFeed(c.Request.Context(), userID, spaceID, page)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(posts))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(posts.Response()))
+}
+
+
+func (h *PostHandler) ExplainRankedFeed(c *gin.Context) {
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+	spaceID, _ := uuid.Parse(authPayload.SpaceID)
+
+	limit, _ := parsePagination(c)
+
+	page, err := h.postService.ExplainRankedFeed(c.Request.Context(), userID, spaceID, c.Query("cursor"), int32(limit))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(page))
 }
 
 
@@ -170,14 +230,19 @@ func (h *PostHandler) GetCommunityPosts(c *gin.Context) {
 
 	
 	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
 
-	posts, err := h.postService.GetCommunityPosts(c.Request.Context(), userID, communityID, int32(limit), int32(offset))
+	posts, err := h.postService.GetCommunityPosts(c.Request.Context(), userID, communityID, page)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(posts))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(posts.Response()))
 }
 
 
@@ -197,14 +262,19 @@ func (h *PostHandler) GetGroupPosts(c *gin.Context) {
 
 	
 	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
 
-	posts, err := h.postService.GetGroupPosts(c.Request.Context(), userID, groupID, int32(limit), int32(offset))
+	posts, err := h.postService.GetGroupPosts(c.Request.Context(), userID, groupID, page)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(posts))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(posts.Response()))
 }
 
 
@@ -221,7 +291,14 @@ func (h *PostHandler) GetTrendingPosts(c *gin.Context) {
 		return
 	}
 
-	posts, err := h.postService.GetTrendingPosts(c.Request.Context(), spaceID)
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	posts, err := h.postService.GetTrendingPosts(c.Request.Context(), spaceID, userID)
 	if err != nil {
 		util.HandleError(c, err)
 		return
@@ -268,7 +345,7 @@ func (h *PostHandler) GetTrendingTopics(c *gin.Context) {
 	
 	offset := (page - 1) * limit
 
-	topics, err := h.postService.GetTrendingTopics(c.Request.Context(), spaceID, limit, offset)
+	topics, err := h.postService.GetTrendingTopics(c.Request.Context(), spaceID, c.Query("period"), limit, offset)
 	if err != nil {
 		util.HandleError(c, err)
 		return
@@ -278,6 +355,73 @@ func (h *PostHandler) GetTrendingTopics(c *gin.Context) {
 }
 
 
+func (h *PostHandler) GetPostsByTag(c *gin.Context) {
+	spaceIDStr := c.Query("space_id")
+	if spaceIDStr == "" {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("missing_space_id", "space_id query parameter is required"))
+		return
+	}
+
+	spaceID, err := uuid.Parse(spaceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_space_id", "Invalid space ID format"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	limit, _ := parsePagination(c)
+	page, err := parsePageRequest(c, limit, 0)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	result, err := h.postService.GetPostsByTag(c.Request.Context(), c.Param("tag"), spaceID, userID, page)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(result))
+}
+
+
+func (h *PostHandler) SearchTags(c *gin.Context) {
+	spaceIDStr := c.Query("space_id")
+	if spaceIDStr == "" {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("missing_space_id", "space_id query parameter is required"))
+		return
+	}
+
+	spaceID, err := uuid.Parse(spaceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_space_id", "Invalid space ID format"))
+		return
+	}
+
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 50 {
+			limit = l
+		}
+	}
+
+	tags, err := h.postService.SearchTags(c.Request.Context(), spaceID, c.Query("q"), int32(limit))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(tags))
+}
+
+
 func (h *PostHandler) SearchPosts(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
@@ -300,7 +444,14 @@ func (h *PostHandler) SearchPosts(c *gin.Context) {
 	
 	limit, offset := parsePagination(c)
 
-	posts, err := h.postService.SearchPosts(c.Request.Context(), query, spaceID, int32(limit), int32(offset))
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	posts, err := h.postService.SearchPosts(c.Request.Context(), query, spaceID, userID, int32(limit), int32(offset))
 	if err != nil {
 		util.HandleError(c, err)
 		return
@@ -332,7 +483,14 @@ func (h *PostHandler) AdvancedSearchPosts(c *gin.Context) {
 	
 	limit, offset := parsePagination(c)
 
-	posts, err := h.postService.AdvancedSearchPosts(c.Request.Context(), query, spaceID, int32(limit), int32(offset))
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	posts, err := h.postService.AdvancedSearchPosts(c.Request.Context(), query, spaceID, userID, int32(limit), int32(offset))
 	if err != nil {
 		util.HandleError(c, err)
 		return
@@ -372,13 +530,27 @@ func (h *PostHandler) GetPostComments(c *gin.Context) {
 		return
 	}
 
-	comments, err := h.postService.GetPostComments(c.Request.Context(), postID)
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	comments, err := h.postService.GetPostComments(c.Request.Context(), postID, userID, page)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(comments))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(comments.Response()))
 }
 
 
@@ -471,7 +643,7 @@ func (h *PostHandler) CreateRepost(c *gin.Context) {
 }
 
 
-func (h *PostHandler) TogglePostLike(c *gin.Context) {
+func (h *PostHandler) QuotePost(c *gin.Context) {
 	postID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
@@ -485,24 +657,35 @@ func (h *PostHandler) TogglePostLike(c *gin.Context) {
 		return
 	}
 	authPayload := payload.(*auth.Payload)
-	userID, _ := uuid.Parse(authPayload.UserID)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+	spaceID, _ := uuid.Parse(authPayload.SpaceID)
 
-	likesCount, err := h.postService.TogglePostLike(c.Request.Context(), userID, postID)
+	var req posts.QuotePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	quote, err := h.postService.CreateRepost(c.Request.Context(), posts.CreateRepostParams{
+		AuthorID:     authorID,
+		SpaceID:      spaceID,
+		QuotedPostID: &postID,
+		Content:      req.Content,
+		Visibility:   req.Visibility,
+	})
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{
-		"likes_count": likesCount,
-	}))
+	c.JSON(http.StatusCreated, util.NewSuccessResponse(quote))
 }
 
 
-func (h *PostHandler) ToggleCommentLike(c *gin.Context) {
-	commentID, err := uuid.Parse(c.Param("id"))
+func (h *PostHandler) UndoRepost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
 		return
 	}
 
@@ -515,63 +698,984 @@ func (h *PostHandler) ToggleCommentLike(c *gin.Context) {
 	authPayload := payload.(*auth.Payload)
 	userID, _ := uuid.Parse(authPayload.UserID)
 
-	liked, err := h.postService.ToggleCommentLike(c.Request.Context(), userID, commentID)
-	if err != nil {
+	if err := h.postService.UndoRepost(c.Request.Context(), userID, postID); err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{
-		"liked": liked,
-	}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Repost removed successfully"}))
 }
 
 
-func (h *PostHandler) PinPost(c *gin.Context) {
+func (h *PostHandler) SetPostContentWarning(c *gin.Context) {
 	postID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
 		return
 	}
 
-	var req struct {
-		IsPinned bool `json:"is_pinned"`
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
 	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.ContentWarningRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
 		return
 	}
 
+	post, err := h.postService.SetPostContentWarning(c.Request.Context(), userID, postID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(post))
+}
+
+
+func (h *PostHandler) RemovePostContentWarning(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
 	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
 
-	err = h.postService.PinPost(c.Request.Context(), postID, req.IsPinned)
+	post, err := h.postService.RemovePostContentWarning(c.Request.Context(), userID, postID)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{
-		"message":   "Post pin status updated",
-		"is_pinned": req.IsPinned,
-	}))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(post))
 }
 
 
-func parsePagination(c *gin.Context) (int, int) {
-	limit := 20 
-	offset := 0
+func (h *PostHandler) GetPostQuotes(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
 
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
-			limit = l
-		}
+	
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
 	}
 
-	if pageStr := c.Query("page"); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
-			offset = (page - 1) * limit
-		}
+	
+	var viewerID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		viewerID, _ = uuid.Parse(authPayload.UserID)
 	}
 
-	return limit, offset
+	quotes, err := h.postService.GetPostQuotes(c.Request.Context(), viewerID, postID, page)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(quotes.Response()))
+}
+
+
+func (h *PostHandler) GetPostImpressions(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	days, _ := strconv.Atoi(c.DefaultQuery("days", "30"))
+
+	impressions, err := h.postService.GetPostImpressions(c.Request.Context(), userID, postID, days)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(impressions))
+}
+
+
+func (h *PostHandler) TogglePostLike(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	likesCount, err := h.postService.TogglePostLike(c.Request.Context(), userID, postID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{
+		"likes_count": likesCount,
+	}))
+}
+
+
+func (h *PostHandler) ToggleCommentLike(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	liked, err := h.postService.ToggleCommentLike(c.Request.Context(), userID, commentID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{
+		"liked": liked,
+	}))
+}
+
+
+func (h *PostHandler) GetReactionSet(c *gin.Context) {
+	spaceID, err := uuid.Parse(c.Query("space_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_space_id", "A valid space_id query parameter is required"))
+		return
+	}
+
+	reactions, err := h.postService.GetReactionSet(c.Request.Context(), spaceID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(reactions))
+}
+
+
+func (h *PostHandler) ReactToPost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	var req posts.ReactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	summary, err := h.postService.ReactToPost(c.Request.Context(), userID, postID, req.Reaction)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(summary))
+}
+
+
+func (h *PostHandler) RemovePostReaction(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	summary, err := h.postService.RemovePostReaction(c.Request.Context(), userID, postID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(summary))
+}
+
+
+func (h *PostHandler) GetPostReactions(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	limit, offset := parsePagination(c)
+
+	reactors, err := h.postService.GetPostReactors(c.Request.Context(), userID, postID, c.Query("reaction"), int32(limit), int32(offset))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(reactors))
+}
+
+
+func (h *PostHandler) ReactToComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	var req posts.ReactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	summary, err := h.postService.ReactToComment(c.Request.Context(), userID, commentID, req.Reaction)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(summary))
+}
+
+
+func (h *PostHandler) RemoveCommentReaction(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	summary, err := h.postService.RemoveCommentReaction(c.Request.Context(), userID, commentID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(summary))
+}
+
+
+func (h *PostHandler) GetCommentReactions(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	limit, offset := parsePagination(c)
+
+	reactors, err := h.postService.GetCommentReactors(c.Request.Context(), userID, commentID, c.Query("reaction"), int32(limit), int32(offset))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(reactors))
+}
+
+
+func (h *PostHandler) UpdateComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	comment, err := h.postService.UpdateComment(c.Request.Context(), commentID, authorID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(comment))
+}
+
+
+func (h *PostHandler) DeleteComment(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+
+	if err := h.postService.DeleteComment(c.Request.Context(), commentID, authorID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Comment deleted successfully"}))
+}
+
+
+func (h *PostHandler) GetThreadedComments(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	limit, _ := parsePagination(c)
+	page, err := parsePageRequest(c, limit, 0)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	result, err := h.postService.GetThreadedComments(c.Request.Context(), postID, userID, page)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(result))
+}
+
+
+func (h *PostHandler) GetCommentReplies(c *gin.Context) {
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid comment ID format"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	limit, _ := parsePagination(c)
+	page, err := parsePageRequest(c, limit, 0)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	result, err := h.postService.GetCommentReplies(c.Request.Context(), commentID, userID, page)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(result))
+}
+
+
+func (h *PostHandler) GetPoll(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+	}
+
+	poll, err := h.postService.GetPoll(c.Request.Context(), postID, userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(poll))
+}
+
+
+func (h *PostHandler) VotePoll(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.VotePollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	poll, err := h.postService.VotePoll(c.Request.Context(), postID, userID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(poll))
+}
+
+
+func (h *PostHandler) GetPollOptionVoters(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	optionID, err := uuid.Parse(c.Param("option_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid option ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	limit, offset := parsePagination(c)
+
+	voters, err := h.postService.GetPollOptionVoters(c.Request.Context(), postID, optionID, userID, int32(limit), int32(offset))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(voters))
+}
+
+
+func (h *PostHandler) GetPendingPosts(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+
+	limit, offset := parsePagination(c)
+
+	pending, err := h.postService.GetPendingPosts(c.Request.Context(), authorID, c.Query("status"), int32(limit), int32(offset))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(pending))
+}
+
+
+func (h *PostHandler) UpdatePendingPost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.UpdatePendingPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	post, err := h.postService.UpdatePendingPost(c.Request.Context(), postID, authorID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(post))
+}
+
+
+func (h *PostHandler) CancelScheduledPost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+
+	post, err := h.postService.CancelScheduledPost(c.Request.Context(), postID, authorID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(post))
+}
+
+
+func (h *PostHandler) PublishPendingPost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	authorID, _ := uuid.Parse(authPayload.UserID)
+
+	post, err := h.postService.PublishPendingPost(c.Request.Context(), postID, authorID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(post))
+}
+
+
+func (h *PostHandler) GetBookmarks(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	var collectionID *uuid.UUID
+	if raw := c.Query("collection_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid collection ID format"))
+			return
+		}
+		collectionID = &id
+	}
+
+	limit, _ := parsePagination(c)
+	page, err := parsePageRequest(c, limit, 0)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	result, err := h.postService.GetBookmarks(c.Request.Context(), userID, collectionID, page)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(result))
+}
+
+
+func (h *PostHandler) AddBookmark(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	
+	var req posts.BookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	bookmark, err := h.postService.AddBookmark(c.Request.Context(), userID, postID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(bookmark))
+}
+
+
+func (h *PostHandler) MoveBookmark(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.BookmarkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	bookmark, err := h.postService.MoveBookmark(c.Request.Context(), userID, postID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(bookmark))
+}
+
+
+func (h *PostHandler) RemoveBookmark(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	if err := h.postService.RemoveBookmark(c.Request.Context(), userID, postID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Bookmark removed successfully"}))
+}
+
+
+func (h *PostHandler) GetBookmarkCollections(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	collections, err := h.postService.GetBookmarkCollections(c.Request.Context(), userID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(collections))
+}
+
+
+func (h *PostHandler) CreateBookmarkCollection(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.BookmarkCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	collection, err := h.postService.CreateBookmarkCollection(c.Request.Context(), userID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, util.NewSuccessResponse(collection))
+}
+
+
+func (h *PostHandler) RenameBookmarkCollection(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid collection ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	var req posts.BookmarkCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	collection, err := h.postService.RenameBookmarkCollection(c.Request.Context(), userID, collectionID, req)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(collection))
+}
+
+
+func (h *PostHandler) DeleteBookmarkCollection(c *gin.Context) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid collection ID format"))
+		return
+	}
+
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Not authenticated"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+	userID, _ := uuid.Parse(authPayload.UserID)
+
+	if err := h.postService.DeleteBookmarkCollection(c.Request.Context(), userID, collectionID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Bookmark collection deleted successfully"}))
+}
+
+
+func (h *PostHandler) PinPost(c *gin.Context) {
+	postID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_id", "Invalid post ID format"))
+		return
+	}
+
+	var req struct {
+		IsPinned bool `json:"is_pinned"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	
+
+	err = h.postService.PinPost(c.Request.Context(), postID, req.IsPinned)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{
+		"message":   "Post pin status updated",
+		"is_pinned": req.IsPinned,
+	}))
+}
+
+
+func parsePagination(c *gin.Context) (int, int) {
+	limit := 20 
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			offset = (page - 1) * limit
+		}
+	}
+
+	return limit, offset
+}
+
+
+func parsePageRequest(c *gin.Context, limit, offset int) (util.PageRequest, error) {
+	page := util.PageRequest{
+		Limit:        int32(limit),
+		Offset:       int32(offset),
+		IncludeTotal: c.Query("include_total") == "true",
+	}
+
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		after, err := util.DecodeCursor(cursor)
+		if err != nil {
+			return page, err
+		}
+		page.After = after
+		page.Keyset = true
+	}
+
+	return page, nil
 }
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/connect-univyn/connect-server/internal/service/search"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/connect-univyn/connect-server/internal/util/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+
+type SearchHandler struct {
+	searchService *search.Service
+}
+
+
+func NewSearchHandler(searchService *search.Service) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("missing_query", "Search query (q) is required"))
+		return
+	}
+
+	
+	var userID uuid.UUID
+	var tokenSpaceID string
+	if payload, exists := c.Get("authorization_payload"); exists {
+		authPayload := payload.(*auth.Payload)
+		userID, _ = uuid.Parse(authPayload.UserID)
+		tokenSpaceID = authPayload.SpaceID
+	}
+
+	spaceIDStr := c.Query("space_id")
+	if spaceIDStr == "" {
+		spaceIDStr = tokenSpaceID
+	}
+	if spaceIDStr == "" {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("missing_space_id", "space_id query parameter is required"))
+		return
+	}
+	spaceID, err := uuid.Parse(spaceIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_space_id", "Invalid space ID format"))
+		return
+	}
+
+	var types []string
+	if typesStr := c.Query("types"); typesStr != "" {
+		types = strings.Split(typesStr, ",")
+	}
+
+	limit, offset := parsePagination(c)
+	page, err := parsePageRequest(c, limit, offset)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	results, err := h.searchService.Search(c.Request.Context(), search.SearchRequest{
+		Query:    query,
+		SpaceID:  spaceID,
+		ViewerID: userID,
+		Types:    types,
+		Page:     page,
+	})
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(results))
+}
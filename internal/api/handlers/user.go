@@ -277,12 +277,18 @@ func (h *UserHandler) FollowUser(c *gin.Context) {
 	}
 
 	
-	if err := h.userService.FollowUser(c.Request.Context(), followerID, followingID, spaceID); err != nil {
+	status, err := h.userService.FollowUser(c.Request.Context(), followerID, followingID, spaceID)
+	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Successfully followed user"}))
+	if status == users.FollowStatusRequested {
+		c.JSON(http.StatusAccepted, util.NewSuccessResponse(gin.H{"message": "Follow request sent", "status": status}))
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Successfully followed user", "status": status}))
 }
 
 
@@ -381,13 +387,19 @@ func (h *UserHandler) GetFollowers(c *gin.Context) {
 	}
 
 	
-	followers, err := h.userService.GetFollowers(c.Request.Context(), userID, page, limit)
+	pagination, err := parsePageRequest(c, int(limit), int((page-1)*limit))
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(followers))
+	followers, err := h.userService.GetFollowers(c.Request.Context(), userID, pagination)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(followers.Response()))
 }
 
 
@@ -421,11 +433,526 @@ func (h *UserHandler) GetFollowing(c *gin.Context) {
 	}
 
 	
-	following, err := h.userService.GetFollowing(c.Request.Context(), userID, page, limit)
+	pagination, err := parsePageRequest(c, int(limit), int((page-1)*limit))
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	following, err := h.userService.GetFollowing(c.Request.Context(), userID, pagination)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(following.Response()))
+}
+
+
+func (h *UserHandler) BlockUser(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	if err := h.userService.BlockUser(c.Request.Context(), userID, targetID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Successfully blocked user"}))
+}
+
+
+func (h *UserHandler) UnblockUser(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	if err := h.userService.UnblockUser(c.Request.Context(), userID, targetID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Successfully unblocked user"}))
+}
+
+
+func (h *UserHandler) MuteUser(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	if err := h.userService.MuteUser(c.Request.Context(), userID, targetID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Successfully muted user"}))
+}
+
+
+func (h *UserHandler) UnmuteUser(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	if err := h.userService.UnmuteUser(c.Request.Context(), userID, targetID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Successfully unmuted user"}))
+}
+
+
+func (h *UserHandler) GetBlockedUsers(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	page := int32(1)
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageInt, err := strconv.ParseInt(pageStr, 10, 32)
+		if err != nil || pageInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid page number"))
+			return
+		}
+		page = int32(pageInt)
+	}
+
+	limit := int32(20)
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitInt, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limitInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid limit"))
+			return
+		}
+		limit = int32(limitInt)
+	}
+
+	
+	blocked, err := h.userService.GetBlockedUsers(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(blocked))
+}
+
+
+func (h *UserHandler) GetMutedUsers(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	page := int32(1)
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageInt, err := strconv.ParseInt(pageStr, 10, 32)
+		if err != nil || pageInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid page number"))
+			return
+		}
+		page = int32(pageInt)
+	}
+
+	limit := int32(20)
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitInt, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limitInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid limit"))
+			return
+		}
+		limit = int32(limitInt)
+	}
+
+	
+	muted, err := h.userService.GetMutedUsers(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(muted))
+}
+
+
+func (h *UserHandler) GetRelationshipStatus(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	status, err := h.userService.GetRelationshipStatus(c.Request.Context(), userID, targetID)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(status))
+}
+
+
+func (h *UserHandler) GetFollowRequests(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	page := int32(1)
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageInt, err := strconv.ParseInt(pageStr, 10, 32)
+		if err != nil || pageInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid page number"))
+			return
+		}
+		page = int32(pageInt)
+	}
+
+	limit := int32(20)
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitInt, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limitInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid limit"))
+			return
+		}
+		limit = int32(limitInt)
+	}
+
+	
+	requests, err := h.userService.GetIncomingFollowRequests(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(requests))
+}
+
+
+func (h *UserHandler) GetSentFollowRequests(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	page := int32(1)
+	if pageStr := c.Query("page"); pageStr != "" {
+		pageInt, err := strconv.ParseInt(pageStr, 10, 32)
+		if err != nil || pageInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid page number"))
+			return
+		}
+		page = int32(pageInt)
+	}
+
+	limit := int32(20)
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limitInt, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil || limitInt < 1 {
+			c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", "Invalid limit"))
+			return
+		}
+		limit = int32(limitInt)
+	}
+
+	
+	requests, err := h.userService.GetOutgoingFollowRequests(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(requests))
+}
+
+
+func (h *UserHandler) ApproveFollowRequest(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	requesterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	if err := h.userService.ApproveFollowRequest(c.Request.Context(), userID, requesterID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Follow request approved"}))
+}
+
+
+func (h *UserHandler) RejectFollowRequest(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	
+	requesterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID format"))
+		return
+	}
+
+	
+	if err := h.userService.RejectFollowRequest(c.Request.Context(), userID, requesterID); err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(gin.H{"message": "Follow request rejected"}))
+}
+
+
+func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	var req users.UpdatePrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	
+	user, err := h.userService.SetAccountPrivacy(c.Request.Context(), userID, *req.PrivateAccount)
 	if err != nil {
 		util.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, util.NewSuccessResponse(following))
+	c.JSON(http.StatusOK, util.NewSuccessResponse(user))
+}
+
+
+func (h *UserHandler) UpdateContentWarningPreference(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	var req users.UpdateContentWarningPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	
+	user, err := h.userService.SetAutoExpandContentWarnings(c.Request.Context(), userID, *req.AutoExpandContentWarnings)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(user))
+}
+
+
+func (h *UserHandler) UpdateMessageRequestPolicy(c *gin.Context) {
+	
+	payload, exists := c.Get("authorization_payload")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, util.NewErrorResponse("unauthorized", "Authentication required"))
+		return
+	}
+	authPayload := payload.(*auth.Payload)
+
+	userID, err := uuid.Parse(authPayload.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("invalid_user_id", "Invalid user ID"))
+		return
+	}
+
+	var req users.UpdateMessageRequestPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, util.NewErrorResponse("validation_error", err.Error()))
+		return
+	}
+
+	
+	user, err := h.userService.SetAllowMessagesFrom(c.Request.Context(), userID, req.AllowMessagesFrom)
+	if err != nil {
+		util.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, util.NewSuccessResponse(user))
 }
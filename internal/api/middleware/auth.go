@@ -51,3 +51,16 @@ func AuthMiddleware(tokenMaker auth.Maker) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+
+func OptionalAuthMiddleware(tokenMaker auth.Maker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := strings.Fields(c.GetHeader(authorizationHeaderKey))
+		if len(fields) >= 2 && strings.ToLower(fields[0]) == authorizationTypeBearer {
+			if payload, err := tokenMaker.VerifyToken(fields[1]); err == nil {
+				c.Set(authorizationPayloadKey, payload)
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/connect-univyn/connect-server/internal/api/handlers"
+	"github.com/connect-univyn/connect-server/internal/api/middleware"
+	"github.com/connect-univyn/connect-server/internal/util/auth"
+	"github.com/gin-gonic/gin"
+)
+
+
+func SetupMentionRoutes(r *gin.RouterGroup, mentionHandler *handlers.MentionHandler, tokenMaker auth.Maker, rateLimitDefault int) {
+	mentions := r.Group("/mentions")
+	mentions.Use(middleware.RateLimitMiddleware(rateLimitDefault))
+	mentions.Use(middleware.AuthMiddleware(tokenMaker))
+	{
+		mentions.GET("/suggest", mentionHandler.Suggest)
+	}
+}
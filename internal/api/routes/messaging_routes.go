@@ -17,20 +17,44 @@ func SetupMessagingRoutes(r *gin.RouterGroup, messagingHandler *handlers.Messagi
 		
 		conversations.POST("", messagingHandler.CreateConversation)
 		conversations.GET("", messagingHandler.GetUserConversations)
+		conversations.GET("/requests", messagingHandler.GetMessageRequests)
+		conversations.GET("/exports/:export_id", messagingHandler.GetConversationExport)
+		conversations.GET("/exports/:export_id/download", messagingHandler.DownloadConversationExport)
 		conversations.GET("/:id", messagingHandler.GetConversation)
 		conversations.POST("/direct", messagingHandler.GetOrCreateDirectConversation)
+		conversations.POST("/join/:code", messagingHandler.JoinConversationByInvite)
+		conversations.PUT("/:id", messagingHandler.UpdateConversation)
 		conversations.POST("/:id/leave", messagingHandler.LeaveConversation)
 		conversations.PUT("/:id/settings", messagingHandler.UpdateParticipantSettings)
+		conversations.PUT("/:id/mute", messagingHandler.MuteConversation)
+		conversations.DELETE("/:id/mute", messagingHandler.UnmuteConversation)
+		conversations.POST("/:id/archive", messagingHandler.ArchiveConversation)
+		conversations.DELETE("/:id/archive", messagingHandler.UnarchiveConversation)
+		conversations.PUT("/:id/disappearing-messages", messagingHandler.SetDisappearingMessages)
+		conversations.POST("/:id/request/accept", messagingHandler.AcceptMessageRequest)
+		conversations.POST("/:id/request/decline", messagingHandler.DeclineMessageRequest)
+		conversations.POST("/:id/request/block", messagingHandler.BlockMessageRequest)
 		
 		
 		conversations.GET("/:id/participants", messagingHandler.GetConversationParticipants)
 		conversations.POST("/:id/participants", messagingHandler.AddConversationParticipants)
+		conversations.DELETE("/:id/participants/:user_id", messagingHandler.RemoveConversationParticipant)
+		conversations.PUT("/:id/participants/:user_id/role", messagingHandler.UpdateParticipantRole)
+		conversations.POST("/:id/transfer-ownership", messagingHandler.TransferConversationOwnership)
+		
+		
+		conversations.POST("/:id/invites", messagingHandler.CreateConversationInvite)
+		conversations.GET("/:id/invites", messagingHandler.GetConversationInvites)
+		conversations.DELETE("/:id/invites/:invite_id", messagingHandler.RevokeConversationInvite)
 		
 		
 		conversations.POST("/:id/messages", messagingHandler.SendMessage)
 		conversations.GET("/:id/messages", messagingHandler.GetConversationMessages)
 		conversations.POST("/:id/read", messagingHandler.MarkMessagesAsRead)
+		conversations.POST("/:id/delivered", messagingHandler.MarkMessagesAsDelivered)
 		conversations.GET("/:id/unread", messagingHandler.GetUnreadCount)
+		conversations.GET("/:id/pins", messagingHandler.GetPinnedMessages)
+		conversations.GET("/:id/export", messagingHandler.ExportConversation)
 	}
 	
 	
@@ -38,8 +62,16 @@ func SetupMessagingRoutes(r *gin.RouterGroup, messagingHandler *handlers.Messagi
 	messages.Use(middleware.RateLimitMiddleware(rateLimitDefault))
 	messages.Use(middleware.AuthMiddleware(tokenMaker))
 	{
+		messages.GET("/search", messagingHandler.SearchMessages)
 		messages.GET("/:id", messagingHandler.GetMessage)
+		messages.PUT("/:id", messagingHandler.EditMessage)
 		messages.DELETE("/:id", messagingHandler.DeleteMessage)
+		messages.POST("/:id/forward", messagingHandler.ForwardMessage)
+		messages.GET("/:id/revisions", messagingHandler.GetMessageRevisions)
+		messages.GET("/:id/replies", messagingHandler.GetThreadReplies)
+		messages.GET("/:id/seen-by", messagingHandler.GetMessageSeenBy)
+		messages.POST("/:id/pin", messagingHandler.PinMessage)
+		messages.DELETE("/:id/pin", messagingHandler.UnpinMessage)
 		messages.POST("/:id/reactions", messagingHandler.AddMessageReaction)
 		messages.DELETE("/:id/reactions/:emoji", messagingHandler.RemoveMessageReaction)
 	}
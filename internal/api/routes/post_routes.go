@@ -11,14 +11,22 @@ import (
 func SetupPostRoutes(r *gin.RouterGroup, postHandler *handlers.PostHandler, tokenMaker auth.Maker, rateLimitDefault int) {
 	posts := r.Group("/posts")
 	posts.Use(middleware.RateLimitMiddleware(rateLimitDefault))
+	posts.Use(middleware.OptionalAuthMiddleware(tokenMaker))
 	{
 		
 		posts.GET("/search", postHandler.SearchPosts)
 		posts.GET("/advanced-search", postHandler.AdvancedSearchPosts)
 		posts.GET("/trending", postHandler.GetTrendingPosts)
+		posts.GET("/tags", postHandler.SearchTags)
+		posts.GET("/reactions", postHandler.GetReactionSet)
+		posts.GET("/tag/:tag", postHandler.GetPostsByTag)
 		posts.GET("/:id", postHandler.GetPost)
 		posts.GET("/:id/comments", postHandler.GetPostComments)
+		posts.GET("/:id/comments/threaded", postHandler.GetThreadedComments)
 		posts.GET("/:id/likes", postHandler.GetPostLikes)
+		posts.GET("/:id/reactions", postHandler.GetPostReactions)
+		posts.GET("/:id/quotes", postHandler.GetPostQuotes)
+		posts.GET("/:id/poll", postHandler.GetPoll)
 		posts.GET("/user/:user_id", postHandler.GetUserPosts)
 		posts.GET("/community/:community_id", postHandler.GetCommunityPosts)
 		posts.GET("/group/:group_id", postHandler.GetGroupPosts)
@@ -30,11 +38,33 @@ func SetupPostRoutes(r *gin.RouterGroup, postHandler *handlers.PostHandler, toke
 			postsAuth.POST("", postHandler.CreatePost)
 			postsAuth.DELETE("/:id", postHandler.DeletePost)
 			postsAuth.GET("/feed", postHandler.GetUserFeed)
+			postsAuth.GET("/feed/explain", postHandler.ExplainRankedFeed)
 			postsAuth.GET("/liked", postHandler.GetUserLikedPosts)
+			postsAuth.GET("/bookmarks", postHandler.GetBookmarks)
+			postsAuth.GET("/bookmarks/collections", postHandler.GetBookmarkCollections)
+			postsAuth.POST("/bookmarks/collections", postHandler.CreateBookmarkCollection)
+			postsAuth.PUT("/bookmarks/collections/:id", postHandler.RenameBookmarkCollection)
+			postsAuth.DELETE("/bookmarks/collections/:id", postHandler.DeleteBookmarkCollection)
+			postsAuth.GET("/scheduled", postHandler.GetPendingPosts)
+			postsAuth.PUT("/scheduled/:id", postHandler.UpdatePendingPost)
+			postsAuth.DELETE("/scheduled/:id", postHandler.CancelScheduledPost)
+			postsAuth.POST("/scheduled/:id/publish", postHandler.PublishPendingPost)
 			postsAuth.POST("/:id/comments", postHandler.CreateComment)
 			postsAuth.POST("/:id/repost", postHandler.CreateRepost)
+			postsAuth.DELETE("/:id/repost", postHandler.UndoRepost)
+			postsAuth.POST("/:id/quote", postHandler.QuotePost)
+			postsAuth.GET("/:id/impressions", postHandler.GetPostImpressions)
+			postsAuth.PUT("/:id/content-warning", postHandler.SetPostContentWarning)
+			postsAuth.DELETE("/:id/content-warning", postHandler.RemovePostContentWarning)
 			postsAuth.POST("/:id/like", postHandler.TogglePostLike)
+			postsAuth.PUT("/:id/reactions", postHandler.ReactToPost)
+			postsAuth.DELETE("/:id/reactions", postHandler.RemovePostReaction)
 			postsAuth.PUT("/:id/pin", postHandler.PinPost)
+			postsAuth.POST("/:id/bookmark", postHandler.AddBookmark)
+			postsAuth.PUT("/:id/bookmark", postHandler.MoveBookmark)
+			postsAuth.DELETE("/:id/bookmark", postHandler.RemoveBookmark)
+			postsAuth.POST("/:id/poll/vote", postHandler.VotePoll)
+			postsAuth.GET("/:id/poll/options/:option_id/voters", postHandler.GetPollOptionVoters)
 		}
 	}
 
@@ -49,8 +79,19 @@ func SetupPostRoutes(r *gin.RouterGroup, postHandler *handlers.PostHandler, toke
 	
 	comments := r.Group("/comments")
 	comments.Use(middleware.RateLimitMiddleware(rateLimitDefault))
-	comments.Use(middleware.AuthMiddleware(tokenMaker))
+	comments.Use(middleware.OptionalAuthMiddleware(tokenMaker))
 	{
-		comments.POST("/:id/like", postHandler.ToggleCommentLike)
+		comments.GET("/:id/replies", postHandler.GetCommentReplies)
+		comments.GET("/:id/reactions", postHandler.GetCommentReactions)
+
+		commentsAuth := comments.Group("")
+		commentsAuth.Use(middleware.AuthMiddleware(tokenMaker))
+		{
+			commentsAuth.PUT("/:id", postHandler.UpdateComment)
+			commentsAuth.DELETE("/:id", postHandler.DeleteComment)
+			commentsAuth.POST("/:id/like", postHandler.ToggleCommentLike)
+			commentsAuth.PUT("/:id/reactions", postHandler.ReactToComment)
+			commentsAuth.DELETE("/:id/reactions", postHandler.RemoveCommentReaction)
+		}
 	}
 }
@@ -18,9 +18,11 @@ import (
 	"github.com/connect-univyn/connect-server/internal/service/events"
 	"github.com/connect-univyn/connect-server/internal/service/groups"
 	"github.com/connect-univyn/connect-server/internal/service/mentorship"
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
 	"github.com/connect-univyn/connect-server/internal/service/messaging"
 	"github.com/connect-univyn/connect-server/internal/service/notifications"
 	"github.com/connect-univyn/connect-server/internal/service/posts"
+	"github.com/connect-univyn/connect-server/internal/service/search"
 	"github.com/connect-univyn/connect-server/internal/service/sessions"
 	"github.com/connect-univyn/connect-server/internal/service/spaces"
 	"github.com/connect-univyn/connect-server/internal/service/users"
@@ -57,6 +59,7 @@ func SetupRouter(
 	
 	var liveService *live.Service
 	var wsHandler *websocket.Handler
+	var wsManager *websocket.Manager
 	if config.LiveEnabled {
 		log.Info().Msg("Initializing live real-time features")
 
@@ -82,7 +85,7 @@ func SetupRouter(
 
 		
 		ctx := context.Background()
-		wsManager := websocket.NewManager(ctx, bus)
+		wsManager = websocket.NewManager(ctx, bus)
 
 		
 		liveService = live.NewService(bus)
@@ -107,19 +110,54 @@ func SetupRouter(
 	api := router.Group("/api")
 	{
 		
-		userService := users.NewService(store)
-		postService := posts.NewService(store, liveService)
+		notificationService := notifications.NewService(store, liveService)
+		userService := users.NewService(store, liveService, notificationService)
+		mentionService := mentions.NewService(store, notificationService)
+		postService := posts.NewService(store, liveService, mentionService)
 		sessionService := sessions.NewService(store)
 		spaceService := spaces.NewService(store)
 		communityService := communities.NewService(store)
 		groupService := groups.NewService(store)
-		messagingService := messaging.NewService(store, liveService)
-		notificationService := notifications.NewService(store, liveService)
+		messagingService := messaging.NewService(store, liveService, mentionService, notificationService)
 		eventService := events.NewService(store)
 		announcementService := announcements.NewService(store)
 		mentorshipService := mentorship.NewService(store)
 		analyticsService := analytics.NewService(store)
 		adminService := admin.NewService(store)
+		searchService := search.NewService(store)
+
+		if wsManager != nil {
+			wsManager.SetAudienceFilter(userService)
+		}
+
+		
+		if config.ScheduledPostInterval > 0 {
+			go postService.RunScheduledPublisher(context.Background(), config.ScheduledPostInterval)
+		}
+		if config.TrendingTopicsInterval > 0 {
+			go postService.RunTrendingTopicsJob(context.Background(), config.TrendingTopicsInterval)
+		}
+
+		
+		if config.RedisURL != "" && !config.LiveUseMemoryBroker {
+			viewBuffer, err := posts.NewRedisViewBuffer(config.RedisURL)
+			if err != nil {
+				log.Fatal().Err(err).Msg("Failed to initialize Redis view buffer")
+			}
+			postService.SetViewBuffer(viewBuffer, config.ViewDedupeWindow)
+		} else {
+			postService.SetViewBuffer(posts.NewMemoryViewBuffer(), config.ViewDedupeWindow)
+		}
+		if config.ViewFlushInterval > 0 {
+			go postService.RunViewFlusher(context.Background(), config.ViewFlushInterval)
+		}
+		messagingService.SetEditWindow(config.MessageEditWindow)
+		if config.MessageReaperInterval > 0 {
+			go messagingService.RunMessageReaper(context.Background(), config.MessageReaperInterval)
+		}
+		if config.ExportReaperInterval > 0 {
+			go messagingService.RunExportReaper(context.Background(), config.ExportReaperInterval)
+		}
 
 		
 		userHandler := handlers.NewUserHandler(userService)
@@ -137,12 +175,14 @@ func SetupRouter(
 		groupHandler := handlers.NewGroupHandler(groupService)
 		messagingHandler := handlers.NewMessagingHandler(messagingService)
 		notificationHandler := handlers.NewNotificationHandler(notificationService)
+		mentionHandler := handlers.NewMentionHandler(mentionService)
 		eventHandler := handlers.NewEventHandler(eventService)
 		announcementHandler := handlers.NewAnnouncementHandler(announcementService)
 		mentorshipHandler := handlers.NewMentorshipHandler(mentorshipService)
 		analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
 		metricsHandler := handlers.NewMetricsHandler(liveService)
 		adminHandler := handlers.NewAdminHandler(adminService)
+		searchHandler := handlers.NewSearchHandler(searchService)
 
 		
 		SetupUserRoutes(api, userHandler, tokenMaker)
@@ -154,11 +194,13 @@ func SetupRouter(
 		SetupGroupRoutes(api, groupHandler, tokenMaker, config.RateLimitDefault)
 		SetupMessagingRoutes(api, messagingHandler, tokenMaker, config.RateLimitDefault)
 		SetupNotificationRoutes(api, notificationHandler, tokenMaker, config.RateLimitDefault)
+		SetupMentionRoutes(api, mentionHandler, tokenMaker, config.RateLimitDefault)
 		SetupEventRoutes(api, eventHandler, tokenMaker, config.RateLimitDefault)
 		SetupAnnouncementRoutes(api, announcementHandler, tokenMaker, config.RateLimitDefault)
 		SetupMentorshipRoutes(api, mentorshipHandler, tokenMaker, config.RateLimitDefault)
 		SetupAnalyticsRoutes(api, analyticsHandler, tokenMaker, config.RateLimitDefault)
 		SetupAdminRoutes(api, adminHandler, tokenMaker)
+		SetupSearchRoutes(api, searchHandler, tokenMaker, config.RateLimitDefault)
 
 		
 		if config.LiveEnabled && wsHandler != nil {
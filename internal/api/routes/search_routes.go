@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/connect-univyn/connect-server/internal/api/handlers"
+	"github.com/connect-univyn/connect-server/internal/api/middleware"
+	"github.com/connect-univyn/connect-server/internal/util/auth"
+	"github.com/gin-gonic/gin"
+)
+
+
+func SetupSearchRoutes(r *gin.RouterGroup, searchHandler *handlers.SearchHandler, tokenMaker auth.Maker, rateLimitDefault int) {
+	search := r.Group("/search")
+	search.Use(middleware.RateLimitMiddleware(rateLimitDefault))
+	search.Use(middleware.OptionalAuthMiddleware(tokenMaker))
+	{
+		search.GET("", searchHandler.Search)
+	}
+}
@@ -34,6 +34,24 @@ func SetupUserRoutes(router *gin.RouterGroup, userHandler *handlers.UserHandler,
 			authUsers.GET("/:id/following", userHandler.GetFollowing)      
 
 			
+			authUsers.GET("/blocked", userHandler.GetBlockedUsers)         
+			authUsers.GET("/muted", userHandler.GetMutedUsers)             
+			authUsers.POST("/:id/block", userHandler.BlockUser)            
+			authUsers.DELETE("/:id/block", userHandler.UnblockUser)        
+			authUsers.POST("/:id/mute", userHandler.MuteUser)              
+			authUsers.DELETE("/:id/mute", userHandler.UnmuteUser)          
+			authUsers.GET("/:id/relationship", userHandler.GetRelationshipStatus) 
+
+			
+			authUsers.PUT("/privacy", userHandler.UpdatePrivacy)                          
+			authUsers.PUT("/content-warnings", userHandler.UpdateContentWarningPreference) 
+			authUsers.PUT("/message-requests", userHandler.UpdateMessageRequestPolicy)     
+			authUsers.GET("/follow-requests", userHandler.GetFollowRequests)              
+			authUsers.GET("/follow-requests/sent", userHandler.GetSentFollowRequests)     
+			authUsers.POST("/follow-requests/:id/approve", userHandler.ApproveFollowRequest) 
+			authUsers.POST("/follow-requests/:id/reject", userHandler.RejectFollowRequest)   
+
+			
 		}
 	}
 }
\ No newline at end of file
@@ -15,6 +15,7 @@ type Event struct {
 	Payload   map[string]interface{} `json:"payload"`    
 	Timestamp time.Time              `json:"timestamp"`  
 	UserID    *uuid.UUID             `json:"user_id"`    
+	ActorID   *uuid.UUID             `json:"actor_id"`   
 	SpaceID   *uuid.UUID             `json:"space_id"`   
 	Metadata  map[string]string      `json:"metadata"`   
 }
@@ -50,7 +51,10 @@ const (
 	EventTypeMessageCreated  = "message.created"
 	EventTypeMessageDelivered = "message.delivered"
 	EventTypeMessageRead     = "message.read"
+	EventTypeMessageUpdated  = "message.updated"
 	EventTypeMessageDeleted  = "message.deleted"
+	EventTypeMessagePinned   = "message.pinned"
+	EventTypeMessageUnpinned = "message.unpinned"
 	EventTypeTypingStarted   = "typing.started"
 	EventTypeTypingStopped   = "typing.stopped"
 
@@ -65,6 +69,8 @@ const (
 	EventTypePostDeleted  = "post.deleted"
 	EventTypePostLiked    = "post.liked"
 	EventTypePostUnliked  = "post.unliked"
+	EventTypePostReacted  = "post.reacted"
+	EventTypePollUpdated  = "poll.updated"
 
 	
 	EventTypeCommentCreated = "comment.created"
@@ -90,6 +96,9 @@ const (
 	EventTypeUserOnline  = "user.online"
 	EventTypeUserOffline = "user.offline"
 	EventTypeUserIdle    = "user.idle"
+
+	
+	EventTypeAudienceChanged = "audience.changed"
 )
 
 
@@ -111,6 +120,12 @@ func (e *Event) WithUserID(userID uuid.UUID) *Event {
 }
 
 
+func (e *Event) WithActorID(actorID uuid.UUID) *Event {
+	e.ActorID = &actorID
+	return e
+}
+
+
 func (e *Event) WithSpaceID(spaceID uuid.UUID) *Event {
 	e.SpaceID = &spaceID
 	return e
@@ -47,7 +47,18 @@ func (s *Service) PublishMessageCreated(ctx context.Context, conversationID, sen
 		eventbus.EventTypeMessageCreated,
 		eventbus.Channel.Conversation(conversationID),
 		message,
-	).WithUserID(senderID)
+	).WithActorID(senderID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishMessageUpdated(ctx context.Context, conversationID, editorID uuid.UUID, message map[string]interface{}) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeMessageUpdated,
+		eventbus.Channel.Conversation(conversationID),
+		message,
+	).WithActorID(editorID)
 
 	return s.bus.Publish(ctx, event)
 }
@@ -61,7 +72,7 @@ func (s *Service) PublishMessageDelivered(ctx context.Context, conversationID, m
 			"message_id":   messageID.String(),
 			"recipient_id": recipientID.String(),
 		},
-	).WithUserID(recipientID)
+	).WithActorID(recipientID)
 
 	return s.bus.Publish(ctx, event)
 }
@@ -80,7 +91,47 @@ func (s *Service) PublishMessageRead(ctx context.Context, conversationID uuid.UU
 			"message_ids": msgIDStrs,
 			"user_id":     userID.String(),
 		},
-	).WithUserID(userID)
+	).WithActorID(userID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishMessageDeleted(ctx context.Context, conversationID, messageID uuid.UUID, reason string) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeMessageDeleted,
+		eventbus.Channel.Conversation(conversationID),
+		map[string]interface{}{
+			"id":              messageID.String(),
+			"conversation_id": conversationID.String(),
+			"reason":          reason,
+		},
+	)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishMessagePinned(ctx context.Context, conversationID, userID uuid.UUID, pin map[string]interface{}) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeMessagePinned,
+		eventbus.Channel.Conversation(conversationID),
+		pin,
+	).WithActorID(userID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishMessageUnpinned(ctx context.Context, conversationID, messageID, userID uuid.UUID) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeMessageUnpinned,
+		eventbus.Channel.Conversation(conversationID),
+		map[string]interface{}{
+			"message_id":  messageID.String(),
+			"unpinned_by": userID.String(),
+		},
+	).WithActorID(userID)
 
 	return s.bus.Publish(ctx, event)
 }
@@ -130,7 +181,7 @@ func (s *Service) PublishPostCreated(ctx context.Context, spaceID, authorID uuid
 		eventbus.EventTypePostCreated,
 		eventbus.Channel.Space(spaceID),
 		post,
-	).WithUserID(authorID).WithSpaceID(spaceID)
+	).WithActorID(authorID).WithSpaceID(spaceID)
 
 	return s.bus.Publish(ctx, event)
 }
@@ -141,14 +192,14 @@ func (s *Service) PublishPostUpdated(ctx context.Context, spaceID, postID, autho
 		eventbus.EventTypePostUpdated,
 		eventbus.Channel.Post(postID),
 		updates,
-	).WithUserID(authorID).WithSpaceID(spaceID)
+	).WithActorID(authorID).WithSpaceID(spaceID)
 
 	
 	spaceEvent := eventbus.NewEvent(
 		eventbus.EventTypePostUpdated,
 		eventbus.Channel.Space(spaceID),
 		updates,
-	).WithUserID(authorID).WithSpaceID(spaceID)
+	).WithActorID(authorID).WithSpaceID(spaceID)
 
 	if err := s.bus.Publish(ctx, event); err != nil {
 		return err
@@ -166,7 +217,32 @@ func (s *Service) PublishPostLiked(ctx context.Context, postID, userID, spaceID
 			"user_id":    userID.String(),
 			"like_count": likeCount,
 		},
-	).WithUserID(userID).WithSpaceID(spaceID)
+	).WithActorID(userID).WithSpaceID(spaceID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishPostReacted(ctx context.Context, postID, userID, spaceID uuid.UUID, reactions map[string]interface{}) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypePostReacted,
+		eventbus.Channel.Post(postID),
+		reactions,
+	).WithActorID(userID).WithSpaceID(spaceID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishPollUpdated(ctx context.Context, postID, pollID, voterID uuid.UUID) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypePollUpdated,
+		eventbus.Channel.Post(postID),
+		map[string]interface{}{
+			"post_id": postID.String(),
+			"poll_id": pollID.String(),
+		},
+	).WithActorID(voterID)
 
 	return s.bus.Publish(ctx, event)
 }
@@ -177,7 +253,32 @@ func (s *Service) PublishCommentCreated(ctx context.Context, postID, authorID uu
 		eventbus.EventTypeCommentCreated,
 		eventbus.Channel.Post(postID),
 		comment,
-	).WithUserID(authorID)
+	).WithActorID(authorID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishCommentUpdated(ctx context.Context, postID, authorID uuid.UUID, comment map[string]interface{}) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeCommentUpdated,
+		eventbus.Channel.Post(postID),
+		comment,
+	).WithActorID(authorID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
+func (s *Service) PublishCommentDeleted(ctx context.Context, postID, commentID, authorID uuid.UUID) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeCommentDeleted,
+		eventbus.Channel.Post(postID),
+		map[string]interface{}{
+			"id":      commentID.String(),
+			"post_id": postID.String(),
+		},
+	).WithActorID(authorID)
 
 	return s.bus.Publish(ctx, event)
 }
@@ -240,6 +341,17 @@ func (s *Service) PublishUserOffline(ctx context.Context, userID uuid.UUID) erro
 }
 
 
+func (s *Service) PublishAudienceChanged(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	event := eventbus.NewEvent(
+		eventbus.EventTypeAudienceChanged,
+		eventbus.Channel.User(userID),
+		map[string]interface{}{},
+	).WithActorID(userID).WithUserID(otherUserID)
+
+	return s.bus.Publish(ctx, event)
+}
+
+
 func (s *Service) PublishLessonPublished(ctx context.Context, spaceID uuid.UUID, lesson map[string]interface{}) error {
 	event := eventbus.NewEvent(
 		eventbus.EventTypeLessonPublished,
@@ -26,6 +26,7 @@ func NewManager(ctx context.Context, bus eventbus.EventBus) *Manager {
 		ctx:        managerCtx,
 		cancel:     cancel,
 		metrics:    &Metrics{StartTime: time.Now()},
+		audience:   make(map[uuid.UUID]cachedAudience),
 	}
 
 	
@@ -65,6 +66,7 @@ func (m *Manager) run() {
 		case <-ticker.C:
 			
 			m.cleanupIdleClients()
+			m.pruneAudienceCache()
 		}
 	}
 }
@@ -241,6 +243,10 @@ func (m *Manager) broadcastMessage(broadcast *BroadcastMessage) {
 
 	sent := 0
 	for _, client := range targetClients {
+		if broadcast.ExcludedUserIDs[client.UserID] {
+			continue
+		}
+
 		
 		client.SubscriptionsMu.RLock()
 		subscribed := client.Subscriptions[broadcast.Channel] || broadcast.Channel == ""
@@ -288,9 +294,21 @@ func (m *Manager) listenToEventBus(bus eventbus.EventBus) {
 				return
 			}
 
+			if event.Type == eventbus.EventTypeAudienceChanged {
+				m.invalidateAudience(event.ActorID, event.UserID)
+				continue
+			}
+
+			excluded, err := m.hiddenAudience(event)
+			if err != nil {
+				log.Error().Err(err).Str("event_id", event.ID).Str("actor_id", event.ActorID.String()).Msg("Dropping event, failed to resolve hidden audience")
+				continue
+			}
+
 			
 			serverMsg := ServerMessage{
 				Type:      MessageTypeEvent,
+				Event:     event.Type,
 				Channel:   event.Channel,
 				Payload:   event.Payload,
 				ID:        event.ID,
@@ -304,9 +322,10 @@ func (m *Manager) listenToEventBus(bus eventbus.EventBus) {
 			}
 
 			m.broadcast <- &BroadcastMessage{
-				UserIDs: userIDs,
-				Channel: event.Channel,
-				Message: serverMsg,
+				UserIDs:         userIDs,
+				ExcludedUserIDs: excluded,
+				Channel:         event.Channel,
+				Message:         serverMsg,
 			}
 		}
 	}
@@ -337,6 +356,72 @@ func (m *Manager) cleanupIdleClients() {
 }
 
 
+func (m *Manager) hiddenAudience(event *eventbus.Event) (map[uuid.UUID]bool, error) {
+	if event.ActorID == nil {
+		return nil, nil
+	}
+
+	m.filterMu.RLock()
+	filter := m.filter
+	m.filterMu.RUnlock()
+	if filter == nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	m.audienceMu.Lock()
+	cached, ok := m.audience[*event.ActorID]
+	m.audienceMu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.hidden, nil
+	}
+
+	hidden, err := filter.HiddenAudience(m.ctx, *event.ActorID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.audienceMu.Lock()
+	m.audience[*event.ActorID] = cachedAudience{hidden: hidden, expiresAt: now.Add(AudienceCacheTTL)}
+	m.audienceMu.Unlock()
+	return hidden, nil
+}
+
+
+func (m *Manager) invalidateAudience(userIDs ...*uuid.UUID) {
+	m.audienceMu.Lock()
+	defer m.audienceMu.Unlock()
+	for _, id := range userIDs {
+		if id != nil {
+			delete(m.audience, *id)
+		}
+	}
+}
+
+
+func (m *Manager) pruneAudienceCache() {
+	now := time.Now()
+	m.audienceMu.Lock()
+	defer m.audienceMu.Unlock()
+	for id, cached := range m.audience {
+		if !now.Before(cached.expiresAt) {
+			delete(m.audience, id)
+		}
+	}
+}
+
+
+func (m *Manager) SetAudienceFilter(filter AudienceFilter) {
+	m.filterMu.Lock()
+	m.filter = filter
+	m.filterMu.Unlock()
+
+	m.audienceMu.Lock()
+	m.audience = make(map[uuid.UUID]cachedAudience)
+	m.audienceMu.Unlock()
+}
+
+
 func (m *Manager) Register(client *Client) {
 	m.register <- client
 }
@@ -38,6 +38,7 @@ type ClientMessage struct {
 
 type ServerMessage struct {
 	Type      string                 `json:"type"`      
+	Event     string                 `json:"event,omitempty"`
 	Channel   string                 `json:"channel"`   
 	Payload   map[string]interface{} `json:"payload"`   
 	ID        string                 `json:"id"`        
@@ -90,6 +91,9 @@ const (
 
 	
 	MaxConnectionsPerIP = 100
+
+	
+	AudienceCacheTTL = 5 * time.Minute
 )
 
 
@@ -106,13 +110,29 @@ type Manager struct {
 	ctx        context.Context         
 	cancel     context.CancelFunc      
 	metrics    *Metrics                
+	filter     AudienceFilter          
+	filterMu   sync.RWMutex            
+	audience   map[uuid.UUID]cachedAudience
+	audienceMu sync.Mutex
+}
+
+
+type cachedAudience struct {
+	hidden    map[uuid.UUID]bool
+	expiresAt time.Time
+}
+
+
+type AudienceFilter interface {
+	HiddenAudience(ctx context.Context, actorID uuid.UUID) (map[uuid.UUID]bool, error)
 }
 
 
 type BroadcastMessage struct {
-	UserIDs []uuid.UUID 
-	Channel string      
-	Message ServerMessage
+	UserIDs         []uuid.UUID        
+	ExcludedUserIDs map[uuid.UUID]bool 
+	Channel         string             
+	Message         ServerMessage
 }
 
 
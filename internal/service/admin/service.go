@@ -772,9 +772,9 @@ func (s *Service) GetNotifications(ctx context.Context, userID uuid.UUID, typeFi
 	
 	
 	notifications, err := s.store.GetUserNotifications(ctx, db.GetUserNotificationsParams{
-		ToUserID: userID,
-		Limit:    limit,
-		Offset:   offset,
+		ToUserID:   userID,
+		PageSize:   limit,
+		PageOffset: offset,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notifications: %w", err)
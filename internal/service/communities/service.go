@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
@@ -159,13 +160,41 @@ func (s *Service) UpdateCommunity(ctx context.Context, communityID uuid.UUID, re
 }
 
 
-func (s *Service) GetCommunityMembers(ctx context.Context, communityID uuid.UUID) ([]CommunityMemberResponse, error) {
-	members, err := s.store.GetCommunityMembers(ctx, communityID)
+func (s *Service) GetCommunityMembers(ctx context.Context, communityID uuid.UUID, page util.PageRequest) (*util.Page[CommunityMemberResponse], error) {
+	params := db.GetCommunityMembersParams{
+		CommunityID:     communityID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+	}
+	if page.Keyset {
+		params.PageSize = sql.NullInt32{Int32: page.PageSize(), Valid: true}
+	}
+
+	members, err := s.store.GetCommunityMembers(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get community members: %w", err)
 	}
 
-	return s.toCommunityMemberResponses(members), nil
+	hasMore := false
+	if page.Keyset {
+		members, hasMore = util.TrimPage(members, page)
+	}
+
+	result := util.NewPage(s.toCommunityMemberResponses(members), page, hasMore)
+	if hasMore {
+		last := members[len(members)-1]
+		result.SetNextCursor(last.JoinedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountCommunityMembers(ctx, communityID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count community members: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
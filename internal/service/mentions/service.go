@@ -0,0 +1,303 @@
+package mentions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/service/notifications"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 30
+	maxMentions       = 20
+)
+
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}_.]+)`)
+
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+
+type Service struct {
+	store               db.Store
+	notificationService *notifications.Service
+}
+
+
+func NewService(store db.Store, notificationService *notifications.Service) *Service {
+	return &Service{
+		store:               store,
+		notificationService: notificationService,
+	}
+}
+
+
+func Parse(content string) []ParsedMention {
+	var parsed []ParsedMention
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(content, -1) {
+		username := strings.TrimRight(content[match[2]:match[3]], ".")
+		length := utf8.RuneCountInString(username)
+		if length < minUsernameLength || length > maxUsernameLength {
+			continue
+		}
+
+		parsed = append(parsed, ParsedMention{
+			Username: username,
+			Offset:   utf16Len(content[:match[2]-1]),
+			Length:   utf16Len(username) + 1,
+		})
+		if len(parsed) == maxMentions {
+			break
+		}
+	}
+	return parsed
+}
+
+
+func (s *Service) Record(ctx context.Context, req RecordMentionsRequest) ([]MentionEntity, error) {
+	previous, err := s.Load(ctx, req.SourceType, []uuid.UUID{req.SourceID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.DeleteMentionsBySource(ctx, db.DeleteMentionsBySourceParams{
+		SourceType: req.SourceType,
+		SourceID:   req.SourceID,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to clear mentions: %w", err)
+	}
+
+	parsed := Parse(req.Content)
+	if len(parsed) == 0 {
+		return []MentionEntity{}, nil
+	}
+
+	usernames := make([]string, 0, len(parsed))
+	for _, p := range parsed {
+		usernames = append(usernames, strings.ToLower(p.Username))
+	}
+
+	var conversationID uuid.NullUUID
+	if req.ConversationID != nil {
+		conversationID = uuid.NullUUID{UUID: *req.ConversationID, Valid: true}
+	}
+
+	var postID uuid.NullUUID
+	if req.PostID != nil {
+		postID = uuid.NullUUID{UUID: *req.PostID, Valid: true}
+	}
+
+	users, err := s.store.ResolveMentionedUsers(ctx, db.ResolveMentionedUsersParams{
+		AuthorID:       req.AuthorID,
+		Usernames:      usernames,
+		ConversationID: conversationID,
+		PostID:         postID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mentioned users: %w", err)
+	}
+
+	byUsername := make(map[string]db.ResolveMentionedUsersRow, len(users))
+	for _, u := range users {
+		byUsername[strings.ToLower(u.Username)] = u
+	}
+
+	entities := []MentionEntity{}
+	params := db.CreateMentionsParams{
+		SourceType: req.SourceType,
+		SourceID:   req.SourceID,
+		AuthorID:   req.AuthorID,
+	}
+	for _, p := range parsed {
+		u, ok := byUsername[strings.ToLower(p.Username)]
+		if !ok {
+			continue
+		}
+		entities = append(entities, MentionEntity{
+			UserID:   u.ID,
+			Username: u.Username,
+			Offset:   int32(p.Offset),
+			Length:   int32(p.Length),
+		})
+		params.UserIds = append(params.UserIds, u.ID)
+		params.Offsets = append(params.Offsets, int32(p.Offset))
+		params.Lengths = append(params.Lengths, int32(p.Length))
+	}
+
+	if len(entities) == 0 {
+		return entities, nil
+	}
+
+	if _, err := s.store.CreateMentions(ctx, params); err != nil {
+		return nil, fmt.Errorf("failed to store mentions: %w", err)
+	}
+
+	
+	alreadyNotified := make(map[uuid.UUID]bool)
+	for _, entity := range previous[req.SourceID] {
+		alreadyNotified[entity.UserID] = true
+	}
+	alreadyNotified[req.AuthorID] = true
+
+	for _, entity := range entities {
+		if alreadyNotified[entity.UserID] {
+			continue
+		}
+		alreadyNotified[entity.UserID] = true
+
+		user := byUsername[strings.ToLower(entity.Username)]
+		if !user.CanViewPost || !allowsMentionFrom(user) {
+			continue
+		}
+		s.notify(ctx, req, entity)
+	}
+
+	return entities, nil
+}
+
+
+func (s *Service) Clear(ctx context.Context, sourceType string, sourceID uuid.UUID) error {
+	if err := s.store.DeleteMentionsBySource(ctx, db.DeleteMentionsBySourceParams{
+		SourceType: sourceType,
+		SourceID:   sourceID,
+	}); err != nil {
+		return fmt.Errorf("failed to clear mentions: %w", err)
+	}
+	return nil
+}
+
+
+func (s *Service) Load(ctx context.Context, sourceType string, sourceIDs []uuid.UUID) (map[uuid.UUID][]MentionEntity, error) {
+	result := make(map[uuid.UUID][]MentionEntity)
+	if len(sourceIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := s.store.GetMentionsBySources(ctx, db.GetMentionsBySourcesParams{
+		SourceType: sourceType,
+		SourceIds:  sourceIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentions: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.SourceID] = append(result[row.SourceID], MentionEntity{
+			UserID:   row.MentionedUserID,
+			Username: row.Username,
+			Offset:   row.StartOffset,
+			Length:   row.Length,
+		})
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) Suggest(ctx context.Context, userID uuid.UUID, prefix string, limit int32) ([]*MentionSuggestion, error) {
+	prefix = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(prefix), "@"))
+	if prefix == "" {
+		return []*MentionSuggestion{}, nil
+	}
+
+	candidates, err := s.store.SearchMentionCandidates(ctx, db.SearchMentionCandidatesParams{
+		UserID:    userID,
+		Prefix:    likeEscaper.Replace(prefix),
+		PageLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search mention candidates: %w", err)
+	}
+
+	suggestions := make([]*MentionSuggestion, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = &MentionSuggestion{
+			ID:          c.ID,
+			Username:    c.Username,
+			FullName:    c.FullName,
+			IsFollowing: c.IsFollowing,
+		}
+		if c.Avatar.Valid {
+			suggestions[i].Avatar = &c.Avatar.String
+		}
+	}
+
+	return suggestions, nil
+}
+
+
+func (s *Service) notify(ctx context.Context, req RecordMentionsRequest, entity MentionEntity) {
+	if s.notificationService == nil {
+		return
+	}
+
+	metadata := map[string]interface{}{
+		"source_type": req.SourceType,
+		"source_id":   req.SourceID.String(),
+		"offset":      entity.Offset,
+		"length":      entity.Length,
+	}
+	if req.PostID != nil {
+		metadata["post_id"] = req.PostID.String()
+	}
+	if req.ConversationID != nil {
+		metadata["conversation_id"] = req.ConversationID.String()
+	}
+
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode mention metadata")
+		return
+	}
+
+	title := "New mention"
+	message := fmt.Sprintf("You were mentioned in a %s", req.SourceType)
+	authorID := req.AuthorID
+	sourceID := req.SourceID
+
+	if _, err := s.notificationService.CreateNotification(ctx, notifications.CreateNotificationRequest{
+		ToUserID:   entity.UserID,
+		FromUserID: &authorID,
+		Type:       "mention",
+		Title:      &title,
+		Message:    &message,
+		RelatedID:  &sourceID,
+		Metadata:   &pqtype.NullRawMessage{RawMessage: raw, Valid: true},
+	}); err != nil && !errors.Is(err, notifications.ErrNotificationSuppressed) {
+		log.Error().Err(err).Str("user_id", entity.UserID.String()).Msg("Failed to create mention notification")
+	}
+}
+
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+
+func allowsMentionFrom(user db.ResolveMentionedUsersRow) bool {
+	switch user.AllowMentionsFrom {
+	case "none":
+		return false
+	case "following":
+		return user.FollowsAuthor
+	default:
+		return true
+	}
+}
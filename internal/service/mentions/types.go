@@ -0,0 +1,46 @@
+package mentions
+
+import (
+	"github.com/google/uuid"
+)
+
+
+const (
+	SourcePost    = "post"
+	SourceComment = "comment"
+	SourceMessage = "message"
+)
+
+
+type ParsedMention struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+
+type RecordMentionsRequest struct {
+	SourceType     string
+	SourceID       uuid.UUID
+	AuthorID       uuid.UUID
+	Content        string
+	PostID         *uuid.UUID
+	ConversationID *uuid.UUID
+}
+
+
+type MentionEntity struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Offset   int32     `json:"offset"`
+	Length   int32     `json:"length"`
+}
+
+
+type MentionSuggestion struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	FullName    string    `json:"full_name"`
+	Avatar      *string   `json:"avatar,omitempty"`
+	IsFollowing bool      `json:"is_following"`
+}
@@ -0,0 +1,155 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+const (
+	minDisappearingSeconds  = 60
+	maxDisappearingSeconds  = 90 * 24 * 60 * 60
+	expiredMessageBatchSize = 500
+)
+
+
+func (s *Service) SetDisappearingMessages(ctx context.Context, conversationID, userID uuid.UUID, req DisappearingMessagesRequest) error {
+	seconds := *req.Seconds
+	if seconds != 0 && (seconds < minDisappearingSeconds || seconds > maxDisappearingSeconds) {
+		return fmt.Errorf("%w: timer must be between 1 minute and 90 days", util.ErrBadRequest)
+	}
+	if err := s.requireModerator(ctx, conversationID, userID); err != nil {
+		return err
+	}
+
+	conversation, err := s.store.GetConversationByID(ctx, db.GetConversationByIDParams{
+		UserID: userID,
+		ID:     conversationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: conversation not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+	if disappearingTimer(conversation.Settings) == seconds {
+		return nil
+	}
+
+	rows, err := s.store.SetDisappearingMessagesTimer(ctx, db.SetDisappearingMessagesTimerParams{
+		Seconds:        seconds,
+		ConversationID: conversationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update disappearing messages: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: conversation not found", util.ErrNotFound)
+	}
+
+	content := fmt.Sprintf("%s turned off disappearing messages", s.displayName(ctx, userID))
+	if seconds > 0 {
+		content = fmt.Sprintf("%s set disappearing messages to %s", s.displayName(ctx, userID), formatTimer(seconds))
+	}
+	s.postSystemMessage(ctx, conversationID, userID, content)
+
+	return nil
+}
+
+
+func (s *Service) DeleteExpiredMessages(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		expired, err := s.store.DeleteExpiredMessages(ctx, expiredMessageBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete expired messages: %w", err)
+		}
+
+		if s.liveService != nil {
+			for _, message := range expired {
+				if err := s.liveService.PublishMessageDeleted(ctx, message.ConversationID, message.ID, "expired"); err != nil {
+					log.Error().Err(err).Msg("Failed to publish message.deleted event")
+				}
+			}
+		}
+
+		total += len(expired)
+		if len(expired) < expiredMessageBatchSize {
+			return total, nil
+		}
+	}
+}
+
+
+func (s *Service) RunMessageReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := s.DeleteExpiredMessages(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Expired message reaper failed")
+			} else if deleted > 0 {
+				log.Info().Int("count", deleted).Msg("Deleted expired messages")
+			}
+		}
+	}
+}
+
+
+func disappearingTimer(settings pqtype.NullRawMessage) int32 {
+	if !settings.Valid {
+		return 0
+	}
+
+	var parsed struct {
+		DisappearingMessagesSeconds int32 `json:"disappearing_messages_seconds"`
+	}
+	if err := json.Unmarshal(settings.RawMessage, &parsed); err != nil {
+		return 0
+	}
+
+	return parsed.DisappearingMessagesSeconds
+}
+
+
+func formatTimer(seconds int32) string {
+	units := []struct {
+		size int32
+		name string
+	}{
+		{24 * 60 * 60, "day"},
+		{60 * 60, "hour"},
+		{60, "minute"},
+	}
+	for _, unit := range units {
+		if seconds%unit.size == 0 {
+			return plural(seconds/unit.size, unit.name)
+		}
+	}
+
+	return plural(seconds, "second")
+}
+
+
+func plural(n int32, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+
+	return fmt.Sprintf("%d %ss", n, unit)
+}
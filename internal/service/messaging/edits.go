@@ -0,0 +1,189 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const defaultEditWindow = 15 * time.Minute
+
+
+func (s *Service) SetEditWindow(window time.Duration) {
+	if window > 0 {
+		s.editWindow = window
+	}
+}
+
+
+func (s *Service) EditMessage(ctx context.Context, messageID, editorID uuid.UUID, req EditMessageRequest) (*MessageResponse, error) {
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("%w: message content cannot be empty", util.ErrBadRequest)
+	}
+
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if message.Status.Valid && message.Status.String == "deleted" {
+		return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+	}
+	if message.MessageType.Valid && message.MessageType.String == "system" {
+		return nil, fmt.Errorf("%w: system messages cannot be edited", util.ErrForbidden)
+	}
+
+	if err := s.checkEditPermission(ctx, message, editorID); err != nil {
+		return nil, err
+	}
+
+	if message.Content.Valid && message.Content.String == content {
+		return s.toMessageResponse(message), nil
+	}
+
+	updated, err := s.store.EditMessage(ctx, db.EditMessageParams{
+		ID:       messageID,
+		EditedBy: editorID,
+		Content:  sql.NullString{String: content, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	response := s.toBasicMessageResponse(updated)
+	if messageDetail, err := s.store.GetMessageByID(ctx, messageID); err == nil {
+		response = s.toMessageResponse(messageDetail)
+	}
+
+	if s.mentionService != nil {
+		conversationID := updated.ConversationID
+		entities, err := s.mentionService.Record(ctx, mentions.RecordMentionsRequest{
+			SourceType:     mentions.SourceMessage,
+			SourceID:       updated.ID,
+			AuthorID:       updated.SenderID,
+			Content:        response.Content,
+			ConversationID: &conversationID,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("message_id", updated.ID.String()).Msg("Failed to record message mentions")
+		} else {
+			response.Mentions = entities
+		}
+	}
+
+	if s.liveService != nil {
+		messagePayload := map[string]interface{}{
+			"id":              updated.ID.String(),
+			"conversation_id": updated.ConversationID.String(),
+			"sender_id":       updated.SenderID.String(),
+			"edited_by":       editorID.String(),
+			"content":         response.Content,
+			"edited_at":       updated.EditedAt.Time.Unix(),
+		}
+		if len(response.Mentions) > 0 {
+			messagePayload["mentions"] = response.Mentions
+		}
+
+		if err := s.liveService.PublishMessageUpdated(ctx, updated.ConversationID, editorID, messagePayload); err != nil {
+			log.Error().Err(err).Msg("Failed to publish message.updated event")
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) GetMessageRevisions(ctx context.Context, messageID, userID uuid.UUID) ([]MessageRevisionResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if _, err := s.participantRole(ctx, message.ConversationID, userID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.store.GetMessageRevisions(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message revisions: %w", err)
+	}
+
+	response := make([]MessageRevisionResponse, len(revisions))
+	for i, r := range revisions {
+		response[i] = MessageRevisionResponse{
+			ID:             r.ID,
+			Content:        r.Content.String,
+			EditedBy:       r.EditedBy,
+			EditorUsername: r.EditorUsername,
+			CreatedAt:      r.CreatedAt,
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) checkEditPermission(ctx context.Context, message db.GetMessageByIDRow, editorID uuid.UUID) error {
+	role, err := s.participantRole(ctx, message.ConversationID, editorID)
+	if err != nil {
+		return err
+	}
+
+	if message.SenderID == editorID {
+		if message.CreatedAt.Valid && time.Since(message.CreatedAt.Time) > s.editWindow {
+			return fmt.Errorf("%w: the edit window for this message has expired", util.ErrForbidden)
+		}
+		return nil
+	}
+
+	switch role {
+	case RoleOwner:
+		return nil
+	case RoleAdmin:
+		senderRole, err := s.participantRole(ctx, message.ConversationID, message.SenderID)
+		if err != nil && !errors.Is(err, util.ErrForbidden) {
+			return err
+		}
+		if senderRole == RoleOwner || senderRole == RoleAdmin {
+			return fmt.Errorf("%w: only the owner can edit messages from admins", util.ErrForbidden)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: you can only edit your own messages", util.ErrForbidden)
+	}
+}
+
+
+func (s *Service) participantRole(ctx context.Context, conversationID, userID uuid.UUID) (string, error) {
+	role, err := s.store.GetConversationParticipantRole(ctx, db.GetConversationParticipantRoleParams{
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%w: not a participant of this conversation", util.ErrForbidden)
+		}
+		return "", fmt.Errorf("failed to get participant role: %w", err)
+	}
+
+	return role, nil
+}
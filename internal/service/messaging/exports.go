@@ -0,0 +1,558 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+	ExportFormatText = "txt"
+)
+
+
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+
+const (
+	exportStreamLimit = 5000
+	exportPageSize    = 500
+	exportRetention   = "7 days"
+	exportJobTimeout  = 10 * time.Minute
+)
+
+
+var exportContentTypes = map[string]string{
+	ExportFormatJSON: "application/json; charset=utf-8",
+	ExportFormatCSV:  "text/csv; charset=utf-8",
+	ExportFormatText: "text/plain; charset=utf-8",
+}
+
+
+func ExportContentType(format string) string {
+	return exportContentTypes[format]
+}
+
+
+func ExportFilename(conversationID uuid.UUID, format string) string {
+	return fmt.Sprintf("conversation-%s.%s", conversationID, format)
+}
+
+
+func (s *Service) RequestConversationExport(ctx context.Context, req ExportConversationRequest) (*ConversationExportResponse, error) {
+	if _, ok := exportContentTypes[req.Format]; !ok {
+		return nil, fmt.Errorf("%w: format must be one of json, csv or txt", util.ErrBadRequest)
+	}
+
+	if _, err := s.store.GetExportWindow(ctx, db.GetExportWindowParams{
+		ConversationID: req.ConversationID,
+		UserID:         req.UserID,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: not a participant of this conversation", util.ErrForbidden)
+		}
+		return nil, fmt.Errorf("failed to get participant: %w", err)
+	}
+
+	count, err := s.store.CountExportMessages(ctx, db.CountExportMessagesParams{
+		UserID:         req.UserID,
+		ConversationID: req.ConversationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count messages: %w", err)
+	}
+	background := count > exportStreamLimit
+
+	s.recordExportAudit(ctx, req, count, background)
+
+	if !background {
+		return nil, nil
+	}
+
+	export, err := s.store.CreateConversationExport(ctx, db.CreateConversationExportParams{
+		ConversationID: req.ConversationID,
+		RequestedBy:    req.UserID,
+		Format:         req.Format,
+		MessageCount:   count,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export: %w", err)
+	}
+
+	go s.runConversationExport(export)
+
+	return toConversationExportResponse(export), nil
+}
+
+
+func (s *Service) WriteConversationExport(ctx context.Context, conversationID, userID uuid.UUID, format string, w io.Writer) (int64, error) {
+	var transcript transcriptWriter
+	switch format {
+	case ExportFormatJSON:
+		transcript = &jsonTranscript{w: w, conversationID: conversationID}
+	case ExportFormatCSV:
+		transcript = &csvTranscript{w: csv.NewWriter(w)}
+	case ExportFormatText:
+		transcript = &textTranscript{w: w}
+	default:
+		return 0, fmt.Errorf("%w: format must be one of json, csv or txt", util.ErrBadRequest)
+	}
+
+	if err := transcript.begin(); err != nil {
+		return 0, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	var (
+		written  int64
+		cursorAt sql.NullTime
+		cursorID uuid.NullUUID
+	)
+	for {
+		rows, err := s.store.GetExportMessages(ctx, db.GetExportMessagesParams{
+			UserID:          userID,
+			ConversationID:  conversationID,
+			CursorCreatedAt: cursorAt,
+			CursorID:        cursorID,
+			PageSize:        exportPageSize,
+		})
+		if err != nil {
+			return written, fmt.Errorf("failed to get messages: %w", err)
+		}
+
+		for _, row := range rows {
+			if err := transcript.write(toExportedMessage(row)); err != nil {
+				return written, fmt.Errorf("failed to write export: %w", err)
+			}
+			written++
+		}
+		if flusher, ok := w.(interface{ Flush() }); ok {
+			flusher.Flush()
+		}
+
+		if len(rows) < exportPageSize {
+			break
+		}
+		last := rows[len(rows)-1]
+		cursorAt = last.CreatedAt
+		cursorID = uuid.NullUUID{UUID: last.ID, Valid: true}
+	}
+
+	if err := transcript.end(); err != nil {
+		return written, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	return written, nil
+}
+
+
+func (s *Service) GetConversationExport(ctx context.Context, exportID, userID uuid.UUID) (*ConversationExportResponse, error) {
+	export, err := s.getConversationExport(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toConversationExportResponse(export), nil
+}
+
+
+func (s *Service) DownloadConversationExport(ctx context.Context, exportID, userID uuid.UUID) (*ConversationExportFile, error) {
+	export, err := s.getConversationExport(ctx, exportID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status != ExportStatusCompleted {
+		return nil, fmt.Errorf("%w: export is not ready for download", util.ErrConflict)
+	}
+
+	return &ConversationExportFile{
+		ConversationID: export.ConversationID,
+		Format:         export.Format,
+		Content:        export.Content,
+	}, nil
+}
+
+
+func (s *Service) DeleteExpiredConversationExports(ctx context.Context) (int64, error) {
+	deleted, err := s.store.DeleteExpiredConversationExports(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired exports: %w", err)
+	}
+
+	return deleted, nil
+}
+
+
+func (s *Service) FailStaleConversationExports(ctx context.Context) (int64, error) {
+	failed, err := s.store.FailStaleConversationExports(ctx, db.FailStaleConversationExportsParams{
+		Error:       sql.NullString{String: "export timed out", Valid: true},
+		Retention:   exportRetention,
+		StaleBefore: time.Now().Add(-exportJobTimeout),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale exports: %w", err)
+	}
+
+	return failed, nil
+}
+
+
+func (s *Service) RunExportReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stale, err := s.FailStaleConversationExports(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Stale export cleanup failed")
+			} else if stale > 0 {
+				log.Info().Int64("count", stale).Msg("Marked stale conversation exports as failed")
+			}
+
+			expired, err := s.DeleteExpiredConversationExports(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Expired export cleanup failed")
+			} else if expired > 0 {
+				log.Info().Int64("count", expired).Msg("Deleted expired conversation exports")
+			}
+		}
+	}
+}
+
+
+func (s *Service) getConversationExport(ctx context.Context, exportID, userID uuid.UUID) (db.ConversationExport, error) {
+	export, err := s.store.GetConversationExport(ctx, db.GetConversationExportParams{
+		ID:          exportID,
+		RequestedBy: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.ConversationExport{}, fmt.Errorf("%w: export not found", util.ErrNotFound)
+		}
+		return db.ConversationExport{}, fmt.Errorf("failed to get export: %w", err)
+	}
+	if export.ExpiresAt.Valid && !export.ExpiresAt.Time.After(time.Now()) {
+		return db.ConversationExport{}, fmt.Errorf("%w: export has expired", util.ErrNotFound)
+	}
+
+	return export, nil
+}
+
+
+func (s *Service) runConversationExport(export db.ConversationExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportJobTimeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	count, err := s.WriteConversationExport(ctx, export.ConversationID, export.RequestedBy, export.Format, &buf)
+	if err != nil {
+		log.Error().Err(err).Str("export_id", export.ID.String()).Msg("Conversation export failed")
+		if err := s.store.FailConversationExport(ctx, db.FailConversationExportParams{
+			Error:     sql.NullString{String: "export could not be generated", Valid: true},
+			Retention: exportRetention,
+			ID:        export.ID,
+		}); err != nil {
+			log.Error().Err(err).Str("export_id", export.ID.String()).Msg("Failed to mark conversation export as failed")
+		}
+		return
+	}
+
+	if err := s.store.CompleteConversationExport(ctx, db.CompleteConversationExportParams{
+		Content:      buf.Bytes(),
+		MessageCount: count,
+		Retention:    exportRetention,
+		ID:           export.ID,
+	}); err != nil {
+		log.Error().Err(err).Str("export_id", export.ID.String()).Msg("Failed to store conversation export")
+	}
+}
+
+
+func (s *Service) recordExportAudit(ctx context.Context, req ExportConversationRequest, count int64, background bool) {
+	details, _ := json.Marshal(map[string]interface{}{
+		"format":        req.Format,
+		"message_count": count,
+		"background":    background,
+	})
+
+	params := db.CreateAuditLogParams{
+		AdminUserID:  req.UserID,
+		Action:       "export_conversation",
+		ResourceType: "conversation",
+		ResourceID:   uuid.NullUUID{UUID: req.ConversationID, Valid: true},
+		Details:      pqtype.NullRawMessage{RawMessage: details, Valid: true},
+		UserAgent:    sql.NullString{String: req.UserAgent, Valid: req.UserAgent != ""},
+	}
+	if ip := net.ParseIP(req.IPAddress); ip != nil {
+		bits := 128
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 32
+		}
+		params.IpAddress = pqtype.Inet{IPNet: net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, Valid: true}
+	}
+
+	if _, err := s.store.CreateAuditLog(ctx, params); err != nil {
+		log.Error().Err(err).Str("conversation_id", req.ConversationID.String()).Msg("Failed to record conversation export audit log")
+	}
+}
+
+
+func toConversationExportResponse(export db.ConversationExport) *ConversationExportResponse {
+	response := &ConversationExportResponse{
+		ID:             export.ID,
+		ConversationID: export.ConversationID,
+		Format:         export.Format,
+		Status:         export.Status,
+		MessageCount:   export.MessageCount,
+		CreatedAt:      export.CreatedAt,
+	}
+	if export.Status == ExportStatusCompleted {
+		url := fmt.Sprintf("/api/conversations/exports/%s/download", export.ID)
+		response.DownloadURL = &url
+	}
+	if export.Error.Valid {
+		response.Error = &export.Error.String
+	}
+	if export.CompletedAt.Valid {
+		response.CompletedAt = &export.CompletedAt.Time
+	}
+	if export.ExpiresAt.Valid {
+		response.ExpiresAt = &export.ExpiresAt.Time
+	}
+
+	return response
+}
+
+
+func toExportedMessage(row db.GetExportMessagesRow) ExportedMessage {
+	message := ExportedMessage{
+		ID:             row.ID,
+		SenderID:       row.SenderID,
+		SenderUsername: row.SenderUsername,
+		SenderFullName: row.SenderFullName,
+		Content:        row.Content.String,
+		MessageType:    row.MessageType.String,
+		Attachments:    attachmentRefs(row.Attachments),
+		IsForwarded:    row.IsForwarded,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	if row.ReplyToID.Valid {
+		message.ReplyToID = &row.ReplyToID.UUID
+	}
+	if row.ThreadRootID.Valid {
+		message.ThreadRootID = &row.ThreadRootID.UUID
+	}
+	if row.SharedType.Valid && row.SharedID.Valid {
+		message.Shared = &SharedContentResponse{Type: row.SharedType.String, ID: row.SharedID.UUID}
+	}
+	if row.EditedAt.Valid {
+		message.EditedAt = &row.EditedAt.Time
+	}
+
+	return message
+}
+
+
+func attachmentRefs(attachments pqtype.NullRawMessage) []string {
+	if !attachments.Valid {
+		return nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(attachments.RawMessage, &items); err != nil {
+		return nil
+	}
+
+	refs := make([]string, 0, len(items))
+	for _, item := range items {
+		var ref string
+		if err := json.Unmarshal(item, &ref); err == nil {
+			refs = append(refs, ref)
+			continue
+		}
+		var object struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(item, &object); err == nil && object.URL != "" {
+			refs = append(refs, object.URL)
+		}
+	}
+
+	return refs
+}
+
+
+type transcriptWriter interface {
+	begin() error
+	write(message ExportedMessage) error
+	end() error
+}
+
+
+type jsonTranscript struct {
+	w              io.Writer
+	conversationID uuid.UUID
+	count          int
+}
+
+
+func (t *jsonTranscript) begin() error {
+	header, err := json.Marshal(map[string]interface{}{
+		"conversation_id": t.conversationID,
+		"exported_at":     time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(t.w, `%s,"messages":[`, header[:len(header)-1])
+	return err
+}
+
+
+func (t *jsonTranscript) write(message ExportedMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if t.count > 0 {
+		if _, err := io.WriteString(t.w, ","); err != nil {
+			return err
+		}
+	}
+	t.count++
+
+	_, err = t.w.Write(data)
+	return err
+}
+
+
+func (t *jsonTranscript) end() error {
+	_, err := io.WriteString(t.w, "]}\n")
+	return err
+}
+
+
+type csvTranscript struct {
+	w *csv.Writer
+}
+
+
+func (t *csvTranscript) begin() error {
+	return t.w.Write([]string{
+		"message_id", "created_at", "sender_id", "sender_username", "sender_full_name",
+		"message_type", "content", "attachments", "reply_to_id", "thread_root_id",
+		"shared_type", "shared_id", "is_forwarded", "edited_at",
+	})
+}
+
+
+func (t *csvTranscript) write(message ExportedMessage) error {
+	record := []string{
+		message.ID.String(),
+		message.CreatedAt.UTC().Format(time.RFC3339),
+		message.SenderID.String(),
+		message.SenderUsername,
+		message.SenderFullName,
+		message.MessageType,
+		message.Content,
+		strings.Join(message.Attachments, " "),
+		optionalUUID(message.ReplyToID),
+		optionalUUID(message.ThreadRootID),
+		"",
+		"",
+		strconv.FormatBool(message.IsForwarded),
+		"",
+	}
+	if message.Shared != nil {
+		record[10] = message.Shared.Type
+		record[11] = message.Shared.ID.String()
+	}
+	if message.EditedAt != nil {
+		record[13] = message.EditedAt.UTC().Format(time.RFC3339)
+	}
+
+	if err := t.w.Write(record); err != nil {
+		return err
+	}
+	t.w.Flush()
+	return t.w.Error()
+}
+
+
+func (t *csvTranscript) end() error {
+	t.w.Flush()
+	return t.w.Error()
+}
+
+
+type textTranscript struct {
+	w io.Writer
+}
+
+
+func (t *textTranscript) begin() error {
+	return nil
+}
+
+
+func (t *textTranscript) write(message ExportedMessage) error {
+	prefix := ""
+	if message.IsForwarded {
+		prefix = "(forwarded) "
+	}
+	if _, err := fmt.Fprintf(t.w, "[%s] %s: %s%s\n", message.CreatedAt.UTC().Format("2006-01-02 15:04:05 MST"), message.SenderUsername, prefix, message.Content); err != nil {
+		return err
+	}
+
+	for _, ref := range message.Attachments {
+		if _, err := fmt.Fprintf(t.w, "    attachment: %s\n", ref); err != nil {
+			return err
+		}
+	}
+	if message.Shared != nil {
+		if _, err := fmt.Fprintf(t.w, "    shared %s: %s\n", message.Shared.Type, message.Shared.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+
+func (t *textTranscript) end() error {
+	return nil
+}
+
+
+func optionalUUID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,543 @@
+package messaging
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+
+const inviteCodeBytes = 12
+
+
+func (s *Service) AddConversationParticipants(ctx context.Context, conversationID, actorID uuid.UUID, userIDs []uuid.UUID) error {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner, RoleAdmin); err != nil {
+		return err
+	}
+
+	allowed, err := s.groupInvitees(ctx, actorID, userIDs)
+	if err != nil {
+		return err
+	}
+
+	added, err := s.store.AddGroupParticipants(ctx, db.AddGroupParticipantsParams{
+		ConversationID: conversationID,
+		UserIds:        allowed,
+		ActorID:        actorID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add participants: %w", err)
+	}
+
+	if len(added) > 0 {
+		s.postSystemMessage(ctx, conversationID, actorID, fmt.Sprintf("%s added %s",
+			s.displayName(ctx, actorID), joinNames(s.displayNames(ctx, added))))
+	}
+
+	return nil
+}
+
+
+func (s *Service) groupInvitees(ctx context.Context, actorID uuid.UUID, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	allowed := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		policy, err := s.store.GetDirectMessagePolicy(ctx, db.GetDirectMessagePolicyParams{
+			SenderID:    actorID,
+			RecipientID: userID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get message policy: %w", err)
+		}
+		if policy.Connected || policy.AllowMessagesFrom == MessagesFromEveryone {
+			allowed = append(allowed, userID)
+		}
+	}
+
+	return allowed, nil
+}
+
+
+func (s *Service) RemoveConversationParticipant(ctx context.Context, conversationID, actorID, targetID uuid.UUID) error {
+	actorRole, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if targetID == actorID {
+		return fmt.Errorf("%w: use leave to remove yourself from a conversation", util.ErrBadRequest)
+	}
+
+	targetRole, err := s.targetRole(ctx, conversationID, targetID)
+	if err != nil {
+		return err
+	}
+	if targetRole == RoleOwner {
+		return fmt.Errorf("%w: the owner cannot be removed", util.ErrForbidden)
+	}
+	if targetRole == RoleAdmin && actorRole != RoleOwner {
+		return fmt.Errorf("%w: only the owner can remove admins", util.ErrForbidden)
+	}
+
+	rows, err := s.store.RemoveConversationParticipant(ctx, db.RemoveConversationParticipantParams{
+		ConversationID: conversationID,
+		UserID:         targetID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove participant: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: participant not found", util.ErrNotFound)
+	}
+
+	s.postSystemMessage(ctx, conversationID, actorID, fmt.Sprintf("%s removed %s",
+		s.displayName(ctx, actorID), s.displayName(ctx, targetID)))
+
+	return nil
+}
+
+
+func (s *Service) UpdateParticipantRole(ctx context.Context, conversationID, actorID, targetID uuid.UUID, req UpdateParticipantRoleRequest) error {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner); err != nil {
+		return err
+	}
+	if targetID == actorID {
+		return fmt.Errorf("%w: transfer ownership to change your own role", util.ErrBadRequest)
+	}
+
+	targetRole, err := s.targetRole(ctx, conversationID, targetID)
+	if err != nil {
+		return err
+	}
+	if targetRole == req.Role {
+		return nil
+	}
+
+	if _, err := s.store.SetParticipantRole(ctx, db.SetParticipantRoleParams{
+		Role:           sql.NullString{String: req.Role, Valid: true},
+		ConversationID: conversationID,
+		UserID:         targetID,
+	}); err != nil {
+		return fmt.Errorf("failed to update participant role: %w", err)
+	}
+
+	text := fmt.Sprintf("%s made %s an admin", s.displayName(ctx, actorID), s.displayName(ctx, targetID))
+	if req.Role == RoleMember {
+		text = fmt.Sprintf("%s removed %s as an admin", s.displayName(ctx, actorID), s.displayName(ctx, targetID))
+	}
+	s.postSystemMessage(ctx, conversationID, actorID, text)
+
+	return nil
+}
+
+
+func (s *Service) TransferOwnership(ctx context.Context, conversationID, actorID uuid.UUID, req TransferOwnershipRequest) error {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner); err != nil {
+		return err
+	}
+	if req.UserID == actorID {
+		return fmt.Errorf("%w: you already own this conversation", util.ErrBadRequest)
+	}
+	if _, err := s.targetRole(ctx, conversationID, req.UserID); err != nil {
+		return err
+	}
+
+	if err := s.store.TransferConversationOwnershipTx(ctx, db.TransferConversationOwnershipTxParams{
+		ConversationID: conversationID,
+		OwnerID:        actorID,
+		NewOwnerID:     req.UserID,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: participant not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to transfer ownership: %w", err)
+	}
+
+	s.postSystemMessage(ctx, conversationID, actorID, fmt.Sprintf("%s transferred ownership to %s",
+		s.displayName(ctx, actorID), s.displayName(ctx, req.UserID)))
+
+	return nil
+}
+
+
+func (s *Service) UpdateConversation(ctx context.Context, conversationID, actorID uuid.UUID, req UpdateConversationRequest) (*ConversationResponse, error) {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var name, avatar, description sql.NullString
+	if req.Name != nil {
+		trimmed := strings.TrimSpace(*req.Name)
+		if trimmed == "" {
+			return nil, fmt.Errorf("%w: conversation name cannot be empty", util.ErrBadRequest)
+		}
+		name = sql.NullString{String: trimmed, Valid: true}
+	}
+	if req.Avatar != nil {
+		avatar = sql.NullString{String: *req.Avatar, Valid: true}
+	}
+	if req.Description != nil {
+		description = sql.NullString{String: *req.Description, Valid: true}
+	}
+	if !name.Valid && !avatar.Valid && !description.Valid {
+		return nil, fmt.Errorf("%w: nothing to update", util.ErrBadRequest)
+	}
+
+	conversation, err := s.store.UpdateConversationDetails(ctx, db.UpdateConversationDetailsParams{
+		Name:        name,
+		Avatar:      avatar,
+		Description: description,
+		ID:          conversationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: conversation not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update conversation: %w", err)
+	}
+
+	actor := s.displayName(ctx, actorID)
+	if name.Valid {
+		s.postSystemMessage(ctx, conversationID, actorID, fmt.Sprintf("%s renamed the group to %q", actor, name.String))
+	}
+	if avatar.Valid {
+		s.postSystemMessage(ctx, conversationID, actorID, fmt.Sprintf("%s changed the group photo", actor))
+	}
+	if description.Valid {
+		s.postSystemMessage(ctx, conversationID, actorID, fmt.Sprintf("%s updated the group description", actor))
+	}
+
+	return s.toConversationResponse(conversation, 0), nil
+}
+
+
+func (s *Service) CreateConversationInvite(ctx context.Context, conversationID, actorID uuid.UUID, req CreateInviteRequest) (*InviteResponse, error) {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invite code: %w", err)
+	}
+
+	params := db.CreateConversationInviteParams{
+		ConversationID: conversationID,
+		Code:           code,
+		CreatedBy:      actorID,
+	}
+	if req.ExpiresInHours != nil {
+		params.ExpiresAt = sql.NullTime{Time: time.Now().Add(time.Duration(*req.ExpiresInHours) * time.Hour), Valid: true}
+	}
+	if req.MaxUses != nil {
+		params.MaxUses = sql.NullInt32{Int32: *req.MaxUses, Valid: true}
+	}
+
+	invite, err := s.store.CreateConversationInvite(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return toInviteResponse(invite), nil
+}
+
+
+func (s *Service) GetConversationInvites(ctx context.Context, conversationID, actorID uuid.UUID) ([]InviteResponse, error) {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.store.GetConversationInvites(ctx, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invites: %w", err)
+	}
+
+	response := make([]InviteResponse, len(invites))
+	for i, invite := range invites {
+		response[i] = *toInviteResponse(invite)
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) RevokeConversationInvite(ctx context.Context, conversationID, inviteID, actorID uuid.UUID) error {
+	if _, err := s.requireGroupRole(ctx, conversationID, actorID, RoleOwner, RoleAdmin); err != nil {
+		return err
+	}
+
+	rows, err := s.store.RevokeConversationInvite(ctx, db.RevokeConversationInviteParams{
+		ID:             inviteID,
+		ConversationID: conversationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke invite: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: invite not found", util.ErrNotFound)
+	}
+
+	return nil
+}
+
+
+func (s *Service) JoinConversationByInvite(ctx context.Context, code string, userID uuid.UUID) (*ConversationResponse, error) {
+	invite, err := s.store.GetConversationInviteByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: invite link is invalid or has expired", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	if _, err := s.participantRole(ctx, invite.ConversationID, userID); err != nil {
+		if !errors.Is(err, util.ErrForbidden) {
+			return nil, err
+		}
+
+		if err := s.checkInviteJoin(ctx, invite, userID); err != nil {
+			return nil, err
+		}
+
+		err := s.store.JoinConversationByInviteTx(ctx, db.JoinConversationByInviteTxParams{
+			InviteID:       invite.ID,
+			ConversationID: invite.ConversationID,
+			UserID:         userID,
+			ActorID:        invite.CreatedBy,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, fmt.Errorf("%w: invite link is invalid or has expired", util.ErrNotFound)
+			}
+			if errors.Is(err, db.ErrJoinRefused) {
+				return nil, fmt.Errorf("%w: you cannot join this conversation", util.ErrForbidden)
+			}
+			return nil, fmt.Errorf("failed to join conversation: %w", err)
+		}
+
+		s.postSystemMessage(ctx, invite.ConversationID, userID, fmt.Sprintf("%s joined using an invite link", s.displayName(ctx, userID)))
+	}
+
+	return s.GetConversationByID(ctx, invite.ConversationID, userID)
+}
+
+
+func (s *Service) checkInviteJoin(ctx context.Context, invite db.ConversationInvite, userID uuid.UUID) error {
+	conversation, err := s.store.GetConversationByID(ctx, db.GetConversationByIDParams{
+		UserID: userID,
+		ID:     invite.ConversationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: invite link is invalid or has expired", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: you cannot join this conversation", util.ErrForbidden)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.SpaceID != conversation.SpaceID {
+		return fmt.Errorf("%w: this invite link belongs to a different space", util.ErrForbidden)
+	}
+
+	blocked, err := s.store.IsBlockedBetween(ctx, db.IsBlockedBetweenParams{
+		UserA: userID,
+		UserB: invite.CreatedBy,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check block status: %w", err)
+	}
+	if blocked {
+		return fmt.Errorf("%w: you cannot join this conversation", util.ErrForbidden)
+	}
+
+	return nil
+}
+
+
+func (s *Service) handOverOwnership(ctx context.Context, conversationID, ownerID uuid.UUID) error {
+	successorID, err := s.store.GetOwnershipSuccessor(ctx, db.GetOwnershipSuccessorParams{
+		ConversationID: conversationID,
+		UserID:         ownerID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to find new owner: %w", err)
+	}
+
+	if err := s.store.TransferConversationOwnershipTx(ctx, db.TransferConversationOwnershipTxParams{
+		ConversationID: conversationID,
+		OwnerID:        ownerID,
+		NewOwnerID:     successorID,
+	}); err != nil {
+		return fmt.Errorf("failed to transfer ownership: %w", err)
+	}
+
+	s.postSystemMessage(ctx, conversationID, ownerID, fmt.Sprintf("%s is now the owner", s.displayName(ctx, successorID)))
+
+	return nil
+}
+
+
+func (s *Service) requireGroupRole(ctx context.Context, conversationID, userID uuid.UUID, allowed ...string) (string, error) {
+	conversation, err := s.store.GetConversationByID(ctx, db.GetConversationByIDParams{
+		UserID: userID,
+		ID:     conversationID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("%w: conversation not found", util.ErrNotFound)
+		}
+		return "", fmt.Errorf("failed to get conversation: %w", err)
+	}
+	if conversation.ConversationType.String != "group" {
+		return "", fmt.Errorf("%w: only group conversations can be managed", util.ErrBadRequest)
+	}
+
+	role, err := s.participantRole(ctx, conversationID, userID)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range allowed {
+		if role == r {
+			return role, nil
+		}
+	}
+	if len(allowed) == 1 && allowed[0] == RoleOwner {
+		return "", fmt.Errorf("%w: only the owner can do this", util.ErrForbidden)
+	}
+
+	return "", fmt.Errorf("%w: only group admins can do this", util.ErrForbidden)
+}
+
+
+func (s *Service) targetRole(ctx context.Context, conversationID, userID uuid.UUID) (string, error) {
+	role, err := s.participantRole(ctx, conversationID, userID)
+	if errors.Is(err, util.ErrForbidden) {
+		return "", fmt.Errorf("%w: participant not found", util.ErrNotFound)
+	}
+
+	return role, err
+}
+
+
+func (s *Service) postSystemMessage(ctx context.Context, conversationID, actorID uuid.UUID, content string) {
+	message, err := s.store.SendMessage(ctx, db.SendMessageParams{
+		ConversationID: conversationID,
+		SenderID:       actorID,
+		Content:        sql.NullString{String: content, Valid: true},
+		MessageType:    sql.NullString{String: "system", Valid: true},
+	})
+	if err != nil {
+		log.Error().Err(err).Str("conversation_id", conversationID.String()).Msg("Failed to record system message")
+		return
+	}
+
+	if err := s.store.UpdateConversationLastMessage(ctx, db.UpdateConversationLastMessageParams{
+		LastMessageID: uuid.NullUUID{UUID: message.ID, Valid: true},
+		ID:            conversationID,
+	}); err != nil {
+		log.Error().Err(err).Str("conversation_id", conversationID.String()).Msg("Failed to update last message")
+	}
+
+	if s.liveService != nil {
+		if err := s.liveService.PublishMessageCreated(ctx, conversationID, actorID, map[string]interface{}{
+			"id":              message.ID.String(),
+			"conversation_id": conversationID.String(),
+			"sender_id":       actorID.String(),
+			"content":         content,
+			"message_type":    "system",
+			"created_at":      message.CreatedAt.Time.Unix(),
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to publish message.created event")
+		}
+	}
+}
+
+
+func (s *Service) displayName(ctx context.Context, userID uuid.UUID) string {
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return "Someone"
+	}
+
+	return user.Username
+}
+
+
+func (s *Service) displayNames(ctx context.Context, userIDs []uuid.UUID) []string {
+	names := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		names[i] = s.displayName(ctx, id)
+	}
+
+	return names
+}
+
+
+func joinNames(names []string) string {
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return names[0]
+	default:
+		return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+}
+
+
+func generateInviteCode() (string, error) {
+	buf := make([]byte, inviteCodeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+
+func toInviteResponse(invite db.ConversationInvite) *InviteResponse {
+	resp := &InviteResponse{
+		ID:             invite.ID,
+		ConversationID: invite.ConversationID,
+		Code:           invite.Code,
+		JoinPath:       "/api/conversations/join/" + invite.Code,
+		CreatedBy:      invite.CreatedBy,
+		UseCount:       invite.UseCount,
+		CreatedAt:      invite.CreatedAt,
+	}
+	if invite.ExpiresAt.Valid {
+		resp.ExpiresAt = &invite.ExpiresAt.Time
+	}
+	if invite.MaxUses.Valid {
+		resp.MaxUses = &invite.MaxUses.Int32
+	}
+
+	return resp
+}
@@ -0,0 +1,179 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const (
+	MessagesFromEveryone        = "everyone"
+	MessagesFromConnections     = "connections"
+	MessagesFromConnectionsOnly = "connections_only"
+)
+
+
+const (
+	RequestStatusPending  = "pending"
+	RequestStatusAccepted = "accepted"
+	RequestStatusDeclined = "declined"
+)
+
+
+func (s *Service) directRequestStatus(ctx context.Context, spaceID, senderID, recipientID uuid.UUID) (sql.NullString, error) {
+	policy, err := s.store.GetDirectMessagePolicy(ctx, db.GetDirectMessagePolicyParams{
+		SenderID:    senderID,
+		RecipientID: recipientID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullString{}, fmt.Errorf("%w: user not found", util.ErrNotFound)
+		}
+		return sql.NullString{}, fmt.Errorf("failed to get message policy: %w", err)
+	}
+
+	if policy.Connected || policy.AllowMessagesFrom == MessagesFromEveryone {
+		return sql.NullString{}, nil
+	}
+
+	if policy.AllowMessagesFrom == MessagesFromConnectionsOnly {
+		_, err := s.store.GetConversationByParticipants(ctx, db.GetConversationByParticipantsParams{
+			SpaceID:  spaceID,
+			UserID:   senderID,
+			UserID_2: recipientID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return sql.NullString{}, fmt.Errorf("%w: this user only accepts messages from connections", util.ErrForbidden)
+			}
+			return sql.NullString{}, fmt.Errorf("failed to get conversation: %w", err)
+		}
+	}
+
+	return sql.NullString{String: RequestStatusPending, Valid: true}, nil
+}
+
+
+func (s *Service) GetMessageRequests(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]MessageRequestResponse, error) {
+	rows, err := s.store.GetMessageRequests(ctx, db.GetMessageRequestsParams{
+		UserID:     userID,
+		PageSize:   limit,
+		PageOffset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message requests: %w", err)
+	}
+
+	response := make([]MessageRequestResponse, len(rows))
+	for i, row := range rows {
+		response[i] = MessageRequestResponse{
+			ConversationID:    row.ConversationID,
+			RequesterID:       row.RequesterID,
+			RequesterUsername: row.RequesterUsername,
+			RequesterFullName: row.RequesterFullName,
+			MessageCount:      row.MessageCount,
+		}
+		if row.RequesterAvatar.Valid {
+			response[i].RequesterAvatar = &row.RequesterAvatar.String
+		}
+		if row.LastMessageContent.Valid {
+			response[i].LastMessageContent = &row.LastMessageContent.String
+		}
+		if row.LastMessageTime.Valid {
+			response[i].LastMessageTime = &row.LastMessageTime.Time
+		}
+		if row.RequestedAt.Valid {
+			response[i].RequestedAt = &row.RequestedAt.Time
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) AcceptMessageRequest(ctx context.Context, conversationID, userID uuid.UUID) error {
+	return s.setMessageRequestStatus(ctx, conversationID, userID, RequestStatusAccepted)
+}
+
+
+func (s *Service) DeclineMessageRequest(ctx context.Context, conversationID, userID uuid.UUID) error {
+	return s.setMessageRequestStatus(ctx, conversationID, userID, RequestStatusDeclined)
+}
+
+
+func (s *Service) BlockMessageRequest(ctx context.Context, conversationID, userID uuid.UUID) error {
+	if err := s.setMessageRequestStatus(ctx, conversationID, userID, RequestStatusDeclined); err != nil {
+		return err
+	}
+
+	requesterID, err := s.store.GetConversationPeer(ctx, db.GetConversationPeerParams{
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: message request not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get requester: %w", err)
+	}
+
+	if _, err := s.store.BlockUserTx(ctx, db.BlockUserParams{
+		BlockerID: userID,
+		BlockedID: requesterID,
+	}); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	return nil
+}
+
+
+func (s *Service) setMessageRequestStatus(ctx context.Context, conversationID, userID uuid.UUID, status string) error {
+	rows, err := s.store.UpdateMessageRequestStatus(ctx, db.UpdateMessageRequestStatusParams{
+		RequestStatus:  sql.NullString{String: status, Valid: true},
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update message request: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: message request not found", util.ErrNotFound)
+	}
+
+	return nil
+}
+
+
+func (s *Service) acceptOwnRequest(ctx context.Context, conversationID, userID uuid.UUID) {
+	if _, err := s.store.UpdateMessageRequestStatus(ctx, db.UpdateMessageRequestStatusParams{
+		RequestStatus:  sql.NullString{String: RequestStatusAccepted, Valid: true},
+		ConversationID: conversationID,
+		UserID:         userID,
+	}); err != nil {
+		log.Error().Err(err).Str("conversation_id", conversationID.String()).Msg("Failed to accept message request")
+	}
+}
+
+
+func (s *Service) receiptsVisible(ctx context.Context, conversationID, userID uuid.UUID) (bool, error) {
+	status, err := s.store.GetParticipantRequestStatus(ctx, db.GetParticipantRequestStatusParams{
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get request status: %w", err)
+	}
+
+	return status == RequestStatusAccepted, nil
+}
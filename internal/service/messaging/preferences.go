@@ -0,0 +1,220 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const maxPinnedMessages = 50
+
+
+func (s *Service) MuteConversation(ctx context.Context, conversationID, userID uuid.UUID, req MuteConversationRequest) error {
+	if !req.Until.After(time.Now()) {
+		return fmt.Errorf("%w: mute must end in the future", util.ErrBadRequest)
+	}
+
+	return s.setMute(ctx, conversationID, userID, sql.NullTime{Time: req.Until, Valid: true})
+}
+
+
+func (s *Service) UnmuteConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	return s.setMute(ctx, conversationID, userID, sql.NullTime{})
+}
+
+
+func (s *Service) SetConversationArchived(ctx context.Context, conversationID, userID uuid.UUID, archived bool) error {
+	rows, err := s.store.SetConversationArchived(ctx, db.SetConversationArchivedParams{
+		Archived:       archived,
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update archive status: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: not a participant of this conversation", util.ErrForbidden)
+	}
+
+	return nil
+}
+
+
+func (s *Service) PinMessage(ctx context.Context, messageID, userID uuid.UUID) (*PinnedMessageResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if message.Status.Valid && message.Status.String == "deleted" {
+		return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+	}
+	if err := s.requireModerator(ctx, message.ConversationID, userID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.store.CountPinnedMessages(ctx, message.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count pinned messages: %w", err)
+	}
+	if count >= maxPinnedMessages {
+		return nil, fmt.Errorf("%w: a conversation can have at most %d pinned messages", util.ErrConflict, maxPinnedMessages)
+	}
+
+	pin, err := s.store.PinMessage(ctx, db.PinMessageParams{
+		ConversationID: message.ConversationID,
+		MessageID:      messageID,
+		PinnedBy:       userID,
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to pin message: %w", err)
+	}
+	pinned := err == nil
+
+	pins, err := s.GetPinnedMessages(ctx, message.ConversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	var response *PinnedMessageResponse
+	for i := range pins {
+		if pins[i].ID == messageID {
+			response = &pins[i]
+			break
+		}
+	}
+	if response == nil {
+		return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+	}
+
+	if pinned && s.liveService != nil {
+		if err := s.liveService.PublishMessagePinned(ctx, message.ConversationID, userID, map[string]interface{}{
+			"message_id":      messageID.String(),
+			"conversation_id": message.ConversationID.String(),
+			"pinned_by":       userID.String(),
+			"content":         response.Content,
+			"pinned_at":       pin.PinnedAt.Unix(),
+		}); err != nil {
+			log.Error().Err(err).Msg("Failed to publish message.pinned event")
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) UnpinMessage(ctx context.Context, messageID, userID uuid.UUID) error {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+	if err := s.requireModerator(ctx, message.ConversationID, userID); err != nil {
+		return err
+	}
+
+	rows, err := s.store.UnpinMessage(ctx, db.UnpinMessageParams{
+		ConversationID: message.ConversationID,
+		MessageID:      messageID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unpin message: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: message is not pinned", util.ErrNotFound)
+	}
+
+	if s.liveService != nil {
+		if err := s.liveService.PublishMessageUnpinned(ctx, message.ConversationID, messageID, userID); err != nil {
+			log.Error().Err(err).Msg("Failed to publish message.unpinned event")
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) GetPinnedMessages(ctx context.Context, conversationID, userID uuid.UUID) ([]PinnedMessageResponse, error) {
+	if _, err := s.participantRole(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.GetPinnedMessages(ctx, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pinned messages: %w", err)
+	}
+
+	response := make([]PinnedMessageResponse, len(rows))
+	for i, row := range rows {
+		response[i] = PinnedMessageResponse{
+			ID:               row.ID,
+			ConversationID:   row.ConversationID,
+			SenderID:         row.SenderID,
+			SenderUsername:   row.SenderUsername,
+			SenderFullName:   row.SenderFullName,
+			Content:          row.Content.String,
+			MessageType:      row.MessageType.String,
+			PinnedBy:         row.PinnedBy,
+			PinnedByUsername: row.PinnedByUsername,
+			PinnedAt:         row.PinnedAt,
+		}
+		if row.SenderAvatar.Valid {
+			response[i].SenderAvatar = &row.SenderAvatar.String
+		}
+		if row.Attachments.Valid {
+			attachments := row.Attachments
+			response[i].Attachments = &attachments
+		}
+		if row.CreatedAt.Valid {
+			response[i].CreatedAt = &row.CreatedAt.Time
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) requireModerator(ctx context.Context, conversationID, userID uuid.UUID) error {
+	_, err := s.requireGroupRole(ctx, conversationID, userID, RoleOwner, RoleAdmin)
+	if errors.Is(err, util.ErrBadRequest) {
+		_, err = s.participantRole(ctx, conversationID, userID)
+	}
+
+	return err
+}
+
+
+func (s *Service) setMute(ctx context.Context, conversationID, userID uuid.UUID, until sql.NullTime) error {
+	rows, err := s.store.SetConversationMute(ctx, db.SetConversationMuteParams{
+		MutedUntil:     until,
+		ConversationID: conversationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update mute status: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: not a participant of this conversation", util.ErrForbidden)
+	}
+
+	return nil
+}
+
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
@@ -0,0 +1,149 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+func (s *Service) MarkMessagesAsDelivered(ctx context.Context, conversationID, userID uuid.UUID, req MarkMessagesRequest) error {
+	if err := s.checkCursorTarget(ctx, conversationID, userID, req.MessageID); err != nil {
+		return err
+	}
+
+	cursor, err := s.store.MarkMessagesAsDelivered(ctx, db.MarkMessagesAsDeliveredParams{
+		ConversationID: conversationID,
+		MessageID:      nullUUID(req.MessageID),
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to mark messages as delivered: %w", err)
+	}
+
+	if s.liveService != nil {
+		if err := s.liveService.PublishMessageDelivered(ctx, conversationID, cursor.MessageID, userID); err != nil {
+			log.Error().Err(err).Msg("Failed to publish message.delivered event")
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) GetMessageSeenBy(ctx context.Context, messageID, userID uuid.UUID) ([]SeenByResponse, error) {
+	message, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	if _, err := s.participantRole(ctx, message.ConversationID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.GetMessageSeenBy(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message readers: %w", err)
+	}
+
+	response := make([]SeenByResponse, len(rows))
+	for i, row := range rows {
+		response[i] = SeenByResponse{
+			UserID:   row.ID,
+			Username: row.Username,
+			FullName: row.FullName,
+		}
+		if row.Avatar.Valid {
+			response[i].Avatar = &row.Avatar.String
+		}
+		if row.LastReadAt.Valid {
+			response[i].ReadAt = &row.LastReadAt.Time
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) checkCursorTarget(ctx context.Context, conversationID, userID uuid.UUID, messageID *uuid.UUID) error {
+	if _, err := s.participantRole(ctx, conversationID, userID); err != nil {
+		return err
+	}
+	if messageID == nil {
+		return nil
+	}
+
+	message, err := s.store.GetMessageByID(ctx, *messageID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+	if err != nil || message.ConversationID != conversationID {
+		return fmt.Errorf("%w: message not found in this conversation", util.ErrBadRequest)
+	}
+
+	return nil
+}
+
+
+func (s *Service) loadReceipts(ctx context.Context, messageIDs []uuid.UUID) (map[uuid.UUID]*MessageReceipts, error) {
+	rows, err := s.store.GetMessageReceiptCounts(ctx, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message receipts: %w", err)
+	}
+
+	receipts := make(map[uuid.UUID]*MessageReceipts, len(rows))
+	for _, row := range rows {
+		receipts[row.MessageID] = &MessageReceipts{
+			RecipientCount: row.RecipientCount,
+			DeliveredCount: row.DeliveredCount,
+			ReadCount:      row.ReadCount,
+		}
+	}
+
+	return receipts, nil
+}
+
+
+func (s *Service) attachReceipts(ctx context.Context, responses []MessageDetailResponse) error {
+	if len(responses) == 0 {
+		return nil
+	}
+
+	messageIDs := make([]uuid.UUID, len(responses))
+	for i, m := range responses {
+		messageIDs[i] = m.ID
+	}
+
+	receipts, err := s.loadReceipts(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+	for i := range responses {
+		if r, ok := receipts[responses[i].ID]; ok {
+			responses[i].Receipts = r
+			responses[i].IsRead = r.RecipientCount > 0 && r.ReadCount == r.RecipientCount
+		}
+	}
+
+	return nil
+}
+
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
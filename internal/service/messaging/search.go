@@ -0,0 +1,157 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+)
+
+
+const (
+	maxSearchQueryLength = 200
+	searchContextSize    = 2
+)
+
+
+func (s *Service) SearchMessages(ctx context.Context, req SearchMessagesRequest) (*MessageSearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", util.ErrBadRequest)
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, fmt.Errorf("%w: search query must be at most %d characters", util.ErrBadRequest, maxSearchQueryLength)
+	}
+	if req.After != nil && req.Before != nil && !req.After.Before(*req.Before) {
+		return nil, fmt.Errorf("%w: from must be earlier than to", util.ErrBadRequest)
+	}
+
+	if req.ConversationID != nil {
+		if _, err := s.participantRole(ctx, *req.ConversationID, req.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	params := db.SearchMessagesParams{
+		Query:          query,
+		UserID:         req.UserID,
+		ConversationID: nullUUID(req.ConversationID),
+		SenderID:       nullUUID(req.SenderID),
+		CursorID:       req.Page.CursorID(),
+		PageSize:       req.Page.PageSize(),
+		PageOffset:     req.Page.PageOffset(),
+	}
+	if req.After != nil {
+		params.After = sql.NullTime{Time: *req.After, Valid: true}
+	}
+	if req.Before != nil {
+		params.Before = sql.NullTime{Time: *req.Before, Valid: true}
+	}
+	if req.HasAttachment != nil {
+		params.HasAttachment = sql.NullBool{Bool: *req.HasAttachment, Valid: true}
+	}
+
+	rows, err := s.store.SearchMessages(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search messages: %w", err)
+	}
+
+	rows, hasMore := util.TrimPage(rows, req.Page)
+
+	results := make([]MessageSearchResultResponse, len(rows))
+	hitIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		hitIDs[i] = row.ID
+		results[i] = MessageSearchResultResponse{
+			ID:               row.ID,
+			ConversationID:   row.ConversationID,
+			ConversationType: row.ConversationType.String,
+			SenderID:         row.SenderID,
+			SenderUsername:   row.SenderUsername,
+			SenderFullName:   row.SenderFullName,
+			Content:          row.Content.String,
+			Highlight:        row.Highlight,
+			MessageType:      row.MessageType.String,
+			Score:            row.Score,
+			Before:           []MessageContextResponse{},
+			After:            []MessageContextResponse{},
+		}
+		if row.ConversationName.Valid {
+			results[i].ConversationName = &row.ConversationName.String
+		}
+		if row.SenderAvatar.Valid {
+			results[i].SenderAvatar = &row.SenderAvatar.String
+		}
+		if row.Attachments.Valid {
+			attachments := row.Attachments
+			results[i].Attachments = &attachments
+		}
+		if row.CreatedAt.Valid {
+			results[i].CreatedAt = &row.CreatedAt.Time
+		}
+	}
+
+	if err := s.attachSearchContext(ctx, hitIDs, results); err != nil {
+		return nil, err
+	}
+
+	page := util.NewPage(results, req.Page, hasMore)
+	if hasMore {
+		last := rows[len(rows)-1]
+		page.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	return &MessageSearchResponse{
+		Page:  page,
+		Query: query,
+	}, nil
+}
+
+
+func (s *Service) attachSearchContext(ctx context.Context, hitIDs []uuid.UUID, results []MessageSearchResultResponse) error {
+	if len(hitIDs) == 0 {
+		return nil
+	}
+
+	rows, err := s.store.GetMessageSearchContext(ctx, db.GetMessageSearchContextParams{
+		ContextSize: searchContextSize,
+		HitIds:      hitIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get search context: %w", err)
+	}
+
+	index := make(map[uuid.UUID]int, len(results))
+	for i, r := range results {
+		index[r.ID] = i
+	}
+	for _, row := range rows {
+		i, ok := index[row.HitID]
+		if !ok {
+			continue
+		}
+		message := MessageContextResponse{
+			ID:             row.ID,
+			SenderID:       row.SenderID,
+			SenderUsername: row.SenderUsername,
+			Content:        row.Content.String,
+			MessageType:    row.MessageType.String,
+		}
+		if row.CreatedAt.Valid {
+			createdAt := row.CreatedAt.Time
+			message.CreatedAt = &createdAt
+		}
+		if row.Position == "before" {
+			results[i].Before = append(results[i].Before, message)
+		} else {
+			results[i].After = append(results[i].After, message)
+		}
+	}
+
+	return nil
+}
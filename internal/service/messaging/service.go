@@ -3,10 +3,16 @@ package messaging
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	db "github.com/connect-univyn/connect-server/db/sqlc"
 	"github.com/connect-univyn/connect-server/internal/live"
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/connect-univyn/connect-server/internal/service/notifications"
+	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/sqlc-dev/pqtype"
@@ -14,15 +20,21 @@ import (
 
 
 type Service struct {
-	store       db.Store
-	liveService *live.Service
+	store               db.Store
+	liveService         *live.Service
+	mentionService      *mentions.Service
+	notificationService *notifications.Service
+	editWindow          time.Duration
 }
 
 
-func NewService(store db.Store, liveService *live.Service) *Service {
+func NewService(store db.Store, liveService *live.Service, mentionService *mentions.Service, notificationService *notifications.Service) *Service {
 	return &Service{
-		store:       store,
-		liveService: liveService,
+		store:               store,
+		liveService:         liveService,
+		mentionService:      mentionService,
+		notificationService: notificationService,
+		editWindow:          defaultEditWindow,
 	}
 }
 
@@ -69,6 +81,17 @@ func (s *Service) CreateConversation(ctx context.Context, req CreateConversation
 			return nil, fmt.Errorf("failed to add participants: %w", err)
 		}
 	}
+
+	
+	if conversation.ConversationType.String == "group" && req.CreatorID != uuid.Nil {
+		if _, err := s.store.SetParticipantRole(ctx, db.SetParticipantRoleParams{
+			Role:           sql.NullString{String: RoleOwner, Valid: true},
+			ConversationID: conversation.ID,
+			UserID:         req.CreatorID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to set conversation owner: %w", err)
+		}
+	}
 	
 	return s.toConversationResponse(conversation, 0), nil
 }
@@ -76,8 +99,8 @@ func (s *Service) CreateConversation(ctx context.Context, req CreateConversation
 
 func (s *Service) GetConversationByID(ctx context.Context, conversationID, userID uuid.UUID) (*ConversationResponse, error) {
 	conversation, err := s.store.GetConversationByID(ctx, db.GetConversationByIDParams{
-		RecipientID: uuid.NullUUID{UUID: userID, Valid: true},
-		ID:          conversationID,
+		UserID: userID,
+		ID:     conversationID,
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -90,8 +113,19 @@ func (s *Service) GetConversationByID(ctx context.Context, conversationID, userI
 }
 
 
-func (s *Service) GetUserConversations(ctx context.Context, userID uuid.UUID) ([]ConversationDetailResponse, error) {
-	conversations, err := s.store.GetUserConversations(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+func (s *Service) GetUserConversations(ctx context.Context, params GetUserConversationsParams) ([]ConversationDetailResponse, error) {
+	
+	archived := sql.NullBool{Bool: false, Valid: true}
+	if params.Archived != nil {
+		archived.Bool = *params.Archived
+	}
+
+	conversations, err := s.store.GetUserConversations(ctx, db.GetUserConversationsParams{
+		UserID:   params.UserID,
+		Archived: archived,
+		Muted:    nullBool(params.Muted),
+		Unread:   nullBool(params.Unread),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user conversations: %w", err)
 	}
@@ -101,6 +135,22 @@ func (s *Service) GetUserConversations(ctx context.Context, userID uuid.UUID) ([
 
 
 func (s *Service) GetOrCreateDirectConversation(ctx context.Context, spaceID, user1ID, user2ID uuid.UUID) (uuid.UUID, error) {
+	blocked, err := s.store.IsBlockedBetween(ctx, db.IsBlockedBetweenParams{
+		UserA: user1ID,
+		UserB: user2ID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to check block status: %w", err)
+	}
+	if blocked {
+		return uuid.Nil, fmt.Errorf("%w: cannot message this user", util.ErrForbidden)
+	}
+
+	requestStatus, err := s.directRequestStatus(ctx, spaceID, user1ID, user2ID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
 	conversationID, err := s.store.GetOrCreateDirectConversation(ctx, db.GetOrCreateDirectConversationParams{
 		SpaceID:  spaceID,
 		UserID:   user1ID,
@@ -109,10 +159,21 @@ func (s *Service) GetOrCreateDirectConversation(ctx context.Context, spaceID, us
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("failed to get or create direct conversation: %w", err)
 	}
+
 	
-	
-	
-	
+	if err := s.store.AddDirectParticipant(ctx, db.AddDirectParticipantParams{
+		ConversationID: conversationID,
+		UserID:         user1ID,
+	}); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to add participants: %w", err)
+	}
+	if err := s.store.AddDirectParticipant(ctx, db.AddDirectParticipantParams{
+		ConversationID: conversationID,
+		UserID:         user2ID,
+		RequestStatus:  requestStatus,
+	}); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to add participants: %w", err)
+	}
 	
 	return conversationID, nil
 }
@@ -140,19 +201,76 @@ func (s *Service) SendMessage(ctx context.Context, req SendMessageRequest) (*Mes
 	if req.Attachments != nil {
 		attachments = *req.Attachments
 	}
+
+	blocked, err := s.store.IsDirectConversationBlocked(ctx, db.IsDirectConversationBlockedParams{
+		SenderID:       req.SenderID,
+		ConversationID: req.ConversationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check block status: %w", err)
+	}
+	if blocked {
+		return nil, fmt.Errorf("%w: cannot message this user", util.ErrForbidden)
+	}
+	if req.MessageType == "system" {
+		return nil, fmt.Errorf("%w: system messages cannot be sent directly", util.ErrBadRequest)
+	}
+	if req.Shared == nil && strings.HasPrefix(req.MessageType, sharedMessagePrefix) {
+		return nil, fmt.Errorf("%w: shared messages require a post, event or community", util.ErrBadRequest)
+	}
+	if req.Shared == nil && strings.TrimSpace(req.Content) == "" {
+		return nil, fmt.Errorf("%w: content is required", util.ErrBadRequest)
+	}
+	if _, err := s.participantRole(ctx, req.ConversationID, req.SenderID); err != nil {
+		return nil, err
+	}
+
+	var sharedType sql.NullString
+	var sharedID, forwardedFromID uuid.NullUUID
+	var sharedPreview pqtype.NullRawMessage
+	if req.Shared != nil {
+		sharedPreview, err = s.sharedContentPreview(ctx, req.ConversationID, req.SenderID, *req.Shared)
+		if err != nil {
+			return nil, err
+		}
+		sharedType = sql.NullString{String: req.Shared.Type, Valid: true}
+		sharedID = uuid.NullUUID{UUID: req.Shared.ID, Valid: true}
+		messageType = sql.NullString{String: sharedMessagePrefix + req.Shared.Type, Valid: true}
+	}
+	if req.ForwardedFromID != nil {
+		forwardedFromID = uuid.NullUUID{UUID: *req.ForwardedFromID, Valid: true}
+	}
+
+	if req.ReplyToID != nil {
+		parent, err := s.store.GetMessageByID(ctx, *req.ReplyToID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get reply target: %w", err)
+		}
+		if err != nil || parent.ConversationID != req.ConversationID {
+			return nil, fmt.Errorf("%w: reply target not found in this conversation", util.ErrBadRequest)
+		}
+	}
 	
 	message, err := s.store.SendMessage(ctx, db.SendMessageParams{
-		ConversationID: req.ConversationID,
-		SenderID:       req.SenderID,
-		RecipientID:    recipientID,
-		Content:        content,
-		Attachments:    attachments,
-		MessageType:    messageType,
-		ReplyToID:      replyToID,
+		ConversationID:  req.ConversationID,
+		SenderID:        req.SenderID,
+		RecipientID:     recipientID,
+		Content:         content,
+		Attachments:     attachments,
+		MessageType:     messageType,
+		ReplyToID:       replyToID,
+		SharedType:      sharedType,
+		SharedID:        sharedID,
+		SharedPreview:   sharedPreview,
+		IsForwarded:     forwardedFromID.Valid,
+		ForwardedFromID: forwardedFromID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
+
+	
+	s.acceptOwnRequest(ctx, req.ConversationID, req.SenderID)
 	
 	
 	go s.store.UpdateConversationLastMessage(context.Background(), db.UpdateConversationLastMessageParams{
@@ -168,6 +286,21 @@ func (s *Service) SendMessage(ctx context.Context, req SendMessageRequest) (*Mes
 	}
 
 	response := s.toMessageResponse(messageDetail)
+	if s.mentionService != nil && response.Content != "" && !message.IsForwarded {
+		conversationID := req.ConversationID
+		entities, err := s.mentionService.Record(ctx, mentions.RecordMentionsRequest{
+			SourceType:     mentions.SourceMessage,
+			SourceID:       message.ID,
+			AuthorID:       req.SenderID,
+			Content:        response.Content,
+			ConversationID: &conversationID,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("message_id", message.ID.String()).Msg("Failed to record message mentions")
+		} else {
+			response.Mentions = entities
+		}
+	}
 
 	
 	if s.liveService != nil {
@@ -187,33 +320,97 @@ func (s *Service) SendMessage(ctx context.Context, req SendMessageRequest) (*Mes
 		if response.Attachments != nil {
 			messagePayload["attachments"] = response.Attachments
 		}
+		if len(response.Mentions) > 0 {
+			messagePayload["mentions"] = response.Mentions
+		}
+		if message.ReplyToID.Valid {
+			messagePayload["reply_to_id"] = message.ReplyToID.UUID.String()
+		}
+		if message.ThreadRootID.Valid {
+			messagePayload["thread_root_id"] = message.ThreadRootID.UUID.String()
+		}
+		if message.ExpiresAt.Valid {
+			messagePayload["expires_at"] = message.ExpiresAt.Time.Unix()
+		}
+		if response.Shared != nil {
+			messagePayload["shared"] = response.Shared
+		}
+		if message.IsForwarded {
+			messagePayload["is_forwarded"] = true
+		}
 
 		if err := s.liveService.PublishMessageCreated(ctx, req.ConversationID, req.SenderID, messagePayload); err != nil {
 			log.Error().Err(err).Msg("Failed to publish message.created event")
 		}
 	}
 
+	if message.ThreadRootID.Valid {
+		s.notifyThreadParticipants(ctx, message, response)
+	}
+
 	return response, nil
 }
 
 
-func (s *Service) GetConversationMessages(ctx context.Context, params GetConversationMessagesParams) ([]MessageDetailResponse, error) {
-	offset := (params.Page - 1) * params.Limit
-	
+func (s *Service) GetConversationMessages(ctx context.Context, params GetConversationMessagesParams) (*util.Page[MessageDetailResponse], error) {
+	page := params.Pagination
+
 	messages, err := s.store.GetConversationMessages(ctx, db.GetConversationMessagesParams{
-		ConversationID: params.ConversationID,
-		Limit:          params.Limit,
-		Offset:         offset,
+		ConversationID:  params.ConversationID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversation messages: %w", err)
 	}
-	
-	return s.toMessageDetailResponses(messages), nil
+
+	messages, hasMore := util.TrimPage(messages, page)
+	responses := s.toMessageDetailResponses(messages)
+	if s.mentionService != nil && len(responses) > 0 {
+		messageIDs := make([]uuid.UUID, len(responses))
+		for i, m := range responses {
+			messageIDs[i] = m.ID
+		}
+
+		entities, err := s.mentionService.Load(ctx, mentions.SourceMessage, messageIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range responses {
+			responses[i].Mentions = entities[responses[i].ID]
+		}
+	}
+	if err := s.attachThreadSummaries(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReceipts(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.resolveDetailSharedPreviews(ctx, params.UserID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := messages[len(messages)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountConversationMessages(ctx, params.ConversationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count messages: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
-func (s *Service) GetMessageByID(ctx context.Context, messageID uuid.UUID) (*MessageResponse, error) {
+func (s *Service) GetMessageByID(ctx context.Context, messageID, viewerID uuid.UUID) (*MessageResponse, error) {
 	message, err := s.store.GetMessageByID(ctx, messageID)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -221,8 +418,33 @@ func (s *Service) GetMessageByID(ctx context.Context, messageID uuid.UUID) (*Mes
 		}
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
+
+	response := s.toMessageResponse(message)
+	if err := s.resolveSharedPreviews(ctx, viewerID, response.Shared); err != nil {
+		return nil, err
+	}
+	if !message.ThreadRootID.Valid {
+		summaries, err := s.loadThreadSummaries(ctx, []uuid.UUID{message.ID})
+		if err != nil {
+			return nil, err
+		}
+		if summary, ok := summaries[message.ID]; ok {
+			response.ReplyCount = summary.replyCount
+			response.LastReplyAt = summary.lastReplyAt
+			response.LatestRepliers = summary.repliers
+		}
+	}
+
+	receipts, err := s.loadReceipts(ctx, []uuid.UUID{message.ID})
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := receipts[message.ID]; ok {
+		response.Receipts = r
+		response.IsRead = r.RecipientCount > 0 && r.ReadCount == r.RecipientCount
+	}
 	
-	return s.toMessageResponse(message), nil
+	return response, nil
 }
 
 
@@ -239,37 +461,43 @@ func (s *Service) DeleteMessage(ctx context.Context, messageID, senderID uuid.UU
 }
 
 
-func (s *Service) MarkMessagesAsRead(ctx context.Context, conversationID, userID uuid.UUID) error {
-	
-	messages, err := s.store.GetConversationMessages(ctx, db.GetConversationMessagesParams{
-		ConversationID: conversationID,
-		Limit:          100, 
-		Offset:         0,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to get messages: %w", err)
-	}
-
-	
-	var messageIDs []uuid.UUID
-	for _, msg := range messages {
-		if msg.RecipientID.Valid && msg.RecipientID.UUID == userID && msg.IsRead.Valid && !msg.IsRead.Bool {
-			messageIDs = append(messageIDs, msg.ID)
-		}
+func (s *Service) MarkMessagesAsRead(ctx context.Context, conversationID, userID uuid.UUID, req MarkMessagesRequest) error {
+	if err := s.checkCursorTarget(ctx, conversationID, userID, req.MessageID); err != nil {
+		return err
 	}
 
-	err = s.store.MarkMessagesAsRead(ctx, db.MarkMessagesAsReadParams{
+	cursor, err := s.store.MarkMessagesAsRead(ctx, db.MarkMessagesAsReadParams{
 		ConversationID: conversationID,
-		RecipientID:    uuid.NullUUID{UUID: userID, Valid: true},
+		MessageID:      nullUUID(req.MessageID),
+		UserID:         userID,
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
 		return fmt.Errorf("failed to mark messages as read: %w", err)
 	}
 
 	
-	if s.liveService != nil && len(messageIDs) > 0 {
-		if err := s.liveService.PublishMessageRead(ctx, conversationID, messageIDs, userID); err != nil {
-			log.Error().Err(err).Msg("Failed to publish message.read event")
+	visible, err := s.receiptsVisible(ctx, conversationID, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check message request status")
+	}
+	if s.liveService != nil && visible {
+		messageIDs, err := s.store.GetMessageIDsInCursorRange(ctx, db.GetMessageIDsInCursorRangeParams{
+			ConversationID: conversationID,
+			UserID:         userID,
+			AfterAt:        cursor.PreviousMessageAt,
+			AfterID:        cursor.PreviousMessageID,
+			UptoAt:         cursor.MessageAt.Time,
+			UptoID:         cursor.MessageID,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to load newly read messages")
+		} else if len(messageIDs) > 0 {
+			if err := s.liveService.PublishMessageRead(ctx, conversationID, messageIDs, userID); err != nil {
+				log.Error().Err(err).Msg("Failed to publish message.read event")
+			}
 		}
 	}
 
@@ -280,7 +508,7 @@ func (s *Service) MarkMessagesAsRead(ctx context.Context, conversationID, userID
 func (s *Service) GetUnreadMessageCount(ctx context.Context, conversationID, userID uuid.UUID) (int64, error) {
 	count, err := s.store.GetUnreadMessageCount(ctx, db.GetUnreadMessageCountParams{
 		ConversationID: conversationID,
-		RecipientID:    uuid.NullUUID{UUID: userID, Valid: true},
+		UserID:         userID,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get unread count: %w", err)
@@ -300,21 +528,21 @@ func (s *Service) GetConversationParticipants(ctx context.Context, conversationI
 }
 
 
-func (s *Service) AddConversationParticipants(ctx context.Context, conversationID uuid.UUID, userIDs []uuid.UUID) error {
-	err := s.store.AddConversationParticipants(ctx, db.AddConversationParticipantsParams{
-		ConversationID: conversationID,
-		Column2:        userIDs,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to add participants: %w", err)
+func (s *Service) LeaveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
+	role, err := s.requireGroupRole(ctx, conversationID, userID, RoleOwner, RoleAdmin, RoleMember)
+	if err == nil {
+		
+		if role == RoleOwner {
+			if err := s.handOverOwnership(ctx, conversationID, userID); err != nil {
+				return err
+			}
+		}
+		s.postSystemMessage(ctx, conversationID, userID, fmt.Sprintf("%s left", s.displayName(ctx, userID)))
+	} else if !errors.Is(err, util.ErrBadRequest) {
+		return err
 	}
-	
-	return nil
-}
 
-
-func (s *Service) LeaveConversation(ctx context.Context, conversationID, userID uuid.UUID) error {
-	err := s.store.LeaveConversation(ctx, db.LeaveConversationParams{
+	err = s.store.LeaveConversation(ctx, db.LeaveConversationParams{
 		ConversationID: conversationID,
 		UserID:         userID,
 	})
@@ -515,6 +743,14 @@ func (s *Service) toUserConversationResponse(c db.GetUserConversationsRow) Conve
 	if c.NotificationsEnabled.Valid {
 		resp.NotificationsEnabled = c.NotificationsEnabled.Bool
 	}
+	if c.MutedUntil.Valid && c.MutedUntil.Time.After(time.Now()) {
+		resp.MutedUntil = &c.MutedUntil.Time
+	}
+	resp.IsMuted = (c.NotificationsEnabled.Valid && !c.NotificationsEnabled.Bool) || resp.MutedUntil != nil
+	if c.ArchivedAt.Valid {
+		resp.IsArchived = true
+		resp.ArchivedAt = &c.ArchivedAt.Time
+	}
 	if c.LastMessageContent.Valid {
 		resp.LastMessageContent = &c.LastMessageContent.String
 	}
@@ -568,6 +804,21 @@ func (s *Service) toBasicMessageResponse(m db.Message) *MessageResponse {
 	if m.CreatedAt.Valid {
 		resp.CreatedAt = &m.CreatedAt.Time
 	}
+	if m.EditedAt.Valid {
+		resp.EditedAt = &m.EditedAt.Time
+		resp.IsEdited = true
+	}
+	if m.ThreadRootID.Valid {
+		resp.ThreadRootID = &m.ThreadRootID.UUID
+	}
+	if m.ExpiresAt.Valid {
+		resp.ExpiresAt = &m.ExpiresAt.Time
+	}
+	resp.Shared = toSharedContentResponse(m.SharedType, m.SharedID, m.SharedPreview)
+	resp.IsForwarded = m.IsForwarded
+	if m.ForwardedFromID.Valid {
+		resp.ForwardedFromID = &m.ForwardedFromID.UUID
+	}
 	
 	return resp
 }
@@ -614,6 +865,21 @@ func (s *Service) toMessageResponse(m db.GetMessageByIDRow) *MessageResponse {
 	if m.SenderAvatar.Valid {
 		resp.SenderAvatar = &m.SenderAvatar.String
 	}
+	if m.EditedAt.Valid {
+		resp.EditedAt = &m.EditedAt.Time
+		resp.IsEdited = true
+	}
+	if m.ThreadRootID.Valid {
+		resp.ThreadRootID = &m.ThreadRootID.UUID
+	}
+	if m.ExpiresAt.Valid {
+		resp.ExpiresAt = &m.ExpiresAt.Time
+	}
+	resp.Shared = toSharedContentResponse(m.SharedType, m.SharedID, m.SharedPreview)
+	resp.IsForwarded = m.IsForwarded
+	if m.ForwardedFromID.Valid {
+		resp.ForwardedFromID = &m.ForwardedFromID.UUID
+	}
 	
 	return resp
 }
@@ -674,6 +940,21 @@ func (s *Service) toMessageDetailResponse(m db.GetConversationMessagesRow) Messa
 	if m.ReplyUsername.Valid {
 		resp.ReplyUsername = &m.ReplyUsername.String
 	}
+	if m.EditedAt.Valid {
+		resp.EditedAt = &m.EditedAt.Time
+		resp.IsEdited = true
+	}
+	if m.ThreadRootID.Valid {
+		resp.ThreadRootID = &m.ThreadRootID.UUID
+	}
+	if m.ExpiresAt.Valid {
+		resp.ExpiresAt = &m.ExpiresAt.Time
+	}
+	resp.Shared = toSharedContentResponse(m.SharedType, m.SharedID, m.SharedPreview)
+	resp.IsForwarded = m.IsForwarded
+	if m.ForwardedFromID.Valid {
+		resp.ForwardedFromID = &m.ForwardedFromID.UUID
+	}
 	
 	return resp
 }
@@ -0,0 +1,281 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+const (
+	SharedTypePost      = "post"
+	SharedTypeEvent     = "event"
+	SharedTypeCommunity = "community"
+)
+
+
+const sharedMessagePrefix = "shared_"
+
+
+func (s *Service) ForwardMessage(ctx context.Context, messageID, userID uuid.UUID, req ForwardMessageRequest) ([]*MessageResponse, error) {
+	original, err := s.store.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if original.Status.String == "deleted" || (original.ExpiresAt.Valid && !original.ExpiresAt.Time.After(time.Now())) {
+		return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+	}
+	if original.ExpiresAt.Valid {
+		return nil, fmt.Errorf("%w: disappearing messages cannot be forwarded", util.ErrForbidden)
+	}
+	if original.MessageType.String == "system" {
+		return nil, fmt.Errorf("%w: system messages cannot be forwarded", util.ErrBadRequest)
+	}
+	if _, err := s.participantRole(ctx, original.ConversationID, userID); err != nil {
+		return nil, err
+	}
+
+	forward := SendMessageRequest{
+		SenderID:        userID,
+		Content:         original.Content.String,
+		MessageType:     original.MessageType.String,
+		ForwardedFromID: &messageID,
+	}
+	if original.Attachments.Valid {
+		forward.Attachments = &original.Attachments
+	}
+	if original.SharedType.Valid && original.SharedID.Valid {
+		forward.MessageType = ""
+		forward.Shared = &SharedContentRequest{
+			Type: original.SharedType.String,
+			ID:   original.SharedID.UUID,
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.ConversationIDs))
+	targets := make([]uuid.UUID, 0, len(req.ConversationIDs))
+	for _, conversationID := range req.ConversationIDs {
+		if seen[conversationID] {
+			continue
+		}
+		seen[conversationID] = true
+		if err := s.checkForwardTarget(ctx, conversationID, userID, forward.Shared); err != nil {
+			return nil, err
+		}
+		targets = append(targets, conversationID)
+	}
+
+	responses := make([]*MessageResponse, 0, len(targets))
+	for _, conversationID := range targets {
+		forward.ConversationID = conversationID
+		response, err := s.SendMessage(ctx, forward)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+
+func (s *Service) checkForwardTarget(ctx context.Context, conversationID, senderID uuid.UUID, shared *SharedContentRequest) error {
+	if _, err := s.participantRole(ctx, conversationID, senderID); err != nil {
+		return err
+	}
+
+	blocked, err := s.store.IsDirectConversationBlocked(ctx, db.IsDirectConversationBlockedParams{
+		SenderID:       senderID,
+		ConversationID: conversationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check block status: %w", err)
+	}
+	if blocked {
+		return fmt.Errorf("%w: cannot message this user", util.ErrForbidden)
+	}
+
+	if shared != nil {
+		return s.checkSharedAccess(ctx, conversationID, senderID, *shared)
+	}
+	return nil
+}
+
+
+func (s *Service) checkSharedAccess(ctx context.Context, conversationID, senderID uuid.UUID, shared SharedContentRequest) error {
+	withoutAccess, err := s.store.GetParticipantsWithoutAccess(ctx, db.GetParticipantsWithoutAccessParams{
+		ConversationID: conversationID,
+		SharedType:     shared.Type,
+		SharedID:       shared.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check %s visibility: %w", shared.Type, err)
+	}
+	for _, userID := range withoutAccess {
+		if userID == senderID {
+			return fmt.Errorf("%w: %s not found", util.ErrNotFound, shared.Type)
+		}
+	}
+	if len(withoutAccess) > 0 {
+		return fmt.Errorf("%w: this %s is not visible to everyone in the conversation", util.ErrForbidden, shared.Type)
+	}
+
+	return nil
+}
+
+
+func (s *Service) sharedContentPreview(ctx context.Context, conversationID, senderID uuid.UUID, shared SharedContentRequest) (pqtype.NullRawMessage, error) {
+	if err := s.checkSharedAccess(ctx, conversationID, senderID, shared); err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+
+	var preview interface{}
+	switch shared.Type {
+	case SharedTypePost:
+		post, err := s.store.GetSharedPost(ctx, shared.ID)
+		if err != nil {
+			return pqtype.NullRawMessage{}, sharedLookupError(shared.Type, err)
+		}
+		postPreview := SharedPostPreview{
+			AuthorID:       post.AuthorID,
+			AuthorUsername: post.AuthorUsername,
+			AuthorFullName: post.AuthorFullName,
+			Content:        post.Content,
+		}
+		if post.AuthorAvatar.Valid {
+			postPreview.AuthorAvatar = &post.AuthorAvatar.String
+		}
+		if post.Media.Valid {
+			postPreview.Media = &post.Media
+		}
+		if post.ContentWarning.Valid {
+			postPreview.ContentWarning = &post.ContentWarning.String
+		}
+		if post.CreatedAt.Valid {
+			postPreview.CreatedAt = &post.CreatedAt.Time
+		}
+		preview = postPreview
+	case SharedTypeEvent:
+		event, err := s.store.GetSharedEvent(ctx, shared.ID)
+		if err != nil {
+			return pqtype.NullRawMessage{}, sharedLookupError(shared.Type, err)
+		}
+		eventPreview := SharedEventPreview{
+			Title:     event.Title,
+			Category:  event.Category,
+			StartDate: event.StartDate,
+			EndDate:   event.EndDate,
+		}
+		if event.Location.Valid {
+			eventPreview.Location = &event.Location.String
+		}
+		if event.ImageUrl.Valid {
+			eventPreview.ImageURL = &event.ImageUrl.String
+		}
+		preview = eventPreview
+	case SharedTypeCommunity:
+		community, err := s.store.GetSharedCommunity(ctx, shared.ID)
+		if err != nil {
+			return pqtype.NullRawMessage{}, sharedLookupError(shared.Type, err)
+		}
+		communityPreview := SharedCommunityPreview{
+			Name:        community.Name,
+			Category:    community.Category,
+			MemberCount: community.MemberCount.Int32,
+		}
+		if community.Description.Valid {
+			communityPreview.Description = &community.Description.String
+		}
+		if community.CoverImage.Valid {
+			communityPreview.CoverImage = &community.CoverImage.String
+		}
+		preview = communityPreview
+	default:
+		return pqtype.NullRawMessage{}, fmt.Errorf("%w: unsupported shared type %q", util.ErrBadRequest, shared.Type)
+	}
+
+	raw, err := json.Marshal(preview)
+	if err != nil {
+		return pqtype.NullRawMessage{}, fmt.Errorf("failed to encode %s preview: %w", shared.Type, err)
+	}
+
+	return pqtype.NullRawMessage{RawMessage: raw, Valid: true}, nil
+}
+
+
+func (s *Service) resolveSharedPreviews(ctx context.Context, viewerID uuid.UUID, shared ...*SharedContentResponse) error {
+	visible := make(map[string]bool)
+	for _, content := range shared {
+		if content == nil || content.Preview == nil {
+			continue
+		}
+
+		key := content.Type + ":" + content.ID.String()
+		canView, ok := visible[key]
+		if !ok {
+			var err error
+			canView, err = s.store.CanViewSharedContent(ctx, db.CanViewSharedContentParams{
+				ViewerID:   viewerID,
+				SharedType: content.Type,
+				SharedID:   content.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to check %s visibility: %w", content.Type, err)
+			}
+			visible[key] = canView
+		}
+		if !canView {
+			content.Preview = nil
+			content.Unavailable = true
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) resolveDetailSharedPreviews(ctx context.Context, viewerID uuid.UUID, messages []MessageDetailResponse) error {
+	shared := make([]*SharedContentResponse, len(messages))
+	for i := range messages {
+		shared[i] = messages[i].Shared
+	}
+
+	return s.resolveSharedPreviews(ctx, viewerID, shared...)
+}
+
+
+func sharedLookupError(sharedType string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s not found", util.ErrNotFound, sharedType)
+	}
+
+	return fmt.Errorf("failed to get shared %s: %w", sharedType, err)
+}
+
+
+func toSharedContentResponse(sharedType sql.NullString, sharedID uuid.NullUUID, preview pqtype.NullRawMessage) *SharedContentResponse {
+	if !sharedType.Valid || !sharedID.Valid {
+		return nil
+	}
+
+	resp := &SharedContentResponse{
+		Type: sharedType.String,
+		ID:   sharedID.UUID,
+	}
+	if preview.Valid {
+		resp.Preview = &preview
+	}
+
+	return resp
+}
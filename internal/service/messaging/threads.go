@@ -0,0 +1,221 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/connect-univyn/connect-server/internal/service/notifications"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+const maxLatestRepliers = 3
+
+
+type threadSummary struct {
+	replyCount  int64
+	lastReplyAt *time.Time
+	repliers    []ThreadReplierResponse
+}
+
+
+func (s *Service) GetThreadReplies(ctx context.Context, params GetThreadRepliesParams) (*util.Page[MessageDetailResponse], error) {
+	root, err := s.store.GetMessageByID(ctx, params.RootID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: message not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+	if root.ThreadRootID.Valid {
+		return nil, fmt.Errorf("%w: message is not a thread root", util.ErrBadRequest)
+	}
+
+	if _, err := s.participantRole(ctx, root.ConversationID, params.UserID); err != nil {
+		return nil, err
+	}
+
+	page := params.Pagination
+	replies, err := s.store.GetThreadReplies(ctx, db.GetThreadRepliesParams{
+		RootID:          params.RootID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread replies: %w", err)
+	}
+
+	replies, hasMore := util.TrimPage(replies, page)
+	responses := make([]MessageDetailResponse, len(replies))
+	for i, r := range replies {
+		responses[i] = s.toMessageDetailResponse(db.GetConversationMessagesRow(r))
+	}
+	if s.mentionService != nil && len(responses) > 0 {
+		messageIDs := make([]uuid.UUID, len(responses))
+		for i, m := range responses {
+			messageIDs[i] = m.ID
+		}
+
+		entities, err := s.mentionService.Load(ctx, mentions.SourceMessage, messageIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range responses {
+			responses[i].Mentions = entities[responses[i].ID]
+		}
+	}
+	if err := s.attachReceipts(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.resolveDetailSharedPreviews(ctx, params.UserID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := replies[len(replies)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) attachThreadSummaries(ctx context.Context, responses []MessageDetailResponse) error {
+	rootIDs := make([]uuid.UUID, 0, len(responses))
+	for _, m := range responses {
+		if m.ThreadRootID == nil {
+			rootIDs = append(rootIDs, m.ID)
+		}
+	}
+	if len(rootIDs) == 0 {
+		return nil
+	}
+
+	summaries, err := s.loadThreadSummaries(ctx, rootIDs)
+	if err != nil {
+		return err
+	}
+	for i := range responses {
+		if summary, ok := summaries[responses[i].ID]; ok {
+			responses[i].ReplyCount = summary.replyCount
+			responses[i].LastReplyAt = summary.lastReplyAt
+			responses[i].LatestRepliers = summary.repliers
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) loadThreadSummaries(ctx context.Context, rootIDs []uuid.UUID) (map[uuid.UUID]*threadSummary, error) {
+	counts, err := s.store.GetThreadSummaries(ctx, rootIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread summaries: %w", err)
+	}
+
+	summaries := make(map[uuid.UUID]*threadSummary, len(counts))
+	if len(counts) == 0 {
+		return summaries, nil
+	}
+	for _, c := range counts {
+		lastReplyAt := c.LastReplyAt
+		summaries[c.RootID] = &threadSummary{
+			replyCount:  c.ReplyCount,
+			lastReplyAt: &lastReplyAt,
+		}
+	}
+
+	repliers, err := s.store.GetThreadLatestRepliers(ctx, db.GetThreadLatestRepliersParams{
+		RootIds:   rootIDs,
+		PerThread: maxLatestRepliers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread repliers: %w", err)
+	}
+	for _, r := range repliers {
+		summary, ok := summaries[r.RootID]
+		if !ok {
+			continue
+		}
+		replier := ThreadReplierResponse{
+			UserID:   r.UserID,
+			Username: r.Username,
+		}
+		if r.Avatar.Valid {
+			replier.Avatar = &r.Avatar.String
+		}
+		summary.repliers = append(summary.repliers, replier)
+	}
+
+	return summaries, nil
+}
+
+
+func (s *Service) notifyThreadParticipants(ctx context.Context, message db.Message, response *MessageResponse) {
+	if s.notificationService == nil {
+		return
+	}
+
+	rootID := message.ThreadRootID.UUID
+	recipients, err := s.store.GetThreadParticipantsToNotify(ctx, db.GetThreadParticipantsToNotifyParams{
+		RootID:    rootID,
+		ReplierID: message.SenderID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("message_id", message.ID.String()).Msg("Failed to get thread participants")
+		return
+	}
+
+	
+	mentioned := make(map[uuid.UUID]bool, len(response.Mentions))
+	for _, m := range response.Mentions {
+		mentioned[m.UserID] = true
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{
+		"conversation_id": message.ConversationID.String(),
+		"thread_root_id":  rootID.String(),
+		"message_id":      message.ID.String(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode thread reply metadata")
+		return
+	}
+
+	title := "New thread reply"
+	text := "New reply in a thread you're part of"
+	if response.SenderUsername != "" {
+		text = fmt.Sprintf("%s replied in a thread you're part of", response.SenderUsername)
+	}
+	senderID := message.SenderID
+	messageID := message.ID
+
+	for _, userID := range recipients {
+		if mentioned[userID] {
+			continue
+		}
+		if _, err := s.notificationService.CreateNotification(ctx, notifications.CreateNotificationRequest{
+			ToUserID:   userID,
+			FromUserID: &senderID,
+			Type:       "thread_reply",
+			Title:      &title,
+			Message:    &text,
+			RelatedID:  &messageID,
+			Metadata:   &pqtype.NullRawMessage{RawMessage: raw, Valid: true},
+		}); err != nil && !errors.Is(err, notifications.ErrNotificationSuppressed) {
+			log.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create thread reply notification")
+		}
+	}
+}
@@ -3,6 +3,8 @@ package messaging
 import (
 	"time"
 
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
@@ -16,6 +18,7 @@ type CreateConversationRequest struct {
 	ConversationType string                 `json:"conversation_type" binding:"required"` 
 	Settings         *pqtype.NullRawMessage `json:"settings,omitempty"`
 	ParticipantIDs   []uuid.UUID            `json:"participant_ids" binding:"required,min=1"`
+	CreatorID        uuid.UUID              `json:"-"`
 }
 
 
@@ -23,10 +26,33 @@ type SendMessageRequest struct {
 	ConversationID uuid.UUID              `json:"conversation_id" binding:"required"`
 	SenderID       uuid.UUID              `json:"sender_id"`
 	RecipientID    *uuid.UUID             `json:"recipient_id,omitempty"`
-	Content        string                 `json:"content" binding:"required,min=1"`
+	Content        string                 `json:"content"`
 	Attachments    *pqtype.NullRawMessage `json:"attachments,omitempty"`
 	MessageType    string                 `json:"message_type,omitempty"` 
 	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
+	Shared         *SharedContentRequest  `json:"shared,omitempty"`
+	ForwardedFromID *uuid.UUID            `json:"-"`
+}
+
+
+type SharedContentRequest struct {
+	Type string    `json:"type" binding:"required,oneof=post event community"`
+	ID   uuid.UUID `json:"id" binding:"required"`
+}
+
+
+type ForwardMessageRequest struct {
+	ConversationIDs []uuid.UUID `json:"conversation_ids" binding:"required,min=1,max=10"`
+}
+
+
+type EditMessageRequest struct {
+	Content string `json:"content" binding:"required,min=1"`
+}
+
+
+type MarkMessagesRequest struct {
+	MessageID *uuid.UUID `json:"message_id,omitempty"`
 }
 
 
@@ -75,6 +101,10 @@ type ConversationDetailResponse struct {
 	UnreadCount          int64                  `json:"unread_count"`
 	UserRole             *string                `json:"user_role,omitempty"`
 	NotificationsEnabled bool                   `json:"notifications_enabled"`
+	IsMuted              bool                   `json:"is_muted"`
+	MutedUntil           *time.Time             `json:"muted_until,omitempty"`
+	IsArchived           bool                   `json:"is_archived"`
+	ArchivedAt           *time.Time             `json:"archived_at,omitempty"`
 	LastMessageContent   *string                `json:"last_message_content,omitempty"`
 	LastMessageTime      *time.Time             `json:"last_message_time,omitempty"`
 	LastSenderUsername   *string                `json:"last_sender_username,omitempty"`
@@ -96,9 +126,21 @@ type MessageResponse struct {
 	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
 	Status         string                 `json:"status"`
 	CreatedAt      *time.Time             `json:"created_at,omitempty"`
+	EditedAt       *time.Time             `json:"edited_at,omitempty"`
+	IsEdited       bool                   `json:"is_edited"`
+	ThreadRootID   *uuid.UUID             `json:"thread_root_id,omitempty"`
+	ExpiresAt      *time.Time             `json:"expires_at,omitempty"`
+	Shared         *SharedContentResponse `json:"shared,omitempty"`
+	IsForwarded    bool                   `json:"is_forwarded"`
+	ForwardedFromID *uuid.UUID            `json:"forwarded_from_id,omitempty"`
+	ReplyCount     int64                  `json:"reply_count"`
+	LastReplyAt    *time.Time             `json:"last_reply_at,omitempty"`
+	LatestRepliers []ThreadReplierResponse `json:"latest_repliers,omitempty"`
+	Receipts       *MessageReceipts       `json:"receipts,omitempty"`
 	SenderUsername string                 `json:"sender_username"`
 	SenderFullName string                 `json:"sender_full_name"`
 	SenderAvatar   *string                `json:"sender_avatar,omitempty"`
+	Mentions       []mentions.MentionEntity `json:"mentions,omitempty"`
 }
 
 
@@ -116,11 +158,23 @@ type MessageDetailResponse struct {
 	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
 	Status         string                 `json:"status"`
 	CreatedAt      *time.Time             `json:"created_at,omitempty"`
+	EditedAt       *time.Time             `json:"edited_at,omitempty"`
+	IsEdited       bool                   `json:"is_edited"`
+	ThreadRootID   *uuid.UUID             `json:"thread_root_id,omitempty"`
+	ExpiresAt      *time.Time             `json:"expires_at,omitempty"`
+	Shared         *SharedContentResponse `json:"shared,omitempty"`
+	IsForwarded    bool                   `json:"is_forwarded"`
+	ForwardedFromID *uuid.UUID            `json:"forwarded_from_id,omitempty"`
+	ReplyCount     int64                  `json:"reply_count"`
+	LastReplyAt    *time.Time             `json:"last_reply_at,omitempty"`
+	LatestRepliers []ThreadReplierResponse `json:"latest_repliers,omitempty"`
+	Receipts       *MessageReceipts       `json:"receipts,omitempty"`
 	SenderUsername string                 `json:"sender_username"`
 	SenderFullName string                 `json:"sender_full_name"`
 	SenderAvatar   *string                `json:"sender_avatar,omitempty"`
 	ReplyContent   *string                `json:"reply_content,omitempty"`
 	ReplyUsername  *string                `json:"reply_username,omitempty"`
+	Mentions       []mentions.MentionEntity `json:"mentions,omitempty"`
 }
 
 
@@ -137,8 +191,265 @@ type ParticipantResponse struct {
 }
 
 
+type ThreadReplierResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Avatar   *string   `json:"avatar,omitempty"`
+}
+
+
+type MessageReceipts struct {
+	RecipientCount int64 `json:"recipient_count"`
+	DeliveredCount int64 `json:"delivered_count"`
+	ReadCount      int64 `json:"read_count"`
+}
+
+
+type SeenByResponse struct {
+	UserID   uuid.UUID  `json:"user_id"`
+	Username string     `json:"username"`
+	FullName string     `json:"full_name"`
+	Avatar   *string    `json:"avatar,omitempty"`
+	ReadAt   *time.Time `json:"read_at,omitempty"`
+}
+
+
+type MessageRequestResponse struct {
+	ConversationID     uuid.UUID  `json:"conversation_id"`
+	RequesterID        uuid.UUID  `json:"requester_id"`
+	RequesterUsername  string     `json:"requester_username"`
+	RequesterFullName  string     `json:"requester_full_name"`
+	RequesterAvatar    *string    `json:"requester_avatar,omitempty"`
+	LastMessageContent *string    `json:"last_message_content,omitempty"`
+	LastMessageTime    *time.Time `json:"last_message_time,omitempty"`
+	MessageCount       int64      `json:"message_count"`
+	RequestedAt        *time.Time `json:"requested_at,omitempty"`
+}
+
+
+type MessageRevisionResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Content        string    `json:"content"`
+	EditedBy       uuid.UUID `json:"edited_by"`
+	EditorUsername string    `json:"editor_username"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+
 type GetConversationMessagesParams struct {
 	ConversationID uuid.UUID
-	Page           int32
-	Limit          int32
+	UserID         uuid.UUID
+	Pagination     util.PageRequest
+}
+
+
+type GetUserConversationsParams struct {
+	UserID   uuid.UUID
+	Archived *bool
+	Muted    *bool
+	Unread   *bool
+}
+
+
+type GetThreadRepliesParams struct {
+	RootID     uuid.UUID
+	UserID     uuid.UUID
+	Pagination util.PageRequest
+}
+
+
+type SearchMessagesRequest struct {
+	Query          string
+	UserID         uuid.UUID
+	ConversationID *uuid.UUID
+	SenderID       *uuid.UUID
+	After          *time.Time
+	Before         *time.Time
+	HasAttachment  *bool
+	Page           util.PageRequest
+}
+
+
+type MessageContextResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	SenderID       uuid.UUID  `json:"sender_id"`
+	SenderUsername string     `json:"sender_username"`
+	Content        string     `json:"content"`
+	MessageType    string     `json:"message_type"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+}
+
+
+type MessageSearchResultResponse struct {
+	ID               uuid.UUID                `json:"id"`
+	ConversationID   uuid.UUID                `json:"conversation_id"`
+	ConversationName *string                  `json:"conversation_name,omitempty"`
+	ConversationType string                   `json:"conversation_type"`
+	SenderID         uuid.UUID                `json:"sender_id"`
+	SenderUsername   string                   `json:"sender_username"`
+	SenderFullName   string                   `json:"sender_full_name"`
+	SenderAvatar     *string                  `json:"sender_avatar,omitempty"`
+	Content          string                   `json:"content"`
+	Highlight        string                   `json:"highlight"`
+	Attachments      *pqtype.NullRawMessage   `json:"attachments,omitempty"`
+	MessageType      string                   `json:"message_type"`
+	CreatedAt        *time.Time               `json:"created_at,omitempty"`
+	Score            float64                  `json:"score"`
+	Before           []MessageContextResponse `json:"before"`
+	After            []MessageContextResponse `json:"after"`
+}
+
+
+type MessageSearchResponse struct {
+	*util.Page[MessageSearchResultResponse]
+	Query string `json:"query"`
+}
+
+
+type UpdateConversationRequest struct {
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=150"`
+	Avatar      *string `json:"avatar,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+
+type UpdateParticipantRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member"`
+}
+
+
+type TransferOwnershipRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
+
+type CreateInviteRequest struct {
+	ExpiresInHours *int   `json:"expires_in_hours,omitempty" binding:"omitempty,min=1,max=8760"`
+	MaxUses        *int32 `json:"max_uses,omitempty" binding:"omitempty,min=1"`
+}
+
+
+type InviteResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	Code           string     `json:"code"`
+	JoinPath       string     `json:"join_path"`
+	CreatedBy      uuid.UUID  `json:"created_by"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	MaxUses        *int32     `json:"max_uses,omitempty"`
+	UseCount       int32      `json:"use_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+
+type MuteConversationRequest struct {
+	Until time.Time `json:"until" binding:"required"`
+}
+
+
+type DisappearingMessagesRequest struct {
+	Seconds *int32 `json:"seconds" binding:"required,min=0"`
+}
+
+
+type PinnedMessageResponse struct {
+	ID               uuid.UUID              `json:"id"`
+	ConversationID   uuid.UUID              `json:"conversation_id"`
+	SenderID         uuid.UUID              `json:"sender_id"`
+	SenderUsername   string                 `json:"sender_username"`
+	SenderFullName   string                 `json:"sender_full_name"`
+	SenderAvatar     *string                `json:"sender_avatar,omitempty"`
+	Content          string                 `json:"content"`
+	Attachments      *pqtype.NullRawMessage `json:"attachments,omitempty"`
+	MessageType      string                 `json:"message_type"`
+	CreatedAt        *time.Time             `json:"created_at,omitempty"`
+	PinnedBy         uuid.UUID              `json:"pinned_by"`
+	PinnedByUsername string                 `json:"pinned_by_username"`
+	PinnedAt         time.Time              `json:"pinned_at"`
+}
+
+
+type SharedContentResponse struct {
+	Type        string                 `json:"type"`
+	ID          uuid.UUID              `json:"id"`
+	Preview     *pqtype.NullRawMessage `json:"preview,omitempty"`
+	Unavailable bool                   `json:"unavailable"`
+}
+
+
+type SharedPostPreview struct {
+	AuthorID       uuid.UUID              `json:"author_id"`
+	AuthorUsername string                 `json:"author_username"`
+	AuthorFullName string                 `json:"author_full_name"`
+	AuthorAvatar   *string                `json:"author_avatar,omitempty"`
+	Content        string                 `json:"content"`
+	Media          *pqtype.NullRawMessage `json:"media,omitempty"`
+	ContentWarning *string                `json:"content_warning,omitempty"`
+	CreatedAt      *time.Time             `json:"created_at,omitempty"`
+}
+
+
+type SharedEventPreview struct {
+	Title     string    `json:"title"`
+	Category  string    `json:"category"`
+	Location  *string   `json:"location,omitempty"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	ImageURL  *string   `json:"image_url,omitempty"`
+}
+
+
+type SharedCommunityPreview struct {
+	Name        string  `json:"name"`
+	Category    string  `json:"category"`
+	Description *string `json:"description,omitempty"`
+	CoverImage  *string `json:"cover_image,omitempty"`
+	MemberCount int32   `json:"member_count"`
+}
+
+
+type ExportConversationRequest struct {
+	ConversationID uuid.UUID
+	UserID         uuid.UUID
+	Format         string
+	IPAddress      string
+	UserAgent      string
+}
+
+
+type ConversationExportResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	ConversationID uuid.UUID  `json:"conversation_id"`
+	Format         string     `json:"format"`
+	Status         string     `json:"status"`
+	MessageCount   int64      `json:"message_count"`
+	DownloadURL    *string    `json:"download_url,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+
+type ConversationExportFile struct {
+	ConversationID uuid.UUID
+	Format         string
+	Content        []byte
+}
+
+
+type ExportedMessage struct {
+	ID             uuid.UUID              `json:"id"`
+	SenderID       uuid.UUID              `json:"sender_id"`
+	SenderUsername string                 `json:"sender_username"`
+	SenderFullName string                 `json:"sender_full_name"`
+	Content        string                 `json:"content"`
+	MessageType    string                 `json:"message_type"`
+	Attachments    []string               `json:"attachments,omitempty"`
+	ReplyToID      *uuid.UUID             `json:"reply_to_id,omitempty"`
+	ThreadRootID   *uuid.UUID             `json:"thread_root_id,omitempty"`
+	Shared         *SharedContentResponse `json:"shared,omitempty"`
+	IsForwarded    bool                   `json:"is_forwarded"`
+	CreatedAt      time.Time              `json:"created_at"`
+	EditedAt       *time.Time             `json:"edited_at,omitempty"`
 }
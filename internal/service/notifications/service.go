@@ -7,12 +7,16 @@ import (
 
 	db "github.com/connect-univyn/connect-server/db/sqlc"
 	"github.com/connect-univyn/connect-server/internal/live"
+	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/sqlc-dev/pqtype"
 )
 
 
+var ErrNotificationSuppressed = fmt.Errorf("%w: recipient does not accept notifications from this user", util.ErrForbidden)
+
+
 type Service struct {
 	store       db.Store
 	liveService *live.Service
@@ -35,6 +39,17 @@ func (s *Service) CreateNotification(ctx context.Context, req CreateNotification
 	
 	if req.FromUserID != nil {
 		fromUserID = uuid.NullUUID{UUID: *req.FromUserID, Valid: true}
+
+		blocked, err := s.store.IsBlockedBetween(ctx, db.IsBlockedBetweenParams{
+			UserA: req.ToUserID,
+			UserB: *req.FromUserID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check block status: %w", err)
+		}
+		if blocked {
+			return nil, ErrNotificationSuppressed
+		}
 	}
 	if req.RelatedID != nil {
 		relatedID = uuid.NullUUID{UUID: *req.RelatedID, Valid: true}
@@ -110,22 +125,40 @@ func (s *Service) CreateNotification(ctx context.Context, req CreateNotification
 }
 
 
-func (s *Service) GetUserNotifications(ctx context.Context, params GetNotificationsParams) ([]NotificationWithUserResponse, error) {
+func (s *Service) GetUserNotifications(ctx context.Context, params GetNotificationsParams) (*util.Page[NotificationWithUserResponse], error) {
+	page := params.Pagination
 	notifications, err := s.store.GetUserNotifications(ctx, db.GetUserNotificationsParams{
-		ToUserID: params.UserID,
-		Limit:    params.Limit,
-		Offset:   params.Offset,
+		ToUserID:        params.UserID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user notifications: %w", err)
 	}
 
+	notifications, hasMore := util.TrimPage(notifications, page)
 	responses := make([]NotificationWithUserResponse, len(notifications))
 	for i, n := range notifications {
 		responses[i] = s.rowToNotificationResponse(n)
 	}
 
-	return responses, nil
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := notifications[len(notifications)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountUserNotifications(ctx, params.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count notifications: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
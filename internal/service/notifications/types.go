@@ -3,6 +3,7 @@ package notifications
 import (
 	"time"
 
+	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
@@ -57,10 +58,8 @@ type NotificationWithUserResponse struct {
 
 
 type GetNotificationsParams struct {
-	UserID   uuid.UUID
-	IsRead   *bool 
-	Page     int32
-	Limit    int32
-	Offset   int32
-	Priority *string 
+	UserID     uuid.UUID
+	IsRead     *bool 
+	Pagination util.PageRequest
+	Priority   *string 
 }
@@ -0,0 +1,290 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+)
+
+
+func (s *Service) AddBookmark(ctx context.Context, userID, postID uuid.UUID, req BookmarkRequest) (*BookmarkResponse, error) {
+	if _, err := s.store.GetPostByID(ctx, db.GetPostByIDParams{UserID: userID, ID: postID}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: post not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	collectionID, err := s.resolveBookmarkCollection(ctx, userID, req.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.store.UpsertBookmark(ctx, db.UpsertBookmarkParams{
+		UserID:       userID,
+		PostID:       postID,
+		CollectionID: collectionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bookmark post: %w", err)
+	}
+
+	return toBookmarkResponse(bookmark), nil
+}
+
+
+func (s *Service) RemoveBookmark(ctx context.Context, userID, postID uuid.UUID) error {
+	removed, err := s.store.DeleteBookmark(ctx, db.DeleteBookmarkParams{
+		UserID: userID,
+		PostID: postID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%w: bookmark not found", util.ErrNotFound)
+	}
+
+	return nil
+}
+
+
+func (s *Service) MoveBookmark(ctx context.Context, userID, postID uuid.UUID, req BookmarkRequest) (*BookmarkResponse, error) {
+	collectionID, err := s.resolveBookmarkCollection(ctx, userID, req.CollectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark, err := s.store.MoveBookmark(ctx, db.MoveBookmarkParams{
+		CollectionID: collectionID,
+		UserID:       userID,
+		PostID:       postID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: bookmark not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to move bookmark: %w", err)
+	}
+
+	return toBookmarkResponse(bookmark), nil
+}
+
+
+func (s *Service) GetBookmarks(ctx context.Context, userID uuid.UUID, collectionID *uuid.UUID, page util.PageRequest) (*util.Page[*BookmarkedPostResponse], error) {
+	filter, err := s.resolveBookmarkCollection(ctx, userID, collectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.GetUserBookmarks(ctx, db.GetUserBookmarksParams{
+		UserID:          userID,
+		CollectionID:    filter,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmarks: %w", err)
+	}
+
+	rows, hasMore := util.TrimPage(rows, page)
+
+	posts := make([]*PostResponse, len(rows))
+	bookmarks := make([]*BookmarkedPostResponse, len(rows))
+	for i, row := range rows {
+		posts[i] = s.toBookmarkedPostResponse(row)
+
+		bookmarks[i] = &BookmarkedPostResponse{
+			PostResponse: posts[i],
+			BookmarkedAt: row.BookmarkedAt,
+		}
+		if row.BookmarkCollectionID.Valid {
+			bookmarks[i].CollectionID = &row.BookmarkCollectionID.UUID
+		}
+	}
+
+	if err := s.attachPolls(ctx, userID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, userID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, userID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, userID, posts); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(bookmarks, page, hasMore)
+	if hasMore {
+		last := rows[len(rows)-1]
+		result.SetNextCursor(last.BookmarkedAt, last.BookmarkID)
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) CreateBookmarkCollection(ctx context.Context, userID uuid.UUID, req BookmarkCollectionRequest) (*BookmarkCollectionResponse, error) {
+	collection, err := s.store.CreateBookmarkCollection(ctx, db.CreateBookmarkCollectionParams{
+		UserID: userID,
+		Name:   req.Name,
+	})
+	if err != nil {
+		if util.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("%w: a collection with this name already exists", util.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to create bookmark collection: %w", err)
+	}
+
+	return toBookmarkCollectionResponse(collection, 0), nil
+}
+
+
+func (s *Service) GetBookmarkCollections(ctx context.Context, userID uuid.UUID) ([]*BookmarkCollectionResponse, error) {
+	collections, err := s.store.GetBookmarkCollections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookmark collections: %w", err)
+	}
+
+	responses := make([]*BookmarkCollectionResponse, len(collections))
+	for i, c := range collections {
+		responses[i] = toBookmarkCollectionResponse(db.BookmarkCollection{
+			ID:        c.ID,
+			UserID:    c.UserID,
+			Name:      c.Name,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}, c.BookmarksCount)
+	}
+
+	return responses, nil
+}
+
+
+func (s *Service) RenameBookmarkCollection(ctx context.Context, userID, collectionID uuid.UUID, req BookmarkCollectionRequest) (*BookmarkCollectionResponse, error) {
+	collection, err := s.store.RenameBookmarkCollection(ctx, db.RenameBookmarkCollectionParams{
+		Name:   req.Name,
+		ID:     collectionID,
+		UserID: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: bookmark collection not found", util.ErrNotFound)
+		}
+		if util.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("%w: a collection with this name already exists", util.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to rename bookmark collection: %w", err)
+	}
+
+	return toBookmarkCollectionResponse(collection, 0), nil
+}
+
+
+func (s *Service) DeleteBookmarkCollection(ctx context.Context, userID, collectionID uuid.UUID) error {
+	deleted, err := s.store.DeleteBookmarkCollection(ctx, db.DeleteBookmarkCollectionParams{
+		ID:     collectionID,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete bookmark collection: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: bookmark collection not found", util.ErrNotFound)
+	}
+
+	return nil
+}
+
+
+func (s *Service) resolveBookmarkCollection(ctx context.Context, userID uuid.UUID, collectionID *uuid.UUID) (uuid.NullUUID, error) {
+	if collectionID == nil {
+		return uuid.NullUUID{}, nil
+	}
+
+	if _, err := s.store.GetBookmarkCollection(ctx, db.GetBookmarkCollectionParams{
+		ID:     *collectionID,
+		UserID: userID,
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.NullUUID{}, fmt.Errorf("%w: bookmark collection not found", util.ErrNotFound)
+		}
+		return uuid.NullUUID{}, fmt.Errorf("failed to get bookmark collection: %w", err)
+	}
+
+	return uuid.NullUUID{UUID: *collectionID, Valid: true}, nil
+}
+
+
+func (s *Service) toBookmarkedPostResponse(row db.GetUserBookmarksRow) *PostResponse {
+	resp := s.toPostResponse(db.Post{
+		ID:             row.ID,
+		AuthorID:       row.AuthorID,
+		SpaceID:        row.SpaceID,
+		CommunityID:    row.CommunityID,
+		GroupID:        row.GroupID,
+		ParentPostID:   row.ParentPostID,
+		QuotedPostID:   row.QuotedPostID,
+		Content:        row.Content,
+		Media:          row.Media,
+		Tags:           row.Tags,
+		LikesCount:     row.LikesCount,
+		CommentsCount:  row.CommentsCount,
+		RepostsCount:   row.RepostsCount,
+		QuotesCount:    row.QuotesCount,
+		ViewsCount:     row.ViewsCount,
+		IsPinned:       row.IsPinned,
+		Visibility:     row.Visibility,
+		Status:         row.Status,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+		PublishAt:      row.PublishAt,
+		ContentWarning: row.ContentWarning,
+		IsSensitive:    row.IsSensitive,
+	})
+
+	isLiked := row.IsLiked
+	isBookmarked := true
+	resp.Username = interfaceToStringPtr(row.Username)
+	resp.FullName = interfaceToStringPtr(row.FullName)
+	if row.AuthorAvatar.Valid {
+		resp.AuthorAvatar = &row.AuthorAvatar.String
+	}
+	resp.IsLiked = &isLiked
+	resp.IsBookmarked = &isBookmarked
+
+	return resp
+}
+
+
+func toBookmarkResponse(bookmark db.Bookmark) *BookmarkResponse {
+	resp := &BookmarkResponse{
+		PostID:    bookmark.PostID,
+		CreatedAt: bookmark.CreatedAt,
+	}
+	if bookmark.CollectionID.Valid {
+		resp.CollectionID = &bookmark.CollectionID.UUID
+	}
+	return resp
+}
+
+
+func toBookmarkCollectionResponse(collection db.BookmarkCollection, bookmarksCount int64) *BookmarkCollectionResponse {
+	return &BookmarkCollectionResponse{
+		ID:             collection.ID,
+		Name:           collection.Name,
+		BookmarksCount: bookmarksCount,
+		CreatedAt:      collection.CreatedAt,
+		UpdatedAt:      collection.UpdatedAt,
+	}
+}
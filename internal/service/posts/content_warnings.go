@@ -0,0 +1,190 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+func (s *Service) SetPostContentWarning(ctx context.Context, userID, postID uuid.UUID, req ContentWarningRequest) (*PostResponse, error) {
+	post, err := s.reactablePost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	warning := contentWarningText(req.ContentWarning)
+	isModerator := false
+	if post.AuthorID != userID || post.WarningByModerator {
+		isModerator, err = s.store.CanModeratePost(ctx, db.CanModeratePostParams{
+			UserID: userID,
+			PostID: postID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check moderator status: %w", err)
+		}
+		if !isModerator {
+			if post.AuthorID == userID {
+				return nil, fmt.Errorf("%w: this content warning was added by a moderator", util.ErrForbidden)
+			}
+			return nil, fmt.Errorf("%w: only the author or a moderator can change content warnings", util.ErrForbidden)
+		}
+	}
+
+	
+	byModerator := isModerator && post.AuthorID != userID && (warning.Valid || req.IsSensitive)
+	params := db.SetPostContentWarningTxParams{
+		Warning: db.SetPostContentWarningParams{
+			ContentWarning:     warning,
+			IsSensitive:        req.IsSensitive,
+			WarningByModerator: byModerator,
+			ID:                 postID,
+		},
+	}
+	if isModerator && post.AuthorID != userID {
+		auditLog, err := contentWarningAuditLog(userID, post, params.Warning)
+		if err != nil {
+			return nil, err
+		}
+		params.AuditLog = &auditLog
+	}
+
+	updated, err := s.store.SetPostContentWarningTx(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: post not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update content warning: %w", err)
+	}
+
+	return s.toPostResponse(updated), nil
+}
+
+
+func (s *Service) RemovePostContentWarning(ctx context.Context, userID, postID uuid.UUID) (*PostResponse, error) {
+	return s.SetPostContentWarning(ctx, userID, postID, ContentWarningRequest{})
+}
+
+
+func contentWarningAuditLog(moderatorID uuid.UUID, before db.GetPostByIDRow, after db.SetPostContentWarningParams) (db.CreateAuditLogParams, error) {
+	action := "add_content_warning"
+	if !after.ContentWarning.Valid && !after.IsSensitive {
+		action = "remove_content_warning"
+	}
+
+	details, err := json.Marshal(map[string]interface{}{
+		"author_id":                before.AuthorID,
+		"content_warning":          nullStringValue(after.ContentWarning),
+		"is_sensitive":             after.IsSensitive,
+		"previous_content_warning": nullStringValue(before.ContentWarning),
+		"previous_is_sensitive":    before.IsSensitive,
+	})
+	if err != nil {
+		return db.CreateAuditLogParams{}, fmt.Errorf("failed to encode content warning audit details: %w", err)
+	}
+
+	return db.CreateAuditLogParams{
+		AdminUserID:  moderatorID,
+		Action:       action,
+		ResourceType: "post",
+		ResourceID:   uuid.NullUUID{UUID: after.ID, Valid: true},
+		Details:      pqtype.NullRawMessage{RawMessage: details, Valid: true},
+	}, nil
+}
+
+
+func (s *Service) expandContentWarnings(ctx context.Context, viewerID uuid.UUID, posts []*PostResponse) error {
+	autoExpand, err := s.autoExpandContentWarnings(ctx, viewerID)
+	if err != nil || !autoExpand {
+		return err
+	}
+
+	for _, post := range posts {
+		post.Collapsed = false
+		if post.QuotedPost != nil {
+			post.QuotedPost.Collapsed = false
+		}
+	}
+	return nil
+}
+
+
+func (s *Service) expandCommentContentWarnings(ctx context.Context, viewerID uuid.UUID, comments []*CommentResponse) error {
+	autoExpand, err := s.autoExpandContentWarnings(ctx, viewerID)
+	if err != nil || !autoExpand {
+		return err
+	}
+
+	for _, comment := range comments {
+		comment.Collapsed = false
+	}
+	return nil
+}
+
+
+func (s *Service) autoExpandContentWarnings(ctx context.Context, viewerID uuid.UUID) (bool, error) {
+	if viewerID == uuid.Nil {
+		return false, nil
+	}
+
+	autoExpand, err := s.store.GetAutoExpandContentWarnings(ctx, viewerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get content warning preference: %w", err)
+	}
+	return autoExpand, nil
+}
+
+
+func applyContentWarning(resp *PostResponse, warning sql.NullString, sensitive bool) {
+	if warning.Valid {
+		resp.ContentWarning = &warning.String
+	}
+	resp.IsSensitive = sensitive
+	resp.Collapsed = warning.Valid || sensitive
+}
+
+
+func applyCommentContentWarning(resp *CommentResponse, warning sql.NullString, sensitive bool) {
+	if warning.Valid {
+		resp.ContentWarning = &warning.String
+	}
+	resp.IsSensitive = sensitive
+	resp.Collapsed = warning.Valid || sensitive
+}
+
+
+func applyQuotedContentWarning(resp *QuotedPostResponse, warning sql.NullString, sensitive bool) {
+	if warning.Valid {
+		resp.ContentWarning = &warning.String
+	}
+	resp.IsSensitive = sensitive
+	resp.Collapsed = warning.Valid || sensitive
+}
+
+
+func contentWarningText(warning *string) sql.NullString {
+	if warning == nil {
+		return sql.NullString{}
+	}
+	text := strings.TrimSpace(*warning)
+	return sql.NullString{String: text, Valid: text != ""}
+}
+
+
+func nullStringValue(value sql.NullString) interface{} {
+	if !value.Valid {
+		return nil
+	}
+	return value.String
+}
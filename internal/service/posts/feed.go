@@ -0,0 +1,196 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+)
+
+
+const feedScoreFormula = "recency * (1 + affinity_weight*affinity + velocity_weight*velocity + membership_weight*membership)"
+
+
+var feedRankingWeights = FeedRankingWeights{
+	WindowHours:      7 * 24,
+	HalfLifeHours:    12,
+	AffinityWeight:   1.5,
+	VelocityWeight:   1.0,
+	MembershipWeight: 0.75,
+}
+
+
+func (s *Service) GetRankedFeed(ctx context.Context, userID, spaceID uuid.UUID, cursor string, limit int32) (*RankedFeedPage, error) {
+	snapshotAt, rows, hasMore, err := s.rankFeed(ctx, userID, spaceID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &RankedFeedPage{
+		Posts:      make([]*PostResponse, 0, len(rows)),
+		SnapshotAt: snapshotAt,
+		HasMore:    hasMore,
+	}
+
+	for _, row := range rows {
+		resp := s.toPostResponse(db.Post{
+			ID:            row.ID,
+			AuthorID:      row.AuthorID,
+			SpaceID:       row.SpaceID,
+			CommunityID:   row.CommunityID,
+			GroupID:       row.GroupID,
+			ParentPostID:  row.ParentPostID,
+			QuotedPostID:  row.QuotedPostID,
+			Content:       row.Content,
+			Media:         row.Media,
+			Tags:          row.Tags,
+			LikesCount:    row.LikesCount,
+			CommentsCount: row.CommentsCount,
+			RepostsCount:  row.RepostsCount,
+			QuotesCount:   row.QuotesCount,
+			ViewsCount:    row.ViewsCount,
+			IsPinned:      row.IsPinned,
+			Visibility:    row.Visibility,
+			Status:        row.Status,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+			PublishAt:     row.PublishAt,
+		})
+
+		isLiked, isBookmarked := row.IsLiked, row.IsBookmarked
+		resp.Username = interfaceToStringPtr(row.Username)
+		resp.FullName = interfaceToStringPtr(row.FullName)
+		if row.AuthorAvatar.Valid {
+			resp.AuthorAvatar = &row.AuthorAvatar.String
+		}
+		if row.AuthorVerified.Valid {
+			resp.AuthorVerified = &row.AuthorVerified.Bool
+		}
+		if row.CommunityName.Valid {
+			resp.CommunityName = &row.CommunityName.String
+		}
+		if row.GroupName.Valid {
+			resp.GroupName = &row.GroupName.String
+		}
+		resp.IsLiked = &isLiked
+		resp.IsBookmarked = &isBookmarked
+
+		page.Posts = append(page.Posts, resp)
+	}
+
+	if err := s.attachPolls(ctx, userID, page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, userID, page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, userID, page.Posts); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, userID, page.Posts); err != nil {
+		return nil, err
+	}
+
+	page.NextCursor = nextRankCursor(snapshotAt, rows, hasMore)
+	return page, nil
+}
+
+
+func (s *Service) ExplainRankedFeed(ctx context.Context, userID, spaceID uuid.UUID, cursor string, limit int32) (*FeedExplanationPage, error) {
+	snapshotAt, rows, hasMore, err := s.rankFeed(ctx, userID, spaceID, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &FeedExplanationPage{
+		Formula:      feedScoreFormula,
+		Weights:      feedRankingWeights,
+		SnapshotAt:   snapshotAt,
+		Explanations: make([]FeedScoreExplanation, 0, len(rows)),
+		HasMore:      hasMore,
+	}
+
+	for i, row := range rows {
+		page.Explanations = append(page.Explanations, FeedScoreExplanation{
+			PostID:   row.ID,
+			AuthorID: row.AuthorID,
+			Rank:     i + 1,
+			Score:    row.Score,
+			Components: FeedScoreComponents{
+				Recency:    row.RecencyScore,
+				Affinity:   row.AffinityScore,
+				Velocity:   row.VelocityScore,
+				Membership: row.MembershipScore,
+			},
+			Signals: FeedScoreSignals{
+				AgeHours:         row.AgeHours,
+				IsFollowing:      row.IsFollowing,
+				InteractionCount: row.InteractionCount,
+				LikeCount:        row.LikeCount,
+				CommentCount:     row.CommentCount,
+				RepostCount:      row.RepostCount,
+				IsMember:         row.IsMember,
+			},
+		})
+	}
+
+	page.NextCursor = nextRankCursor(snapshotAt, rows, hasMore)
+	return page, nil
+}
+
+
+func (s *Service) rankFeed(ctx context.Context, userID, spaceID uuid.UUID, cursor string, limit int32) (time.Time, []db.GetRankedFeedRow, bool, error) {
+	after, err := util.DecodeRankCursor(cursor)
+	if err != nil {
+		return time.Time{}, nil, false, err
+	}
+
+	snapshotAt := time.Now().UTC()
+	params := db.GetRankedFeedParams{
+		SpaceID:          spaceID,
+		WindowHours:      feedRankingWeights.WindowHours,
+		ViewerID:         userID,
+		HalfLifeHours:    feedRankingWeights.HalfLifeHours,
+		AffinityWeight:   feedRankingWeights.AffinityWeight,
+		VelocityWeight:   feedRankingWeights.VelocityWeight,
+		MembershipWeight: feedRankingWeights.MembershipWeight,
+		PageSize:         limit + 1,
+	}
+	if after != nil {
+		snapshotAt = after.SnapshotAt
+		params.CursorScore = sql.NullFloat64{Float64: after.Score, Valid: true}
+		params.CursorID = uuid.NullUUID{UUID: after.ID, Valid: true}
+	}
+	params.SnapshotAt = snapshotAt
+
+	rows, err := s.store.GetRankedFeed(ctx, params)
+	if err != nil {
+		return time.Time{}, nil, false, fmt.Errorf("failed to get ranked feed: %w", err)
+	}
+
+	hasMore := false
+	if int32(len(rows)) > limit {
+		rows = rows[:limit]
+		hasMore = true
+	}
+
+	return snapshotAt, rows, hasMore, nil
+}
+
+
+func nextRankCursor(snapshotAt time.Time, rows []db.GetRankedFeedRow, hasMore bool) *string {
+	if !hasMore || len(rows) == 0 {
+		return nil
+	}
+
+	last := rows[len(rows)-1]
+	next := util.EncodeRankCursor(snapshotAt, last.Score, last.ID)
+	return &next
+}
@@ -0,0 +1,98 @@
+package posts
+
+import (
+	"context"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+func (s *Service) recordPostMentions(ctx context.Context, post db.Post, response *PostResponse) {
+	if s.mentionService == nil {
+		return
+	}
+
+	postID := post.ID
+	entities, err := s.mentionService.Record(ctx, mentions.RecordMentionsRequest{
+		SourceType: mentions.SourcePost,
+		SourceID:   post.ID,
+		AuthorID:   post.AuthorID,
+		Content:    post.Content,
+		PostID:     &postID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("post_id", post.ID.String()).Msg("Failed to record post mentions")
+		return
+	}
+	response.Mentions = entities
+}
+
+
+func (s *Service) recordCommentMentions(ctx context.Context, comment db.Comment, response *CommentResponse) {
+	if s.mentionService == nil {
+		return
+	}
+
+	postID := comment.PostID
+	entities, err := s.mentionService.Record(ctx, mentions.RecordMentionsRequest{
+		SourceType: mentions.SourceComment,
+		SourceID:   comment.ID,
+		AuthorID:   comment.AuthorID,
+		Content:    comment.Content,
+		PostID:     &postID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("comment_id", comment.ID.String()).Msg("Failed to record comment mentions")
+		return
+	}
+	response.Mentions = entities
+}
+
+
+func (s *Service) attachMentions(ctx context.Context, posts []*PostResponse) error {
+	if s.mentionService == nil || len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	entities, err := s.mentionService.Load(ctx, mentions.SourcePost, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		post.Mentions = entities[post.ID]
+	}
+
+	return nil
+}
+
+
+func (s *Service) attachCommentMentions(ctx context.Context, comments []*CommentResponse) error {
+	if s.mentionService == nil || len(comments) == 0 {
+		return nil
+	}
+
+	commentIDs := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+	}
+
+	entities, err := s.mentionService.Load(ctx, mentions.SourceComment, commentIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, comment := range comments {
+		comment.Mentions = entities[comment.ID]
+	}
+
+	return nil
+}
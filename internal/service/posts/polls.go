@@ -0,0 +1,274 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+func (s *Service) GetPoll(ctx context.Context, postID, viewerID uuid.UUID) (*PollResponse, error) {
+	if _, err := s.store.GetPostByID(ctx, db.GetPostByIDParams{UserID: viewerID, ID: postID}); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: poll not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	polls, err := s.loadPolls(ctx, viewerID, []uuid.UUID{postID})
+	if err != nil {
+		return nil, err
+	}
+
+	poll, ok := polls[postID]
+	if !ok {
+		return nil, fmt.Errorf("%w: poll not found", util.ErrNotFound)
+	}
+
+	return poll, nil
+}
+
+
+func (s *Service) VotePoll(ctx context.Context, postID, userID uuid.UUID, req VotePollRequest) (*PollResponse, error) {
+	poll, err := s.GetPoll(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.IsClosed {
+		return nil, fmt.Errorf("%w: poll is closed", util.ErrBadRequest)
+	}
+	if poll.HasVoted {
+		return nil, fmt.Errorf("%w: you have already voted in this poll", util.ErrConflict)
+	}
+
+	validOptions := make(map[uuid.UUID]bool, len(poll.Options))
+	for _, option := range poll.Options {
+		validOptions[option.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.OptionIDs))
+	optionIDs := make([]uuid.UUID, 0, len(req.OptionIDs))
+	for _, id := range req.OptionIDs {
+		if !validOptions[id] {
+			return nil, fmt.Errorf("%w: option %s does not belong to this poll", util.ErrBadRequest, id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			optionIDs = append(optionIDs, id)
+		}
+	}
+
+	if !poll.AllowMultiple && len(optionIDs) > 1 {
+		return nil, fmt.Errorf("%w: this poll allows a single choice", util.ErrBadRequest)
+	}
+
+	recorded, err := s.store.CastPollVote(ctx, db.CastPollVoteParams{
+		UserID:    userID,
+		PollID:    poll.ID,
+		OptionIds: optionIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to cast poll vote: %w", err)
+	}
+
+	
+	if recorded == 0 {
+		return nil, fmt.Errorf("%w: you have already voted in this poll or it has closed", util.ErrConflict)
+	}
+
+	updated, err := s.GetPoll(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	
+	if s.liveService != nil {
+		if err := s.liveService.PublishPollUpdated(ctx, postID, updated.ID, userID); err != nil {
+			log.Error().Err(err).Msg("Failed to publish poll.updated event")
+		}
+	}
+
+	return updated, nil
+}
+
+
+func (s *Service) GetPollOptionVoters(ctx context.Context, postID, optionID, viewerID uuid.UUID, limit, offset int32) ([]*UserLikeResponse, error) {
+	poll, err := s.GetPoll(ctx, postID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if poll.IsAnonymous {
+		return nil, fmt.Errorf("%w: voters are hidden for anonymous polls", util.ErrForbidden)
+	}
+	if !poll.ResultsVisible {
+		return nil, fmt.Errorf("%w: results are hidden until you vote or the poll closes", util.ErrForbidden)
+	}
+
+	found := false
+	for _, option := range poll.Options {
+		if option.ID == optionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: poll option not found", util.ErrNotFound)
+	}
+
+	voters, err := s.store.GetPollOptionVoters(ctx, db.GetPollOptionVotersParams{
+		OptionID: optionID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll voters: %w", err)
+	}
+
+	responses := make([]*UserLikeResponse, len(voters))
+	for i, voter := range voters {
+		votedAt := voter.CreatedAt
+		responses[i] = &UserLikeResponse{
+			ID:        voter.ID,
+			Username:  voter.Username,
+			FullName:  voter.FullName,
+			CreatedAt: &votedAt,
+		}
+		if voter.Avatar.Valid {
+			responses[i].Avatar = &voter.Avatar.String
+		}
+	}
+
+	return responses, nil
+}
+
+
+func (s *Service) attachPolls(ctx context.Context, viewerID uuid.UUID, posts []*PostResponse) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+	}
+
+	polls, err := s.loadPolls(ctx, viewerID, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		if poll, ok := polls[post.ID]; ok {
+			post.Poll = poll
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) loadPolls(ctx context.Context, viewerID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]*PollResponse, error) {
+	polls, err := s.store.GetPollsByPostIDs(ctx, db.GetPollsByPostIDsParams{
+		ViewerID: viewerID,
+		PostIds:  postIDs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
+	}
+
+	result := make(map[uuid.UUID]*PollResponse, len(polls))
+	if len(polls) == 0 {
+		return result, nil
+	}
+
+	pollIDs := make([]uuid.UUID, len(polls))
+	for i, poll := range polls {
+		pollIDs[i] = poll.ID
+	}
+
+	options, err := s.store.GetPollOptionsByPollIDs(ctx, pollIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll options: %w", err)
+	}
+
+	optionsByPoll := make(map[uuid.UUID][]db.PollOption, len(polls))
+	for _, option := range options {
+		optionsByPoll[option.PollID] = append(optionsByPoll[option.PollID], option)
+	}
+
+	for _, row := range polls {
+		poll := db.Poll{
+			ID:            row.ID,
+			PostID:        row.PostID,
+			AllowMultiple: row.AllowMultiple,
+			IsAnonymous:   row.IsAnonymous,
+			ClosesAt:      row.ClosesAt,
+			VotersCount:   row.VotersCount,
+			CreatedAt:     row.CreatedAt,
+		}
+		result[row.PostID] = toPollResponse(poll, optionsByPoll[row.ID], row.ViewerOptionIds)
+	}
+
+	return result, nil
+}
+
+
+func toPollResponse(poll db.Poll, options []db.PollOption, viewerVotes []uuid.UUID) *PollResponse {
+	resp := &PollResponse{
+		ID:            poll.ID,
+		PostID:        poll.PostID,
+		AllowMultiple: poll.AllowMultiple,
+		IsAnonymous:   poll.IsAnonymous,
+		HasVoted:      len(viewerVotes) > 0,
+		Options:       make([]*PollOptionResponse, len(options)),
+	}
+
+	if poll.ClosesAt.Valid {
+		resp.ClosesAt = &poll.ClosesAt.Time
+		resp.IsClosed = !poll.ClosesAt.Time.After(time.Now())
+	}
+
+	
+	resp.ResultsVisible = resp.IsClosed || resp.HasVoted
+	if resp.HasVoted {
+		resp.MyVotes = viewerVotes
+	}
+	if resp.ResultsVisible {
+		votersCount := poll.VotersCount
+		resp.VotersCount = &votersCount
+	}
+
+	for i, option := range options {
+		optionResp := &PollOptionResponse{
+			ID:       option.ID,
+			Position: option.Position,
+			Text:     option.Text,
+		}
+		if resp.ResultsVisible {
+			votesCount := option.VotesCount
+			optionResp.VotesCount = &votesCount
+		}
+		resp.Options[i] = optionResp
+	}
+
+	return resp
+}
+
+
+func pollClosesAt(closesAt *time.Time) (sql.NullTime, error) {
+	if closesAt == nil {
+		return sql.NullTime{}, nil
+	}
+	if !closesAt.After(time.Now()) {
+		return sql.NullTime{}, fmt.Errorf("%w: poll closing time must be in the future", util.ErrBadRequest)
+	}
+	return sql.NullTime{Time: *closesAt, Valid: true}, nil
+}
@@ -0,0 +1,414 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/sqlc-dev/pqtype"
+)
+
+
+const (
+	DefaultReaction     = "like"
+	maxReactionSetSize  = 12
+	maxReactionNameSize = 32
+)
+
+
+var defaultReactionSet = []string{DefaultReaction, "love", "haha", "wow", "sad", "celebrate"}
+
+
+func (s *Service) GetReactionSet(ctx context.Context, spaceID uuid.UUID) (*ReactionSetResponse, error) {
+	reactions, err := s.spaceReactions(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReactionSetResponse{
+		SpaceID:   spaceID,
+		Reactions: reactions,
+		Default:   DefaultReaction,
+	}, nil
+}
+
+
+func (s *Service) ReactToPost(ctx context.Context, userID, postID uuid.UUID, reaction string) (*ReactionSummaryResponse, error) {
+	post, err := s.reactablePost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction = strings.ToLower(strings.TrimSpace(reaction))
+	if err := s.validateReaction(ctx, post.SpaceID, reaction); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.UpsertPostReaction(ctx, db.UpsertPostReactionParams{
+		UserID:   userID,
+		PostID:   postID,
+		Reaction: reaction,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to react to post: %w", err)
+	}
+
+	return s.publishPostReactions(ctx, userID, post, nil)
+}
+
+
+func (s *Service) RemovePostReaction(ctx context.Context, userID, postID uuid.UUID) (*ReactionSummaryResponse, error) {
+	post, err := s.reactablePost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.store.RemovePostReaction(ctx, db.RemovePostReactionParams{
+		UserID: userID,
+		PostID: postID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove post reaction: %w", err)
+	}
+	if !removed.RemovedReaction.Valid {
+		return nil, fmt.Errorf("%w: no reaction to remove", util.ErrNotFound)
+	}
+
+	return s.publishPostReactions(ctx, userID, post, nil)
+}
+
+
+func (s *Service) ReactToComment(ctx context.Context, userID, commentID uuid.UUID, reaction string) (*ReactionSummaryResponse, error) {
+	post, err := s.reactableComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction = strings.ToLower(strings.TrimSpace(reaction))
+	if err := s.validateReaction(ctx, post.SpaceID, reaction); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.store.UpsertCommentReaction(ctx, db.UpsertCommentReactionParams{
+		UserID:    userID,
+		CommentID: commentID,
+		Reaction:  reaction,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to react to comment: %w", err)
+	}
+
+	return s.publishPostReactions(ctx, userID, post, &commentID)
+}
+
+
+func (s *Service) RemoveCommentReaction(ctx context.Context, userID, commentID uuid.UUID) (*ReactionSummaryResponse, error) {
+	post, err := s.reactableComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := s.store.RemoveCommentReaction(ctx, db.RemoveCommentReactionParams{
+		UserID:    userID,
+		CommentID: commentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove comment reaction: %w", err)
+	}
+	if !removed.RemovedReaction.Valid {
+		return nil, fmt.Errorf("%w: no reaction to remove", util.ErrNotFound)
+	}
+
+	return s.publishPostReactions(ctx, userID, post, &commentID)
+}
+
+
+func (s *Service) GetPostReactors(ctx context.Context, viewerID, postID uuid.UUID, reaction string, limit, offset int32) ([]*ReactorResponse, error) {
+	if _, err := s.reactablePost(ctx, viewerID, postID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.GetPostReactors(ctx, db.GetPostReactorsParams{
+		PostID:     postID,
+		Reaction:   sql.NullString{String: reaction, Valid: reaction != ""},
+		ViewerID:   viewerID,
+		PageSize:   limit,
+		PageOffset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post reactions: %w", err)
+	}
+
+	reactors := make([]*ReactorResponse, len(rows))
+	for i, row := range rows {
+		reactors[i] = toReactorResponse(row.ID, row.Username, row.FullName, row.Avatar, row.Reaction, row.ReactedAt)
+	}
+
+	return reactors, nil
+}
+
+
+func (s *Service) GetCommentReactors(ctx context.Context, viewerID, commentID uuid.UUID, reaction string, limit, offset int32) ([]*ReactorResponse, error) {
+	if _, err := s.reactableComment(ctx, viewerID, commentID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.store.GetCommentReactors(ctx, db.GetCommentReactorsParams{
+		CommentID:  commentID,
+		Reaction:   sql.NullString{String: reaction, Valid: reaction != ""},
+		ViewerID:   viewerID,
+		PageSize:   limit,
+		PageOffset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment reactions: %w", err)
+	}
+
+	reactors := make([]*ReactorResponse, len(rows))
+	for i, row := range rows {
+		reactors[i] = toReactorResponse(row.ID, row.Username, row.FullName, row.Avatar, row.Reaction, row.ReactedAt)
+	}
+
+	return reactors, nil
+}
+
+
+func (s *Service) attachReactions(ctx context.Context, viewerID uuid.UUID, posts []*PostResponse) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*PostResponse, len(posts))
+	postIDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+		byID[post.ID] = post
+	}
+
+	rows, err := s.store.GetPostReactionCounts(ctx, db.GetPostReactionCountsParams{
+		ViewerID: viewerID,
+		PostIds:  postIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get post reactions: %w", err)
+	}
+
+	for _, row := range rows {
+		post, ok := byID[row.PostID]
+		if !ok {
+			continue
+		}
+		if post.ReactionCounts == nil {
+			post.ReactionCounts = make(map[string]int64)
+		}
+		post.ReactionCounts[row.Reaction] = row.ReactionCount
+		if row.ViewerReacted {
+			reaction := row.Reaction
+			post.ViewerReaction = &reaction
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) attachCommentReactions(ctx context.Context, viewerID uuid.UUID, comments []*CommentResponse) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*CommentResponse, len(comments))
+	commentIDs := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+		byID[comment.ID] = comment
+	}
+
+	rows, err := s.store.GetCommentReactionCounts(ctx, db.GetCommentReactionCountsParams{
+		ViewerID:   viewerID,
+		CommentIds: commentIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get comment reactions: %w", err)
+	}
+
+	for _, row := range rows {
+		comment, ok := byID[row.CommentID]
+		if !ok {
+			continue
+		}
+		if comment.ReactionCounts == nil {
+			comment.ReactionCounts = make(map[string]int64)
+		}
+		comment.ReactionCounts[row.Reaction] = row.ReactionCount
+		if row.ViewerReacted {
+			reaction := row.Reaction
+			comment.ViewerReaction = &reaction
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) reactablePost(ctx context.Context, viewerID, postID uuid.UUID) (db.GetPostByIDRow, error) {
+	post, err := s.store.GetPostByID(ctx, db.GetPostByIDParams{
+		UserID: viewerID,
+		ID:     postID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return post, fmt.Errorf("%w: post not found", util.ErrNotFound)
+		}
+		return post, fmt.Errorf("failed to get post: %w", err)
+	}
+
+	return post, nil
+}
+
+
+func (s *Service) reactableComment(ctx context.Context, viewerID, commentID uuid.UUID) (db.GetPostByIDRow, error) {
+	comment, err := s.store.GetCommentByID(ctx, commentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.GetPostByIDRow{}, fmt.Errorf("%w: comment not found", util.ErrNotFound)
+		}
+		return db.GetPostByIDRow{}, fmt.Errorf("failed to get comment: %w", err)
+	}
+	if comment.Status.String != "active" {
+		return db.GetPostByIDRow{}, fmt.Errorf("%w: comment not found", util.ErrNotFound)
+	}
+
+	return s.reactablePost(ctx, viewerID, comment.PostID)
+}
+
+
+func (s *Service) validateReaction(ctx context.Context, spaceID uuid.UUID, reaction string) error {
+	reactions, err := s.spaceReactions(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	for _, allowed := range reactions {
+		if allowed == reaction {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: reaction %q is not available in this space", util.ErrBadRequest, reaction)
+}
+
+
+func (s *Service) spaceReactions(ctx context.Context, spaceID uuid.UUID) ([]string, error) {
+	space, err := s.store.GetSpace(ctx, spaceID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: space not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get space: %w", err)
+	}
+
+	return reactionSetFromSettings(space.Settings), nil
+}
+
+
+func reactionSetFromSettings(settings pqtype.NullRawMessage) []string {
+	if !settings.Valid {
+		return defaultReactionSet
+	}
+
+	var parsed struct {
+		Reactions []string `json:"reactions"`
+	}
+	if err := json.Unmarshal(settings.RawMessage, &parsed); err != nil || len(parsed.Reactions) == 0 {
+		return defaultReactionSet
+	}
+
+	reactions := []string{DefaultReaction}
+	seen := map[string]bool{DefaultReaction: true}
+	for _, reaction := range parsed.Reactions {
+		reaction = strings.ToLower(strings.TrimSpace(reaction))
+		if reaction == "" || len(reaction) > maxReactionNameSize || seen[reaction] {
+			continue
+		}
+		seen[reaction] = true
+		reactions = append(reactions, reaction)
+		if len(reactions) == maxReactionSetSize {
+			break
+		}
+	}
+
+	return reactions
+}
+
+
+func (s *Service) publishPostReactions(ctx context.Context, userID uuid.UUID, post db.GetPostByIDRow, commentID *uuid.UUID) (*ReactionSummaryResponse, error) {
+	summary := &ReactionSummaryResponse{
+		PostID:    post.ID,
+		CommentID: commentID,
+		Counts:    map[string]int64{},
+	}
+
+	if commentID != nil {
+		comment := &CommentResponse{ID: *commentID}
+		if err := s.attachCommentReactions(ctx, userID, []*CommentResponse{comment}); err != nil {
+			return nil, err
+		}
+		if comment.ReactionCounts != nil {
+			summary.Counts = comment.ReactionCounts
+		}
+		summary.ViewerReaction = comment.ViewerReaction
+	} else {
+		response := &PostResponse{ID: post.ID}
+		if err := s.attachReactions(ctx, userID, []*PostResponse{response}); err != nil {
+			return nil, err
+		}
+		if response.ReactionCounts != nil {
+			summary.Counts = response.ReactionCounts
+		}
+		summary.ViewerReaction = response.ViewerReaction
+	}
+
+	for _, count := range summary.Counts {
+		summary.Total += int32(count)
+	}
+
+	
+	if s.liveService != nil {
+		payload := map[string]interface{}{
+			"post_id": post.ID.String(),
+			"user_id": userID.String(),
+			"counts":  summary.Counts,
+			"total":   summary.Total,
+		}
+		if commentID != nil {
+			payload["comment_id"] = commentID.String()
+		}
+		if err := s.liveService.PublishPostReacted(ctx, post.ID, userID, post.SpaceID, payload); err != nil {
+			log.Error().Err(err).Msg("Failed to publish post.reacted event")
+		}
+	}
+
+	return summary, nil
+}
+
+
+func toReactorResponse(id uuid.UUID, username, fullName string, avatar sql.NullString, reaction string, reactedAt sql.NullTime) *ReactorResponse {
+	reactor := &ReactorResponse{
+		ID:       id,
+		Username: username,
+		FullName: fullName,
+		Reaction: reaction,
+	}
+	if avatar.Valid {
+		reactor.Avatar = &avatar.String
+	}
+	if reactedAt.Valid {
+		reactor.ReactedAt = &reactedAt.Time
+	}
+	return reactor
+}
@@ -0,0 +1,224 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+)
+
+
+func (s *Service) UndoRepost(ctx context.Context, userID, postID uuid.UUID) error {
+	_, err := s.store.RemoveRepost(ctx, db.RemoveRepostParams{
+		AuthorID:     userID,
+		QuotedPostID: postID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: repost not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to undo repost: %w", err)
+	}
+
+	return nil
+}
+
+
+func (s *Service) GetPostQuotes(ctx context.Context, viewerID, postID uuid.UUID, page util.PageRequest) (*util.Page[*PostResponse], error) {
+	if _, err := s.reactablePost(ctx, viewerID, postID); err != nil {
+		return nil, err
+	}
+
+	posts, err := s.store.GetPostQuotes(ctx, db.GetPostQuotesParams{
+		ViewerID:        viewerID,
+		QuotedPostID:    postID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post quotes: %w", err)
+	}
+
+	posts, hasMore := util.TrimPage(posts, page)
+	responses := s.toQuotePostResponses(posts)
+	if err := s.attachPolls(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := posts[len(posts)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) quotablePostID(ctx context.Context, userID, postID uuid.UUID) (uuid.UUID, error) {
+	post, err := s.reactablePost(ctx, userID, postID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	
+	if post.Content == "" && post.QuotedPostID.Valid {
+		return s.quotablePostID(ctx, userID, post.QuotedPostID.UUID)
+	}
+
+	if post.AuthorID == userID {
+		return post.ID, nil
+	}
+	if post.Visibility.Valid && post.Visibility.String != "public" {
+		return uuid.Nil, fmt.Errorf("%w: only public posts can be reposted", util.ErrForbidden)
+	}
+
+	private, err := s.store.IsPrivateAccount(ctx, post.AuthorID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to check account privacy: %w", err)
+	}
+	if private {
+		return uuid.Nil, fmt.Errorf("%w: posts from private accounts cannot be reposted", util.ErrForbidden)
+	}
+
+	return post.ID, nil
+}
+
+
+func (s *Service) attachQuotedPosts(ctx context.Context, viewerID uuid.UUID, posts []*PostResponse) error {
+	var postIDs []uuid.UUID
+	for _, post := range posts {
+		if post.QuotedPostID != nil {
+			postIDs = append(postIDs, *post.QuotedPostID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	rows, err := s.store.GetQuotedPostPreviews(ctx, db.GetQuotedPostPreviewsParams{
+		PostIds:  postIDs,
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get quoted posts: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*QuotedPostResponse, len(rows))
+	for _, row := range rows {
+		byID[row.ID] = toQuotedPostResponse(row)
+	}
+
+	
+	for _, post := range posts {
+		if post.QuotedPostID == nil {
+			continue
+		}
+		if quoted, ok := byID[*post.QuotedPostID]; ok {
+			post.QuotedPost = quoted
+		} else {
+			post.QuotedPost = &QuotedPostResponse{ID: *post.QuotedPostID, Unavailable: true}
+		}
+	}
+
+	return nil
+}
+
+
+func toQuotedPostResponse(row db.GetQuotedPostPreviewsRow) *QuotedPostResponse {
+	resp := &QuotedPostResponse{
+		ID:       row.ID,
+		AuthorID: &row.AuthorID,
+		Username: &row.Username,
+		FullName: &row.FullName,
+		Content:  &row.Content,
+	}
+
+	if row.AuthorAvatar.Valid {
+		resp.AuthorAvatar = &row.AuthorAvatar.String
+	}
+	if row.AuthorVerified.Valid {
+		resp.AuthorVerified = &row.AuthorVerified.Bool
+	}
+	if row.Media.Valid {
+		resp.Media = &row.Media
+	}
+	if row.CreatedAt.Valid {
+		resp.CreatedAt = &row.CreatedAt.Time
+	}
+	applyQuotedContentWarning(resp, row.ContentWarning, row.IsSensitive)
+
+	return resp
+}
+
+
+func (s *Service) toQuotePostResponses(posts []db.GetPostQuotesRow) []*PostResponse {
+	responses := make([]*PostResponse, len(posts))
+	for i, post := range posts {
+		resp := &PostResponse{
+			ID:            post.ID,
+			AuthorID:      post.AuthorID,
+			SpaceID:       post.SpaceID,
+			Content:       post.Content,
+			Tags:          post.Tags,
+			LikesCount:    post.LikesCount.Int32,
+			CommentsCount: post.CommentsCount.Int32,
+			RepostsCount:  post.RepostsCount.Int32,
+			QuotesCount:   post.QuotesCount.Int32,
+			ViewsCount:    post.ViewsCount.Int32,
+			IsPinned:      post.IsPinned.Bool,
+			Visibility:    post.Visibility.String,
+			Status:        post.Status.String,
+			Username:      interfaceToStringPtr(post.Username),
+			FullName:      interfaceToStringPtr(post.FullName),
+			IsLiked:       &post.IsLiked,
+		}
+
+		if post.CommunityID.Valid {
+			resp.CommunityID = &post.CommunityID.UUID
+		}
+		if post.GroupID.Valid {
+			resp.GroupID = &post.GroupID.UUID
+		}
+		if post.QuotedPostID.Valid {
+			resp.QuotedPostID = &post.QuotedPostID.UUID
+		}
+		if post.Media.Valid {
+			resp.Media = &post.Media
+		}
+		if post.CreatedAt.Valid {
+			resp.CreatedAt = &post.CreatedAt.Time
+		}
+		if post.UpdatedAt.Valid {
+			resp.UpdatedAt = &post.UpdatedAt.Time
+		}
+		if post.AuthorAvatar.Valid {
+			resp.AuthorAvatar = &post.AuthorAvatar.String
+		}
+		if post.CommunityName.Valid {
+			resp.CommunityName = &post.CommunityName.String
+		}
+		if post.GroupName.Valid {
+			resp.GroupName = &post.GroupName.String
+		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
+
+		responses[i] = resp
+	}
+	return responses
+}
@@ -0,0 +1,264 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const scheduledPublishBatchSize = 100
+
+
+func (s *Service) GetPendingPosts(ctx context.Context, authorID uuid.UUID, status string, limit, offset int32) ([]*PostResponse, error) {
+	var statusFilter sql.NullString
+	if status != "" {
+		if status != "draft" && status != "scheduled" {
+			return nil, fmt.Errorf("%w: status must be draft or scheduled", util.ErrBadRequest)
+		}
+		statusFilter = sql.NullString{String: status, Valid: true}
+	}
+
+	posts, err := s.store.GetPendingPosts(ctx, db.GetPendingPostsParams{
+		AuthorID:   authorID,
+		Status:     statusFilter,
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending posts: %w", err)
+	}
+
+	responses := make([]*PostResponse, len(posts))
+	for i, post := range posts {
+		responses[i] = s.toPostResponse(post)
+	}
+
+	if err := s.attachPolls(ctx, authorID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, authorID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, authorID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, authorID, responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+
+func (s *Service) UpdatePendingPost(ctx context.Context, postID, authorID uuid.UUID, req UpdatePendingPostRequest) (*PostResponse, error) {
+	existing, err := s.store.GetPendingPost(ctx, db.GetPendingPostParams{
+		ID:       postID,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: draft or scheduled post not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get pending post: %w", err)
+	}
+
+	params := db.UpdatePendingPostParams{
+		Content:    existing.Content,
+		Tags:       existing.Tags,
+		Visibility: existing.Visibility,
+		Status:     existing.Status,
+		PublishAt:  existing.PublishAt,
+		ID:         postID,
+		AuthorID:   authorID,
+	}
+
+	if req.Content != nil {
+		params.Content = *req.Content
+	}
+	if req.Tags != nil {
+		params.Tags = req.Tags
+	} else if req.Content != nil {
+		params.Tags = withoutHashtags(existing.Tags, existing.Content)
+	}
+	params.Tags = buildPostTags(params.Tags, params.Content)
+	if req.Visibility != nil {
+		params.Visibility = sql.NullString{String: *req.Visibility, Valid: true}
+	}
+
+	if req.Status != nil || req.PublishAt != nil {
+		status := existing.Status.String
+		if req.Status != nil {
+			status = *req.Status
+		}
+
+		publishAt := req.PublishAt
+		if publishAt == nil && status == "scheduled" && existing.PublishAt.Valid {
+			publishAt = &existing.PublishAt.Time
+		}
+
+		params.Status, params.PublishAt, err = resolvePostLifecycle(status, publishAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	post, err := s.store.UpdatePendingPost(ctx, params)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: draft or scheduled post not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update pending post: %w", err)
+	}
+
+	return s.toPostResponse(post), nil
+}
+
+
+func (s *Service) CancelScheduledPost(ctx context.Context, postID, authorID uuid.UUID) (*PostResponse, error) {
+	status := "draft"
+	return s.UpdatePendingPost(ctx, postID, authorID, UpdatePendingPostRequest{Status: &status})
+}
+
+
+func (s *Service) PublishPendingPost(ctx context.Context, postID, authorID uuid.UUID) (*PostResponse, error) {
+	post, err := s.store.PublishPendingPost(ctx, db.PublishPendingPostParams{
+		ID:       postID,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: draft or scheduled post not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to publish post: %w", err)
+	}
+
+	response := s.toPostResponse(post)
+	s.afterPostPublished(ctx, post, response)
+
+	return response, nil
+}
+
+
+func (s *Service) PublishDuePosts(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		posts, err := s.store.PublishDuePosts(ctx, scheduledPublishBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to publish scheduled posts: %w", err)
+		}
+
+		for _, post := range posts {
+			s.afterPostPublished(ctx, post, s.toPostResponse(post))
+		}
+
+		total += len(posts)
+		if len(posts) < scheduledPublishBatchSize {
+			return total, nil
+		}
+	}
+}
+
+
+func (s *Service) RunScheduledPublisher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := s.PublishDuePosts(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Scheduled post publisher failed")
+				continue
+			}
+			if published > 0 {
+				log.Info().Int("count", published).Msg("Published scheduled posts")
+			}
+		}
+	}
+}
+
+
+func (s *Service) afterPostPublished(ctx context.Context, post db.Post, response *PostResponse) {
+	s.syncPostTags(ctx, post)
+	s.recordPostMentions(ctx, post, response)
+
+	if post.CommunityID.Valid {
+		if err := s.store.UpdateCommunityStats(ctx, post.CommunityID.UUID); err != nil {
+			log.Error().Err(err).Str("community_id", post.CommunityID.UUID.String()).Msg("Failed to update community stats")
+		}
+	}
+	if post.GroupID.Valid {
+		if err := s.store.UpdateGroupStats(ctx, post.GroupID.UUID); err != nil {
+			log.Error().Err(err).Str("group_id", post.GroupID.UUID.String()).Msg("Failed to update group stats")
+		}
+	}
+
+	
+	if s.liveService != nil {
+		postPayload := map[string]interface{}{
+			"id":         post.ID.String(),
+			"author_id":  post.AuthorID.String(),
+			"space_id":   post.SpaceID.String(),
+			"content":    post.Content,
+			"tags":       post.Tags,
+			"visibility": response.Visibility,
+			"created_at": post.CreatedAt.Time.Unix(),
+		}
+		if response.Media != nil {
+			postPayload["media"] = response.Media
+		}
+		if post.CommunityID.Valid {
+			postPayload["community_id"] = post.CommunityID.UUID.String()
+		}
+		if post.GroupID.Valid {
+			postPayload["group_id"] = post.GroupID.UUID.String()
+		}
+
+		if err := s.liveService.PublishPostCreated(ctx, post.SpaceID, post.AuthorID, postPayload); err != nil {
+			log.Error().Err(err).Msg("Failed to publish post.created event")
+		}
+	}
+}
+
+
+func resolvePostLifecycle(status string, publishAt *time.Time) (sql.NullString, sql.NullTime, error) {
+	if status == "" {
+		status = "active"
+		if publishAt != nil {
+			status = "scheduled"
+		}
+	}
+
+	switch status {
+	case "active":
+		if publishAt != nil {
+			return sql.NullString{}, sql.NullTime{}, fmt.Errorf("%w: publish_at requires a scheduled post", util.ErrBadRequest)
+		}
+		return sql.NullString{String: status, Valid: true}, sql.NullTime{}, nil
+	case "draft":
+		return sql.NullString{String: status, Valid: true}, sql.NullTime{}, nil
+	case "scheduled":
+		if publishAt == nil {
+			return sql.NullString{}, sql.NullTime{}, fmt.Errorf("%w: scheduled posts require publish_at", util.ErrBadRequest)
+		}
+		if !publishAt.After(time.Now()) {
+			return sql.NullString{}, sql.NullTime{}, fmt.Errorf("%w: publish_at must be in the future", util.ErrBadRequest)
+		}
+		return sql.NullString{String: status, Valid: true}, sql.NullTime{Time: *publishAt, Valid: true}, nil
+	default:
+		return sql.NullString{}, sql.NullTime{}, fmt.Errorf("%w: invalid post status %q", util.ErrBadRequest, status)
+	}
+}
@@ -3,10 +3,15 @@ package posts
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	db "github.com/connect-univyn/connect-server/db/sqlc"
 	"github.com/connect-univyn/connect-server/internal/live"
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
+	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"github.com/sqlc-dev/pqtype"
@@ -14,15 +19,21 @@ import (
 
 
 type Service struct {
-	store       db.Store
-	liveService *live.Service
+	store          db.Store
+	liveService    *live.Service
+	mentionService *mentions.Service
+	viewBuffer     ViewBuffer
+	viewWindow     time.Duration
 }
 
 
-func NewService(store db.Store, liveService *live.Service) *Service {
+func NewService(store db.Store, liveService *live.Service, mentionService *mentions.Service) *Service {
 	return &Service{
-		store:       store,
-		liveService: liveService,
+		store:          store,
+		liveService:    liveService,
+		mentionService: mentionService,
+		viewBuffer:     NewMemoryViewBuffer(),
+		viewWindow:     defaultViewWindow,
 	}
 }
 
@@ -65,53 +76,62 @@ func (s *Service) CreatePost(ctx context.Context, req CreatePostRequest) (*PostR
 		media = *req.Media
 	}
 
-	tags := req.Tags
-	if tags == nil {
-		tags = []string{}
-	}
+	tags := buildPostTags(req.Tags, req.Content)
 
-	post, err := s.store.CreatePost(ctx, db.CreatePostParams{
-		AuthorID:     req.AuthorID,
-		SpaceID:      req.SpaceID,
-		CommunityID:  communityID,
-		GroupID:      groupID,
-		ParentPostID: parentPostID,
-		QuotedPostID: quotedPostID,
-		Content:      req.Content,
-		Media:        media,
-		Tags:         tags,
-		Visibility:   visibility,
-	})
+	status, publishAt, err := resolvePostLifecycle(req.Status, req.PublishAt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create post: %w", err)
+		return nil, err
+	}
+
+	params := db.CreatePostParams{
+		AuthorID:       req.AuthorID,
+		SpaceID:        req.SpaceID,
+		CommunityID:    communityID,
+		GroupID:        groupID,
+		ParentPostID:   parentPostID,
+		QuotedPostID:   quotedPostID,
+		Content:        req.Content,
+		Media:          media,
+		Tags:           tags,
+		Visibility:     visibility,
+		Status:         status,
+		PublishAt:      publishAt,
+		ContentWarning: contentWarningText(req.ContentWarning),
+		IsSensitive:    req.IsSensitive,
+	}
+
+	var post db.Post
+	var poll *PollResponse
+	if req.Poll != nil {
+		closesAt, err := pollClosesAt(req.Poll.ClosesAt)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := s.store.CreatePostWithPollTx(ctx, db.CreatePostWithPollTxParams{
+			Post:          params,
+			Options:       req.Poll.Options,
+			AllowMultiple: req.Poll.AllowMultiple,
+			IsAnonymous:   req.Poll.IsAnonymous,
+			ClosesAt:      closesAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create post: %w", err)
+		}
+		post = result.Post
+		poll = toPollResponse(result.Poll, result.Options, nil)
+	} else {
+		post, err = s.store.CreatePost(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create post: %w", err)
+		}
 	}
 
 	response := s.toPostResponse(post)
+	response.Poll = poll
 
-	
-	if s.liveService != nil {
-		postPayload := map[string]interface{}{
-			"id":         post.ID.String(),
-			"author_id":  post.AuthorID.String(),
-			"space_id":   post.SpaceID.String(),
-			"content":    post.Content,
-			"tags":       post.Tags,
-			"visibility": response.Visibility,
-			"created_at": post.CreatedAt.Time.Unix(),
-		}
-		if response.Media != nil {
-			postPayload["media"] = response.Media
-		}
-		if req.CommunityID != nil {
-			postPayload["community_id"] = req.CommunityID.String()
-		}
-		if req.GroupID != nil {
-			postPayload["group_id"] = req.GroupID.String()
-		}
-
-		if err := s.liveService.PublishPostCreated(ctx, req.SpaceID, req.AuthorID, postPayload); err != nil {
-			log.Error().Err(err).Msg("Failed to publish post.created event")
-		}
+	if post.Status.String == "active" {
+		s.afterPostPublished(ctx, post, response)
 	}
 
 	return response, nil
@@ -130,7 +150,24 @@ func (s *Service) GetPostByID(ctx context.Context, postID uuid.UUID, userID uuid
 		return nil, fmt.Errorf("failed to get post: %w", err)
 	}
 
-	return s.toDetailedPostResponse(post), nil
+	response := s.toDetailedPostResponse(post)
+	if err := s.attachPolls(ctx, userID, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, userID, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, userID, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, userID, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 
@@ -146,76 +183,236 @@ func (s *Service) DeletePost(ctx context.Context, postID uuid.UUID, authorID uui
 }
 
 
-func (s *Service) GetUserPosts(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
+func (s *Service) GetUserPosts(ctx context.Context, userID, viewerID uuid.UUID, page util.PageRequest) (*util.Page[*PostResponse], error) {
 	posts, err := s.store.GetUserPosts(ctx, db.GetUserPostsParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
+		ViewerID:        viewerID,
+		AuthorID:        userID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user posts: %w", err)
 	}
 
-	return s.toUserPostResponses(posts), nil
+	posts, hasMore := util.TrimPage(posts, page)
+	responses := s.toUserPostResponses(posts)
+	if err := s.attachPolls(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := posts[len(posts)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountUserPosts(ctx, db.CountUserPostsParams{AuthorID: userID, ViewerID: viewerID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
-func (s *Service) GetUserFeed(ctx context.Context, userID uuid.UUID, spaceID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
+func (s *Service) GetUserFeed(ctx context.Context, userID uuid.UUID, spaceID uuid.UUID, page util.PageRequest) (*util.Page[*PostResponse], error) {
 	posts, err := s.store.GetUserFeed(ctx, db.GetUserFeedParams{
-		UserID:  userID,
-		SpaceID: spaceID,
-		Limit:   limit,
-		Offset:  offset,
+		UserID:          userID,
+		SpaceID:         spaceID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user feed: %w", err)
 	}
 
-	return s.toUserFeedResponses(posts), nil
+	posts, hasMore := util.TrimPage(posts, page)
+	responses := s.toUserFeedResponses(posts)
+	if err := s.attachPolls(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := posts[len(posts)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountUserFeed(ctx, db.CountUserFeedParams{SpaceID: spaceID, UserID: userID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
-func (s *Service) GetCommunityPosts(ctx context.Context, userID uuid.UUID, communityID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
+func (s *Service) GetCommunityPosts(ctx context.Context, userID uuid.UUID, communityID uuid.UUID, page util.PageRequest) (*util.Page[*PostResponse], error) {
 	posts, err := s.store.GetCommunityPosts(ctx, db.GetCommunityPostsParams{
-		UserID:      userID,
-		CommunityID: uuid.NullUUID{UUID: communityID, Valid: true},
-		Limit:       limit,
-		Offset:      offset,
+		UserID:          userID,
+		CommunityID:     uuid.NullUUID{UUID: communityID, Valid: true},
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PinnedFirst:     !page.Keyset,
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get community posts: %w", err)
 	}
 
-	return s.toCommunityPostResponses(posts), nil
+	posts, hasMore := util.TrimPage(posts, page)
+	responses := s.toCommunityPostResponses(posts)
+	if err := s.attachPolls(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := posts[len(posts)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountCommunityPosts(ctx, db.CountCommunityPostsParams{
+			CommunityID: uuid.NullUUID{UUID: communityID, Valid: true},
+			UserID:      userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
-func (s *Service) GetGroupPosts(ctx context.Context, userID uuid.UUID, groupID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
+func (s *Service) GetGroupPosts(ctx context.Context, userID uuid.UUID, groupID uuid.UUID, page util.PageRequest) (*util.Page[*PostResponse], error) {
 	posts, err := s.store.GetGroupPosts(ctx, db.GetGroupPostsParams{
-		UserID:  userID,
-		GroupID: uuid.NullUUID{UUID: groupID, Valid: true},
-		Limit:   limit,
-		Offset:  offset,
+		UserID:          userID,
+		GroupID:         uuid.NullUUID{UUID: groupID, Valid: true},
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PinnedFirst:     !page.Keyset,
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get group posts: %w", err)
 	}
 
-	return s.toGroupPostResponses(posts), nil
+	posts, hasMore := util.TrimPage(posts, page)
+	responses := s.toGroupPostResponses(posts)
+	if err := s.attachPolls(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, userID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := posts[len(posts)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountGroupPosts(ctx, db.CountGroupPostsParams{
+			GroupID: uuid.NullUUID{UUID: groupID, Valid: true},
+			UserID:  userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count posts: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
-func (s *Service) GetTrendingPosts(ctx context.Context, spaceID uuid.UUID) ([]*PostResponse, error) {
-	posts, err := s.store.GetTrendingPosts(ctx, spaceID)
+func (s *Service) GetTrendingPosts(ctx context.Context, spaceID, viewerID uuid.UUID) ([]*PostResponse, error) {
+	posts, err := s.store.GetTrendingPosts(ctx, db.GetTrendingPostsParams{
+		SpaceID:  spaceID,
+		ViewerID: viewerID,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get trending posts: %w", err)
 	}
 
-	return s.toTrendingPostResponses(posts), nil
+	responses := s.toTrendingPostResponses(posts)
+	if err := s.expandContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
 }
 
 
-func (s *Service) GetTrendingTopics(ctx context.Context, spaceID uuid.UUID, limit, offset int32) ([]TrendingTopicResponse, error) {
+func (s *Service) GetTrendingTopics(ctx context.Context, spaceID uuid.UUID, period string, limit, offset int32) ([]TrendingTopicResponse, error) {
+	if period == "" {
+		period = "daily"
+	}
+	if !isValidTrendPeriod(period) {
+		return nil, fmt.Errorf("%w: period must be hourly, daily or weekly", util.ErrBadRequest)
+	}
+
 	
 	if limit <= 0 {
 		limit = 10
@@ -229,6 +426,7 @@ func (s *Service) GetTrendingTopics(ctx context.Context, spaceID uuid.UUID, limi
 
 	topics, err := s.store.GetTrendingTopics(ctx, db.GetTrendingTopicsParams{
 		SpaceID: spaceID,
+		Period:  sql.NullString{String: period, Valid: true},
 		Limit:   limit,
 		Offset:  offset,
 	})
@@ -250,6 +448,10 @@ func (s *Service) GetTrendingTopics(ctx context.Context, spaceID uuid.UUID, limi
 			Category:   topic.Category,
 			PostsCount: topic.PostsCount,
 			TrendScore: topic.TrendScore,
+			Period:     period,
+		}
+		if topic.RecordedAt.Valid {
+			responses[i].RecordedAt = &topic.RecordedAt.Time
 		}
 	}
 
@@ -257,34 +459,46 @@ func (s *Service) GetTrendingTopics(ctx context.Context, spaceID uuid.UUID, limi
 }
 
 
-func (s *Service) SearchPosts(ctx context.Context, query string, spaceID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
+func (s *Service) SearchPosts(ctx context.Context, query string, spaceID, viewerID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
 	posts, err := s.store.SearchPosts(ctx, db.SearchPostsParams{
 		SpaceID:        spaceID,
 		PlaintoTsquery: query,
 		Content:        "%" + query + "%",
 		Limit:          limit,
 		Offset:         offset,
+		ViewerID:       viewerID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to search posts: %w", err)
 	}
 
-	return s.toSearchPostResponses(posts), nil
+	responses := s.toSearchPostResponses(posts)
+	if err := s.expandContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
 }
 
 
-func (s *Service) AdvancedSearchPosts(ctx context.Context, query string, spaceID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
+func (s *Service) AdvancedSearchPosts(ctx context.Context, query string, spaceID, viewerID uuid.UUID, limit, offset int32) ([]*PostResponse, error) {
 	posts, err := s.store.AdvancedSearchPosts(ctx, db.AdvancedSearchPostsParams{
 		PlaintoTsquery: query,
 		SpaceID:        spaceID,
 		Limit:          limit,
 		Offset:         offset,
+		ViewerID:       viewerID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform advanced search: %w", err)
 	}
 
-	return s.toAdvancedSearchPostResponses(posts), nil
+	responses := s.toAdvancedSearchPostResponses(posts)
+	if err := s.expandContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	return responses, nil
 }
 
 
@@ -302,13 +516,43 @@ func (s *Service) GetUserLikedPosts(ctx context.Context, userID uuid.UUID, limit
 }
 
 
-func (s *Service) GetPostComments(ctx context.Context, postID uuid.UUID) ([]*CommentResponse, error) {
-	comments, err := s.store.GetPostComments(ctx, postID)
+func (s *Service) GetPostComments(ctx context.Context, postID, viewerID uuid.UUID, page util.PageRequest) (*util.Page[*CommentResponse], error) {
+	comments, err := s.store.GetPostComments(ctx, db.GetPostCommentsParams{
+		PostID:          postID,
+		ViewerID:        viewerID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post comments: %w", err)
 	}
 
-	return s.toCommentResponses(comments), nil
+	comments, hasMore := trimCommentThreads(comments, page.Limit)
+	responses := s.toCommentResponses(comments)
+	if err := s.expandCommentContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := comments[len(comments)-1]
+		result.SetNextCursor(last.RootCreatedAt.Time, last.RootID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountTopLevelComments(ctx, db.CountTopLevelCommentsParams{
+			PostID:   postID,
+			ViewerID: viewerID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count comments: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
 }
 
 
@@ -333,12 +577,15 @@ func (s *Service) CreateComment(ctx context.Context, req CreateCommentRequest) (
 		AuthorID:        req.AuthorID,
 		ParentCommentID: parentCommentID,
 		Content:         req.Content,
+		ContentWarning:  contentWarningText(req.ContentWarning),
+		IsSensitive:     req.IsSensitive,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create comment: %w", err)
 	}
 
 	response := s.toSimpleCommentResponse(comment)
+	s.recordCommentMentions(ctx, comment, response)
 
 	
 	if s.liveService != nil {
@@ -349,6 +596,9 @@ func (s *Service) CreateComment(ctx context.Context, req CreateCommentRequest) (
 			"content":    comment.Content,
 			"created_at": comment.CreatedAt.Time.Unix(),
 		}
+		if len(response.Mentions) > 0 {
+			commentPayload["mentions"] = response.Mentions
+		}
 		if req.ParentCommentID != nil {
 			commentPayload["parent_comment_id"] = req.ParentCommentID.String()
 		}
@@ -363,13 +613,16 @@ func (s *Service) CreateComment(ctx context.Context, req CreateCommentRequest) (
 
 
 func (s *Service) CreateRepost(ctx context.Context, req CreateRepostParams) (*PostResponse, error) {
-	var quotedPostID uuid.NullUUID
-	var visibility sql.NullString
+	if req.QuotedPostID == nil {
+		return nil, fmt.Errorf("%w: quoted post is required", util.ErrBadRequest)
+	}
 
-	if req.QuotedPostID != nil {
-		quotedPostID = uuid.NullUUID{UUID: *req.QuotedPostID, Valid: true}
+	quotedPostID, err := s.quotablePostID(ctx, req.AuthorID, *req.QuotedPostID)
+	if err != nil {
+		return nil, err
 	}
 
+	var visibility sql.NullString
 	if req.Visibility != "" {
 		visibility = sql.NullString{String: req.Visibility, Valid: true}
 	} else {
@@ -379,15 +632,28 @@ func (s *Service) CreateRepost(ctx context.Context, req CreateRepostParams) (*Po
 	post, err := s.store.CreateRepost(ctx, db.CreateRepostParams{
 		AuthorID:     req.AuthorID,
 		SpaceID:      req.SpaceID,
-		QuotedPostID: quotedPostID,
-		Content:      req.Content,
+		QuotedPostID: uuid.NullUUID{UUID: quotedPostID, Valid: true},
+		Content:      strings.TrimSpace(req.Content),
 		Visibility:   visibility,
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: post already reposted", util.ErrConflict)
+		}
 		return nil, fmt.Errorf("failed to create repost: %w", err)
 	}
 
-	return s.toPostResponse(post), nil
+	response := s.toPostResponse(post)
+	if err := s.attachQuotedPosts(ctx, req.AuthorID, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, req.AuthorID, []*PostResponse{response}); err != nil {
+		return nil, err
+	}
+
+	s.afterPostPublished(ctx, post, response)
+
+	return response, nil
 }
 
 
@@ -401,13 +667,27 @@ func (s *Service) TogglePostLike(ctx context.Context, userID uuid.UUID, postID u
 		return 0, fmt.Errorf("failed to get post details: %w", err)
 	}
 
-	likesCount, err := s.store.TogglePostLike(ctx, db.TogglePostLikeParams{
+	
+	removed, err := s.store.RemovePostReaction(ctx, db.RemovePostReactionParams{
 		UserID: userID,
-		ID:     postID,
+		PostID: postID,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to toggle post like: %w", err)
 	}
+	likesCount := removed.LikesCount
+
+	if !removed.RemovedReaction.Valid {
+		added, err := s.store.UpsertPostReaction(ctx, db.UpsertPostReactionParams{
+			UserID:   userID,
+			PostID:   postID,
+			Reaction: DefaultReaction,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to toggle post like: %w", err)
+		}
+		likesCount = added.LikesCount
+	}
 
 	var count int32
 	if likesCount.Valid {
@@ -420,45 +700,193 @@ func (s *Service) TogglePostLike(ctx context.Context, userID uuid.UUID, postID u
 			log.Error().Err(err).Msg("Failed to publish post.liked event")
 		}
 	}
+	if _, err := s.publishPostReactions(ctx, userID, postDetail, nil); err != nil {
+		log.Error().Err(err).Msg("Failed to refresh post reactions")
+	}
 
 	return count, nil
 }
 
 
 func (s *Service) ToggleCommentLike(ctx context.Context, userID uuid.UUID, commentID uuid.UUID) (bool, error) {
-	liked, err := s.store.ToggleCommentLike(ctx, db.ToggleCommentLikeParams{
+	removed, err := s.store.RemoveCommentReaction(ctx, db.RemoveCommentReactionParams{
 		UserID:    userID,
-		CommentID: uuid.NullUUID{UUID: commentID, Valid: true},
+		CommentID: commentID,
 	})
 	if err != nil {
 		return false, fmt.Errorf("failed to toggle comment like: %w", err)
 	}
+	if removed.RemovedReaction.Valid {
+		return false, nil
+	}
+
+	if _, err := s.store.UpsertCommentReaction(ctx, db.UpsertCommentReactionParams{
+		UserID:    userID,
+		CommentID: commentID,
+		Reaction:  DefaultReaction,
+	}); err != nil {
+		return false, fmt.Errorf("failed to toggle comment like: %w", err)
+	}
 
-	return liked, nil
+	return true, nil
 }
 
 
-func (s *Service) PinPost(ctx context.Context, postID uuid.UUID, isPinned bool) error {
-	err := s.store.PinPost(ctx, db.PinPostParams{
-		IsPinned: sql.NullBool{Bool: isPinned, Valid: true},
-		ID:       postID,
+func (s *Service) UpdateComment(ctx context.Context, commentID, authorID uuid.UUID, req UpdateCommentRequest) (*CommentResponse, error) {
+	comment, err := s.store.UpdateComment(ctx, db.UpdateCommentParams{
+		Content:  req.Content,
+		ID:       commentID,
+		AuthorID: authorID,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to pin/unpin post: %w", err)
+		if err == sql.ErrNoRows {
+			return nil, s.commentAccessError(ctx, commentID, authorID)
+		}
+		return nil, fmt.Errorf("failed to update comment: %w", err)
 	}
-	return nil
+
+	response := s.toSimpleCommentResponse(comment)
+	s.recordCommentMentions(ctx, comment, response)
+
+	
+	if s.liveService != nil {
+		commentPayload := map[string]interface{}{
+			"id":         comment.ID.String(),
+			"post_id":    comment.PostID.String(),
+			"author_id":  comment.AuthorID.String(),
+			"content":    comment.Content,
+			"updated_at": comment.UpdatedAt.Time.Unix(),
+		}
+
+		if err := s.liveService.PublishCommentUpdated(ctx, comment.PostID, authorID, commentPayload); err != nil {
+			log.Error().Err(err).Msg("Failed to publish comment.updated event")
+		}
+	}
+
+	return response, nil
 }
 
 
-func (s *Service) IncrementPostViews(ctx context.Context, postID uuid.UUID) error {
-	err := s.store.IncrementPostViews(ctx, postID)
+func (s *Service) DeleteComment(ctx context.Context, commentID, authorID uuid.UUID) error {
+	comment, err := s.store.SoftDeleteComment(ctx, db.SoftDeleteCommentParams{
+		ID:       commentID,
+		AuthorID: authorID,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to increment post views: %w", err)
+		if err == sql.ErrNoRows {
+			return s.commentAccessError(ctx, commentID, authorID)
+		}
+		return fmt.Errorf("failed to delete comment: %w", err)
 	}
+
+	if s.mentionService != nil {
+		if err := s.mentionService.Clear(ctx, mentions.SourceComment, comment.ID); err != nil {
+			log.Error().Err(err).Str("comment_id", comment.ID.String()).Msg("Failed to clear comment mentions")
+		}
+	}
+
+	
+	if s.liveService != nil {
+		if err := s.liveService.PublishCommentDeleted(ctx, comment.PostID, comment.ID, authorID); err != nil {
+			log.Error().Err(err).Msg("Failed to publish comment.deleted event")
+		}
+	}
+
 	return nil
 }
 
 
+func (s *Service) GetThreadedComments(ctx context.Context, postID, viewerID uuid.UUID, page util.PageRequest) (*util.Page[*CommentResponse], error) {
+	comments, err := s.store.GetTopLevelComments(ctx, db.GetTopLevelCommentsParams{
+		ViewerID:        viewerID,
+		PostID:          postID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post comments: %w", err)
+	}
+
+	return s.toThreadedCommentPage(ctx, viewerID, comments, page)
+}
+
+
+func (s *Service) GetCommentReplies(ctx context.Context, commentID, viewerID uuid.UUID, page util.PageRequest) (*util.Page[*CommentResponse], error) {
+	replies, err := s.store.GetCommentReplies(ctx, db.GetCommentRepliesParams{
+		ViewerID:        viewerID,
+		ParentCommentID: commentID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment replies: %w", err)
+	}
+
+	comments := make([]db.GetTopLevelCommentsRow, len(replies))
+	for i, reply := range replies {
+		comments[i] = db.GetTopLevelCommentsRow(reply)
+	}
+
+	return s.toThreadedCommentPage(ctx, viewerID, comments, page)
+}
+
+
+func (s *Service) toThreadedCommentPage(ctx context.Context, viewerID uuid.UUID, comments []db.GetTopLevelCommentsRow, page util.PageRequest) (*util.Page[*CommentResponse], error) {
+	comments, hasMore := util.TrimPage(comments, page)
+	responses := s.toThreadedCommentResponses(comments)
+	if err := s.attachCommentMentions(ctx, responses); err != nil {
+		return nil, err
+	}
+	if err := s.attachCommentReactions(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+	if err := s.expandCommentContentWarnings(ctx, viewerID, responses); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(responses, page, hasMore)
+	if hasMore {
+		last := comments[len(comments)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) commentAccessError(ctx context.Context, commentID, authorID uuid.UUID) error {
+	comment, err := s.store.GetCommentByID(ctx, commentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: comment not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if comment.Status.String != "active" {
+		return fmt.Errorf("%w: comment not found", util.ErrNotFound)
+	}
+	if comment.AuthorID != authorID {
+		return fmt.Errorf("%w: only the author can modify this comment", util.ErrForbidden)
+	}
+
+	return fmt.Errorf("%w: comment not found", util.ErrNotFound)
+}
+
+
+func (s *Service) PinPost(ctx context.Context, postID uuid.UUID, isPinned bool) error {
+	err := s.store.PinPost(ctx, db.PinPostParams{
+		IsPinned: sql.NullBool{Bool: isPinned, Valid: true},
+		ID:       postID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to pin/unpin post: %w", err)
+	}
+	return nil
+}
+
 
 func (s *Service) toPostResponse(post db.Post) *PostResponse {
 	resp := &PostResponse{
@@ -495,6 +923,10 @@ func (s *Service) toPostResponse(post db.Post) *PostResponse {
 	if post.UpdatedAt.Valid {
 		resp.UpdatedAt = &post.UpdatedAt.Time
 	}
+	if post.PublishAt.Valid {
+		resp.PublishAt = &post.PublishAt.Time
+	}
+	applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 	return resp
 }
@@ -559,6 +991,10 @@ func (s *Service) toDetailedPostResponse(post db.GetPostByIDRow) *PostResponse {
 	resp.QuotedFullName = interfaceToStringPtr(post.QuotedFullName)
 
 	resp.IsLiked = &post.IsLiked
+	resp.IsBookmarked = &post.IsBookmarked
+	resp.IsQuoted = &post.IsQuoted
+	resp.IsReposted = &post.IsReposted
+	applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 	return resp
 }
@@ -583,6 +1019,7 @@ func (s *Service) toUserPostResponses(posts []db.GetUserPostsRow) []*PostRespons
 			Username:      interfaceToStringPtr(post.Username),
 			FullName:      interfaceToStringPtr(post.FullName),
 			IsLiked:       &post.IsLiked,
+			IsBookmarked:  &post.IsBookmarked,
 		}
 
 		if post.CommunityID.Valid {
@@ -612,6 +1049,7 @@ func (s *Service) toUserPostResponses(posts []db.GetUserPostsRow) []*PostRespons
 		if post.GroupName.Valid {
 			resp.GroupName = &post.GroupName.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -638,6 +1076,7 @@ func (s *Service) toUserFeedResponses(posts []db.GetUserFeedRow) []*PostResponse
 			Username:       interfaceToStringPtr(post.Username),
 			FullName:       interfaceToStringPtr(post.FullName),
 			IsLiked:        &post.IsLiked,
+			IsBookmarked:   &post.IsBookmarked,
 			AuthorVerified: &post.AuthorVerified.Bool,
 		}
 
@@ -668,6 +1107,7 @@ func (s *Service) toUserFeedResponses(posts []db.GetUserFeedRow) []*PostResponse
 		if post.GroupName.Valid {
 			resp.GroupName = &post.GroupName.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -694,6 +1134,7 @@ func (s *Service) toCommunityPostResponses(posts []db.GetCommunityPostsRow) []*P
 			Username:      interfaceToStringPtr(post.Username),
 			FullName:      interfaceToStringPtr(post.FullName),
 			IsLiked:       &post.IsLiked,
+			IsBookmarked:  &post.IsBookmarked,
 		}
 
 		if post.CommunityID.Valid {
@@ -717,6 +1158,7 @@ func (s *Service) toCommunityPostResponses(posts []db.GetCommunityPostsRow) []*P
 		if post.AuthorAvatar.Valid {
 			resp.AuthorAvatar = &post.AuthorAvatar.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -743,6 +1185,7 @@ func (s *Service) toGroupPostResponses(posts []db.GetGroupPostsRow) []*PostRespo
 			Username:      interfaceToStringPtr(post.Username),
 			FullName:      interfaceToStringPtr(post.FullName),
 			IsLiked:       &post.IsLiked,
+			IsBookmarked:  &post.IsBookmarked,
 		}
 
 		if post.CommunityID.Valid {
@@ -766,6 +1209,7 @@ func (s *Service) toGroupPostResponses(posts []db.GetGroupPostsRow) []*PostRespo
 		if post.AuthorAvatar.Valid {
 			resp.AuthorAvatar = &post.AuthorAvatar.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -815,6 +1259,7 @@ func (s *Service) toTrendingPostResponses(posts []db.GetTrendingPostsRow) []*Pos
 		if post.AuthorAvatar.Valid {
 			resp.AuthorAvatar = &post.AuthorAvatar.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -872,6 +1317,7 @@ func (s *Service) toSearchPostResponses(posts []db.SearchPostsRow) []*PostRespon
 		if post.GroupName.Valid {
 			resp.GroupName = &post.GroupName.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -927,6 +1373,7 @@ func (s *Service) toAdvancedSearchPostResponses(posts []db.AdvancedSearchPostsRo
 		if post.GroupName.Valid {
 			resp.GroupName = &post.GroupName.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -975,6 +1422,7 @@ func (s *Service) toUserLikedPostResponses(posts []db.GetUserLikedPostsRow) []*P
 		if post.AuthorAvatar.Valid {
 			resp.AuthorAvatar = &post.AuthorAvatar.String
 		}
+		applyContentWarning(resp, post.ContentWarning, post.IsSensitive)
 
 		responses[i] = resp
 	}
@@ -1008,12 +1456,27 @@ func (s *Service) toCommentResponses(comments []db.GetPostCommentsRow) []*Commen
 			resp.Avatar = &comment.Avatar.String
 		}
 		resp.Depth = &comment.Depth
+		applyCommentContentWarning(resp, comment.ContentWarning, comment.IsSensitive)
 
 		responses[i] = resp
 	}
 	return responses
 }
 
+func trimCommentThreads(rows []db.GetPostCommentsRow, limit int32) ([]db.GetPostCommentsRow, bool) {
+	var threads int32
+	for i, row := range rows {
+		if i == 0 || row.RootID != rows[i-1].RootID {
+			threads++
+		}
+		if threads > limit {
+			return rows[:i], true
+		}
+	}
+	return rows, false
+}
+
+
 func (s *Service) toSimpleCommentResponse(comment db.Comment) *CommentResponse {
 	resp := &CommentResponse{
 		ID:         comment.ID,
@@ -1033,10 +1496,60 @@ func (s *Service) toSimpleCommentResponse(comment db.Comment) *CommentResponse {
 	if comment.UpdatedAt.Valid {
 		resp.UpdatedAt = &comment.UpdatedAt.Time
 	}
+	resp.IsDeleted = resp.Status == "deleted"
+	resp.IsEdited = !resp.IsDeleted && comment.UpdatedAt.Valid && comment.CreatedAt.Valid &&
+		comment.UpdatedAt.Time.After(comment.CreatedAt.Time)
+	applyCommentContentWarning(resp, comment.ContentWarning, comment.IsSensitive)
 
 	return resp
 }
 
+func (s *Service) toThreadedCommentResponses(comments []db.GetTopLevelCommentsRow) []*CommentResponse {
+	responses := make([]*CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		resp := &CommentResponse{
+			ID:         comment.ID,
+			PostID:     comment.PostID,
+			AuthorID:   comment.AuthorID,
+			Content:    comment.Content,
+			LikesCount: comment.LikesCount.Int32,
+			Status:     comment.Status.String,
+			IsDeleted:  comment.Status.String == "deleted",
+		}
+
+		if comment.ParentCommentID.Valid {
+			resp.ParentCommentID = &comment.ParentCommentID.UUID
+		}
+		if comment.CreatedAt.Valid {
+			resp.CreatedAt = &comment.CreatedAt.Time
+		}
+		if comment.UpdatedAt.Valid {
+			resp.UpdatedAt = &comment.UpdatedAt.Time
+		}
+		resp.IsEdited = !resp.IsDeleted && comment.UpdatedAt.Valid && comment.CreatedAt.Valid &&
+			comment.UpdatedAt.Time.After(comment.CreatedAt.Time)
+
+		
+		if !resp.IsDeleted {
+			resp.Username = &comment.Username
+			resp.FullName = &comment.FullName
+			if comment.Avatar.Valid {
+				resp.Avatar = &comment.Avatar.String
+			}
+		}
+
+		replyCount := comment.ReplyCount
+		isLiked := comment.IsLiked
+		resp.ReplyCount = &replyCount
+		resp.IsLiked = &isLiked
+		applyCommentContentWarning(resp, comment.ContentWarning, comment.IsSensitive)
+
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
+
 func (s *Service) toUserLikeResponses(likes []db.GetPostLikesRow) []*UserLikeResponse {
 	responses := make([]*UserLikeResponse, len(likes))
 	for i, like := range likes {
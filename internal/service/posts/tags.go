@@ -0,0 +1,279 @@
+package posts
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const (
+	maxTagLength       = 100
+	maxTagsPerPost     = 30
+	trendingPerSpace   = 100
+	tagSuggestionLimit = 10
+)
+
+
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/])#([\p{L}\p{N}_]+)`)
+
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+
+type trendPeriod struct {
+	Name          string
+	WindowHours   int32
+	HalfLifeHours float64
+}
+
+
+var trendPeriods = []trendPeriod{
+	{Name: "hourly", WindowHours: 6, HalfLifeHours: 1},
+	{Name: "daily", WindowHours: 72, HalfLifeHours: 12},
+	{Name: "weekly", WindowHours: 21 * 24, HalfLifeHours: 3 * 24},
+}
+
+
+func (s *Service) GetPostsByTag(ctx context.Context, tag string, spaceID, viewerID uuid.UUID, page util.PageRequest) (*util.Page[*PostResponse], error) {
+	name := normalizeTag(tag)
+	if name == "" {
+		return nil, fmt.Errorf("%w: invalid tag", util.ErrBadRequest)
+	}
+
+	found, err := s.store.GetTagByName(ctx, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return util.NewPage([]*PostResponse{}, page, false), nil
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	rows, err := s.store.GetPostsByTag(ctx, db.GetPostsByTagParams{
+		ViewerID:        viewerID,
+		TagID:           found.ID,
+		SpaceID:         spaceID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get posts by tag: %w", err)
+	}
+
+	rows, hasMore := util.TrimPage(rows, page)
+
+	posts := make([]*PostResponse, 0, len(rows))
+	for _, row := range rows {
+		resp := s.toPostResponse(db.Post{
+			ID:            row.ID,
+			AuthorID:      row.AuthorID,
+			SpaceID:       row.SpaceID,
+			CommunityID:   row.CommunityID,
+			GroupID:       row.GroupID,
+			ParentPostID:  row.ParentPostID,
+			QuotedPostID:  row.QuotedPostID,
+			Content:       row.Content,
+			Media:         row.Media,
+			Tags:          row.Tags,
+			LikesCount:    row.LikesCount,
+			CommentsCount: row.CommentsCount,
+			RepostsCount:  row.RepostsCount,
+			QuotesCount:   row.QuotesCount,
+			ViewsCount:    row.ViewsCount,
+			IsPinned:      row.IsPinned,
+			Visibility:    row.Visibility,
+			Status:        row.Status,
+			CreatedAt:     row.CreatedAt,
+			UpdatedAt:     row.UpdatedAt,
+			PublishAt:     row.PublishAt,
+		})
+
+		isLiked, isBookmarked := row.IsLiked, row.IsBookmarked
+		resp.Username = interfaceToStringPtr(row.Username)
+		resp.FullName = interfaceToStringPtr(row.FullName)
+		if row.AuthorAvatar.Valid {
+			resp.AuthorAvatar = &row.AuthorAvatar.String
+		}
+		resp.IsLiked = &isLiked
+		resp.IsBookmarked = &isBookmarked
+
+		posts = append(posts, resp)
+	}
+
+	if err := s.attachPolls(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachReactions(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachMentions(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := s.attachQuotedPosts(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+	if err := s.expandContentWarnings(ctx, viewerID, posts); err != nil {
+		return nil, err
+	}
+
+	result := util.NewPage(posts, page, hasMore)
+	if hasMore {
+		last := rows[len(rows)-1]
+		result.SetNextCursor(last.CreatedAt.Time, last.ID)
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) SearchTags(ctx context.Context, spaceID uuid.UUID, prefix string, limit int32) ([]*TagResponse, error) {
+	name := normalizeTag(prefix)
+	if name == "" {
+		return []*TagResponse{}, nil
+	}
+	if limit <= 0 || limit > 50 {
+		limit = tagSuggestionLimit
+	}
+
+	tags, err := s.store.SearchTags(ctx, db.SearchTagsParams{
+		SpaceID:   spaceID,
+		Prefix:    likeEscaper.Replace(name),
+		PageLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tags: %w", err)
+	}
+
+	responses := make([]*TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = &TagResponse{
+			ID:         tag.ID,
+			Name:       tag.Name,
+			UsageCount: tag.UsageCount,
+		}
+	}
+
+	return responses, nil
+}
+
+
+func (s *Service) RefreshTrendingTopics(ctx context.Context) error {
+	for _, period := range trendPeriods {
+		if _, err := s.store.RefreshTrendingTopicsTx(ctx, db.InsertTrendingTopicsParams{
+			HalfLifeHours: period.HalfLifeHours,
+			WindowHours:   period.WindowHours,
+			Period:        period.Name,
+			PerSpaceLimit: trendingPerSpace,
+		}); err != nil {
+			return fmt.Errorf("failed to refresh %s trending topics: %w", period.Name, err)
+		}
+	}
+
+	return nil
+}
+
+
+func (s *Service) RunTrendingTopicsJob(ctx context.Context, interval time.Duration) {
+	if err := s.RefreshTrendingTopics(ctx); err != nil {
+		log.Error().Err(err).Msg("Trending topics job failed")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RefreshTrendingTopics(ctx); err != nil {
+				log.Error().Err(err).Msg("Trending topics job failed")
+			}
+		}
+	}
+}
+
+
+func (s *Service) syncPostTags(ctx context.Context, post db.Post) {
+	if err := s.store.SyncPostTags(ctx, db.SyncPostTagsParams{
+		Names:   post.Tags,
+		PostID:  post.ID,
+		SpaceID: post.SpaceID,
+	}); err != nil {
+		log.Error().Err(err).Str("post_id", post.ID.String()).Msg("Failed to sync post tags")
+	}
+}
+
+
+func isValidTrendPeriod(period string) bool {
+	for _, p := range trendPeriods {
+		if p.Name == period {
+			return true
+		}
+	}
+	return false
+}
+
+
+func extractHashtags(content string) []string {
+	matches := hashtagPattern.FindAllStringSubmatch(content, -1)
+	tags := make([]string, 0, len(matches))
+	for _, match := range matches {
+		tags = append(tags, match[1])
+	}
+	return tags
+}
+
+
+func buildPostTags(clientTags []string, content string) []string {
+	seen := make(map[string]bool)
+	tags := []string{}
+
+	for _, raw := range append(append([]string{}, clientTags...), extractHashtags(content)...) {
+		name := normalizeTag(raw)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+		if len(tags) == maxTagsPerPost {
+			break
+		}
+	}
+
+	return tags
+}
+
+
+func withoutHashtags(tags []string, content string) []string {
+	drop := make(map[string]bool)
+	for _, tag := range extractHashtags(content) {
+		drop[normalizeTag(tag)] = true
+	}
+
+	kept := []string{}
+	for _, tag := range tags {
+		if !drop[normalizeTag(tag)] {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+
+func normalizeTag(tag string) string {
+	name := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(tag), "#")))
+	if len(name) > maxTagLength || strings.ContainsAny(name, " \t\n#") {
+		return ""
+	}
+	return name
+}
@@ -3,22 +3,50 @@ package posts
 import (
 	"time"
 
+	"github.com/connect-univyn/connect-server/internal/service/mentions"
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
 
 type CreatePostRequest struct {
-	AuthorID     uuid.UUID              `json:"author_id"` 
-	SpaceID      uuid.UUID              `json:"space_id" binding:"required"`
-	CommunityID  *uuid.UUID             `json:"community_id,omitempty"`
-	GroupID      *uuid.UUID             `json:"group_id,omitempty"`
-	ParentPostID *uuid.UUID             `json:"parent_post_id,omitempty"`
-	QuotedPostID *uuid.UUID             `json:"quoted_post_id,omitempty"`
-	Content      string                 `json:"content" binding:"required,min=1,max=5000"`
-	Media        *pqtype.NullRawMessage `json:"media,omitempty"`
-	Tags         []string               `json:"tags,omitempty"`
-	Visibility   string                 `json:"visibility,omitempty"` 
+	AuthorID       uuid.UUID              `json:"author_id"` 
+	SpaceID        uuid.UUID              `json:"space_id" binding:"required"`
+	CommunityID    *uuid.UUID             `json:"community_id,omitempty"`
+	GroupID        *uuid.UUID             `json:"group_id,omitempty"`
+	ParentPostID   *uuid.UUID             `json:"parent_post_id,omitempty"`
+	QuotedPostID   *uuid.UUID             `json:"quoted_post_id,omitempty"`
+	Content        string                 `json:"content" binding:"required,min=1,max=5000"`
+	Media          *pqtype.NullRawMessage `json:"media,omitempty"`
+	Tags           []string               `json:"tags,omitempty"`
+	Visibility     string                 `json:"visibility,omitempty"` 
+	Poll           *CreatePollRequest     `json:"poll,omitempty"`
+	Status         string                 `json:"status,omitempty" binding:"omitempty,oneof=active draft scheduled"`
+	PublishAt      *time.Time             `json:"publish_at,omitempty"`
+	ContentWarning *string                `json:"content_warning,omitempty" binding:"omitempty,max=200"`
+	IsSensitive    bool                   `json:"is_sensitive,omitempty"`
+}
+
+
+type CreatePollRequest struct {
+	Options       []string   `json:"options" binding:"required,min=2,max=10,dive,required,max=200"`
+	AllowMultiple bool       `json:"allow_multiple"`
+	IsAnonymous   bool       `json:"is_anonymous"`
+	ClosesAt      *time.Time `json:"closes_at,omitempty"`
+}
+
+
+type VotePollRequest struct {
+	OptionIDs []uuid.UUID `json:"option_ids" binding:"required,min=1,max=10"`
+}
+
+
+type UpdatePendingPostRequest struct {
+	Content    *string    `json:"content,omitempty" binding:"omitempty,min=1,max=5000"`
+	Tags       []string   `json:"tags,omitempty"`
+	Visibility *string    `json:"visibility,omitempty"`
+	Status     *string    `json:"status,omitempty" binding:"omitempty,oneof=draft scheduled"`
+	PublishAt  *time.Time `json:"publish_at,omitempty"`
 }
 
 
@@ -34,6 +62,8 @@ type CreateCommentRequest struct {
 	AuthorID        uuid.UUID  `json:"author_id"` 
 	ParentCommentID *uuid.UUID `json:"parent_comment_id,omitempty"`
 	Content         string     `json:"content" binding:"required,min=1,max=1000"`
+	ContentWarning  *string    `json:"content_warning,omitempty" binding:"omitempty,max=200"`
+	IsSensitive     bool       `json:"is_sensitive,omitempty"`
 }
 
 
@@ -41,11 +71,18 @@ type CreateRepostParams struct {
 	AuthorID     uuid.UUID  `json:"author_id"` 
 	SpaceID      uuid.UUID  `json:"space_id"` 
 	QuotedPostID *uuid.UUID `json:"quoted_post_id"` 
-	Content      string     `json:"content,omitempty"`
+	Content      string     `json:"content,omitempty" binding:"max=5000"`
 	Visibility   string     `json:"visibility,omitempty"`
 }
 
 
+type QuotePostRequest struct {
+	SpaceID    uuid.UUID `json:"space_id"`
+	Content    string    `json:"content" binding:"required,min=1,max=5000"`
+	Visibility string    `json:"visibility,omitempty"`
+}
+
+
 type PostResponse struct {
 	ID               uuid.UUID              `json:"id"`
 	AuthorID         uuid.UUID              `json:"author_id"`
@@ -67,12 +104,14 @@ type PostResponse struct {
 	Status           string                 `json:"status"`
 	CreatedAt        *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt        *time.Time             `json:"updated_at,omitempty"`
+	PublishAt        *time.Time             `json:"publish_at,omitempty"`
 	Username         *string                `json:"username,omitempty"`
 	FullName         *string                `json:"full_name,omitempty"`
 	AuthorAvatar     *string                `json:"author_avatar,omitempty"`
 	CommunityName    *string                `json:"community_name,omitempty"`
 	GroupName        *string                `json:"group_name,omitempty"`
 	IsLiked          *bool                  `json:"is_liked,omitempty"`
+	IsBookmarked     *bool                  `json:"is_bookmarked,omitempty"`
 	AuthorVerified   *bool                  `json:"author_verified,omitempty"`
 	QuotedContent    *string                `json:"quoted_content,omitempty"`
 	QuotedAuthorID   *uuid.UUID             `json:"quoted_author_id,omitempty"`
@@ -80,6 +119,56 @@ type PostResponse struct {
 	QuotedFullName   *string                `json:"quoted_full_name,omitempty"`
 	RelevanceScore   *float32               `json:"relevance_score,omitempty"`
 	EngagementScore  *int32                 `json:"engagement_score,omitempty"`
+	Poll             *PollResponse          `json:"poll,omitempty"`
+	Mentions         []mentions.MentionEntity `json:"mentions,omitempty"`
+	ReactionCounts   map[string]int64       `json:"reaction_counts,omitempty"`
+	ViewerReaction   *string                `json:"viewer_reaction,omitempty"`
+	QuotedPost       *QuotedPostResponse    `json:"quoted_post,omitempty"`
+	IsQuoted         *bool                  `json:"is_quoted,omitempty"`
+	IsReposted       *bool                  `json:"is_reposted,omitempty"`
+	ContentWarning   *string                `json:"content_warning,omitempty"`
+	IsSensitive      bool                   `json:"is_sensitive"`
+	Collapsed        bool                   `json:"collapsed"`
+}
+
+
+type QuotedPostResponse struct {
+	ID             uuid.UUID              `json:"id"`
+	AuthorID       *uuid.UUID             `json:"author_id,omitempty"`
+	Username       *string                `json:"username,omitempty"`
+	FullName       *string                `json:"full_name,omitempty"`
+	AuthorAvatar   *string                `json:"author_avatar,omitempty"`
+	AuthorVerified *bool                  `json:"author_verified,omitempty"`
+	Content        *string                `json:"content,omitempty"`
+	Media          *pqtype.NullRawMessage `json:"media,omitempty"`
+	CreatedAt      *time.Time             `json:"created_at,omitempty"`
+	ContentWarning *string                `json:"content_warning,omitempty"`
+	IsSensitive    bool                   `json:"is_sensitive"`
+	Collapsed      bool                   `json:"collapsed"`
+	Unavailable    bool                   `json:"unavailable"`
+}
+
+
+type PollResponse struct {
+	ID             uuid.UUID             `json:"id"`
+	PostID         uuid.UUID             `json:"post_id"`
+	AllowMultiple  bool                  `json:"allow_multiple"`
+	IsAnonymous    bool                  `json:"is_anonymous"`
+	ClosesAt       *time.Time            `json:"closes_at,omitempty"`
+	IsClosed       bool                  `json:"is_closed"`
+	HasVoted       bool                  `json:"has_voted"`
+	ResultsVisible bool                  `json:"results_visible"`
+	VotersCount    *int32                `json:"voters_count,omitempty"`
+	Options        []*PollOptionResponse `json:"options"`
+	MyVotes        []uuid.UUID           `json:"my_votes,omitempty"`
+}
+
+
+type PollOptionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Position   int32     `json:"position"`
+	Text       string    `json:"text"`
+	VotesCount *int32    `json:"votes_count,omitempty"`
 }
 
 
@@ -97,6 +186,91 @@ type CommentResponse struct {
 	FullName        *string    `json:"full_name,omitempty"`
 	Avatar          *string    `json:"avatar,omitempty"`
 	Depth           *int32     `json:"depth,omitempty"`
+	ReplyCount      *int64     `json:"reply_count,omitempty"`
+	IsLiked         *bool      `json:"is_liked,omitempty"`
+	IsEdited        bool       `json:"is_edited"`
+	IsDeleted       bool       `json:"is_deleted"`
+	Mentions        []mentions.MentionEntity `json:"mentions,omitempty"`
+	ReactionCounts  map[string]int64 `json:"reaction_counts,omitempty"`
+	ViewerReaction  *string    `json:"viewer_reaction,omitempty"`
+	ContentWarning  *string    `json:"content_warning,omitempty"`
+	IsSensitive     bool       `json:"is_sensitive"`
+	Collapsed       bool       `json:"collapsed"`
+}
+
+
+type ContentWarningRequest struct {
+	ContentWarning *string `json:"content_warning,omitempty" binding:"omitempty,max=200"`
+	IsSensitive    bool    `json:"is_sensitive"`
+}
+
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required,min=1,max=1000"`
+}
+
+
+type BookmarkRequest struct {
+	CollectionID *uuid.UUID `json:"collection_id,omitempty"`
+}
+
+
+type BookmarkCollectionRequest struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+
+type BookmarkCollectionResponse struct {
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	BookmarksCount int64     `json:"bookmarks_count"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+
+type BookmarkResponse struct {
+	PostID       uuid.UUID  `json:"post_id"`
+	CollectionID *uuid.UUID `json:"collection_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+
+type BookmarkedPostResponse struct {
+	*PostResponse
+	CollectionID *uuid.UUID `json:"collection_id,omitempty"`
+	BookmarkedAt time.Time  `json:"bookmarked_at"`
+}
+
+
+type ReactRequest struct {
+	Reaction string `json:"reaction" binding:"required,max=32"`
+}
+
+
+type ReactionSummaryResponse struct {
+	PostID         uuid.UUID        `json:"post_id"`
+	CommentID      *uuid.UUID       `json:"comment_id,omitempty"`
+	Counts         map[string]int64 `json:"counts"`
+	Total          int32            `json:"total"`
+	ViewerReaction *string          `json:"viewer_reaction,omitempty"`
+}
+
+
+type ReactionSetResponse struct {
+	SpaceID   uuid.UUID `json:"space_id"`
+	Reactions []string  `json:"reactions"`
+	Default   string    `json:"default"`
+}
+
+
+type ReactorResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	Username  string     `json:"username"`
+	FullName  string     `json:"full_name"`
+	Avatar    *string    `json:"avatar,omitempty"`
+	Reaction  string     `json:"reaction"`
+	ReactedAt *time.Time `json:"reacted_at,omitempty"`
 }
 
 
@@ -125,9 +299,88 @@ type SearchPostsParams struct {
 
 
 type TrendingTopicResponse struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Category   string `json:"category"`
-	PostsCount int64  `json:"posts_count"`
-	TrendScore int32  `json:"trend_score"`
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Category   string     `json:"category"`
+	PostsCount int64      `json:"posts_count"`
+	TrendScore float64    `json:"trend_score"`
+	Period     string     `json:"period"`
+	RecordedAt *time.Time `json:"recorded_at,omitempty"`
+}
+
+
+type TagResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	UsageCount int32     `json:"usage_count"`
+}
+
+
+type FeedRankingWeights struct {
+	WindowHours      int32   `json:"window_hours"`
+	HalfLifeHours    float64 `json:"half_life_hours"`
+	AffinityWeight   float64 `json:"affinity_weight"`
+	VelocityWeight   float64 `json:"velocity_weight"`
+	MembershipWeight float64 `json:"membership_weight"`
+}
+
+
+type RankedFeedPage struct {
+	Posts      []*PostResponse `json:"posts"`
+	SnapshotAt time.Time       `json:"snapshot_at"`
+	NextCursor *string         `json:"next_cursor"`
+	HasMore    bool            `json:"has_more"`
+}
+
+
+type FeedScoreSignals struct {
+	AgeHours         float64 `json:"age_hours"`
+	IsFollowing      bool    `json:"is_following"`
+	InteractionCount int32   `json:"interaction_count"`
+	LikeCount        int32   `json:"like_count"`
+	CommentCount     int32   `json:"comment_count"`
+	RepostCount      int32   `json:"repost_count"`
+	IsMember         bool    `json:"is_member"`
+}
+
+
+type FeedScoreComponents struct {
+	Recency    float64 `json:"recency"`
+	Affinity   float64 `json:"affinity"`
+	Velocity   float64 `json:"velocity"`
+	Membership float64 `json:"membership"`
+}
+
+
+type FeedScoreExplanation struct {
+	PostID     uuid.UUID           `json:"post_id"`
+	AuthorID   uuid.UUID           `json:"author_id"`
+	Rank       int                 `json:"rank"`
+	Score      float64             `json:"score"`
+	Components FeedScoreComponents `json:"components"`
+	Signals    FeedScoreSignals    `json:"signals"`
+}
+
+
+type FeedExplanationPage struct {
+	Formula      string                 `json:"formula"`
+	Weights      FeedRankingWeights     `json:"weights"`
+	SnapshotAt   time.Time              `json:"snapshot_at"`
+	Explanations []FeedScoreExplanation `json:"explanations"`
+	NextCursor   *string                `json:"next_cursor"`
+	HasMore      bool                   `json:"has_more"`
+}
+
+
+type PostImpressionsResponse struct {
+	PostID      uuid.UUID                  `json:"post_id"`
+	TotalViews  int32                      `json:"total_views"`
+	PeriodViews int32                      `json:"period_views"`
+	Days        []DailyImpressionsResponse `json:"days"`
+}
+
+
+type DailyImpressionsResponse struct {
+	Date  string `json:"date"`
+	Views int32  `json:"views"`
 }
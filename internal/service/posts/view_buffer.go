@@ -0,0 +1,209 @@
+package posts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+
+const (
+	viewDayLayout         = "2006-01-02"
+	viewSeenKeyPrefix     = "post_views:seen:"
+	viewPendingKey        = "post_views:pending"
+	viewFlushingKeyPrefix = "post_views:flushing:"
+)
+
+
+type ViewBuffer interface {
+	Record(ctx context.Context, postID uuid.UUID, viewerKey string, at time.Time, window time.Duration) (bool, error)
+	Drain(ctx context.Context) ([]PostViewCount, error)
+	Requeue(ctx context.Context, counts []PostViewCount) error
+}
+
+
+type PostViewCount struct {
+	PostID uuid.UUID
+	Day    string
+	Views  int64
+}
+
+
+type viewBucket struct {
+	postID uuid.UUID
+	day    string
+}
+
+
+type MemoryViewBuffer struct {
+	mu      sync.Mutex
+	seen    map[string]time.Time
+	pending map[viewBucket]int64
+}
+
+
+func NewMemoryViewBuffer() *MemoryViewBuffer {
+	return &MemoryViewBuffer{
+		seen:    make(map[string]time.Time),
+		pending: make(map[viewBucket]int64),
+	}
+}
+
+
+func (b *MemoryViewBuffer) Record(ctx context.Context, postID uuid.UUID, viewerKey string, at time.Time, window time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := postID.String() + ":" + viewerKey
+	if expiresAt, ok := b.seen[key]; ok && at.Before(expiresAt) {
+		return false, nil
+	}
+	b.seen[key] = at.Add(window)
+	b.pending[viewBucket{postID: postID, day: at.UTC().Format(viewDayLayout)}]++
+
+	return true, nil
+}
+
+
+func (b *MemoryViewBuffer) Drain(ctx context.Context) ([]PostViewCount, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	
+	now := time.Now()
+	for key, expiresAt := range b.seen {
+		if !now.Before(expiresAt) {
+			delete(b.seen, key)
+		}
+	}
+
+	counts := make([]PostViewCount, 0, len(b.pending))
+	for bucket, views := range b.pending {
+		counts = append(counts, PostViewCount{PostID: bucket.postID, Day: bucket.day, Views: views})
+	}
+	b.pending = make(map[viewBucket]int64)
+
+	return counts, nil
+}
+
+
+func (b *MemoryViewBuffer) Requeue(ctx context.Context, counts []PostViewCount) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, count := range counts {
+		b.pending[viewBucket{postID: count.PostID, day: count.Day}] += count.Views
+	}
+	return nil
+}
+
+
+type RedisViewBuffer struct {
+	client *redis.Client
+}
+
+
+func NewRedisViewBuffer(redisURL string) (*RedisViewBuffer, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisViewBuffer{client: client}, nil
+}
+
+
+func (b *RedisViewBuffer) Record(ctx context.Context, postID uuid.UUID, viewerKey string, at time.Time, window time.Duration) (bool, error) {
+	fresh, err := b.client.SetNX(ctx, viewSeenKeyPrefix+postID.String()+":"+viewerKey, 1, window).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to record view: %w", err)
+	}
+	if !fresh {
+		return false, nil
+	}
+
+	if err := b.client.HIncrBy(ctx, viewPendingKey, viewField(postID, at.UTC().Format(viewDayLayout)), 1).Err(); err != nil {
+		return false, fmt.Errorf("failed to buffer view: %w", err)
+	}
+	return true, nil
+}
+
+
+func (b *RedisViewBuffer) Drain(ctx context.Context) ([]PostViewCount, error) {
+	
+	flushingKey := viewFlushingKeyPrefix + uuid.NewString()
+	if err := b.client.Rename(ctx, viewPendingKey, flushingKey).Err(); err != nil {
+		if strings.Contains(err.Error(), "no such key") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to drain views: %w", err)
+	}
+
+	fields, err := b.client.HGetAll(ctx, flushingKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read buffered views: %w", err)
+	}
+
+	counts := make([]PostViewCount, 0, len(fields))
+	for field, value := range fields {
+		postID, day, ok := parseViewField(field)
+		if !ok {
+			continue
+		}
+		var views int64
+		if _, err := fmt.Sscan(value, &views); err != nil || views <= 0 {
+			continue
+		}
+		counts = append(counts, PostViewCount{PostID: postID, Day: day, Views: views})
+	}
+
+	if err := b.client.Del(ctx, flushingKey).Err(); err != nil {
+		return nil, fmt.Errorf("failed to clear buffered views: %w", err)
+	}
+
+	return counts, nil
+}
+
+
+func (b *RedisViewBuffer) Requeue(ctx context.Context, counts []PostViewCount) error {
+	pipe := b.client.Pipeline()
+	for _, count := range counts {
+		pipe.HIncrBy(ctx, viewPendingKey, viewField(count.PostID, count.Day), count.Views)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to requeue views: %w", err)
+	}
+	return nil
+}
+
+
+func viewField(postID uuid.UUID, day string) string {
+	return postID.String() + "|" + day
+}
+
+
+func parseViewField(field string) (uuid.UUID, string, bool) {
+	id, day, found := strings.Cut(field, "|")
+	if !found {
+		return uuid.Nil, "", false
+	}
+	postID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", false
+	}
+	return postID, day, true
+}
@@ -0,0 +1,140 @@
+package posts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+const (
+	defaultViewWindow      = 30 * time.Minute
+	defaultImpressionsDays = 30
+	maxImpressionsDays     = 90
+)
+
+
+func (s *Service) SetViewBuffer(buffer ViewBuffer, window time.Duration) {
+	s.viewBuffer = buffer
+	if window > 0 {
+		s.viewWindow = window
+	}
+}
+
+
+func (s *Service) RecordPostView(ctx context.Context, postID, viewerID uuid.UUID, clientIP string) {
+	viewerKey := "ip:" + clientIP
+	if viewerID != uuid.Nil {
+		viewerKey = "user:" + viewerID.String()
+	}
+
+	if _, err := s.viewBuffer.Record(ctx, postID, viewerKey, time.Now(), s.viewWindow); err != nil {
+		log.Error().Err(err).Str("post_id", postID.String()).Msg("Failed to record post view")
+	}
+}
+
+
+func (s *Service) FlushPostViews(ctx context.Context) (int, error) {
+	counts, err := s.viewBuffer.Drain(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(counts) == 0 {
+		return 0, nil
+	}
+
+	params := db.FlushPostViewsParams{
+		PostIds:    make([]uuid.UUID, len(counts)),
+		Days:       make([]string, len(counts)),
+		ViewCounts: make([]int32, len(counts)),
+	}
+	for i, count := range counts {
+		params.PostIds[i] = count.PostID
+		params.Days[i] = count.Day
+		params.ViewCounts[i] = int32(count.Views)
+	}
+
+	if err := s.store.FlushPostViews(ctx, params); err != nil {
+		if requeueErr := s.viewBuffer.Requeue(ctx, counts); requeueErr != nil {
+			log.Error().Err(requeueErr).Int("buckets", len(counts)).Msg("Failed to requeue post views")
+		}
+		return 0, fmt.Errorf("failed to flush post views: %w", err)
+	}
+
+	return len(counts), nil
+}
+
+
+func (s *Service) RunViewFlusher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			flushed, err := s.FlushPostViews(ctx)
+			if err != nil {
+				log.Error().Err(err).Msg("Post view flusher failed")
+				continue
+			}
+			if flushed > 0 {
+				log.Debug().Int("buckets", flushed).Msg("Flushed post views")
+			}
+		}
+	}
+}
+
+
+func (s *Service) GetPostImpressions(ctx context.Context, userID, postID uuid.UUID, days int) (*PostImpressionsResponse, error) {
+	post, err := s.reactablePost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.AuthorID != userID {
+		return nil, fmt.Errorf("%w: only the author can view post impressions", util.ErrForbidden)
+	}
+
+	if days <= 0 {
+		days = defaultImpressionsDays
+	}
+	if days > maxImpressionsDays {
+		days = maxImpressionsDays
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(days - 1))
+
+	rows, err := s.store.GetPostDailyViews(ctx, db.GetPostDailyViewsParams{
+		PostID: postID,
+		Since:  since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post impressions: %w", err)
+	}
+
+	viewsByDay := make(map[string]int32, len(rows))
+	for _, row := range rows {
+		viewsByDay[row.Day.Format(viewDayLayout)] = row.Views
+	}
+
+	
+	response := &PostImpressionsResponse{
+		PostID:     postID,
+		TotalViews: post.ViewsCount.Int32,
+		Days:       make([]DailyImpressionsResponse, days),
+	}
+	for i := 0; i < days; i++ {
+		day := since.AddDate(0, 0, i).Format(viewDayLayout)
+		response.Days[i] = DailyImpressionsResponse{Date: day, Views: viewsByDay[day]}
+		response.PeriodViews += viewsByDay[day]
+	}
+
+	return response, nil
+}
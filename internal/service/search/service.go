@@ -0,0 +1,171 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+)
+
+
+const maxQueryLength = 200
+
+
+type Service struct {
+	store db.Store
+}
+
+
+func NewService(store db.Store) *Service {
+	return &Service{
+		store: store,
+	}
+}
+
+
+func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: search query is required", util.ErrBadRequest)
+	}
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		return nil, fmt.Errorf("%w: search query must be at most %d characters", util.ErrBadRequest, maxQueryLength)
+	}
+
+	types := make([]string, 0, len(req.Types))
+	for _, t := range req.Types {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if !validTypes[t] {
+			return nil, fmt.Errorf("%w: unknown search type %q", util.ErrBadRequest, t)
+		}
+		types = append(types, t)
+	}
+
+	
+	rows, err := s.store.SearchDocuments(ctx, db.SearchDocumentsParams{
+		Query:       query,
+		SpaceID:     req.SpaceID,
+		EntityTypes: types,
+		ViewerID:    req.ViewerID,
+		CursorID:    req.Page.CursorID(),
+		PageSize:    req.Page.PageSize(),
+		PageOffset:  req.Page.PageOffset(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	rows, hasMore := util.TrimPage(rows, req.Page)
+
+	facetRows, err := s.store.GetSearchFacets(ctx, db.GetSearchFacetsParams{
+		Query:    query,
+		SpaceID:  req.SpaceID,
+		ViewerID: req.ViewerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search facets: %w", err)
+	}
+
+	
+	facets := make(map[string]int64, len(validTypes))
+	for t := range validTypes {
+		facets[t] = 0
+	}
+	var total int64
+	for _, row := range facetRows {
+		facets[row.EntityType] = row.ResultCount
+		if len(types) == 0 || containsType(types, row.EntityType) {
+			total += row.ResultCount
+		}
+	}
+
+	autoExpand, err := s.autoExpandContentWarnings(ctx, req.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*SearchResultResponse, len(rows))
+	for i, row := range rows {
+		results[i] = toSearchResultResponse(row)
+		if autoExpand {
+			results[i].Collapsed = false
+		}
+	}
+
+	page := util.NewPage(results, req.Page, hasMore)
+	page.Total = &total
+	if hasMore {
+		last := rows[len(rows)-1]
+		page.SetNextCursor(last.CreatedAt.Time, last.EntityID)
+	}
+
+	return &SearchResponse{
+		Page:   page,
+		Query:  query,
+		Facets: facets,
+	}, nil
+}
+
+
+func toSearchResultResponse(row db.SearchDocumentsRow) *SearchResultResponse {
+	resp := &SearchResultResponse{
+		Type:           row.EntityType,
+		ID:             row.EntityID,
+		Title:          row.Title,
+		TitleHighlight: row.TitleHighlight,
+		Snippet:        row.Snippet,
+		Score:          row.Score,
+	}
+
+	if row.Subtitle.Valid {
+		resp.Subtitle = &row.Subtitle.String
+	}
+	if row.Image.Valid {
+		resp.Image = &row.Image.String
+	}
+	if row.CreatedAt.Valid {
+		resp.CreatedAt = &row.CreatedAt.Time
+	}
+	if row.ContentWarning.Valid {
+		resp.ContentWarning = &row.ContentWarning.String
+	}
+	resp.IsSensitive = row.IsSensitive
+	resp.Collapsed = row.ContentWarning.Valid || row.IsSensitive
+
+	return resp
+}
+
+
+func (s *Service) autoExpandContentWarnings(ctx context.Context, viewerID uuid.UUID) (bool, error) {
+	if viewerID == uuid.Nil {
+		return false, nil
+	}
+
+	autoExpand, err := s.store.GetAutoExpandContentWarnings(ctx, viewerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get content warning preference: %w", err)
+	}
+	return autoExpand, nil
+}
+
+
+func containsType(types []string, t string) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"time"
+
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+)
+
+
+const (
+	TypePost      = "post"
+	TypeUser      = "user"
+	TypeCommunity = "community"
+	TypeGroup     = "group"
+	TypeEvent     = "event"
+)
+
+
+var validTypes = map[string]bool{
+	TypePost:      true,
+	TypeUser:      true,
+	TypeCommunity: true,
+	TypeGroup:     true,
+	TypeEvent:     true,
+}
+
+
+type SearchRequest struct {
+	Query    string
+	SpaceID  uuid.UUID
+	ViewerID uuid.UUID
+	Types    []string
+	Page     util.PageRequest
+}
+
+
+type SearchResultResponse struct {
+	Type           string     `json:"type"`
+	ID             uuid.UUID  `json:"id"`
+	Title          string     `json:"title"`
+	TitleHighlight string     `json:"title_highlight"`
+	Subtitle       *string    `json:"subtitle,omitempty"`
+	Image          *string    `json:"image,omitempty"`
+	Snippet        string     `json:"snippet"`
+	Score          float64    `json:"score"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	ContentWarning *string    `json:"content_warning,omitempty"`
+	IsSensitive    bool       `json:"is_sensitive"`
+	Collapsed      bool       `json:"collapsed"`
+}
+
+
+type SearchResponse struct {
+	*util.Page[*SearchResultResponse]
+	Query  string           `json:"query"`
+	Facets map[string]int64 `json:"facets"`
+}
@@ -0,0 +1,198 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+func (s *Service) BlockUser(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	if blockerID == blockedID {
+		return fmt.Errorf("%w: cannot block yourself", util.ErrBadRequest)
+	}
+
+	if err := s.ensureUserExists(ctx, blockedID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.BlockUserTx(ctx, db.BlockUserParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}); err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+
+	s.publishAudienceChanged(ctx, blockerID, blockedID)
+	return nil
+}
+
+
+func (s *Service) UnblockUser(ctx context.Context, blockerID, blockedID uuid.UUID) error {
+	removed, err := s.store.UnblockUser(ctx, db.UnblockUserParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%w: user is not blocked", util.ErrNotFound)
+	}
+
+	s.publishAudienceChanged(ctx, blockerID, blockedID)
+	return nil
+}
+
+
+func (s *Service) MuteUser(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	if muterID == mutedID {
+		return fmt.Errorf("%w: cannot mute yourself", util.ErrBadRequest)
+	}
+
+	if err := s.ensureUserExists(ctx, mutedID); err != nil {
+		return err
+	}
+
+	if _, err := s.store.MuteUser(ctx, db.MuteUserParams{
+		MuterID: muterID,
+		MutedID: mutedID,
+	}); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to mute user: %w", err)
+	}
+
+	s.publishAudienceChanged(ctx, muterID, mutedID)
+	return nil
+}
+
+
+func (s *Service) UnmuteUser(ctx context.Context, muterID, mutedID uuid.UUID) error {
+	removed, err := s.store.UnmuteUser(ctx, db.UnmuteUserParams{
+		MuterID: muterID,
+		MutedID: mutedID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unmute user: %w", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("%w: user is not muted", util.ErrNotFound)
+	}
+
+	s.publishAudienceChanged(ctx, muterID, mutedID)
+	return nil
+}
+
+
+func (s *Service) GetBlockedUsers(ctx context.Context, userID uuid.UUID, page, limit int32) ([]BlockedUserResponse, error) {
+	rows, err := s.store.GetBlockedUsers(ctx, db.GetBlockedUsersParams{
+		BlockerID: userID,
+		Limit:     limit,
+		Offset:    (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %w", err)
+	}
+
+	response := make([]BlockedUserResponse, len(rows))
+	for i, row := range rows {
+		response[i] = BlockedUserResponse{
+			ID:        row.ID,
+			Username:  row.Username,
+			FullName:  row.FullName,
+			Avatar:    nullStringToPtr(row.Avatar),
+			BlockedAt: row.BlockedAt,
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) GetMutedUsers(ctx context.Context, userID uuid.UUID, page, limit int32) ([]MutedUserResponse, error) {
+	rows, err := s.store.GetMutedUsers(ctx, db.GetMutedUsersParams{
+		MuterID: userID,
+		Limit:   limit,
+		Offset:  (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muted users: %w", err)
+	}
+
+	response := make([]MutedUserResponse, len(rows))
+	for i, row := range rows {
+		response[i] = MutedUserResponse{
+			ID:       row.ID,
+			Username: row.Username,
+			FullName: row.FullName,
+			Avatar:   nullStringToPtr(row.Avatar),
+			MutedAt:  row.MutedAt,
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) GetRelationshipStatus(ctx context.Context, viewerID, targetID uuid.UUID) (*RelationshipStatusResponse, error) {
+	status, err := s.store.GetRelationshipStatus(ctx, db.GetRelationshipStatusParams{
+		ViewerID: viewerID,
+		TargetID: targetID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relationship status: %w", err)
+	}
+
+	isFollowing, err := s.CheckIfFollowing(ctx, viewerID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RelationshipStatusResponse{
+		IsFollowing: isFollowing,
+		IsRequested: status.IsRequested,
+		IsBlocking:  status.IsBlocking,
+		IsBlockedBy: status.IsBlockedBy,
+		IsMuting:    status.IsMuting,
+	}, nil
+}
+
+
+func (s *Service) HiddenAudience(ctx context.Context, actorID uuid.UUID) (map[uuid.UUID]bool, error) {
+	userIDs, err := s.store.GetHiddenAudience(ctx, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hidden audience: %w", err)
+	}
+
+	hidden := make(map[uuid.UUID]bool, len(userIDs))
+	for _, id := range userIDs {
+		hidden[id] = true
+	}
+	return hidden, nil
+}
+
+
+func (s *Service) publishAudienceChanged(ctx context.Context, userID, otherUserID uuid.UUID) {
+	if s.liveService == nil {
+		return
+	}
+	if err := s.liveService.PublishAudienceChanged(ctx, userID, otherUserID); err != nil {
+		log.Error().Err(err).Msg("Failed to publish audience.changed event")
+	}
+}
+
+
+func (s *Service) ensureUserExists(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.store.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: user not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package users
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/service/notifications"
+	"github.com/connect-univyn/connect-server/internal/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+
+func (s *Service) requestFollow(ctx context.Context, requesterID, targetID, spaceID uuid.UUID) (string, error) {
+	_, err := s.store.CreateFollowRequest(ctx, db.CreateFollowRequestParams{
+		RequesterID: requesterID,
+		TargetID:    targetID,
+		SpaceID:     spaceID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return FollowStatusRequested, nil
+		}
+		return "", fmt.Errorf("failed to create follow request: %w", err)
+	}
+
+	s.notifyFollow(ctx, targetID, requesterID, "follow_request", "New follow request", "wants to follow you", true)
+
+	return FollowStatusRequested, nil
+}
+
+
+func (s *Service) ApproveFollowRequest(ctx context.Context, targetID, requesterID uuid.UUID) error {
+	_, err := s.store.ApproveFollowRequestTx(ctx, db.DeleteFollowRequestParams{
+		RequesterID: requesterID,
+		TargetID:    targetID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: follow request not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to approve follow request: %w", err)
+	}
+
+	s.notifyFollow(ctx, requesterID, targetID, "follow_request_approved", "Follow request approved", "accepted your follow request", false)
+
+	return nil
+}
+
+
+func (s *Service) RejectFollowRequest(ctx context.Context, targetID, requesterID uuid.UUID) error {
+	_, err := s.store.DeleteFollowRequest(ctx, db.DeleteFollowRequestParams{
+		RequesterID: requesterID,
+		TargetID:    targetID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: follow request not found", util.ErrNotFound)
+		}
+		return fmt.Errorf("failed to reject follow request: %w", err)
+	}
+
+	return nil
+}
+
+
+func (s *Service) GetIncomingFollowRequests(ctx context.Context, userID uuid.UUID, page, limit int32) ([]FollowRequestResponse, error) {
+	rows, err := s.store.GetIncomingFollowRequests(ctx, db.GetIncomingFollowRequestsParams{
+		TargetID: userID,
+		Limit:    limit,
+		Offset:   (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow requests: %w", err)
+	}
+
+	response := make([]FollowRequestResponse, len(rows))
+	for i, row := range rows {
+		response[i] = FollowRequestResponse{
+			ID:          row.ID,
+			Username:    row.Username,
+			FullName:    row.FullName,
+			Avatar:      nullStringToPtr(row.Avatar),
+			RequestedAt: row.RequestedAt,
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) GetOutgoingFollowRequests(ctx context.Context, userID uuid.UUID, page, limit int32) ([]FollowRequestResponse, error) {
+	rows, err := s.store.GetOutgoingFollowRequests(ctx, db.GetOutgoingFollowRequestsParams{
+		RequesterID: userID,
+		Limit:       limit,
+		Offset:      (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follow requests: %w", err)
+	}
+
+	response := make([]FollowRequestResponse, len(rows))
+	for i, row := range rows {
+		response[i] = FollowRequestResponse{
+			ID:          row.ID,
+			Username:    row.Username,
+			FullName:    row.FullName,
+			Avatar:      nullStringToPtr(row.Avatar),
+			RequestedAt: row.RequestedAt,
+		}
+	}
+
+	return response, nil
+}
+
+
+func (s *Service) SetAccountPrivacy(ctx context.Context, userID uuid.UUID, private bool) (*UserResponse, error) {
+	user, err := s.store.SetAccountPrivacy(ctx, db.SetAccountPrivacyParams{
+		PrivateAccount: private,
+		ID:             userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update account privacy: %w", err)
+	}
+
+	return s.toUserResponse(user), nil
+}
+
+
+func (s *Service) notifyFollow(ctx context.Context, toUserID, fromUserID uuid.UUID, notificationType, title, action string, actionRequired bool) {
+	if s.notificationService == nil {
+		return
+	}
+
+	message := action
+	if user, err := s.store.GetUserByID(ctx, fromUserID); err == nil {
+		message = fmt.Sprintf("%s %s", user.Username, action)
+	}
+	relatedID := fromUserID
+
+	if _, err := s.notificationService.CreateNotification(ctx, notifications.CreateNotificationRequest{
+		ToUserID:       toUserID,
+		FromUserID:     &fromUserID,
+		Type:           notificationType,
+		Title:          &title,
+		Message:        &message,
+		RelatedID:      &relatedID,
+		ActionRequired: actionRequired,
+	}); err != nil && !errors.Is(err, notifications.ErrNotificationSuppressed) {
+		log.Error().Err(err).Str("user_id", toUserID.String()).Msg("Failed to create follow notification")
+	}
+}
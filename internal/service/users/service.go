@@ -9,6 +9,8 @@ import (
 	"time"
 
 	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/live"
+	"github.com/connect-univyn/connect-server/internal/service/notifications"
 	"github.com/connect-univyn/connect-server/internal/util"
 	"github.com/connect-univyn/connect-server/internal/util/auth"
 	"github.com/google/uuid"
@@ -16,13 +18,17 @@ import (
 
 
 type Service struct {
-	store db.Store
+	store               db.Store
+	liveService         *live.Service
+	notificationService *notifications.Service
 }
 
 
-func NewService(store db.Store) *Service {
+func NewService(store db.Store, liveService *live.Service, notificationService *notifications.Service) *Service {
 	return &Service{
-		store: store,
+		store:               store,
+		liveService:         liveService,
+		notificationService: notificationService,
 	}
 }
 
@@ -367,19 +373,46 @@ func (s *Service) Authenticate(ctx context.Context, email, password string) (*Us
 }
 
 
-func (s *Service) FollowUser(ctx context.Context, followerID, followingID, spaceID uuid.UUID) error {
+func (s *Service) FollowUser(ctx context.Context, followerID, followingID, spaceID uuid.UUID) (string, error) {
 	
 	if followerID == followingID {
-		return fmt.Errorf("%w: cannot follow yourself", util.ErrBadRequest)
+		return "", fmt.Errorf("%w: cannot follow yourself", util.ErrBadRequest)
 	}
 
 	
 	_, err := s.store.GetUserByID(ctx, followingID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("%w: user not found", util.ErrNotFound)
+			return "", fmt.Errorf("%w: user not found", util.ErrNotFound)
 		}
-		return fmt.Errorf("failed to get user: %w", err)
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	blocked, err := s.store.IsBlockedBetween(ctx, db.IsBlockedBetweenParams{
+		UserA: followerID,
+		UserB: followingID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to check block status: %w", err)
+	}
+	if blocked {
+		return "", fmt.Errorf("%w: cannot follow this user", util.ErrForbidden)
+	}
+
+	isFollowing, err := s.CheckIfFollowing(ctx, followerID, followingID)
+	if err != nil {
+		return "", err
+	}
+	if isFollowing {
+		return FollowStatusFollowing, nil
+	}
+
+	isPrivate, err := s.store.IsPrivateAccount(ctx, followingID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check account privacy: %w", err)
+	}
+	if isPrivate {
+		return s.requestFollow(ctx, followerID, followingID, spaceID)
 	}
 
 	
@@ -391,20 +424,20 @@ func (s *Service) FollowUser(ctx context.Context, followerID, followingID, space
 	if err != nil {
 		
 		if strings.Contains(err.Error(), "no rows") {
-			return nil 
+			return FollowStatusFollowing, nil 
 		}
-		return fmt.Errorf("failed to follow user: %w", err)
+		return "", fmt.Errorf("failed to follow user: %w", err)
 	}
 
 	
 	if err := s.store.IncrementFollowingCount(ctx, followerID); err != nil {
-		return fmt.Errorf("failed to update following count: %w", err)
+		return "", fmt.Errorf("failed to update following count: %w", err)
 	}
 	if err := s.store.IncrementFollowersCount(ctx, followingID); err != nil {
-		return fmt.Errorf("failed to update followers count: %w", err)
+		return "", fmt.Errorf("failed to update followers count: %w", err)
 	}
 
-	return nil
+	return FollowStatusFollowing, nil
 }
 
 
@@ -415,7 +448,27 @@ func (s *Service) UnfollowUser(ctx context.Context, followerID, followingID uuid
 	}
 
 	
-	err := s.store.UnfollowUser(ctx, db.UnfollowUserParams{
+	_, err := s.store.DeleteFollowRequest(ctx, db.DeleteFollowRequestParams{
+		RequesterID: followerID,
+		TargetID:    followingID,
+	})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to cancel follow request: %w", err)
+	}
+
+	isFollowing, err := s.CheckIfFollowing(ctx, followerID, followingID)
+	if err != nil {
+		return err
+	}
+	if !isFollowing {
+		return nil
+	}
+
+	
+	err = s.store.UnfollowUser(ctx, db.UnfollowUserParams{
 		FollowerID:  followerID,
 		FollowingID: followingID,
 	})
@@ -447,18 +500,19 @@ func (s *Service) CheckIfFollowing(ctx context.Context, followerID, followingID
 }
 
 
-func (s *Service) GetFollowers(ctx context.Context, userID uuid.UUID, page, limit int32) ([]UserFollowResponse, error) {
-	offset := (page - 1) * limit
-
+func (s *Service) GetFollowers(ctx context.Context, userID uuid.UUID, page util.PageRequest) (*util.Page[UserFollowResponse], error) {
 	followers, err := s.store.GetUserFollowers(ctx, db.GetUserFollowersParams{
-		FollowingID: userID,
-		Limit:       limit,
-		Offset:      offset,
+		FollowingID:    userID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get followers: %w", err)
 	}
 
+	followers, hasMore := util.TrimPage(followers, page)
 	response := make([]UserFollowResponse, len(followers))
 	for i, follower := range followers {
 		response[i] = UserFollowResponse{
@@ -474,22 +528,37 @@ func (s *Service) GetFollowers(ctx context.Context, userID uuid.UUID, page, limi
 		}
 	}
 
-	return response, nil
-}
+	result := util.NewPage(response, page, hasMore)
+	if hasMore {
+		last := followers[len(followers)-1]
+		result.SetNextCursor(last.FollowedAt.Time, last.ID)
+	}
 
+	if page.IncludeTotal {
+		total, err := s.store.CountUserFollowers(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count follows: %w", err)
+		}
+		result.Total = &total
+	}
 
-func (s *Service) GetFollowing(ctx context.Context, userID uuid.UUID, page, limit int32) ([]UserFollowResponse, error) {
-	offset := (page - 1) * limit
+	return result, nil
+}
 
+
+func (s *Service) GetFollowing(ctx context.Context, userID uuid.UUID, page util.PageRequest) (*util.Page[UserFollowResponse], error) {
 	following, err := s.store.GetUserFollowing(ctx, db.GetUserFollowingParams{
-		FollowerID: userID,
-		Limit:      limit,
-		Offset:     offset,
+		FollowerID:     userID,
+		CursorCreatedAt: page.CursorCreatedAt(),
+		CursorID:        page.CursorID(),
+		PageSize:        page.PageSize(),
+		PageOffset:      page.PageOffset(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get following: %w", err)
 	}
 
+	following, hasMore := util.TrimPage(following, page)
 	response := make([]UserFollowResponse, len(following))
 	for i, user := range following {
 		response[i] = UserFollowResponse{
@@ -505,7 +574,53 @@ func (s *Service) GetFollowing(ctx context.Context, userID uuid.UUID, page, limi
 		}
 	}
 
-	return response, nil
+	result := util.NewPage(response, page, hasMore)
+	if hasMore {
+		last := following[len(following)-1]
+		result.SetNextCursor(last.FollowedAt.Time, last.ID)
+	}
+
+	if page.IncludeTotal {
+		total, err := s.store.CountUserFollowing(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count follows: %w", err)
+		}
+		result.Total = &total
+	}
+
+	return result, nil
+}
+
+
+func (s *Service) SetAutoExpandContentWarnings(ctx context.Context, userID uuid.UUID, autoExpand bool) (*UserResponse, error) {
+	user, err := s.store.SetAutoExpandContentWarnings(ctx, db.SetAutoExpandContentWarningsParams{
+		AutoExpand: autoExpand,
+		ID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update content warning preference: %w", err)
+	}
+
+	return s.toUserResponse(user), nil
+}
+
+
+func (s *Service) SetAllowMessagesFrom(ctx context.Context, userID uuid.UUID, policy string) (*UserResponse, error) {
+	user, err := s.store.SetAllowMessagesFrom(ctx, db.SetAllowMessagesFromParams{
+		AllowMessagesFrom: policy,
+		ID:                userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user not found", util.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to update message request policy: %w", err)
+	}
+
+	return s.toUserResponse(user), nil
 }
 
 
@@ -103,3 +103,60 @@ type UserFollowResponse struct {
 	FollowingCount *int32     `json:"following_count"`
 	FollowedAt     *time.Time `json:"followed_at"`
 }
+
+
+type BlockedUserResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"full_name"`
+	Avatar    *string   `json:"avatar"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+
+type MutedUserResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	FullName string    `json:"full_name"`
+	Avatar   *string   `json:"avatar"`
+	MutedAt  time.Time `json:"muted_at"`
+}
+
+
+type RelationshipStatusResponse struct {
+	IsFollowing bool `json:"is_following"`
+	IsRequested bool `json:"is_requested"`
+	IsBlocking  bool `json:"is_blocking"`
+	IsBlockedBy bool `json:"is_blocked_by"`
+	IsMuting    bool `json:"is_muting"`
+}
+
+
+const (
+	FollowStatusFollowing = "following"
+	FollowStatusRequested = "requested"
+)
+
+
+type FollowRequestResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Username    string    `json:"username"`
+	FullName    string    `json:"full_name"`
+	Avatar      *string   `json:"avatar"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+
+type UpdatePrivacyRequest struct {
+	PrivateAccount *bool `json:"private_account" binding:"required"`
+}
+
+
+type UpdateContentWarningPreferenceRequest struct {
+	AutoExpandContentWarnings *bool `json:"auto_expand_content_warnings" binding:"required"`
+}
+
+
+type UpdateMessageRequestPolicyRequest struct {
+	AllowMessagesFrom string `json:"allow_messages_from" binding:"required,oneof=everyone connections connections_only"`
+}
@@ -9,22 +9,29 @@ import (
 
 
 type Config struct {
-	Environment           string        `mapstructure:"ENVIRONMENT"`
-	ServerAddress         string        `mapstructure:"SERVER_ADDRESS"`
-	DatabaseURL           string        `mapstructure:"DATABASE_URL"`
-	RedisURL              string        `mapstructure:"REDIS_URL"`
-	TokenSymmetricKey     string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration   time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
-	RefreshTokenDuration  time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	RateLimitDefault      int           `mapstructure:"RATE_LIMIT_DEFAULT"`
-	RateLimitAuth         int           `mapstructure:"RATE_LIMIT_AUTH"`
-	RateLimitEnabled      bool          `mapstructure:"RATE_LIMIT_ENABLED"`
-	CORSAllowedOrigins    string        `mapstructure:"CORS_ALLOWED_ORIGINS"`
-	CORSAllowedMethods    string        `mapstructure:"CORS_ALLOWED_METHODS"`
-	CORSAllowedHeaders    string        `mapstructure:"CORS_ALLOWED_HEADERS"`
-	CORSAllowCredentials  bool          `mapstructure:"CORS_ALLOW_CREDENTIALS"`
-	LiveEnabled           bool          `mapstructure:"LIVE_ENABLED"`
-	LiveUseMemoryBroker   bool          `mapstructure:"LIVE_USE_MEMORY_BROKER"`
+	Environment            string        `mapstructure:"ENVIRONMENT"`
+	ServerAddress          string        `mapstructure:"SERVER_ADDRESS"`
+	DatabaseURL            string        `mapstructure:"DATABASE_URL"`
+	RedisURL               string        `mapstructure:"REDIS_URL"`
+	TokenSymmetricKey      string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration    time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	RefreshTokenDuration   time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	RateLimitDefault       int           `mapstructure:"RATE_LIMIT_DEFAULT"`
+	RateLimitAuth          int           `mapstructure:"RATE_LIMIT_AUTH"`
+	RateLimitEnabled       bool          `mapstructure:"RATE_LIMIT_ENABLED"`
+	CORSAllowedOrigins     string        `mapstructure:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedMethods     string        `mapstructure:"CORS_ALLOWED_METHODS"`
+	CORSAllowedHeaders     string        `mapstructure:"CORS_ALLOWED_HEADERS"`
+	CORSAllowCredentials   bool          `mapstructure:"CORS_ALLOW_CREDENTIALS"`
+	LiveEnabled            bool          `mapstructure:"LIVE_ENABLED"`
+	LiveUseMemoryBroker    bool          `mapstructure:"LIVE_USE_MEMORY_BROKER"`
+	ScheduledPostInterval  time.Duration `mapstructure:"SCHEDULED_POST_INTERVAL"`
+	TrendingTopicsInterval time.Duration `mapstructure:"TRENDING_TOPICS_INTERVAL"`
+	ViewFlushInterval      time.Duration `mapstructure:"VIEW_FLUSH_INTERVAL"`
+	ViewDedupeWindow       time.Duration `mapstructure:"VIEW_DEDUPE_WINDOW"`
+	MessageEditWindow      time.Duration `mapstructure:"MESSAGE_EDIT_WINDOW"`
+	MessageReaperInterval  time.Duration `mapstructure:"MESSAGE_REAPER_INTERVAL"`
+	ExportReaperInterval   time.Duration `mapstructure:"EXPORT_REAPER_INTERVAL"`
 }
 
 
@@ -52,6 +59,14 @@ func LoadConfig(path string) (config Config, err error) {
 	
 	viper.SetDefault("LIVE_ENABLED", true)
 	viper.SetDefault("LIVE_USE_MEMORY_BROKER", false) 
+	
+	viper.SetDefault("SCHEDULED_POST_INTERVAL", "1m")
+	viper.SetDefault("TRENDING_TOPICS_INTERVAL", "10m")
+	viper.SetDefault("VIEW_FLUSH_INTERVAL", "30s")
+	viper.SetDefault("VIEW_DEDUPE_WINDOW", "30m")
+	viper.SetDefault("MESSAGE_EDIT_WINDOW", "15m")
+	viper.SetDefault("MESSAGE_REAPER_INTERVAL", "1m")
+	viper.SetDefault("EXPORT_REAPER_INTERVAL", "5m")
 
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -0,0 +1,99 @@
+package util
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	return &Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+
+type RankCursor struct {
+	SnapshotAt time.Time
+	Score      float64
+	ID         uuid.UUID
+}
+
+
+func EncodeRankCursor(snapshotAt time.Time, score float64, id uuid.UUID) string {
+	raw := snapshotAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatFloat(score, 'g', -1, 64) + "|" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+
+func DecodeRankCursor(encoded string) (*RankCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	snapshotAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	score, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", ErrBadRequest)
+	}
+
+	return &RankCursor{SnapshotAt: snapshotAt, Score: score, ID: id}, nil
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+
+type PageRequest struct {
+	Limit        int32
+	Offset       int32
+	After        *Cursor
+	Keyset       bool
+	IncludeTotal bool
+}
+
+
+func (r PageRequest) CursorCreatedAt() sql.NullTime {
+	if r.After == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: r.After.CreatedAt, Valid: true}
+}
+
+
+func (r PageRequest) CursorID() uuid.NullUUID {
+	if r.After == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: r.After.ID, Valid: true}
+}
+
+
+func (r PageRequest) PageSize() int32 {
+	return r.Limit + 1
+}
+
+
+func (r PageRequest) PageOffset() int32 {
+	if r.Keyset {
+		return 0
+	}
+	return r.Offset
+}
+
+
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+	Total      *int64  `json:"total,omitempty"`
+	keyset     bool
+}
+
+
+func TrimPage[R any](rows []R, req PageRequest) ([]R, bool) {
+	if int32(len(rows)) > req.Limit {
+		return rows[:req.Limit], true
+	}
+	return rows, false
+}
+
+
+func NewPage[T any](items []T, req PageRequest, hasMore bool) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{Items: items, HasMore: hasMore, keyset: req.Keyset}
+}
+
+
+func (p *Page[T]) SetNextCursor(createdAt time.Time, id uuid.UUID) {
+	if !p.HasMore {
+		return
+	}
+	next := EncodeCursor(createdAt, id)
+	p.NextCursor = &next
+}
+
+
+func (p *Page[T]) Response() interface{} {
+	if p.keyset {
+		return p
+	}
+	return p.Items
+}
@@ -0,0 +1,4 @@
+-- Rollback comment threads
+
+DROP INDEX IF EXISTS idx_comments_parent_thread;
+DROP INDEX IF EXISTS idx_comments_post_thread;
@@ -0,0 +1,5 @@
+-- Comment threads
+-- Keyset pagination indexes for top-level comments and replies
+
+CREATE INDEX IF NOT EXISTS idx_comments_post_thread ON comments(post_id, created_at, id) WHERE parent_comment_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_parent_thread ON comments(parent_comment_id, created_at, id) WHERE parent_comment_id IS NOT NULL;
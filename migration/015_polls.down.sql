@@ -0,0 +1,12 @@
+-- UNIVYN Database Migration
+-- Version: 015_polls DOWN
+-- Description: Drop poll tables
+
+BEGIN;
+
+DROP TABLE IF EXISTS poll_votes;
+DROP TABLE IF EXISTS poll_voters;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
+
+COMMIT;
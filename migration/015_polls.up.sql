@@ -0,0 +1,54 @@
+-- UNIVYN Database Migration
+-- Version: 015_polls UP
+-- Description: Create poll tables attached to posts
+
+BEGIN;
+
+-- Create polls table
+CREATE TABLE polls (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    post_id UUID NOT NULL UNIQUE,
+    allow_multiple BOOLEAN NOT NULL DEFAULT false,
+    is_anonymous BOOLEAN NOT NULL DEFAULT false,
+    closes_at TIMESTAMPTZ,
+    voters_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+
+-- Create poll_options table
+CREATE TABLE poll_options (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    poll_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    votes_count INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    UNIQUE(poll_id, position)
+);
+
+-- One ballot per user per poll
+CREATE TABLE poll_voters (
+    poll_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (poll_id, user_id),
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Options selected on each ballot
+CREATE TABLE poll_votes (
+    poll_id UUID NOT NULL,
+    option_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (option_id, user_id),
+    FOREIGN KEY (poll_id, user_id) REFERENCES poll_voters(poll_id, user_id) ON DELETE CASCADE,
+    FOREIGN KEY (option_id) REFERENCES poll_options(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_poll_options_poll_id ON poll_options(poll_id);
+CREATE INDEX idx_poll_votes_poll_user ON poll_votes(poll_id, user_id);
+
+COMMIT;
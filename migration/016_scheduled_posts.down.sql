@@ -0,0 +1,48 @@
+-- UNIVYN Database Migration
+-- Version: 016_scheduled_posts DOWN
+-- Description: Remove draft and scheduled post lifecycle
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_log_post_published ON posts;
+DROP TRIGGER IF EXISTS trigger_log_post_created ON posts;
+CREATE TRIGGER trigger_log_post_created
+    AFTER INSERT ON posts
+    FOR EACH ROW
+    EXECUTE FUNCTION log_space_activity('post_created', 'New post created');
+
+CREATE OR REPLACE FUNCTION log_space_activity()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_actor_id UUID;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        IF TG_TABLE_NAME = 'posts' THEN
+            v_actor_id := NEW.author_id;
+        ELSIF TG_TABLE_NAME IN ('communities', 'groups') THEN
+            v_actor_id := NEW.created_by;
+        ELSE
+            v_actor_id := NULL;
+        END IF;
+
+        INSERT INTO space_activities (space_id, activity_type, actor_id, actor_name, description, metadata)
+        VALUES (
+            NEW.space_id,
+            TG_ARGV[0],
+            v_actor_id,
+            (SELECT full_name FROM users WHERE id = v_actor_id),
+            TG_ARGV[1],
+            jsonb_build_object('resource_id', NEW.id)
+        );
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE posts SET status = 'removed' WHERE status IN ('draft', 'scheduled');
+
+DROP INDEX IF EXISTS idx_posts_author_pending;
+DROP INDEX IF EXISTS idx_posts_scheduled_publish_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS publish_at;
+
+COMMIT;
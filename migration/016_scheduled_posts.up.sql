@@ -0,0 +1,56 @@
+-- UNIVYN Database Migration
+-- Version: 016_scheduled_posts UP
+-- Description: Add draft and scheduled post lifecycle
+
+BEGIN;
+
+-- Posts in 'draft' or 'scheduled' status are not visible until published
+ALTER TABLE posts ADD COLUMN publish_at TIMESTAMPTZ;
+
+CREATE INDEX idx_posts_scheduled_publish_at ON posts(publish_at) WHERE status = 'scheduled';
+CREATE INDEX idx_posts_author_pending ON posts(author_id, status) WHERE status IN ('draft', 'scheduled');
+
+-- Only log post activity once the post is actually published
+CREATE OR REPLACE FUNCTION log_space_activity()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_actor_id UUID;
+BEGIN
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        IF TG_TABLE_NAME = 'posts' THEN
+            v_actor_id := NEW.author_id;
+        ELSIF TG_TABLE_NAME IN ('communities', 'groups') THEN
+            v_actor_id := NEW.created_by;
+        ELSE
+            v_actor_id := NULL;
+        END IF;
+
+        INSERT INTO space_activities (space_id, activity_type, actor_id, actor_name, description, metadata)
+        VALUES (
+            NEW.space_id,
+            TG_ARGV[0],
+            v_actor_id,
+            (SELECT full_name FROM users WHERE id = v_actor_id),
+            TG_ARGV[1],
+            jsonb_build_object('resource_id', NEW.id)
+        );
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_log_post_created ON posts;
+CREATE TRIGGER trigger_log_post_created
+    AFTER INSERT ON posts
+    FOR EACH ROW
+    WHEN (NEW.status IS NULL OR NEW.status = 'active')
+    EXECUTE FUNCTION log_space_activity('post_created', 'New post created');
+
+DROP TRIGGER IF EXISTS trigger_log_post_published ON posts;
+CREATE TRIGGER trigger_log_post_published
+    AFTER UPDATE OF status ON posts
+    FOR EACH ROW
+    WHEN (OLD.status IN ('draft', 'scheduled') AND NEW.status = 'active')
+    EXECUTE FUNCTION log_space_activity('post_created', 'New post created');
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- UNIVYN Database Migration
+-- Version: 017_bookmarks DOWN
+-- Description: Drop bookmark tables
+
+BEGIN;
+
+DROP TABLE IF EXISTS bookmarks;
+DROP TABLE IF EXISTS bookmark_collections;
+
+COMMIT;
@@ -0,0 +1,36 @@
+-- UNIVYN Database Migration
+-- Version: 017_bookmarks UP
+-- Description: Create private bookmarks and named bookmark collections
+
+BEGIN;
+
+-- Create bookmark_collections table
+CREATE TABLE bookmark_collections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(user_id, name)
+);
+
+-- Create bookmarks table; a post is saved at most once per user
+CREATE TABLE bookmarks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    post_id UUID NOT NULL,
+    collection_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (collection_id) REFERENCES bookmark_collections(id) ON DELETE SET NULL,
+    UNIQUE(user_id, post_id)
+);
+
+CREATE INDEX idx_bookmark_collections_user_id ON bookmark_collections(user_id);
+CREATE INDEX idx_bookmarks_user_created ON bookmarks(user_id, created_at DESC, id DESC);
+CREATE INDEX idx_bookmarks_collection_created ON bookmarks(collection_id, created_at DESC, id DESC) WHERE collection_id IS NOT NULL;
+CREATE INDEX idx_bookmarks_post_id ON bookmarks(post_id);
+
+COMMIT;
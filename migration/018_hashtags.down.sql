@@ -0,0 +1,19 @@
+-- UNIVYN Database Migration
+-- Version: 018_hashtags DOWN
+-- Description: Drop hashtag tables and triggers
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_clear_post_tags ON posts;
+DROP FUNCTION IF EXISTS clear_post_tags();
+
+DROP TRIGGER IF EXISTS trigger_post_tags_usage ON post_tags;
+DROP FUNCTION IF EXISTS update_tag_space_usage();
+
+DROP INDEX IF EXISTS idx_trending_topics_space_period_score;
+
+DROP TABLE IF EXISTS tag_space_usage;
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS tags;
+
+COMMIT;
@@ -0,0 +1,98 @@
+-- UNIVYN Database Migration
+-- Version: 018_hashtags UP
+-- Description: Normalize hashtags into a tag table with per-space usage and computed trends
+
+BEGIN;
+
+-- Create tags table; names are stored lowercase without the leading '#'
+CREATE TABLE tags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create post_tags table linking published posts to their tags
+CREATE TABLE post_tags (
+    post_id UUID NOT NULL,
+    tag_id UUID NOT NULL,
+    space_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, tag_id),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE,
+    FOREIGN KEY (space_id) REFERENCES spaces(id) ON DELETE CASCADE
+);
+
+-- Create tag_space_usage table holding per-space usage counters
+CREATE TABLE tag_space_usage (
+    tag_id UUID NOT NULL,
+    space_id UUID NOT NULL,
+    usage_count INTEGER NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tag_id, space_id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE,
+    FOREIGN KEY (space_id) REFERENCES spaces(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_tags_name_pattern ON tags(name varchar_pattern_ops);
+CREATE INDEX idx_post_tags_tag_created ON post_tags(tag_id, created_at DESC);
+CREATE INDEX idx_post_tags_space_created ON post_tags(space_id, created_at DESC);
+CREATE INDEX idx_tag_space_usage_space_count ON tag_space_usage(space_id, usage_count DESC);
+CREATE INDEX idx_trending_topics_space_period_score ON trending_topics(space_id, period, trend_score DESC);
+
+-- Keep tag_space_usage in step with post_tags
+CREATE OR REPLACE FUNCTION update_tag_space_usage()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO tag_space_usage (tag_id, space_id, usage_count, last_used_at)
+        VALUES (NEW.tag_id, NEW.space_id, 1, NOW())
+        ON CONFLICT (tag_id, space_id)
+        DO UPDATE SET usage_count = tag_space_usage.usage_count + 1, last_used_at = NOW();
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE tag_space_usage
+        SET usage_count = GREATEST(usage_count - 1, 0)
+        WHERE tag_id = OLD.tag_id AND space_id = OLD.space_id;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_post_tags_usage
+    AFTER INSERT OR DELETE ON post_tags
+    FOR EACH ROW
+    EXECUTE FUNCTION update_tag_space_usage();
+
+-- Removed or hidden posts no longer count towards tag usage
+CREATE OR REPLACE FUNCTION clear_post_tags()
+RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM post_tags WHERE post_id = NEW.id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_clear_post_tags
+    AFTER UPDATE OF status ON posts
+    FOR EACH ROW
+    WHEN (OLD.status = 'active' AND NEW.status IS DISTINCT FROM 'active')
+    EXECUTE FUNCTION clear_post_tags();
+
+-- Backfill from existing active posts
+INSERT INTO tags (name)
+SELECT DISTINCT lower(t)
+FROM posts p, unnest(p.tags) AS t
+WHERE p.status = 'active' AND t <> '' AND length(t) <= 100
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO post_tags (post_id, tag_id, space_id, created_at)
+SELECT DISTINCT p.id, tg.id, p.space_id, COALESCE(p.created_at, NOW())
+FROM posts p
+CROSS JOIN LATERAL unnest(p.tags) AS t
+JOIN tags tg ON tg.name = lower(t)
+WHERE p.status = 'active'
+ON CONFLICT DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,10 @@
+-- UNIVYN Database Migration
+-- Version: 019_mentions DOWN
+-- Description: Drop mentions table
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_users_username_lower;
+DROP TABLE IF EXISTS mentions;
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- UNIVYN Database Migration
+-- Version: 019_mentions UP
+-- Description: Store @mentions found in posts, comments and messages
+
+BEGIN;
+
+-- Create mentions table; offsets and lengths are measured in characters
+CREATE TABLE mentions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_type VARCHAR(20) NOT NULL CHECK (source_type IN ('post', 'comment', 'message')),
+    source_id UUID NOT NULL,
+    author_id UUID NOT NULL,
+    mentioned_user_id UUID NOT NULL,
+    start_offset INTEGER NOT NULL,
+    length INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (mentioned_user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(source_type, source_id, start_offset)
+);
+
+CREATE INDEX idx_mentions_source ON mentions(source_type, source_id);
+CREATE INDEX idx_mentions_mentioned_user ON mentions(mentioned_user_id, created_at DESC);
+
+-- Case-insensitive username lookups for mention resolution and autocomplete
+CREATE INDEX idx_users_username_lower ON users(lower(username) varchar_pattern_ops);
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- UNIVYN Database Migration
+-- Version: 020_blocks_mutes DOWN
+-- Description: Drop user blocks and mutes
+
+BEGIN;
+
+DROP FUNCTION IF EXISTS is_hidden_from(UUID, UUID);
+DROP FUNCTION IF EXISTS is_blocked_between(UUID, UUID);
+DROP TABLE IF EXISTS user_mutes;
+DROP TABLE IF EXISTS user_blocks;
+
+COMMIT;
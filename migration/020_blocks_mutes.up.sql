@@ -0,0 +1,54 @@
+-- UNIVYN Database Migration
+-- Version: 020_blocks_mutes UP
+-- Description: Add user blocks and mutes with helper functions for content visibility
+
+BEGIN;
+
+-- Create user_blocks table; a block hides both users from each other
+CREATE TABLE user_blocks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    blocker_id UUID NOT NULL,
+    blocked_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- Create user_mutes table; a mute only hides the muted user's content from the muter
+CREATE TABLE user_mutes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    muter_id UUID NOT NULL,
+    muted_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (muter_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (muted_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE(muter_id, muted_id),
+    CHECK (muter_id <> muted_id)
+);
+
+CREATE INDEX idx_user_blocks_blocked_id ON user_blocks(blocked_id);
+CREATE INDEX idx_user_mutes_muted_id ON user_mutes(muted_id);
+
+-- True when either user has blocked the other
+CREATE OR REPLACE FUNCTION is_blocked_between(user_a UUID, user_b UUID)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1 FROM user_blocks
+        WHERE (blocker_id = user_a AND blocked_id = user_b)
+           OR (blocker_id = user_b AND blocked_id = user_a)
+    );
+$$ LANGUAGE sql STABLE;
+
+-- True when content authored by author_id must not be shown to viewer_id
+CREATE OR REPLACE FUNCTION is_hidden_from(viewer_id UUID, author_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT is_blocked_between(viewer_id, author_id)
+        OR EXISTS (
+            SELECT 1 FROM user_mutes
+            WHERE muter_id = viewer_id AND muted_id = author_id
+        );
+$$ LANGUAGE sql STABLE;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- UNIVYN Database Migration
+-- Version: 021_feed_ranking DOWN
+-- Description: Drop ranked feed indexes
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_comments_author_created;
+DROP INDEX IF EXISTS idx_likes_user_created;
+DROP INDEX IF EXISTS idx_likes_post_created;
+DROP INDEX IF EXISTS idx_posts_space_status_created;
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- UNIVYN Database Migration
+-- Version: 021_feed_ranking UP
+-- Description: Indexes supporting the ranked "For You" feed
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_posts_space_status_created ON posts(space_id, created_at DESC) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_likes_post_created ON likes(post_id, created_at) WHERE post_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_likes_user_created ON likes(user_id, created_at) WHERE post_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_comments_author_created ON comments(author_id, created_at);
+
+COMMIT;
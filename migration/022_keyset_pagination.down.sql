@@ -0,0 +1,17 @@
+-- UNIVYN Database Migration
+-- Version: 022_keyset_pagination DOWN
+-- Description: Drop keyset pagination indexes
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_community_members_keyset;
+DROP INDEX IF EXISTS idx_follows_follower_keyset;
+DROP INDEX IF EXISTS idx_follows_following_keyset;
+DROP INDEX IF EXISTS idx_notifications_user_keyset;
+DROP INDEX IF EXISTS idx_messages_conversation_keyset;
+DROP INDEX IF EXISTS idx_posts_group_keyset;
+DROP INDEX IF EXISTS idx_posts_community_keyset;
+DROP INDEX IF EXISTS idx_posts_author_keyset;
+DROP INDEX IF EXISTS idx_posts_space_keyset;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- UNIVYN Database Migration
+-- Version: 022_keyset_pagination UP
+-- Description: Composite (created_at, id) indexes for keyset pagination
+
+BEGIN;
+
+CREATE INDEX IF NOT EXISTS idx_posts_space_keyset ON posts(space_id, created_at DESC, id DESC) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_posts_author_keyset ON posts(author_id, created_at DESC, id DESC) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_posts_community_keyset ON posts(community_id, created_at DESC, id DESC) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_posts_group_keyset ON posts(group_id, created_at DESC, id DESC) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_keyset ON messages(conversation_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_keyset ON notifications(to_user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_follows_following_keyset ON follows(following_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_follows_follower_keyset ON follows(follower_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_community_members_keyset ON community_members(community_id, joined_at, user_id);
+
+COMMIT;
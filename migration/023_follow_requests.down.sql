@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 023_follow_requests DOWN
+-- Description: Drop follow requests and the post visibility check
+
+BEGIN;
+
+DROP FUNCTION IF EXISTS can_view_post(UUID, UUID, VARCHAR, UUID, UUID);
+DROP FUNCTION IF EXISTS is_private_account(UUID);
+DROP TABLE IF EXISTS follow_requests;
+
+COMMIT;
@@ -0,0 +1,44 @@
+-- UNIVYN Database Migration
+-- Version: 023_follow_requests UP
+-- Description: Pending follow requests for private accounts and a shared post visibility check
+
+BEGIN;
+
+-- Pending follows to private accounts; rows move into follows once approved
+CREATE TABLE follow_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    requester_id UUID NOT NULL,
+    target_id UUID NOT NULL,
+    space_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (target_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (space_id) REFERENCES spaces(id) ON DELETE CASCADE,
+    UNIQUE(requester_id, target_id),
+    CHECK (requester_id <> target_id)
+);
+
+CREATE INDEX idx_follow_requests_target ON follow_requests(target_id, created_at DESC);
+
+-- Accounts opt into approval-only follows through users.settings
+CREATE OR REPLACE FUNCTION is_private_account(user_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE((SELECT (settings->>'private_account')::boolean FROM users WHERE id = user_id), false);
+$$ LANGUAGE sql STABLE;
+
+-- True when viewer_id may see a post; followers-only posts and posts by private
+-- accounts require an approved follow, community or group membership
+CREATE OR REPLACE FUNCTION can_view_post(viewer_id UUID, author_id UUID, visibility VARCHAR, community_id UUID, group_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE(
+        viewer_id = author_id
+        OR (COALESCE(visibility, 'public') = 'public' AND NOT is_private_account(author_id))
+        OR EXISTS (SELECT 1 FROM follows f WHERE f.follower_id = viewer_id AND f.following_id = author_id)
+        OR (community_id IS NOT NULL AND EXISTS (
+            SELECT 1 FROM community_members cm WHERE cm.community_id = can_view_post.community_id AND cm.user_id = viewer_id))
+        OR (group_id IS NOT NULL AND EXISTS (
+            SELECT 1 FROM group_members gm WHERE gm.group_id = can_view_post.group_id AND gm.user_id = viewer_id)),
+        false);
+$$ LANGUAGE sql STABLE;
+
+COMMIT;
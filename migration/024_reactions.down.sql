@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 024_reactions DOWN
+-- Description: Collapse reactions back into plain likes
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_likes_comment_reaction;
+DROP INDEX IF EXISTS idx_likes_post_reaction;
+ALTER TABLE likes DROP COLUMN IF EXISTS reaction;
+
+COMMIT;
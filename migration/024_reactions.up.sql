@@ -0,0 +1,20 @@
+-- UNIVYN Database Migration
+-- Version: 024_reactions UP
+-- Description: Turn likes into typed reactions; existing likes become the default 'like' reaction
+
+BEGIN;
+
+-- Every existing like is migrated as the default reaction
+ALTER TABLE likes ADD COLUMN reaction VARCHAR(32) NOT NULL DEFAULT 'like';
+
+CREATE INDEX idx_likes_post_reaction ON likes(post_id, reaction) WHERE post_id IS NOT NULL;
+CREATE INDEX idx_likes_comment_reaction ON likes(comment_id, reaction) WHERE comment_id IS NOT NULL;
+
+-- likes_count now holds the total number of reactions; resync it from the source rows
+UPDATE posts p
+SET likes_count = (SELECT COUNT(*) FROM likes l WHERE l.post_id = p.id);
+
+UPDATE comments c
+SET likes_count = (SELECT COUNT(*) FROM likes l WHERE l.comment_id = c.id);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- UNIVYN Database Migration
+-- Version: 025_reposts DOWN
+-- Description: Drop repost counter trigger and repost indexes
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_post_share_counts ON posts;
+DROP FUNCTION IF EXISTS update_post_share_counts();
+
+DROP INDEX IF EXISTS idx_posts_quotes;
+DROP INDEX IF EXISTS idx_posts_unique_repost;
+
+COMMIT;
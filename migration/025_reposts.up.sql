@@ -0,0 +1,67 @@
+-- UNIVYN Database Migration
+-- Version: 025_reposts UP
+-- Description: One plain repost per user and post; trigger-maintained repost and quote counters
+
+BEGIN;
+
+-- Keep only the earliest plain repost when a user reposted the same post more than once
+UPDATE posts SET status = 'removed', updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY author_id, quoted_post_id ORDER BY created_at, id) AS rn
+        FROM posts
+        WHERE quoted_post_id IS NOT NULL AND content = '' AND status = 'active'
+    ) duplicates
+    WHERE rn > 1
+);
+
+-- A plain repost is a post that quotes another post without commentary
+CREATE UNIQUE INDEX idx_posts_unique_repost ON posts(author_id, quoted_post_id)
+    WHERE quoted_post_id IS NOT NULL AND content = '' AND status = 'active';
+
+CREATE INDEX idx_posts_quotes ON posts(quoted_post_id, created_at DESC)
+    WHERE quoted_post_id IS NOT NULL AND content <> '' AND status = 'active';
+
+-- Keep reposts_count and quotes_count in step with the posts that reference them.
+-- Only active posts count, so publishing, removing or undoing a repost all adjust the totals.
+CREATE OR REPLACE FUNCTION update_post_share_counts()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE')
+       AND OLD.quoted_post_id IS NOT NULL AND COALESCE(OLD.status, 'active') = 'active' THEN
+        UPDATE posts
+        SET reposts_count = GREATEST(COALESCE(reposts_count, 0) - CASE WHEN OLD.content = '' THEN 1 ELSE 0 END, 0),
+            quotes_count = GREATEST(COALESCE(quotes_count, 0) - CASE WHEN OLD.content <> '' THEN 1 ELSE 0 END, 0)
+        WHERE id = OLD.quoted_post_id;
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE')
+       AND NEW.quoted_post_id IS NOT NULL AND COALESCE(NEW.status, 'active') = 'active' THEN
+        UPDATE posts
+        SET reposts_count = COALESCE(reposts_count, 0) + CASE WHEN NEW.content = '' THEN 1 ELSE 0 END,
+            quotes_count = COALESCE(quotes_count, 0) + CASE WHEN NEW.content <> '' THEN 1 ELSE 0 END
+        WHERE id = NEW.quoted_post_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_post_share_counts ON posts;
+CREATE TRIGGER trigger_post_share_counts
+    AFTER INSERT OR DELETE OR UPDATE OF status, content, quoted_post_id ON posts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_post_share_counts();
+
+-- reposts_count and quotes_count were never maintained; resync them from the source rows
+UPDATE posts p
+SET reposts_count = (
+        SELECT COUNT(*) FROM posts r
+        WHERE r.quoted_post_id = p.id AND r.content = '' AND r.status = 'active'
+    ),
+    quotes_count = (
+        SELECT COUNT(*) FROM posts q
+        WHERE q.quoted_post_id = p.id AND q.content <> '' AND q.status = 'active'
+    );
+
+COMMIT;
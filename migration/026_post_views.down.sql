@@ -0,0 +1,9 @@
+-- UNIVYN Database Migration
+-- Version: 026_post_views DOWN
+-- Description: Drop daily post impressions
+
+BEGIN;
+
+DROP TABLE IF EXISTS post_daily_views;
+
+COMMIT;
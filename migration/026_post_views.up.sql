@@ -0,0 +1,17 @@
+-- UNIVYN Database Migration
+-- Version: 026_post_views UP
+-- Description: Daily post impressions, written in batches by the view flusher
+
+BEGIN;
+
+CREATE TABLE post_daily_views (
+    post_id UUID NOT NULL,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, day),
+    FOREIGN KEY (post_id) REFERENCES posts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_post_daily_views_day ON post_daily_views(day);
+
+COMMIT;
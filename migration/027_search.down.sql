@@ -0,0 +1,24 @@
+-- UNIVYN Database Migration
+-- Version: 027_search DOWN
+-- Description: Drop the unified search index and its triggers
+
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_event_search_document ON events;
+DROP TRIGGER IF EXISTS trigger_group_search_document ON groups;
+DROP TRIGGER IF EXISTS trigger_community_search_document ON communities;
+DROP TRIGGER IF EXISTS trigger_user_search_document ON users;
+DROP TRIGGER IF EXISTS trigger_post_search_document ON posts;
+
+DROP FUNCTION IF EXISTS can_view_search_document(UUID, VARCHAR, UUID);
+DROP FUNCTION IF EXISTS html_escape(TEXT);
+DROP FUNCTION IF EXISTS index_event_search_document();
+DROP FUNCTION IF EXISTS index_group_search_document();
+DROP FUNCTION IF EXISTS index_community_search_document();
+DROP FUNCTION IF EXISTS index_user_search_document();
+DROP FUNCTION IF EXISTS index_post_search_document();
+DROP FUNCTION IF EXISTS upsert_search_document(VARCHAR, UUID, UUID, TEXT, TEXT, TEXT, TEXT, TSVECTOR, TIMESTAMPTZ);
+
+DROP TABLE IF EXISTS search_documents;
+
+COMMIT;
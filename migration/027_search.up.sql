@@ -0,0 +1,273 @@
+-- UNIVYN Database Migration
+-- Version: 027_search UP
+-- Description: Unified search index with weighted tsvectors, trigram matching and visibility checks
+
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- One row per searchable entity; rows are kept in step with their source tables by triggers
+CREATE TABLE search_documents (
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    space_id UUID NOT NULL,
+    title TEXT NOT NULL,
+    subtitle TEXT,
+    body TEXT NOT NULL DEFAULT '',
+    image TEXT,
+    search_vector TSVECTOR NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (entity_type, entity_id),
+    CHECK (entity_type IN ('post', 'user', 'community', 'group', 'event')),
+    FOREIGN KEY (space_id) REFERENCES spaces(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_search_documents_vector ON search_documents USING GIN (search_vector);
+CREATE INDEX idx_search_documents_title_trgm ON search_documents USING GIN (title gin_trgm_ops);
+CREATE INDEX idx_search_documents_space_type ON search_documents(space_id, entity_type);
+
+CREATE OR REPLACE FUNCTION upsert_search_document(
+    doc_type VARCHAR, doc_id UUID, doc_space_id UUID, doc_title TEXT, doc_subtitle TEXT,
+    doc_body TEXT, doc_image TEXT, doc_vector TSVECTOR, doc_created_at TIMESTAMPTZ)
+RETURNS VOID AS $$
+    INSERT INTO search_documents (entity_type, entity_id, space_id, title, subtitle, body, image, search_vector, created_at, updated_at)
+    VALUES (doc_type, doc_id, doc_space_id, COALESCE(doc_title, ''), doc_subtitle, COALESCE(doc_body, ''), doc_image, doc_vector, doc_created_at, NOW())
+    ON CONFLICT (entity_type, entity_id) DO UPDATE SET
+        space_id = EXCLUDED.space_id,
+        title = EXCLUDED.title,
+        subtitle = EXCLUDED.subtitle,
+        body = EXCLUDED.body,
+        image = EXCLUDED.image,
+        search_vector = EXCLUDED.search_vector,
+        updated_at = NOW();
+$$ LANGUAGE sql;
+
+-- Posts: tags weigh more than the body text
+CREATE OR REPLACE FUNCTION index_post_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'post' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    IF COALESCE(NEW.status, 'active') <> 'active' OR NEW.content = '' THEN
+        DELETE FROM search_documents WHERE entity_type = 'post' AND entity_id = NEW.id;
+        RETURN NEW;
+    END IF;
+
+    PERFORM upsert_search_document('post', NEW.id, NEW.space_id, LEFT(NEW.content, 140), NULL, NEW.content, NULL,
+        setweight(to_tsvector('english', COALESCE(array_to_string(NEW.tags, ' '), '')), 'A') ||
+        setweight(to_tsvector('english', NEW.content), 'B'),
+        NEW.created_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_post_search_document
+    AFTER INSERT OR DELETE OR UPDATE OF content, tags, status ON posts
+    FOR EACH ROW
+    EXECUTE FUNCTION index_post_search_document();
+
+-- Users: names first, then academic details, then the bio
+CREATE OR REPLACE FUNCTION index_user_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'user' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    IF COALESCE(NEW.status, 'active') <> 'active' THEN
+        DELETE FROM search_documents WHERE entity_type = 'user' AND entity_id = NEW.id;
+        RETURN NEW;
+    END IF;
+
+    PERFORM upsert_search_document('user', NEW.id, NEW.space_id, NEW.full_name, NEW.username, COALESCE(NEW.bio, ''), NEW.avatar,
+        setweight(to_tsvector('simple', COALESCE(NEW.username, '') || ' ' || COALESCE(NEW.full_name, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.department, '') || ' ' || COALESCE(NEW.major, '') || ' ' ||
+            COALESCE(array_to_string(NEW.interests, ' '), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.bio, '')), 'C'),
+        NEW.created_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_user_search_document
+    AFTER INSERT OR DELETE OR UPDATE OF username, full_name, bio, avatar, department, major, interests, status ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION index_user_search_document();
+
+CREATE OR REPLACE FUNCTION index_community_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'community' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    IF COALESCE(NEW.status, 'active') <> 'active' THEN
+        DELETE FROM search_documents WHERE entity_type = 'community' AND entity_id = NEW.id;
+        RETURN NEW;
+    END IF;
+
+    PERFORM upsert_search_document('community', NEW.id, NEW.space_id, NEW.name, NEW.category, COALESCE(NEW.description, ''), NEW.cover_image,
+        setweight(to_tsvector('english', NEW.name), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.category, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'C'),
+        NEW.created_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_community_search_document
+    AFTER INSERT OR DELETE OR UPDATE OF name, category, description, cover_image, status ON communities
+    FOR EACH ROW
+    EXECUTE FUNCTION index_community_search_document();
+
+CREATE OR REPLACE FUNCTION index_group_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'group' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    IF COALESCE(NEW.status, 'active') <> 'active' THEN
+        DELETE FROM search_documents WHERE entity_type = 'group' AND entity_id = NEW.id;
+        RETURN NEW;
+    END IF;
+
+    PERFORM upsert_search_document('group', NEW.id, NEW.space_id, NEW.name, NEW.category, COALESCE(NEW.description, ''), NEW.avatar,
+        setweight(to_tsvector('english', NEW.name), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.category, '') || ' ' || COALESCE(array_to_string(NEW.tags, ' '), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'C'),
+        NEW.created_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_group_search_document
+    AFTER INSERT OR DELETE OR UPDATE OF name, category, description, tags, avatar, status ON groups
+    FOR EACH ROW
+    EXECUTE FUNCTION index_group_search_document();
+
+CREATE OR REPLACE FUNCTION index_event_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM search_documents WHERE entity_type = 'event' AND entity_id = OLD.id;
+        RETURN OLD;
+    END IF;
+
+    IF COALESCE(NEW.status, 'published') <> 'published' THEN
+        DELETE FROM search_documents WHERE entity_type = 'event' AND entity_id = NEW.id;
+        RETURN NEW;
+    END IF;
+
+    PERFORM upsert_search_document('event', NEW.id, NEW.space_id, NEW.title, NEW.location, COALESCE(NEW.description, ''), NEW.image_url,
+        setweight(to_tsvector('english', NEW.title), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.category, '') || ' ' || COALESCE(NEW.location, '') || ' ' ||
+            COALESCE(array_to_string(NEW.tags, ' '), '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'C'),
+        NEW.created_at);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_event_search_document
+    AFTER INSERT OR DELETE OR UPDATE OF title, category, location, description, tags, image_url, status ON events
+    FOR EACH ROW
+    EXECUTE FUNCTION index_event_search_document();
+
+-- Escapes markup in indexed text before ts_headline wraps matches in <mark>
+CREATE OR REPLACE FUNCTION html_escape(input TEXT)
+RETURNS TEXT AS $$
+    SELECT replace(replace(replace(replace(replace(input,
+        '&', '&amp;'),
+        '<', '&lt;'),
+        '>', '&gt;'),
+        '"', '&quot;'),
+        '''', '&#39;');
+$$ LANGUAGE sql IMMUTABLE;
+
+-- True when viewer_id may see the entity behind a search document
+CREATE OR REPLACE FUNCTION can_view_search_document(viewer_id UUID, doc_type VARCHAR, doc_id UUID)
+RETURNS BOOLEAN AS $$
+    SELECT COALESCE(CASE doc_type
+        WHEN 'post' THEN EXISTS (
+            SELECT 1 FROM posts p
+            WHERE p.id = doc_id AND p.status = 'active'
+              AND NOT is_hidden_from(viewer_id, p.author_id)
+              AND can_view_post(viewer_id, p.author_id, p.visibility, p.community_id, p.group_id))
+        WHEN 'user' THEN EXISTS (
+            SELECT 1 FROM users u
+            WHERE u.id = doc_id AND COALESCE(u.status, 'active') = 'active'
+              AND NOT is_hidden_from(viewer_id, u.id))
+        WHEN 'community' THEN EXISTS (
+            SELECT 1 FROM communities c
+            WHERE c.id = doc_id
+              AND (COALESCE(c.is_public, true)
+                   OR EXISTS (SELECT 1 FROM community_members cm WHERE cm.community_id = c.id AND cm.user_id = viewer_id)))
+        WHEN 'group' THEN EXISTS (
+            SELECT 1 FROM groups g
+            WHERE g.id = doc_id
+              AND (COALESCE(g.visibility, 'public') = 'public'
+                   OR EXISTS (SELECT 1 FROM group_members gm WHERE gm.group_id = g.id AND gm.user_id = viewer_id)))
+        WHEN 'event' THEN EXISTS (
+            SELECT 1 FROM events e
+            WHERE e.id = doc_id
+              AND (COALESCE(e.is_public, true) OR e.organizer = viewer_id))
+        ELSE false
+    END, false);
+$$ LANGUAGE sql STABLE;
+
+-- Backfill the index from existing rows
+INSERT INTO search_documents (entity_type, entity_id, space_id, title, subtitle, body, image, search_vector, created_at)
+SELECT 'post', p.id, p.space_id, LEFT(p.content, 140), NULL, p.content, NULL,
+    setweight(to_tsvector('english', COALESCE(array_to_string(p.tags, ' '), '')), 'A') ||
+    setweight(to_tsvector('english', p.content), 'B'),
+    p.created_at
+FROM posts p
+WHERE COALESCE(p.status, 'active') = 'active' AND p.content <> '';
+
+INSERT INTO search_documents (entity_type, entity_id, space_id, title, subtitle, body, image, search_vector, created_at)
+SELECT 'user', u.id, u.space_id, COALESCE(u.full_name, ''), u.username, COALESCE(u.bio, ''), u.avatar,
+    setweight(to_tsvector('simple', COALESCE(u.username, '') || ' ' || COALESCE(u.full_name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(u.department, '') || ' ' || COALESCE(u.major, '') || ' ' ||
+        COALESCE(array_to_string(u.interests, ' '), '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(u.bio, '')), 'C'),
+    u.created_at
+FROM users u
+WHERE COALESCE(u.status, 'active') = 'active';
+
+INSERT INTO search_documents (entity_type, entity_id, space_id, title, subtitle, body, image, search_vector, created_at)
+SELECT 'community', c.id, c.space_id, COALESCE(c.name, ''), c.category, COALESCE(c.description, ''), c.cover_image,
+    setweight(to_tsvector('english', c.name), 'A') ||
+    setweight(to_tsvector('english', COALESCE(c.category, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(c.description, '')), 'C'),
+    c.created_at
+FROM communities c
+WHERE COALESCE(c.status, 'active') = 'active';
+
+INSERT INTO search_documents (entity_type, entity_id, space_id, title, subtitle, body, image, search_vector, created_at)
+SELECT 'group', g.id, g.space_id, COALESCE(g.name, ''), g.category, COALESCE(g.description, ''), g.avatar,
+    setweight(to_tsvector('english', g.name), 'A') ||
+    setweight(to_tsvector('english', COALESCE(g.category, '') || ' ' || COALESCE(array_to_string(g.tags, ' '), '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(g.description, '')), 'C'),
+    g.created_at
+FROM groups g
+WHERE COALESCE(g.status, 'active') = 'active';
+
+INSERT INTO search_documents (entity_type, entity_id, space_id, title, subtitle, body, image, search_vector, created_at)
+SELECT 'event', e.id, e.space_id, COALESCE(e.title, ''), e.location, COALESCE(e.description, ''), e.image_url,
+    setweight(to_tsvector('english', e.title), 'A') ||
+    setweight(to_tsvector('english', COALESCE(e.category, '') || ' ' || COALESCE(e.location, '') || ' ' ||
+        COALESCE(array_to_string(e.tags, ' '), '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(e.description, '')), 'C'),
+    e.created_at
+FROM events e
+WHERE COALESCE(e.status, 'published') = 'published';
+
+COMMIT;
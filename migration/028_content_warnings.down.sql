@@ -0,0 +1,16 @@
+-- UNIVYN Database Migration
+-- Version: 028_content_warnings DOWN
+-- Description: Remove content warnings and sensitive-media flags
+
+BEGIN;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS is_sensitive,
+    DROP COLUMN IF EXISTS content_warning;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS warning_by_moderator,
+    DROP COLUMN IF EXISTS is_sensitive,
+    DROP COLUMN IF EXISTS content_warning;
+
+COMMIT;
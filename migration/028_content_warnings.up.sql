@@ -0,0 +1,16 @@
+-- UNIVYN Database Migration
+-- Version: 028_content_warnings UP
+-- Description: Content warnings and sensitive-media flags on posts and comments
+
+BEGIN;
+
+ALTER TABLE posts
+    ADD COLUMN content_warning VARCHAR(200),
+    ADD COLUMN is_sensitive BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN warning_by_moderator BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE comments
+    ADD COLUMN content_warning VARCHAR(200),
+    ADD COLUMN is_sensitive BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
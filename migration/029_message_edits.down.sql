@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 029_message_edits DOWN
+-- Description: Remove message editing and revision history
+
+BEGIN;
+
+DROP TABLE IF EXISTS message_revisions;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS edited_at;
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- UNIVYN Database Migration
+-- Version: 029_message_edits UP
+-- Description: Message editing with edited_at timestamp and revision history
+
+BEGIN;
+
+ALTER TABLE messages ADD COLUMN edited_at TIMESTAMPTZ;
+
+CREATE TABLE message_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT,
+    edited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_message_revisions_message ON message_revisions(message_id, created_at DESC);
+
+COMMIT;
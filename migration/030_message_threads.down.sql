@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 030_message_threads DOWN
+-- Description: Remove thread roots from messages
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_messages_thread_root;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS thread_root_id;
+
+COMMIT;
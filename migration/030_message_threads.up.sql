@@ -0,0 +1,25 @@
+-- UNIVYN Database Migration
+-- Version: 030_message_threads UP
+-- Description: Thread roots on messages for reply threads
+
+BEGIN;
+
+ALTER TABLE messages ADD COLUMN thread_root_id UUID REFERENCES messages(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_messages_thread_root ON messages(thread_root_id, created_at) WHERE thread_root_id IS NOT NULL;
+
+WITH RECURSIVE chain AS (
+    SELECT id, id AS root_id
+    FROM messages
+    WHERE reply_to_id IS NULL
+    UNION ALL
+    SELECT m.id, chain.root_id
+    FROM messages m
+    JOIN chain ON m.reply_to_id = chain.id
+)
+UPDATE messages m
+SET thread_root_id = chain.root_id
+FROM chain
+WHERE m.id = chain.id AND chain.root_id <> m.id;
+
+COMMIT;
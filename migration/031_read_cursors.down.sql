@@ -0,0 +1,17 @@
+-- UNIVYN Database Migration
+-- Version: 031_read_cursors DOWN
+-- Description: Remove per-participant delivery and read cursors
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_messages_conversation_cursor;
+
+ALTER TABLE conversation_participants
+    DROP COLUMN IF EXISTS last_delivered_at,
+    DROP COLUMN IF EXISTS last_delivered_message_at,
+    DROP COLUMN IF EXISTS last_delivered_message_id,
+    DROP COLUMN IF EXISTS last_read_at,
+    DROP COLUMN IF EXISTS last_read_message_at,
+    DROP COLUMN IF EXISTS last_read_message_id;
+
+COMMIT;
@@ -0,0 +1,56 @@
+-- UNIVYN Database Migration
+-- Version: 031_read_cursors UP
+-- Description: Per-participant delivery and read cursors for conversations
+
+BEGIN;
+
+ALTER TABLE conversation_participants
+    ADD COLUMN last_read_message_id UUID,
+    ADD COLUMN last_read_message_at TIMESTAMPTZ,
+    ADD COLUMN last_read_at TIMESTAMPTZ,
+    ADD COLUMN last_delivered_message_id UUID,
+    ADD COLUMN last_delivered_message_at TIMESTAMPTZ,
+    ADD COLUMN last_delivered_at TIMESTAMPTZ;
+
+CREATE INDEX idx_messages_conversation_cursor ON messages(conversation_id, created_at, id);
+
+-- Direct conversations created through GetOrCreateDirectConversation never
+-- recorded their participants; recover them from the messages exchanged.
+INSERT INTO conversation_participants (conversation_id, user_id, role)
+SELECT DISTINCT m.conversation_id, p.user_id, 'member'
+FROM messages m
+JOIN conversations c ON c.id = m.conversation_id AND c.conversation_type = 'direct'
+CROSS JOIN LATERAL (VALUES (m.sender_id), (m.recipient_id)) AS p(user_id)
+WHERE p.user_id IS NOT NULL
+ON CONFLICT (conversation_id, user_id) DO NOTHING;
+
+-- Start each cursor at the newest message preceding the participant's
+-- oldest unread message, or at the newest message when nothing is unread.
+UPDATE conversation_participants cp
+SET last_read_message_id = initial.message_id,
+    last_read_message_at = initial.message_at,
+    last_read_at = NOW(),
+    last_delivered_message_id = initial.message_id,
+    last_delivered_message_at = initial.message_at,
+    last_delivered_at = NOW()
+FROM (
+    SELECT p.id AS participant_id, latest.id AS message_id, latest.created_at AS message_at
+    FROM conversation_participants p
+    CROSS JOIN LATERAL (
+        SELECT m.id, m.created_at
+        FROM messages m
+        WHERE m.conversation_id = p.conversation_id
+          AND m.created_at < COALESCE(
+              (SELECT MIN(u.created_at)
+               FROM messages u
+               WHERE u.conversation_id = p.conversation_id
+                 AND u.recipient_id = p.user_id
+                 AND u.is_read = false),
+              'infinity'::timestamptz)
+        ORDER BY m.created_at DESC, m.id DESC
+        LIMIT 1
+    ) latest
+) initial
+WHERE cp.id = initial.participant_id;
+
+COMMIT;
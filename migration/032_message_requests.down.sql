@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 032_message_requests DOWN
+-- Description: Remove the message-request inbox
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_conversation_participants_requests;
+
+ALTER TABLE conversation_participants DROP COLUMN IF EXISTS request_status;
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- UNIVYN Database Migration
+-- Version: 032_message_requests UP
+-- Description: Message-request inbox for direct messages from non-connections
+
+BEGIN;
+
+ALTER TABLE conversation_participants
+    ADD COLUMN request_status VARCHAR(20) CHECK (request_status IN ('pending', 'accepted', 'declined'));
+
+CREATE INDEX idx_conversation_participants_requests ON conversation_participants(user_id)
+    WHERE request_status = 'pending';
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- UNIVYN Database Migration
+-- Version: 033_message_search DOWN
+-- Description: Remove the message content search index
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_messages_content_search;
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 033_message_search UP
+-- Description: Full-text index on message content for in-conversation search
+
+BEGIN;
+
+CREATE INDEX idx_messages_content_search ON messages
+    USING GIN (to_tsvector('english', COALESCE(content, '')))
+    WHERE COALESCE(status, 'sent') <> 'deleted';
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- UNIVYN Database Migration
+-- Version: 034_group_admin DOWN
+-- Description: Remove group conversation invite links and role constraints
+
+BEGIN;
+
+DROP TABLE IF EXISTS conversation_invites;
+
+DROP INDEX IF EXISTS idx_conversation_participants_owner;
+
+ALTER TABLE conversation_participants
+    DROP CONSTRAINT IF EXISTS conversation_participants_role_check;
+
+COMMIT;
@@ -0,0 +1,60 @@
+-- UNIVYN Database Migration
+-- Version: 034_group_admin UP
+-- Description: Group conversation roles, single ownership and revocable invite links
+
+BEGIN;
+
+UPDATE conversation_participants
+SET role = 'member'
+WHERE role IS NULL OR role NOT IN ('owner', 'admin', 'member');
+
+ALTER TABLE conversation_participants
+    ALTER COLUMN role SET DEFAULT 'member',
+    ADD CONSTRAINT conversation_participants_role_check CHECK (role IN ('owner', 'admin', 'member'));
+
+-- Keep the longest-standing owner when a conversation has several.
+UPDATE conversation_participants cp
+SET role = 'admin'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY conversation_id ORDER BY joined_at, id) AS rn
+    FROM conversation_participants
+    WHERE role = 'owner' AND is_active = true
+) ranked
+WHERE cp.id = ranked.id AND ranked.rn > 1;
+
+-- Groups without an owner are handed to their earliest active participant.
+UPDATE conversation_participants cp
+SET role = 'owner'
+FROM (
+    SELECT DISTINCT ON (p.conversation_id) p.id
+    FROM conversation_participants p
+    JOIN conversations c ON c.id = p.conversation_id
+    WHERE c.conversation_type = 'group'
+      AND p.is_active = true
+      AND NOT EXISTS (
+          SELECT 1 FROM conversation_participants o
+          WHERE o.conversation_id = p.conversation_id AND o.role = 'owner' AND o.is_active = true
+      )
+    ORDER BY p.conversation_id, p.joined_at, p.id
+) first_member
+WHERE cp.id = first_member.id;
+
+CREATE UNIQUE INDEX idx_conversation_participants_owner ON conversation_participants(conversation_id)
+    WHERE role = 'owner' AND is_active = true;
+
+CREATE TABLE conversation_invites (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    code VARCHAR(32) NOT NULL UNIQUE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ,
+    max_uses INTEGER CHECK (max_uses > 0),
+    use_count INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_conversation_invites_conversation ON conversation_invites(conversation_id, created_at DESC)
+    WHERE revoked_at IS NULL;
+
+COMMIT;
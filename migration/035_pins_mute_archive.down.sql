@@ -0,0 +1,13 @@
+-- UNIVYN Database Migration
+-- Version: 035_pins_mute_archive DOWN
+-- Description: Remove pinned messages and participant mute/archive flags
+
+BEGIN;
+
+ALTER TABLE conversation_participants
+    DROP COLUMN IF EXISTS archived_at,
+    DROP COLUMN IF EXISTS muted_until;
+
+DROP TABLE IF EXISTS message_pins;
+
+COMMIT;
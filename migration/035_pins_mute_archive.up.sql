@@ -0,0 +1,21 @@
+-- UNIVYN Database Migration
+-- Version: 035_pins_mute_archive UP
+-- Description: Pinned messages plus per-participant mute-until and archive flags
+
+BEGIN;
+
+CREATE TABLE message_pins (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL UNIQUE REFERENCES messages(id) ON DELETE CASCADE,
+    pinned_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pinned_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_message_pins_conversation ON message_pins(conversation_id, pinned_at DESC);
+
+ALTER TABLE conversation_participants
+    ADD COLUMN muted_until TIMESTAMPTZ,
+    ADD COLUMN archived_at TIMESTAMPTZ;
+
+COMMIT;
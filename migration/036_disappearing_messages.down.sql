@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 036_disappearing_messages DOWN
+-- Description: Remove per-message expiry
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_messages_expires_at;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS expires_at;
+
+COMMIT;
@@ -0,0 +1,11 @@
+-- UNIVYN Database Migration
+-- Version: 036_disappearing_messages UP
+-- Description: Per-message expiry for conversations with disappearing messages enabled
+
+BEGIN;
+
+ALTER TABLE messages ADD COLUMN expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_messages_expires_at ON messages(expires_at) WHERE expires_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,16 @@
+-- UNIVYN Database Migration
+-- Version: 037_message_sharing DOWN
+-- Description: Remove shared previews and forwarded message markers
+
+BEGIN;
+
+DROP INDEX IF EXISTS idx_messages_shared;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS forwarded_from_id,
+    DROP COLUMN IF EXISTS is_forwarded,
+    DROP COLUMN IF EXISTS shared_preview,
+    DROP COLUMN IF EXISTS shared_id,
+    DROP COLUMN IF EXISTS shared_type;
+
+COMMIT;
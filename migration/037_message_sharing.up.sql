@@ -0,0 +1,16 @@
+-- UNIVYN Database Migration
+-- Version: 037_message_sharing UP
+-- Description: Shared post/event/community previews and forwarded messages
+
+BEGIN;
+
+ALTER TABLE messages
+    ADD COLUMN shared_type VARCHAR(20) CHECK (shared_type IN ('post', 'event', 'community')),
+    ADD COLUMN shared_id UUID,
+    ADD COLUMN shared_preview JSONB,
+    ADD COLUMN is_forwarded BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN forwarded_from_id UUID REFERENCES messages(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_messages_shared ON messages(shared_type, shared_id) WHERE shared_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- UNIVYN Database Migration
+-- Version: 038_conversation_exports DOWN
+-- Description: Remove conversation transcript exports
+
+BEGIN;
+
+DROP TABLE IF EXISTS conversation_exports;
+
+COMMIT;
@@ -0,0 +1,24 @@
+-- UNIVYN Database Migration
+-- Version: 038_conversation_exports UP
+-- Description: Background conversation transcript exports
+
+BEGIN;
+
+CREATE TABLE conversation_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    conversation_id UUID NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('json', 'csv', 'txt')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'failed')),
+    message_count BIGINT NOT NULL DEFAULT 0,
+    content BYTEA,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_conversation_exports_requested_by ON conversation_exports(requested_by, created_at DESC);
+CREATE INDEX idx_conversation_exports_expires_at ON conversation_exports(expires_at) WHERE expires_at IS NOT NULL;
+
+COMMIT;
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	testhelpers "github.com/connect-univyn/connect-server/test/db"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
 
@@ -151,3 +153,50 @@ func RequireFieldNotExists(t *testing.T, data map[string]interface{}, field stri
 	_, exists := data[field]
 	require.False(t, exists, "Field %s should not exist", field)
 }
+
+
+func (ts *TestServer) DialWebSocket(t *testing.T, token string, channels ...string) *websocket.Conn {
+	server := httptest.NewServer(ts.Server.GetRouter())
+	t.Cleanup(server.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?token=" + token
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	ReadWebSocketMessage(t, conn)
+	for i, channel := range channels {
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{
+			"type":    "subscribe",
+			"channel": channel,
+			"id":      fmt.Sprintf("sub-%d", i),
+		}))
+		ack := ReadWebSocketMessage(t, conn)
+		require.Equal(t, "ack", ack["type"], "subscription to %s was not acknowledged", channel)
+	}
+
+	return conn
+}
+
+
+func ReadWebSocketMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	var msg map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+
+func ReadWebSocketEvent(t *testing.T, conn *websocket.Conn, event string, match func(payload map[string]interface{}) bool) map[string]interface{} {
+	for {
+		msg := ReadWebSocketMessage(t, conn)
+		if msg["type"] != "event" || msg["event"] != event {
+			continue
+		}
+		payload, _ := msg["payload"].(map[string]interface{})
+		if match == nil || match(payload) {
+			return payload
+		}
+	}
+}
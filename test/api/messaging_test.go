@@ -1,10 +1,16 @@
 package api_test
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
+	db "github.com/connect-univyn/connect-server/db/sqlc"
 	testhelpers "github.com/connect-univyn/connect-server/test/db"
 	"github.com/google/uuid"
 )
@@ -245,3 +251,1702 @@ func TestGetOrCreateDirectConversation(t *testing.T) {
 		})
 	}
 }
+
+func TestEditMessage(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	sender := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	recipient := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	outsider := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	senderToken := ts.CreateAuthToken(t, sender.ID)
+	recipientToken := ts.CreateAuthToken(t, recipient.ID)
+	outsiderToken := ts.CreateAuthToken(t, outsider.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/direct", map[string]interface{}{
+		"recipient_id": recipient.ID.String(),
+		"space_id":     spaceID.String(),
+	}, senderToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	conversationID := ParseSuccessResponse(t, recorder)["conversation_id"].(string)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/messages", conversationID), map[string]interface{}{
+		"content": "Original message",
+	}, senderToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	messageID := ParseSuccessResponse(t, recorder)["id"].(string)
+	messageURL := fmt.Sprintf("/api/messages/%s", messageID)
+
+	t.Run("SenderEdits", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, messageURL, map[string]interface{}{
+			"content": "Edited message",
+		}, senderToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		data := ParseSuccessResponse(t, recorder)
+		if data["content"] != "Edited message" {
+			t.Errorf("Expected edited content, got %v", data["content"])
+		}
+		if data["is_edited"] != true {
+			t.Errorf("Expected is_edited to be true")
+		}
+		RequireFieldExists(t, data, "edited_at")
+	})
+
+	t.Run("RevisionsKeepPriorContent", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, messageURL+"/revisions", nil, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Data) != 1 {
+			t.Fatalf("Expected 1 revision, got %d", len(response.Data))
+		}
+		if response.Data[0]["content"] != "Original message" {
+			t.Errorf("Expected original content in revision, got %v", response.Data[0]["content"])
+		}
+	})
+
+	t.Run("OutsiderCannotViewRevisions", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, messageURL+"/revisions", nil, outsiderToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("OtherParticipantCannotEdit", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, messageURL, map[string]interface{}{
+			"content": "Hijacked",
+		}, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("OutsiderCannotEdit", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, messageURL, map[string]interface{}{
+			"content": "Hijacked",
+		}, outsiderToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("GroupRolesCanEditOthers", func(t *testing.T) {
+		admin := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		adminToken := ts.CreateAuthToken(t, admin.ID)
+
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+			"space_id":          spaceID.String(),
+			"name":              "Edit Group",
+			"participant_ids":   []string{sender.ID.String(), recipient.ID.String(), admin.ID.String()},
+			"conversation_type": "group",
+		}, senderToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		groupID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, fmt.Sprintf("/api/conversations/%s/participants/%s/role", groupID, admin.ID), map[string]interface{}{
+			"role": "admin",
+		}, senderToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		send := func(token, content string) string {
+			recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/messages", groupID), map[string]interface{}{
+				"content": content,
+			}, token)
+			CheckResponseCode(t, recorder, http.StatusCreated)
+			return ParseSuccessResponse(t, recorder)["id"].(string)
+		}
+		memberMessageID := send(recipientToken, "Member message")
+		ownerMessageID := send(senderToken, "Owner message")
+		adminMessageID := send(adminToken, "Admin message")
+
+		testCases := []struct {
+			name         string
+			messageID    string
+			token        string
+			expectedCode int
+		}{
+			{name: "OwnerEditsMember", messageID: memberMessageID, token: senderToken, expectedCode: http.StatusOK},
+			{name: "OwnerEditsAdmin", messageID: adminMessageID, token: senderToken, expectedCode: http.StatusOK},
+			{name: "AdminEditsMember", messageID: memberMessageID, token: adminToken, expectedCode: http.StatusOK},
+			{name: "AdminCannotEditOwner", messageID: ownerMessageID, token: adminToken, expectedCode: http.StatusForbidden},
+			{name: "MemberCannotEditOthers", messageID: adminMessageID, token: recipientToken, expectedCode: http.StatusForbidden},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				recorder := ts.MakeRequest(t, http.MethodPut, fmt.Sprintf("/api/messages/%s", tc.messageID), map[string]interface{}{
+					"content": "Edited by " + tc.name,
+				}, tc.token)
+				CheckResponseCode(t, recorder, tc.expectedCode)
+			})
+		}
+	})
+
+	t.Run("EditWindowExpired", func(t *testing.T) {
+		_, err := ts.TestDB.DB.Exec("UPDATE messages SET created_at = NOW() - INTERVAL '2 hours' WHERE id = $1", messageID)
+		if err != nil {
+			t.Fatalf("Failed to age message: %v", err)
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodPut, messageURL, map[string]interface{}{
+			"content": "Too late",
+		}, senderToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("MissingContent", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, messageURL, map[string]interface{}{}, senderToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+}
+
+func TestMessageThreads(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	replier := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	outsider := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	replierToken := ts.CreateAuthToken(t, replier.ID)
+	outsiderToken := ts.CreateAuthToken(t, outsider.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/direct", map[string]interface{}{
+		"recipient_id": replier.ID.String(),
+		"space_id":     spaceID.String(),
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	conversationID := ParseSuccessResponse(t, recorder)["conversation_id"].(string)
+	messagesURL := fmt.Sprintf("/api/conversations/%s/messages", conversationID)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+		"content": "Thread root",
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	rootID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+		"content":     "First reply",
+		"reply_to_id": rootID,
+	}, replierToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	firstReply := ParseSuccessResponse(t, recorder)
+	if firstReply["thread_root_id"] != rootID {
+		t.Errorf("Expected thread_root_id %s, got %v", rootID, firstReply["thread_root_id"])
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+		"content":     "Reply to the reply",
+		"reply_to_id": firstReply["id"],
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	if ParseSuccessResponse(t, recorder)["thread_root_id"] != rootID {
+		t.Errorf("Expected nested reply to join the root thread")
+	}
+
+	t.Run("RootCarriesSummary", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/messages/"+rootID, nil, authorToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		data := ParseSuccessResponse(t, recorder)
+		if data["reply_count"] != float64(2) {
+			t.Errorf("Expected reply_count 2, got %v", data["reply_count"])
+		}
+		repliers, ok := data["latest_repliers"].([]interface{})
+		if !ok || len(repliers) != 2 {
+			t.Fatalf("Expected 2 latest repliers, got %v", data["latest_repliers"])
+		}
+		if repliers[0].(map[string]interface{})["user_id"] != author.ID.String() {
+			t.Errorf("Expected most recent replier first")
+		}
+	})
+
+	t.Run("PageThroughReplies", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/replies?limit=1", rootID), nil, replierToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Data) != 1 || response.Data[0]["content"] != "First reply" {
+			t.Fatalf("Expected first reply on the first page, got %v", response.Data)
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/replies?limit=1&page=2", rootID), nil, replierToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Data) != 1 || response.Data[0]["content"] != "Reply to the reply" {
+			t.Fatalf("Expected nested reply on the second page, got %v", response.Data)
+		}
+	})
+
+	t.Run("RepliesOfReplyRejected", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/replies", firstReply["id"]), nil, authorToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("OutsiderCannotOpenThread", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/replies", rootID), nil, outsiderToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("ReplyTargetMustBeInConversation", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"content":     "Dangling reply",
+			"reply_to_id": uuid.New().String(),
+		}, authorToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("ThreadParticipantsNotified", func(t *testing.T) {
+		var count int
+		err := ts.TestDB.DB.QueryRow(
+			"SELECT COUNT(*) FROM notifications WHERE to_user_id = $1 AND type = 'thread_reply'", author.ID,
+		).Scan(&count)
+		if err != nil {
+			t.Fatalf("Failed to count notifications: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 thread reply notification for the root author, got %d", count)
+		}
+	})
+}
+
+func TestThreadReplyReachesOtherParticipants(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	replier := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	replierToken := ts.CreateAuthToken(t, replier.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/direct", map[string]interface{}{
+		"recipient_id": replier.ID.String(),
+		"space_id":     spaceID.String(),
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	conversationID := ParseSuccessResponse(t, recorder)["conversation_id"].(string)
+	messagesURL := fmt.Sprintf("/api/conversations/%s/messages", conversationID)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+		"content": "Thread root",
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	rootID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	conn := ts.DialWebSocket(t, authorToken, "conv:"+conversationID)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+		"content":     "Reply from the other side",
+		"reply_to_id": rootID,
+	}, replierToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	replyID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	payload := ReadWebSocketEvent(t, conn, "message.created", func(payload map[string]interface{}) bool {
+		return payload["id"] == replyID
+	})
+	if payload["thread_root_id"] != rootID {
+		t.Errorf("Expected thread_root_id %s, got %v", rootID, payload["thread_root_id"])
+	}
+}
+
+func TestReadReceipts(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	sender := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	reader := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	lurker := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	senderToken := ts.CreateAuthToken(t, sender.ID)
+	readerToken := ts.CreateAuthToken(t, reader.ID)
+	lurkerToken := ts.CreateAuthToken(t, lurker.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+		"space_id":          spaceID.String(),
+		"name":              "Study Group",
+		"participant_ids":   []string{sender.ID.String(), reader.ID.String(), lurker.ID.String()},
+		"conversation_type": "group",
+	}, senderToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	conversationID := ParseSuccessResponse(t, recorder)["id"].(string)
+	messagesURL := fmt.Sprintf("/api/conversations/%s/messages", conversationID)
+
+	var messageIDs []string
+	for _, content := range []string{"First", "Second"} {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{"content": content}, senderToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		messageIDs = append(messageIDs, ParseSuccessResponse(t, recorder)["id"].(string))
+	}
+
+	unreadCount := func(t *testing.T, token string) float64 {
+		recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/conversations/%s/unread", conversationID), nil, token)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		return ParseSuccessResponse(t, recorder)["unread_count"].(float64)
+	}
+
+	seenBy := func(t *testing.T, messageID string) []map[string]interface{} {
+		recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/seen-by", messageID), nil, senderToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response.Data
+	}
+
+	t.Run("UnreadFromCursor", func(t *testing.T) {
+		if count := unreadCount(t, readerToken); count != 2 {
+			t.Errorf("Expected 2 unread messages, got %v", count)
+		}
+		if count := unreadCount(t, senderToken); count != 0 {
+			t.Errorf("Expected own messages to be excluded from unread, got %v", count)
+		}
+	})
+
+	t.Run("ReadAdvancesCursor", func(t *testing.T) {
+		conn := ts.DialWebSocket(t, senderToken, "conv:"+conversationID)
+
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/read", conversationID), nil, readerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		ReadWebSocketEvent(t, conn, "message.read", func(payload map[string]interface{}) bool {
+			return payload["user_id"] == reader.ID.String()
+		})
+		if count := unreadCount(t, readerToken); count != 0 {
+			t.Errorf("Expected 0 unread messages after reading, got %v", count)
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/read", conversationID), map[string]interface{}{
+			"message_id": messageIDs[0],
+		}, lurkerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if count := unreadCount(t, lurkerToken); count != 1 {
+			t.Errorf("Expected 1 unread message after partial read, got %v", count)
+		}
+	})
+
+	t.Run("SeenBy", func(t *testing.T) {
+		if readers := seenBy(t, messageIDs[0]); len(readers) != 2 {
+			t.Errorf("Expected first message seen by 2 participants, got %d", len(readers))
+		}
+		readers := seenBy(t, messageIDs[1])
+		if len(readers) != 1 || readers[0]["user_id"] != reader.ID.String() {
+			t.Errorf("Expected second message seen only by reader, got %v", readers)
+		}
+	})
+
+	t.Run("ReceiptAggregation", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/delivered", conversationID), nil, lurkerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodGet, "/api/messages/"+messageIDs[1], nil, senderToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		receipts := ParseSuccessResponse(t, recorder)["receipts"].(map[string]interface{})
+		if receipts["recipient_count"] != float64(2) {
+			t.Errorf("Expected 2 recipients, got %v", receipts["recipient_count"])
+		}
+		if receipts["delivered_count"] != float64(2) {
+			t.Errorf("Expected 2 deliveries, got %v", receipts["delivered_count"])
+		}
+		if receipts["read_count"] != float64(1) {
+			t.Errorf("Expected 1 read, got %v", receipts["read_count"])
+		}
+	})
+
+	t.Run("ForeignMessageRejected", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/read", conversationID), map[string]interface{}{
+			"message_id": uuid.New().String(),
+		}, readerToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+}
+
+
+func TestMessageRequests(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	recipient := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	stranger := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	friend := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	recipientToken := ts.CreateAuthToken(t, recipient.ID)
+	strangerToken := ts.CreateAuthToken(t, stranger.ID)
+	friendToken := ts.CreateAuthToken(t, friend.ID)
+
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, recipient.ID, friend.ID, spaceID)
+
+	openDirect := func(t *testing.T, token string, expectedCode int) string {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/direct", map[string]interface{}{
+			"recipient_id": recipient.ID.String(),
+			"space_id":     spaceID.String(),
+		}, token)
+		CheckResponseCode(t, recorder, expectedCode)
+		if expectedCode != http.StatusOK {
+			return ""
+		}
+		return ParseSuccessResponse(t, recorder)["conversation_id"].(string)
+	}
+
+	listIDs := func(t *testing.T, url, field string) map[string]bool {
+		recorder := ts.MakeRequest(t, http.MethodGet, url, nil, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		ids := make(map[string]bool, len(response.Data))
+		for _, item := range response.Data {
+			ids[item[field].(string)] = true
+		}
+		return ids
+	}
+
+	strangerConversation := openDirect(t, strangerToken, http.StatusOK)
+	recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/messages", strangerConversation), map[string]interface{}{
+		"content": "Hi, we haven't met",
+	}, strangerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	strangerMessageID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	t.Run("StrangerLandsInRequests", func(t *testing.T) {
+		if !listIDs(t, "/api/conversations/requests", "conversation_id")[strangerConversation] {
+			t.Error("Expected stranger's conversation in message requests")
+		}
+		if listIDs(t, "/api/conversations", "id")[strangerConversation] {
+			t.Error("Expected stranger's conversation to be kept out of the inbox")
+		}
+	})
+
+	t.Run("ReceiptsHiddenUntilAccepted", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/read", strangerConversation), nil, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/seen-by", strangerMessageID), nil, strangerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if strings.Contains(recorder.Body.String(), recipient.ID.String()) {
+			t.Error("Expected read receipt to be hidden while the request is pending")
+		}
+	})
+
+	t.Run("AcceptMovesToInbox", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/request/accept", strangerConversation), nil, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if listIDs(t, "/api/conversations/requests", "conversation_id")[strangerConversation] {
+			t.Error("Expected accepted conversation to leave message requests")
+		}
+		if !listIDs(t, "/api/conversations", "id")[strangerConversation] {
+			t.Error("Expected accepted conversation in the inbox")
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/messages/%s/seen-by", strangerMessageID), nil, strangerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if !strings.Contains(recorder.Body.String(), recipient.ID.String()) {
+			t.Error("Expected read receipt to be visible after accepting")
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/request/accept", strangerConversation), nil, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusNotFound)
+	})
+
+	t.Run("ConnectionGoesToInbox", func(t *testing.T) {
+		conversationID := openDirect(t, friendToken, http.StatusOK)
+		if !listIDs(t, "/api/conversations", "id")[conversationID] {
+			t.Error("Expected conversation from a followed user in the inbox")
+		}
+	})
+
+	t.Run("ConnectionsOnlyPolicy", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, "/api/users/message-requests", map[string]interface{}{
+			"allow_messages_from": "connections_only",
+		}, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		other := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		openDirect(t, ts.CreateAuthToken(t, other.ID), http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, "/api/users/message-requests", map[string]interface{}{
+			"allow_messages_from": "nobody",
+		}, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("BlockRequester", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, "/api/users/message-requests", map[string]interface{}{
+			"allow_messages_from": "connections",
+		}, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		spammer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		spammerToken := ts.CreateAuthToken(t, spammer.ID)
+		conversationID := openDirect(t, spammerToken, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/request/block", conversationID), nil, recipientToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		openDirect(t, spammerToken, http.StatusForbidden)
+	})
+}
+
+
+func TestSearchMessages(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	alice := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	bob := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	carol := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	aliceToken := ts.CreateAuthToken(t, alice.ID)
+	bobToken := ts.CreateAuthToken(t, bob.ID)
+	carolToken := ts.CreateAuthToken(t, carol.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+		"space_id":          spaceID.String(),
+		"name":              "Project Team",
+		"participant_ids":   []string{alice.ID.String(), bob.ID.String(), carol.ID.String()},
+		"conversation_type": "group",
+	}, aliceToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	conversationID := ParseSuccessResponse(t, recorder)["id"].(string)
+	messagesURL := fmt.Sprintf("/api/conversations/%s/messages", conversationID)
+
+	send := func(t *testing.T, token, content string) string {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{"content": content}, token)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		return ParseSuccessResponse(t, recorder)["id"].(string)
+	}
+
+	send(t, aliceToken, "Morning everyone")
+	send(t, bobToken, "Morning!")
+	budgetID := send(t, aliceToken, "The quarterly budget spreadsheet is ready")
+	replyID := send(t, bobToken, "Thanks, reviewing now")
+	send(t, carolToken, "Looks good to me")
+	deletedID := send(t, bobToken, "Old budget numbers, ignore these")
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, "/api/messages/"+deletedID, nil, bobToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	search := func(t *testing.T, query, token string) map[string]interface{} {
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/messages/search?"+query, nil, token)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		return ParseSuccessResponse(t, recorder)
+	}
+
+	t.Run("MatchWithContext", func(t *testing.T) {
+		results := search(t, "q=budget", carolToken)["items"].([]interface{})
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result excluding the deleted message, got %d", len(results))
+		}
+
+		result := results[0].(map[string]interface{})
+		if result["id"] != budgetID {
+			t.Errorf("Expected budget message, got %v", result["id"])
+		}
+		if !strings.Contains(result["highlight"].(string), "<mark>budget</mark>") {
+			t.Errorf("Expected highlighted match, got %v", result["highlight"])
+		}
+		if before := result["before"].([]interface{}); len(before) != 2 {
+			t.Errorf("Expected 2 messages of context before, got %d", len(before))
+		}
+		if after := result["after"].([]interface{}); len(after) != 2 {
+			t.Errorf("Expected 2 messages of context after, got %d", len(after))
+		}
+	})
+
+	t.Run("ExpiredContextHidden", func(t *testing.T) {
+		if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, replyID); err != nil {
+			t.Fatalf("Failed to expire message: %v", err)
+		}
+
+		results := search(t, "q=budget", carolToken)["items"].([]interface{})
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+		after := results[0].(map[string]interface{})["after"].([]interface{})
+		if len(after) != 1 {
+			t.Fatalf("Expected 1 message of context after, got %d", len(after))
+		}
+		if after[0].(map[string]interface{})["id"] == replyID {
+			t.Error("Expected expired message to be left out of the context")
+		}
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		results := search(t, fmt.Sprintf("q=morning&sender_id=%s", bob.ID), aliceToken)["items"].([]interface{})
+		if len(results) != 1 {
+			t.Errorf("Expected 1 result from bob, got %d", len(results))
+		}
+
+		results = search(t, "q=budget&has_attachment=true", aliceToken)["items"].([]interface{})
+		if len(results) != 0 {
+			t.Errorf("Expected no results with attachments, got %d", len(results))
+		}
+
+		results = search(t, "q=budget&to=2000-01-01T00:00:00Z", aliceToken)["items"].([]interface{})
+		if len(results) != 0 {
+			t.Errorf("Expected no results before 2000, got %d", len(results))
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/messages/search?q=budget&from=yesterday", nil, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("HighlightEscapesMarkup", func(t *testing.T) {
+		send(t, bobToken, `<script>alert("x")</script> invoice attached`)
+
+		results := search(t, "q=invoice", aliceToken)["items"].([]interface{})
+		if len(results) != 1 {
+			t.Fatalf("Expected 1 result, got %d", len(results))
+		}
+
+		highlight := results[0].(map[string]interface{})["highlight"].(string)
+		if strings.Contains(highlight, "<script") || !strings.Contains(highlight, "&lt;script&gt;") {
+			t.Errorf("Expected escaped markup in highlight, got %v", highlight)
+		}
+		if !strings.Contains(highlight, "<mark>invoice</mark>") {
+			t.Errorf("Expected highlighted match, got %v", highlight)
+		}
+	})
+
+	t.Run("ForeignConversationRejected", func(t *testing.T) {
+		outsider := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		outsiderToken := ts.CreateAuthToken(t, outsider.ID)
+
+		results := search(t, "q=budget", outsiderToken)["items"].([]interface{})
+		if len(results) != 0 {
+			t.Errorf("Expected outsider to find nothing, got %d", len(results))
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/messages/search?q=budget&conversation_id="+conversationID, nil, outsiderToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("LeftConversationExcluded", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/conversations/%s/leave", conversationID), nil, carolToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		results := search(t, "q=budget", carolToken)["items"].([]interface{})
+		if len(results) != 0 {
+			t.Errorf("Expected no results after leaving, got %d", len(results))
+		}
+	})
+
+	t.Run("MissingQuery", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/messages/search", nil, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+}
+
+
+func TestGroupAdministration(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	owner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	admin := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	member := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	newcomer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	ownerToken := ts.CreateAuthToken(t, owner.ID)
+	adminToken := ts.CreateAuthToken(t, admin.ID)
+	memberToken := ts.CreateAuthToken(t, member.ID)
+	newcomerToken := ts.CreateAuthToken(t, newcomer.ID)
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, newcomer.ID, admin.ID, spaceID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+		"space_id":          spaceID.String(),
+		"name":              "Committee",
+		"participant_ids":   []string{admin.ID.String(), member.ID.String()},
+		"conversation_type": "group",
+	}, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	conversationID := ParseSuccessResponse(t, recorder)["id"].(string)
+	conversationURL := "/api/conversations/" + conversationID
+
+	systemMessages := func(t *testing.T) []string {
+		recorder := ts.MakeRequest(t, http.MethodGet, conversationURL+"/messages", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		var contents []string
+		for _, m := range response.Data {
+			if m["message_type"] == "system" {
+				contents = append(contents, m["content"].(string))
+			}
+		}
+		return contents
+	}
+
+	hasSystemMessage := func(t *testing.T, fragment string) bool {
+		for _, content := range systemMessages(t) {
+			if strings.Contains(content, fragment) {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("PromoteToAdmin", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, fmt.Sprintf("%s/participants/%s/role", conversationURL, admin.ID), map[string]interface{}{
+			"role": "admin",
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, fmt.Sprintf("%s/participants/%s/role", conversationURL, admin.ID), map[string]interface{}{
+			"role": "admin",
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !hasSystemMessage(t, admin.Username+" an admin") {
+			t.Error("Expected a system message for the promotion")
+		}
+	})
+
+	t.Run("OnlyAdminsAddParticipants", func(t *testing.T) {
+		body := map[string]interface{}{"user_ids": []string{newcomer.ID.String()}}
+
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/participants", body, memberToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/participants", body, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !hasSystemMessage(t, fmt.Sprintf("%s added %s", admin.Username, newcomer.Username)) {
+			t.Error("Expected a system message for the added participant")
+		}
+	})
+
+	t.Run("BlockedAndRestrictedUsersSkipped", func(t *testing.T) {
+		blocker := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		stranger := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		testhelpers.CreateTestFollow(t, ts.TestDB.Store, blocker.ID, admin.ID, spaceID)
+
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/%s/block", admin.ID), nil, ts.CreateAuthToken(t, blocker.ID))
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/participants", map[string]interface{}{
+			"user_ids": []string{blocker.ID.String(), stranger.ID.String()},
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodGet, conversationURL+"/participants", nil, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		for _, p := range response.Data {
+			if p["id"] == blocker.ID.String() || p["id"] == stranger.ID.String() {
+				t.Errorf("Expected %v not to be added", p["id"])
+			}
+		}
+	})
+
+	t.Run("RenameAndAvatar", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, conversationURL, map[string]interface{}{
+			"name": "Renamed",
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, conversationURL, map[string]interface{}{
+			"name":   "Steering Committee",
+			"avatar": "https://example.com/group.png",
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		data := ParseSuccessResponse(t, recorder)
+		if data["name"] != "Steering Committee" {
+			t.Errorf("Expected updated name, got %v", data["name"])
+		}
+		if !hasSystemMessage(t, `renamed the group to "Steering Committee"`) {
+			t.Error("Expected a system message for the rename")
+		}
+	})
+
+	t.Run("RemoveParticipant", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("%s/participants/%s", conversationURL, owner.ID), nil, adminToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("%s/participants/%s", conversationURL, member.ID), nil, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+			"content": "Am I still here?",
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("TransferOwnership", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/transfer-ownership", map[string]interface{}{
+			"user_id": admin.ID.String(),
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/transfer-ownership", map[string]interface{}{
+			"user_id": owner.ID.String(),
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodGet, conversationURL+"/participants", nil, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		roles := make(map[string]interface{}, len(response.Data))
+		for _, p := range response.Data {
+			roles[p["id"].(string)] = p["role"]
+		}
+		if roles[admin.ID.String()] != "owner" || roles[owner.ID.String()] != "admin" {
+			t.Errorf("Expected roles to be swapped, got %v", roles)
+		}
+	})
+
+	t.Run("InviteLinks", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/invites", map[string]interface{}{
+			"max_uses": 1,
+		}, newcomerToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/invites", map[string]interface{}{
+			"max_uses": 1,
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		invite := ParseSuccessResponse(t, recorder)
+		code := invite["code"].(string)
+
+		joiner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/conversations/join/"+code, nil, ts.CreateAuthToken(t, joiner.ID))
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if !hasSystemMessage(t, joiner.Username+" joined using an invite link") {
+			t.Error("Expected a system message for the invite join")
+		}
+
+		late := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/conversations/join/"+code, nil, ts.CreateAuthToken(t, late.ID))
+		CheckResponseCode(t, recorder, http.StatusNotFound)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/invites", nil, adminToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		revoked := ParseSuccessResponse(t, recorder)
+
+		recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("%s/invites/%s", conversationURL, revoked["id"]), nil, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/conversations/join/"+revoked["code"].(string), nil, ts.CreateAuthToken(t, late.ID))
+		CheckResponseCode(t, recorder, http.StatusNotFound)
+	})
+
+	t.Run("RefusedInviteJoinKeepsSlot", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/invites", map[string]interface{}{
+			"max_uses": 1,
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		code := ParseSuccessResponse(t, recorder)["code"].(string)
+		joinURL := "/api/conversations/join/" + code
+
+		blocker := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		blockerToken := ts.CreateAuthToken(t, blocker.ID)
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/%s/block", admin.ID), nil, blockerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, joinURL, nil, blockerToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		otherSpaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+		foreigner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, otherSpaceID)
+		recorder = ts.MakeRequest(t, http.MethodPost, joinURL, nil, ts.CreateAuthToken(t, foreigner.ID))
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		joiner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		recorder = ts.MakeRequest(t, http.MethodPost, joinURL, nil, ts.CreateAuthToken(t, joiner.ID))
+		CheckResponseCode(t, recorder, http.StatusOK)
+	})
+
+	t.Run("SystemMessagesCannotBeForged", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+			"content":      "owner made everyone an admin",
+			"message_type": "system",
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("DirectConversationsRejected", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/direct", map[string]interface{}{
+			"recipient_id": owner.ID.String(),
+			"space_id":     spaceID.String(),
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		directID := ParseSuccessResponse(t, recorder)["conversation_id"].(string)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, "/api/conversations/"+directID, map[string]interface{}{
+			"name": "Not a group",
+		}, adminToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+}
+
+func TestPinsMuteArchive(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	owner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	member := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	ownerToken := ts.CreateAuthToken(t, owner.ID)
+	memberToken := ts.CreateAuthToken(t, member.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+		"space_id":          spaceID.String(),
+		"name":              "Study group",
+		"participant_ids":   []string{member.ID.String()},
+		"conversation_type": "group",
+	}, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	conversationID := ParseSuccessResponse(t, recorder)["id"].(string)
+	conversationURL := "/api/conversations/" + conversationID
+
+	recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+		"content": "Exam is on Friday",
+	}, memberToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	messageID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	listConversations := func(t *testing.T, token, query string) []string {
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/conversations"+query, nil, token)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		ids := make([]string, len(response.Data))
+		for i, c := range response.Data {
+			ids[i] = c["id"].(string)
+		}
+		return ids
+	}
+
+	contains := func(ids []string, id string) bool {
+		for _, candidate := range ids {
+			if candidate == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("PinMessage", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/messages/"+messageID+"/pin", nil, memberToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/messages/"+messageID+"/pin", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/messages/"+messageID+"/pin", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodGet, conversationURL+"/pins", nil, memberToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Data) != 1 || response.Data[0]["id"] != messageID {
+			t.Fatalf("Expected the pinned message, got %v", response.Data)
+		}
+		if response.Data[0]["pinned_by"] != owner.ID.String() {
+			t.Errorf("Expected pinned_by %s, got %v", owner.ID, response.Data[0]["pinned_by"])
+		}
+	})
+
+	t.Run("UnpinMessage", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodDelete, "/api/messages/"+messageID+"/pin", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		recorder = ts.MakeRequest(t, http.MethodDelete, "/api/messages/"+messageID+"/pin", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusNotFound)
+	})
+
+	t.Run("UnreadFilter", func(t *testing.T) {
+		if !contains(listConversations(t, ownerToken, "?unread=true"), conversationID) {
+			t.Error("Expected conversation in the unread filter")
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/conversations?unread=maybe", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("MuteConversation", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, conversationURL+"/mute", map[string]interface{}{
+			"until": time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, conversationURL+"/mute", map[string]interface{}{
+			"until": time.Now().Add(time.Hour).Format(time.RFC3339),
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !contains(listConversations(t, ownerToken, "?muted=true"), conversationID) {
+			t.Error("Expected conversation in the muted filter")
+		}
+		if contains(listConversations(t, memberToken, "?muted=true"), conversationID) {
+			t.Error("Mute should only apply to the caller")
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodDelete, conversationURL+"/mute", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if contains(listConversations(t, ownerToken, "?muted=true"), conversationID) {
+			t.Error("Expected conversation to be unmuted")
+		}
+	})
+
+	t.Run("ArchiveConversation", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/archive", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if contains(listConversations(t, ownerToken, ""), conversationID) {
+			t.Error("Archived conversation should be hidden from the default list")
+		}
+		if !contains(listConversations(t, ownerToken, "?archived=true"), conversationID) {
+			t.Error("Expected conversation in the archived list")
+		}
+		if !contains(listConversations(t, memberToken, ""), conversationID) {
+			t.Error("Archive should only apply to the caller")
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodDelete, conversationURL+"/archive", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !contains(listConversations(t, ownerToken, ""), conversationID) {
+			t.Error("Expected conversation back in the default list")
+		}
+	})
+}
+
+func TestDisappearingMessages(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	owner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	member := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	ownerToken := ts.CreateAuthToken(t, owner.ID)
+	memberToken := ts.CreateAuthToken(t, member.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+		"space_id":          spaceID.String(),
+		"name":              "Peer counselling",
+		"participant_ids":   []string{member.ID.String()},
+		"conversation_type": "group",
+	}, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	conversationID := ParseSuccessResponse(t, recorder)["id"].(string)
+	conversationURL := "/api/conversations/" + conversationID
+
+	listMessages := func(t *testing.T) []map[string]interface{} {
+		recorder := ts.MakeRequest(t, http.MethodGet, conversationURL+"/messages", nil, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		return response.Data
+	}
+
+	hasSystemMessage := func(t *testing.T, fragment string) bool {
+		for _, m := range listMessages(t) {
+			if m["message_type"] == "system" && strings.Contains(m["content"].(string), fragment) {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("SetTimer", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, conversationURL+"/disappearing-messages", map[string]interface{}{
+			"seconds": 3600,
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, conversationURL+"/disappearing-messages", map[string]interface{}{
+			"seconds": 5,
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+
+		recorder = ts.MakeRequest(t, http.MethodPut, conversationURL+"/disappearing-messages", map[string]interface{}{
+			"seconds": 3600,
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !hasSystemMessage(t, "set disappearing messages to 1 hour") {
+			t.Error("Expected a system message for the timer change")
+		}
+	})
+
+	t.Run("MessagesExpire", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+			"content": "This should not stick around",
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		message := ParseSuccessResponse(t, recorder)
+		if message["expires_at"] == nil {
+			t.Fatal("Expected the message to have an expiry")
+		}
+
+		if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, message["id"]); err != nil {
+			t.Fatalf("Failed to expire message: %v", err)
+		}
+
+		for _, m := range listMessages(t) {
+			if m["id"] == message["id"] {
+				t.Error("Expired message should not be listed")
+			}
+		}
+	})
+
+	t.Run("TurnOffTimer", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPut, conversationURL+"/disappearing-messages", map[string]interface{}{
+			"seconds": 0,
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !hasSystemMessage(t, "turned off disappearing messages") {
+			t.Error("Expected a system message for turning the timer off")
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+			"content": "This one stays",
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		if ParseSuccessResponse(t, recorder)["expires_at"] != nil {
+			t.Error("Expected no expiry once the timer is off")
+		}
+	})
+
+	t.Run("ReapingRootKeepsReplies", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+			"content": "Root that will expire",
+		}, ownerToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		rootID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, conversationURL+"/messages", map[string]interface{}{
+			"content":     "Reply that should stay",
+			"reply_to_id": rootID,
+		}, memberToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		replyID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1`, rootID); err != nil {
+			t.Fatalf("Failed to expire message: %v", err)
+		}
+		if _, err := ts.TestDB.Store.DeleteExpiredMessages(context.Background(), 100); err != nil {
+			t.Fatalf("Failed to reap expired messages: %v", err)
+		}
+
+		found := false
+		for _, m := range listMessages(t) {
+			if m["id"] == rootID {
+				t.Error("Expired root should be reaped")
+			}
+			if m["id"] == replyID {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("Expected the reply to survive its root being reaped")
+		}
+	})
+}
+
+func TestSharingAndForwarding(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	alice := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	bob := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	aliceToken := ts.CreateAuthToken(t, alice.ID)
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, alice.ID, author.ID, spaceID)
+
+	createGroup := func(t *testing.T, token, name string, participants ...string) string {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+			"space_id":          spaceID.String(),
+			"name":              name,
+			"participant_ids":   participants,
+			"conversation_type": "group",
+		}, token)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		return ParseSuccessResponse(t, recorder)["id"].(string)
+	}
+
+	createPost := func(t *testing.T, visibility string) string {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+			"space_id":   spaceID.String(),
+			"content":    "Exam timetable is out",
+			"visibility": visibility,
+		}, authorToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		return ParseSuccessResponse(t, recorder)["id"].(string)
+	}
+
+	conversationID := createGroup(t, aliceToken, "Course reps", bob.ID.String())
+	otherConversationID := createGroup(t, aliceToken, "Study buddies", bob.ID.String())
+	authorConversationID := createGroup(t, authorToken, "Author only", bob.ID.String())
+	messagesURL := "/api/conversations/" + conversationID + "/messages"
+
+	t.Run("SharePublicPost", func(t *testing.T) {
+		postID := createPost(t, "public")
+
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"shared": map[string]interface{}{"type": "post", "id": postID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+
+		message := ParseSuccessResponse(t, recorder)
+		if message["message_type"] != "shared_post" {
+			t.Errorf("Expected message_type shared_post, got %v", message["message_type"])
+		}
+		shared, ok := message["shared"].(map[string]interface{})
+		if !ok || shared["id"] != postID {
+			t.Fatalf("Expected shared post %s, got %v", postID, message["shared"])
+		}
+		preview := shared["preview"].(map[string]interface{})
+		if preview["content"] != "Exam timetable is out" || preview["author_username"] != author.Username {
+			t.Errorf("Unexpected preview: %v", preview)
+		}
+	})
+
+	t.Run("RecipientCannotSeePost", func(t *testing.T) {
+		postID := createPost(t, "followers")
+
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"shared": map[string]interface{}{"type": "post", "id": postID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("UnknownSharedContent", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"shared": map[string]interface{}{"type": "community", "id": uuid.New().String()},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusNotFound)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"shared": map[string]interface{}{"type": "poll", "id": uuid.New().String()},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"message_type": "shared_post",
+			"content":      "No payload",
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("ForwardMessage", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"content": "Notes attached",
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		messageID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET attachments = '[{"url": "https://example.com/notes.pdf", "type": "file"}]' WHERE id = $1`, messageID); err != nil {
+			t.Fatalf("Failed to attach file: %v", err)
+		}
+		forwardURL := "/api/messages/" + messageID + "/forward"
+
+		recorder = ts.MakeRequest(t, http.MethodPost, forwardURL, map[string]interface{}{
+			"conversation_ids": []string{authorConversationID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, forwardURL, map[string]interface{}{
+			"conversation_ids": []string{otherConversationID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+
+		var response struct {
+			Data []map[string]interface{} `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if len(response.Data) != 1 {
+			t.Fatalf("Expected one forwarded message, got %d", len(response.Data))
+		}
+		forwarded := response.Data[0]
+		if forwarded["conversation_id"] != otherConversationID || forwarded["content"] != "Notes attached" {
+			t.Errorf("Unexpected forwarded message: %v", forwarded)
+		}
+		if forwarded["is_forwarded"] != true || forwarded["forwarded_from_id"] != messageID {
+			t.Errorf("Expected forwarded marker, got %v", forwarded)
+		}
+		if forwarded["attachments"] == nil {
+			t.Error("Expected attachments to be carried over")
+		}
+	})
+
+	t.Run("ForwardIsAllOrNothing", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"content": "Only for some",
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		messageID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/messages/"+messageID+"/forward", map[string]interface{}{
+			"conversation_ids": []string{otherConversationID, authorConversationID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+
+		var forwarded int
+		if err := ts.TestDB.DB.QueryRow(`SELECT COUNT(*) FROM messages WHERE forwarded_from_id = $1`, messageID).Scan(&forwarded); err != nil {
+			t.Fatalf("Failed to count forwards: %v", err)
+		}
+		if forwarded != 0 {
+			t.Errorf("Expected no partial forwards, got %d", forwarded)
+		}
+	})
+
+	t.Run("ForwardDisappearingMessage", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"content": "Gone in an hour",
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		messageID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET expires_at = NOW() + INTERVAL '1 hour' WHERE id = $1`, messageID); err != nil {
+			t.Fatalf("Failed to set expiry: %v", err)
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodPost, "/api/messages/"+messageID+"/forward", map[string]interface{}{
+			"conversation_ids": []string{otherConversationID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("PreviewFollowsCurrentVisibility", func(t *testing.T) {
+		postID := createPost(t, "public")
+
+		recorder := ts.MakeRequest(t, http.MethodPost, messagesURL, map[string]interface{}{
+			"shared": map[string]interface{}{"type": "post", "id": postID},
+		}, aliceToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		messageID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+		if _, err := ts.TestDB.DB.Exec(`UPDATE posts SET visibility = 'followers' WHERE id = $1`, postID); err != nil {
+			t.Fatalf("Failed to restrict post: %v", err)
+		}
+
+		sharedFor := func(t *testing.T, token string) map[string]interface{} {
+			recorder := ts.MakeRequest(t, http.MethodGet, "/api/messages/"+messageID, nil, token)
+			CheckResponseCode(t, recorder, http.StatusOK)
+			return ParseSuccessResponse(t, recorder)["shared"].(map[string]interface{})
+		}
+
+		if shared := sharedFor(t, aliceToken); shared["preview"] == nil || shared["unavailable"] != false {
+			t.Errorf("Expected follower to keep the preview, got %v", shared)
+		}
+		if shared := sharedFor(t, ts.CreateAuthToken(t, bob.ID)); shared["preview"] != nil || shared["unavailable"] != true {
+			t.Errorf("Expected preview to be withheld from non-follower, got %v", shared)
+		}
+	})
+}
+
+
+func TestConversationExport(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	lead := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	member := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	latecomer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	outsider := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	leadToken := ts.CreateAuthToken(t, lead.ID)
+	memberToken := ts.CreateAuthToken(t, member.ID)
+	latecomerToken := ts.CreateAuthToken(t, latecomer.ID)
+	outsiderToken := ts.CreateAuthToken(t, outsider.ID)
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, latecomer.ID, lead.ID, spaceID)
+
+	createGroup := func(t *testing.T, name string) string {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations", map[string]interface{}{
+			"space_id":          spaceID.String(),
+			"name":              name,
+			"participant_ids":   []string{member.ID.String()},
+			"conversation_type": "group",
+		}, leadToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		return ParseSuccessResponse(t, recorder)["id"].(string)
+	}
+
+	send := func(t *testing.T, conversationID, token, content string) string {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/"+conversationID+"/messages", map[string]interface{}{
+			"content": content,
+		}, token)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		return ParseSuccessResponse(t, recorder)["id"].(string)
+	}
+
+	conversationID := createGroup(t, "Capstone project")
+	exportURL := "/api/conversations/" + conversationID + "/export"
+
+	decisionID := send(t, conversationID, leadToken, "We will use Postgres for the backend")
+	if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET attachments = '[{"url": "https://example.com/architecture.pdf", "type": "file"}]' WHERE id = $1`, decisionID); err != nil {
+		t.Fatalf("Failed to set attachments: %v", err)
+	}
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/conversations/"+conversationID+"/participants", map[string]interface{}{
+		"user_ids": []string{latecomer.ID.String()},
+	}, leadToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	send(t, conversationID, memberToken, "Agreed, I will set up the schema")
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/conversations/"+conversationID+"/leave", nil, memberToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	send(t, conversationID, latecomerToken, "Catching up on the schema work")
+
+	t.Run("JSON", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL, nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "application/json") {
+			t.Errorf("Expected JSON content type, got %s", recorder.Header().Get("Content-Type"))
+		}
+		if !strings.Contains(recorder.Header().Get("Content-Disposition"), "conversation-"+conversationID+".json") {
+			t.Errorf("Unexpected Content-Disposition: %s", recorder.Header().Get("Content-Disposition"))
+		}
+
+		var transcript struct {
+			ConversationID string                   `json:"conversation_id"`
+			Messages       []map[string]interface{} `json:"messages"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &transcript); err != nil {
+			t.Fatalf("Failed to parse export: %v", err)
+		}
+		if transcript.ConversationID != conversationID {
+			t.Errorf("Expected conversation %s, got %s", conversationID, transcript.ConversationID)
+		}
+
+		var contents []string
+		for _, message := range transcript.Messages {
+			if message["message_type"] == "system" {
+				continue
+			}
+			contents = append(contents, message["content"].(string))
+		}
+		if len(contents) != 3 {
+			t.Fatalf("Expected 3 messages, got %v", contents)
+		}
+		if contents[0] != "We will use Postgres for the backend" {
+			t.Errorf("Expected messages in chronological order, got %v", contents)
+		}
+
+		attachments, ok := transcript.Messages[0]["attachments"].([]interface{})
+		if !ok || len(attachments) != 1 || attachments[0] != "https://example.com/architecture.pdf" {
+			t.Errorf("Expected attachment reference, got %v", transcript.Messages[0]["attachments"])
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL+"?format=csv", nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		body := recorder.Body.String()
+		if !strings.HasPrefix(body, "message_id,created_at,sender_id") {
+			t.Errorf("Expected CSV header, got %s", body)
+		}
+		if !strings.Contains(body, "https://example.com/architecture.pdf") {
+			t.Error("Expected attachment reference in CSV export")
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL+"?format=txt", nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		body := recorder.Body.String()
+		if !strings.Contains(body, lead.Username+": We will use Postgres for the backend") {
+			t.Errorf("Expected transcript line, got %s", body)
+		}
+		if !strings.Contains(body, "attachment: https://example.com/architecture.pdf") {
+			t.Error("Expected attachment reference in text export")
+		}
+	})
+
+	t.Run("JoinWindow", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL+"?format=txt", nil, latecomerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		body := recorder.Body.String()
+		if strings.Contains(body, "We will use Postgres") {
+			t.Error("Expected messages sent before joining to be excluded")
+		}
+		if !strings.Contains(body, "Catching up on the schema work") {
+			t.Error("Expected messages sent after joining to be included")
+		}
+	})
+
+	t.Run("LeaveWindow", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL+"?format=txt", nil, memberToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		body := recorder.Body.String()
+		if !strings.Contains(body, "Agreed, I will set up the schema") {
+			t.Error("Expected messages sent before leaving to be included")
+		}
+		if strings.Contains(body, "Catching up on the schema work") {
+			t.Error("Expected messages sent after leaving to be excluded")
+		}
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL+"?format=xml", nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusBadRequest)
+	})
+
+	t.Run("NonParticipant", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL, nil, outsiderToken)
+		CheckResponseCode(t, recorder, http.StatusForbidden)
+	})
+
+	t.Run("AuditLog", func(t *testing.T) {
+		var count int
+		if err := ts.TestDB.DB.QueryRow(
+			`SELECT COUNT(*) FROM audit_logs WHERE admin_user_id = $1 AND action = 'export_conversation' AND resource_id = $2`,
+			lead.ID, conversationID,
+		).Scan(&count); err != nil {
+			t.Fatalf("Failed to query audit logs: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Expected 3 audit log entries, got %d", count)
+		}
+	})
+
+	t.Run("BackgroundExport", func(t *testing.T) {
+		largeID := createGroup(t, "Project archive")
+		if _, err := ts.TestDB.DB.Exec(`
+			INSERT INTO messages (conversation_id, sender_id, content, message_type, created_at)
+			SELECT $1, $2, 'Decision ' || i, 'text', NOW() + i * INTERVAL '1 millisecond'
+			FROM generate_series(1, 5001) AS i`, largeID, lead.ID); err != nil {
+			t.Fatalf("Failed to insert messages: %v", err)
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/conversations/"+largeID+"/export?format=csv", nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusAccepted)
+
+		export := ParseSuccessResponse(t, recorder)
+		exportURL := fmt.Sprintf("/api/conversations/exports/%s", export["id"])
+
+		recorder = ts.MakeRequest(t, http.MethodGet, exportURL, nil, memberToken)
+		CheckResponseCode(t, recorder, http.StatusNotFound)
+
+		deadline := time.Now().Add(10 * time.Second)
+		for export["status"] == "pending" && time.Now().Before(deadline) {
+			time.Sleep(100 * time.Millisecond)
+			recorder = ts.MakeRequest(t, http.MethodGet, exportURL, nil, leadToken)
+			CheckResponseCode(t, recorder, http.StatusOK)
+			export = ParseSuccessResponse(t, recorder)
+		}
+		if export["status"] != "completed" {
+			t.Fatalf("Expected completed export, got %v", export["status"])
+		}
+		if export["download_url"] != exportURL+"/download" {
+			t.Errorf("Unexpected download URL: %v", export["download_url"])
+		}
+
+		recorder = ts.MakeRequest(t, http.MethodGet, exportURL+"/download", nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+
+		lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+		if len(lines) < 5002 {
+			t.Errorf("Expected at least 5002 CSV lines, got %d", len(lines))
+		}
+	})
+
+	t.Run("DisappearingMessagesExcluded", func(t *testing.T) {
+		messageID := send(t, conversationID, leadToken, "Self-destructing note")
+		if _, err := ts.TestDB.DB.Exec(`UPDATE messages SET expires_at = NOW() + INTERVAL '1 hour' WHERE id = $1`, messageID); err != nil {
+			t.Fatalf("Failed to set expiry: %v", err)
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodGet, exportURL+"?format=txt", nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if strings.Contains(recorder.Body.String(), "Self-destructing note") {
+			t.Error("Expected disappearing messages to be left out of the export")
+		}
+	})
+
+	t.Run("StalePendingExportsFail", func(t *testing.T) {
+		var exportID string
+		if err := ts.TestDB.DB.QueryRow(`
+			INSERT INTO conversation_exports (conversation_id, requested_by, format, created_at)
+			VALUES ($1, $2, 'json', NOW() - INTERVAL '1 hour')
+			RETURNING id`, conversationID, lead.ID).Scan(&exportID); err != nil {
+			t.Fatalf("Failed to insert export: %v", err)
+		}
+
+		failed, err := ts.TestDB.Store.FailStaleConversationExports(context.Background(), db.FailStaleConversationExportsParams{
+			Error:       sql.NullString{String: "export timed out", Valid: true},
+			Retention:   "7 days",
+			StaleBefore: time.Now().Add(-10 * time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("Failed to fail stale exports: %v", err)
+		}
+		if failed < 1 {
+			t.Errorf("Expected the stale export to be marked failed, got %d", failed)
+		}
+
+		recorder := ts.MakeRequest(t, http.MethodGet, "/api/conversations/exports/"+exportID, nil, leadToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		if status := ParseSuccessResponse(t, recorder)["status"]; status != "failed" {
+			t.Errorf("Expected failed status, got %v", status)
+		}
+	})
+}
@@ -2,11 +2,16 @@ package api_test
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	db "github.com/connect-univyn/connect-server/db/sqlc"
+	"github.com/connect-univyn/connect-server/internal/service/posts"
 	testhelpers "github.com/connect-univyn/connect-server/test/db"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -921,3 +926,1329 @@ func TestToggleCommentLike(t *testing.T) {
 		})
 	}
 }
+
+
+
+
+
+func TestUpdateAndDeleteComment(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	other := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	otherToken := ts.CreateAuthToken(t, other.ID)
+
+	post, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:  spaceID,
+		AuthorID: author.ID,
+		Content:  "Test post",
+	})
+	require.NoError(t, err)
+
+	comment, err := ts.TestDB.Store.CreateComment(context.Background(), db.CreateCommentParams{
+		PostID:   post.ID,
+		AuthorID: author.ID,
+		Content:  "Original comment",
+	})
+	require.NoError(t, err)
+
+	_, err = ts.TestDB.Store.CreateComment(context.Background(), db.CreateCommentParams{
+		PostID:          post.ID,
+		AuthorID:        other.ID,
+		ParentCommentID: uuid.NullUUID{UUID: comment.ID, Valid: true},
+		Content:         "Reply",
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		method       string
+		commentID    string
+		body         map[string]interface{}
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "EditByAuthor",
+			method:       http.MethodPut,
+			commentID:    comment.ID.String(),
+			body:         map[string]interface{}{"content": "Edited comment"},
+			token:        authorToken,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "EditByOtherUser",
+			method:       http.MethodPut,
+			commentID:    comment.ID.String(),
+			body:         map[string]interface{}{"content": "Hijacked"},
+			token:        otherToken,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "EditNonexistent",
+			method:       http.MethodPut,
+			commentID:    uuid.New().String(),
+			body:         map[string]interface{}{"content": "Nothing here"},
+			token:        authorToken,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "DeleteByOtherUser",
+			method:       http.MethodDelete,
+			commentID:    comment.ID.String(),
+			token:        otherToken,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "DeleteByAuthor",
+			method:       http.MethodDelete,
+			commentID:    comment.ID.String(),
+			token:        authorToken,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "DeleteTwice",
+			method:       http.MethodDelete,
+			commentID:    comment.ID.String(),
+			token:        authorToken,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url := fmt.Sprintf("/api/comments/%s", tc.commentID)
+			var body interface{}
+			if tc.body != nil {
+				body = tc.body
+			}
+			recorder := ts.MakeRequest(t, tc.method, url, body, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	
+	recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/comments/threaded", post.ID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	data := ParseSuccessResponse(t, recorder)
+	RequireFieldExists(t, data, "has_more")
+	comments := data["items"].([]interface{})
+	require.Len(t, comments, 1)
+	placeholder := comments[0].(map[string]interface{})
+	require.Equal(t, true, placeholder["is_deleted"])
+	require.Equal(t, float64(1), placeholder["reply_count"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/comments/%s/replies", comment.ID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	data = ParseSuccessResponse(t, recorder)
+	require.Len(t, data["items"].([]interface{}), 1)
+}
+
+
+
+
+
+func TestPollVoting(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	voter := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	voterToken := ts.CreateAuthToken(t, voter.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Where should we meet?",
+		"poll": map[string]interface{}{
+			"options": []string{"Library", "Cafeteria"},
+		},
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	post := ParseSuccessResponse(t, recorder)
+	RequireFieldExists(t, post, "poll")
+	postID := post["id"].(string)
+
+	poll := post["poll"].(map[string]interface{})
+	require.Equal(t, false, poll["results_visible"])
+	options := poll["options"].([]interface{})
+	require.Len(t, options, 2)
+	firstOption := options[0].(map[string]interface{})["id"].(string)
+	secondOption := options[1].(map[string]interface{})["id"].(string)
+
+	testCases := []struct {
+		name         string
+		body         map[string]interface{}
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "NoAuth",
+			body:         map[string]interface{}{"option_ids": []string{firstOption}},
+			token:        "",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "MultipleChoicesOnSinglePoll",
+			body:         map[string]interface{}{"option_ids": []string{firstOption, secondOption}},
+			token:        voterToken,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "UnknownOption",
+			body:         map[string]interface{}{"option_ids": []string{uuid.New().String()}},
+			token:        voterToken,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "ValidVote",
+			body:         map[string]interface{}{"option_ids": []string{firstOption}},
+			token:        voterToken,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "SecondVote",
+			body:         map[string]interface{}{"option_ids": []string{secondOption}},
+			token:        voterToken,
+			expectedCode: http.StatusConflict,
+		},
+	}
+
+	conn := ts.DialWebSocket(t, authorToken, "post:"+postID)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url := fmt.Sprintf("/api/posts/%s/poll/vote", postID)
+			recorder := ts.MakeRequest(t, http.MethodPost, url, tc.body, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	update := ReadWebSocketEvent(t, conn, "poll.updated", nil)
+	require.Equal(t, postID, update["post_id"])
+	require.Equal(t, poll["id"], update["poll_id"])
+	require.NotContains(t, update, "options")
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/poll", postID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	data := ParseSuccessResponse(t, recorder)
+	require.Equal(t, false, data["results_visible"])
+	RequireFieldNotExists(t, data, "voters_count")
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id":   spaceID.String(),
+		"content":    "Pick the next meetup venue",
+		"publish_at": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+		"poll": map[string]interface{}{
+			"options": []string{"Library", "Cafeteria"},
+		},
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	scheduled := ParseSuccessResponse(t, recorder)
+	scheduledID := scheduled["id"].(string)
+	scheduledOption := scheduled["poll"].(map[string]interface{})["options"].([]interface{})[0].(map[string]interface{})["id"].(string)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/poll", scheduledID), nil, voterToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/%s/poll/vote", scheduledID), map[string]interface{}{
+		"option_ids": []string{scheduledOption},
+	}, voterToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+}
+
+
+
+
+
+func TestScheduledPosts(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	user := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	token := ts.CreateAuthToken(t, user.ID)
+
+	testCases := []struct {
+		name         string
+		body         map[string]interface{}
+		expectedCode int
+	}{
+		{
+			name: "PublishAtInPast",
+			body: map[string]interface{}{
+				"space_id":   spaceID.String(),
+				"content":    "Too late",
+				"status":     "scheduled",
+				"publish_at": time.Now().Add(-time.Hour).Format(time.RFC3339),
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "ScheduledWithoutPublishAt",
+			body: map[string]interface{}{
+				"space_id": spaceID.String(),
+				"content":  "When?",
+				"status":   "scheduled",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "InvalidStatus",
+			body: map[string]interface{}{
+				"space_id": spaceID.String(),
+				"content":  "Hidden",
+				"status":   "removed",
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", tc.body, token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id":   spaceID.String(),
+		"content":    "Exam week reminder",
+		"publish_at": time.Now().Add(24 * time.Hour).Format(time.RFC3339),
+	}, token)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	post := ParseSuccessResponse(t, recorder)
+	require.Equal(t, "scheduled", post["status"])
+	postID := post["id"].(string)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", postID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/posts/scheduled?status=scheduled", nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/scheduled/%s", postID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Equal(t, "draft", ParseSuccessResponse(t, recorder)["status"])
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/scheduled/%s/publish", postID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Equal(t, "active", ParseSuccessResponse(t, recorder)["status"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", postID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+}
+
+
+
+
+
+func TestBookmarks(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	reader := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	readerToken := ts.CreateAuthToken(t, reader.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Notes for the final exam",
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	postID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/posts/bookmarks/collections", map[string]interface{}{
+		"name": "Exams",
+	}, readerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	collectionID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		body         map[string]interface{}
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "NoAuth",
+			method:       http.MethodPost,
+			url:          fmt.Sprintf("/api/posts/%s/bookmark", postID),
+			token:        "",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "UnknownPost",
+			method:       http.MethodPost,
+			url:          fmt.Sprintf("/api/posts/%s/bookmark", uuid.New()),
+			token:        readerToken,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "OtherUsersCollection",
+			method:       http.MethodPost,
+			url:          fmt.Sprintf("/api/posts/%s/bookmark", postID),
+			body:         map[string]interface{}{"collection_id": collectionID},
+			token:        authorToken,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "DuplicateCollectionName",
+			method:       http.MethodPost,
+			url:          "/api/posts/bookmarks/collections",
+			body:         map[string]interface{}{"name": "Exams"},
+			token:        readerToken,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "Bookmark",
+			method:       http.MethodPost,
+			url:          fmt.Sprintf("/api/posts/%s/bookmark", postID),
+			token:        readerToken,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "MoveToCollection",
+			method:       http.MethodPut,
+			url:          fmt.Sprintf("/api/posts/%s/bookmark", postID),
+			body:         map[string]interface{}{"collection_id": collectionID},
+			token:        readerToken,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body interface{}
+			if tc.body != nil {
+				body = tc.body
+			}
+			recorder := ts.MakeRequest(t, tc.method, tc.url, body, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", postID), nil, readerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	post := ParseSuccessResponse(t, recorder)
+	require.Equal(t, true, post["is_bookmarked"])
+	require.Equal(t, float64(0), post["likes_count"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/user/%s", author.ID), nil, readerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), `"is_bookmarked":true`)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/bookmarks?collection_id=%s", collectionID), nil, readerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	page := ParseSuccessResponse(t, recorder)
+	require.Len(t, page["items"].([]interface{}), 1)
+	require.Equal(t, false, page["has_more"])
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/bookmark", postID), nil, readerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/bookmark", postID), nil, readerToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	t.Run("HiddenPostsDropOut", func(t *testing.T) {
+		testhelpers.CreateTestFollow(t, ts.TestDB.Store, reader.ID, author.ID, spaceID)
+
+		for _, visibility := range []string{"public", "followers"} {
+			recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+				"space_id":   spaceID.String(),
+				"content":    "Bookmarked " + visibility + " post",
+				"visibility": visibility,
+			}, authorToken)
+			CheckResponseCode(t, recorder, http.StatusCreated)
+			id := ParseSuccessResponse(t, recorder)["id"].(string)
+
+			recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/%s/bookmark", id), nil, readerToken)
+			CheckResponseCode(t, recorder, http.StatusOK)
+		}
+
+		bookmarkCount := func() int {
+			recorder := ts.MakeRequest(t, http.MethodGet, "/api/posts/bookmarks", nil, readerToken)
+			CheckResponseCode(t, recorder, http.StatusOK)
+			return len(ParseSuccessResponse(t, recorder)["items"].([]interface{}))
+		}
+		require.Equal(t, 2, bookmarkCount())
+
+		recorder := ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/users/%s/follow", author.ID), nil, readerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		require.Equal(t, 1, bookmarkCount())
+
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/%s/block", author.ID), nil, readerToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		require.Equal(t, 0, bookmarkCount())
+	})
+}
+
+
+
+
+
+func TestHashtags(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	user := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	token := ts.CreateAuthToken(t, user.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Revision session tonight #Finals #StudyGroup see you there #finals",
+	}, token)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	post := ParseSuccessResponse(t, recorder)
+	require.ElementsMatch(t, []interface{}{"finals", "studygroup"}, post["tags"])
+	postID := post["id"].(string)
+
+	testCases := []struct {
+		name         string
+		url          string
+		expectedCode int
+	}{
+		{
+			name:         "MissingSpace",
+			url:          "/api/posts/tag/finals",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "InvalidCursor",
+			url:          fmt.Sprintf("/api/posts/tag/finals?space_id=%s&cursor=not-a-cursor", spaceID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "UnknownTag",
+			url:          fmt.Sprintf("/api/posts/tag/nothing_here?space_id=%s", spaceID),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "InvalidPeriod",
+			url:          fmt.Sprintf("/api/topics/trending?space_id=%s&period=monthly", spaceID),
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodGet, tc.url, nil, "")
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/tag/%%23Finals?space_id=%s", spaceID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	page := ParseSuccessResponse(t, recorder)
+	posts := page["items"].([]interface{})
+	require.Len(t, posts, 1)
+	require.Equal(t, postID, posts[0].(map[string]interface{})["id"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/tags?space_id=%s&q=stu", spaceID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	var suggestions struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &suggestions))
+	require.NotEmpty(t, suggestions.Data)
+	require.Equal(t, "studygroup", suggestions.Data[0]["name"])
+	require.Equal(t, float64(1), suggestions.Data[0]["usage_count"])
+}
+
+
+
+
+
+func TestMentions(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	mentioned := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	mentionedToken := ts.CreateAuthToken(t, mentioned.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  fmt.Sprintf("Great notes @%s, and thanks @nobody_here_at_all", mentioned.Username),
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	post := ParseSuccessResponse(t, recorder)
+
+	entities := post["mentions"].([]interface{})
+	require.Len(t, entities, 1)
+	entity := entities[0].(map[string]interface{})
+	require.Equal(t, mentioned.ID.String(), entity["user_id"])
+	require.Equal(t, float64(12), entity["offset"])
+	require.Equal(t, float64(len(mentioned.Username)+1), entity["length"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post["id"]), nil, mentionedToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	fetched := ParseSuccessResponse(t, recorder)
+	require.Len(t, fetched["mentions"], 1)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/notifications", nil, mentionedToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), `"mention"`)
+
+	t.Run("OffsetsUseUTF16Units", func(t *testing.T) {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+			"space_id": spaceID.String(),
+			"content":  fmt.Sprintf("🎉 café @%s", mentioned.Username),
+		}, authorToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		post := ParseSuccessResponse(t, recorder)
+
+		entities := post["mentions"].([]interface{})
+		require.Len(t, entities, 1)
+		entity := entities[0].(map[string]interface{})
+		require.Equal(t, float64(8), entity["offset"])
+		require.Equal(t, float64(len(mentioned.Username)+1), entity["length"])
+	})
+
+	t.Run("HiddenPostDoesNotNotify", func(t *testing.T) {
+		outsider := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		outsiderToken := ts.CreateAuthToken(t, outsider.ID)
+
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+			"space_id":   spaceID.String(),
+			"content":    fmt.Sprintf("Followers only, @%s", outsider.Username),
+			"visibility": "followers",
+		}, authorToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		post := ParseSuccessResponse(t, recorder)
+
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/%s/comments", post["id"]), map[string]interface{}{
+			"content": fmt.Sprintf("Still hidden, @%s", outsider.Username),
+		}, authorToken)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+
+		recorder = ts.MakeRequest(t, http.MethodGet, "/api/notifications", nil, outsiderToken)
+		CheckResponseCode(t, recorder, http.StatusOK)
+		require.NotContains(t, recorder.Body.String(), `"mention"`)
+	})
+
+	testCases := []struct {
+		name         string
+		token        string
+		query        string
+		expectedCode int
+		expectedLen  int
+	}{
+		{
+			name:         "Unauthorized",
+			query:        "q=user",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "EmptyPrefix",
+			token:        authorToken,
+			query:        "q=@",
+			expectedCode: http.StatusOK,
+			expectedLen:  0,
+		},
+		{
+			name:         "MatchesUsername",
+			token:        authorToken,
+			query:        "q=@" + mentioned.Username,
+			expectedCode: http.StatusOK,
+			expectedLen:  1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodGet, "/api/mentions/suggest?"+tc.query, nil, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			var suggestions struct {
+				Data []map[string]interface{} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &suggestions))
+			require.Len(t, suggestions.Data, tc.expectedLen)
+		})
+	}
+}
+
+
+
+
+
+func TestRankedFeed(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	viewer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	followed := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	stranger := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	token := ts.CreateAuthToken(t, viewer.ID)
+
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, viewer.ID, followed.ID, spaceID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Post from someone the viewer follows",
+	}, ts.CreateAuthToken(t, followed.ID))
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	followedPost := ParseSuccessResponse(t, recorder)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Newer post from a stranger",
+	}, ts.CreateAuthToken(t, stranger.ID))
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	strangerPost := ParseSuccessResponse(t, recorder)
+
+	testCases := []struct {
+		name         string
+		query        string
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "NoAuth",
+			query:        "?mode=for_you",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "InvalidMode",
+			query:        "?mode=popular",
+			token:        token,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "InvalidCursor",
+			query:        "?mode=for_you&cursor=not-a-cursor",
+			token:        token,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Chronological",
+			query:        "?mode=chronological",
+			token:        token,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodGet, "/api/posts/feed"+tc.query, nil, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/posts/feed?mode=for_you&limit=1", nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	first := ParseSuccessResponse(t, recorder)
+	firstPosts := first["posts"].([]interface{})
+	require.Len(t, firstPosts, 1)
+	require.Equal(t, followedPost["id"], firstPosts[0].(map[string]interface{})["id"])
+	require.Equal(t, true, first["has_more"])
+	require.NotNil(t, first["next_cursor"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/feed?mode=for_you&limit=1&cursor=%s", first["next_cursor"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	second := ParseSuccessResponse(t, recorder)
+	secondPosts := second["posts"].([]interface{})
+	require.Len(t, secondPosts, 1)
+	require.Equal(t, strangerPost["id"], secondPosts[0].(map[string]interface{})["id"])
+	require.Equal(t, first["snapshot_at"], second["snapshot_at"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/posts/feed/explain", nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	explained := ParseSuccessResponse(t, recorder)
+	explanations := explained["explanations"].([]interface{})
+	require.Len(t, explanations, 2)
+	top := explanations[0].(map[string]interface{})
+	require.Equal(t, followedPost["id"], top["post_id"])
+	require.Equal(t, true, top["signals"].(map[string]interface{})["is_following"])
+	require.Greater(t, top["components"].(map[string]interface{})["affinity"].(float64), 0.0)
+}
+
+
+
+
+
+func TestCursorPagination(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	token := ts.CreateAuthToken(t, author.ID)
+
+	for i := 0; i < 3; i++ {
+		recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+			"space_id": spaceID.String(),
+			"content":  fmt.Sprintf("Paged post %d", i),
+		}, token)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+	}
+
+	for i := 0; i < 3; i++ {
+		follower := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+		testhelpers.CreateTestFollow(t, ts.TestDB.Store, follower.ID, author.ID, spaceID)
+	}
+
+	testCases := []struct {
+		name         string
+		url          string
+		expectedCode int
+	}{
+		{
+			name:         "InvalidCursor",
+			url:          fmt.Sprintf("/api/posts/user/%s?cursor=not-a-cursor", author.ID),
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "OffsetStillSupported",
+			url:          fmt.Sprintf("/api/posts/user/%s?page=2&limit=2", author.ID),
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodGet, tc.url, nil, token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/user/%s?page=2&limit=2", author.ID), nil, token)
+	var legacy struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &legacy))
+	require.Len(t, legacy.Data, 1)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/user/%s?cursor=&limit=2", author.ID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	first := ParseSuccessResponse(t, recorder)
+	require.Len(t, first["items"], 2)
+	require.Equal(t, true, first["has_more"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/user/%s?cursor=%s&limit=2", author.ID, first["next_cursor"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	second := ParseSuccessResponse(t, recorder)
+	require.Len(t, second["items"], 1)
+	require.Equal(t, false, second["has_more"])
+	require.Nil(t, second["next_cursor"])
+
+	seen := map[interface{}]bool{}
+	for _, item := range append(first["items"].([]interface{}), second["items"].([]interface{})...) {
+		id := item.(map[string]interface{})["id"]
+		require.False(t, seen[id], "post returned on more than one page")
+		seen[id] = true
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/users/%s/followers?cursor=&limit=2&include_total=true", author.ID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	followers := ParseSuccessResponse(t, recorder)
+	require.Len(t, followers["items"], 2)
+	require.Equal(t, float64(3), followers["total"])
+	require.NotNil(t, followers["next_cursor"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/user/%s?cursor=&limit=2&include_total=true", author.ID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Equal(t, float64(3), ParseSuccessResponse(t, recorder)["total"])
+
+	postID := first["items"].([]interface{})[0].(map[string]interface{})["id"].(string)
+	commentsURL := fmt.Sprintf("/api/posts/%s/comments", postID)
+	var rootID string
+	for i := 0; i < 3; i++ {
+		recorder = ts.MakeRequest(t, http.MethodPost, commentsURL, map[string]interface{}{
+			"content": fmt.Sprintf("Paged comment %d", i),
+		}, token)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+		if i == 0 {
+			rootID = ParseSuccessResponse(t, recorder)["id"].(string)
+		}
+	}
+	recorder = ts.MakeRequest(t, http.MethodPost, commentsURL, map[string]interface{}{
+		"content":           "Reply to the first comment",
+		"parent_comment_id": rootID,
+	}, token)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, commentsURL+"?cursor=&limit=2&include_total=true", nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	comments := ParseSuccessResponse(t, recorder)
+	require.Len(t, comments["items"], 3)
+	require.Equal(t, true, comments["has_more"])
+	require.Equal(t, float64(3), comments["total"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("%s?cursor=%s&limit=2", commentsURL, comments["next_cursor"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	comments = ParseSuccessResponse(t, recorder)
+	require.Len(t, comments["items"], 1)
+	require.Equal(t, false, comments["has_more"])
+}
+
+
+
+
+
+func TestPostReactions(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	_, err := ts.TestDB.DB.Exec(`UPDATE spaces SET settings = '{"reactions": ["love", "fire"]}' WHERE id = $1`, spaceID)
+	require.NoError(t, err)
+
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	reactor := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	liker := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	reactorToken := ts.CreateAuthToken(t, reactor.ID)
+	likerToken := ts.CreateAuthToken(t, liker.ID)
+
+	post, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:  spaceID,
+		AuthorID: author.ID,
+		Content:  "React to this",
+	})
+	require.NoError(t, err)
+
+	comment, err := ts.TestDB.Store.CreateComment(context.Background(), db.CreateCommentParams{
+		PostID:   post.ID,
+		AuthorID: author.ID,
+		Content:  "And this",
+	})
+	require.NoError(t, err)
+
+	recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/reactions?space_id=%s", spaceID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	set := ParseSuccessResponse(t, recorder)
+	require.Equal(t, []interface{}{"like", "love", "fire"}, set["reactions"])
+
+	testCases := []struct {
+		name         string
+		url          string
+		reaction     string
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "NoAuth",
+			url:          fmt.Sprintf("/api/posts/%s/reactions", post.ID),
+			reaction:     "fire",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "ReactionNotInSpaceSet",
+			url:          fmt.Sprintf("/api/posts/%s/reactions", post.ID),
+			reaction:     "wow",
+			token:        reactorToken,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "UnknownPost",
+			url:          fmt.Sprintf("/api/posts/%s/reactions", uuid.New()),
+			reaction:     "fire",
+			token:        reactorToken,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "ValidPostReaction",
+			url:          fmt.Sprintf("/api/posts/%s/reactions", post.ID),
+			reaction:     "fire",
+			token:        reactorToken,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "ValidCommentReaction",
+			url:          fmt.Sprintf("/api/comments/%s/reactions", comment.ID),
+			reaction:     "love",
+			token:        reactorToken,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodPut, tc.url, map[string]interface{}{"reaction": tc.reaction}, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/%s/like", post.ID), nil, likerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Equal(t, float64(2), ParseSuccessResponse(t, recorder)["likes_count"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post.ID), nil, reactorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	detail := ParseSuccessResponse(t, recorder)
+	require.Equal(t, map[string]interface{}{"fire": float64(1), "like": float64(1)}, detail["reaction_counts"])
+	require.Equal(t, "fire", detail["viewer_reaction"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/reactions?reaction=fire", post.ID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), reactor.ID.String())
+	require.NotContains(t, recorder.Body.String(), liker.ID.String())
+
+	recorder = ts.MakeRequest(t, http.MethodPut, fmt.Sprintf("/api/posts/%s/reactions", post.ID), map[string]interface{}{"reaction": "love"}, reactorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	summary := ParseSuccessResponse(t, recorder)
+	require.Equal(t, float64(2), summary["total"])
+	require.Equal(t, "love", summary["viewer_reaction"])
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/reactions", post.ID), nil, reactorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Equal(t, float64(1), ParseSuccessResponse(t, recorder)["total"])
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/reactions", post.ID), nil, reactorToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/comments/%s/reactions", comment.ID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), "love")
+
+	t.Run("ConcurrentUpsertsCountOnce", func(t *testing.T) {
+		racer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+
+		var before int32
+		require.NoError(t, ts.TestDB.DB.QueryRow(`SELECT COALESCE(likes_count, 0) FROM posts WHERE id = $1`, post.ID).Scan(&before))
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 8)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(reaction string) {
+				defer wg.Done()
+				_, err := ts.TestDB.Store.UpsertPostReaction(context.Background(), db.UpsertPostReactionParams{
+					UserID:   racer.ID,
+					PostID:   post.ID,
+					Reaction: reaction,
+				})
+				errs <- err
+			}([]string{"like", "fire"}[i%2])
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		var after int32
+		require.NoError(t, ts.TestDB.DB.QueryRow(`SELECT COALESCE(likes_count, 0) FROM posts WHERE id = $1`, post.ID).Scan(&after))
+		require.Equal(t, before+1, after)
+	})
+}
+
+
+
+
+
+func TestQuotePostsAndUndoRepost(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	reposter := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	quoter := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	reposterToken := ts.CreateAuthToken(t, reposter.ID)
+	quoterToken := ts.CreateAuthToken(t, quoter.ID)
+
+	post, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    "Quote me",
+		Visibility: sql.NullString{String: "public", Valid: true},
+	})
+	require.NoError(t, err)
+
+	followersOnly, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    "Followers only",
+		Visibility: sql.NullString{String: "followers", Valid: true},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		url          string
+		body         map[string]interface{}
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "PlainRepost",
+			url:          fmt.Sprintf("/api/posts/%s/repost", post.ID),
+			body:         map[string]interface{}{},
+			token:        reposterToken,
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "DuplicateRepost",
+			url:          fmt.Sprintf("/api/posts/%s/repost", post.ID),
+			body:         map[string]interface{}{},
+			token:        reposterToken,
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "QuoteWithoutContent",
+			url:          fmt.Sprintf("/api/posts/%s/quote", post.ID),
+			body:         map[string]interface{}{},
+			token:        quoterToken,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "QuoteHiddenPost",
+			url:          fmt.Sprintf("/api/posts/%s/quote", followersOnly.ID),
+			body:         map[string]interface{}{"content": "Cannot see this"},
+			token:        quoterToken,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "ValidQuote",
+			url:          fmt.Sprintf("/api/posts/%s/quote", post.ID),
+			body:         map[string]interface{}{"content": "Worth reading"},
+			token:        quoterToken,
+			expectedCode: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodPost, tc.url, tc.body, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post.ID), nil, reposterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	detail := ParseSuccessResponse(t, recorder)
+	require.Equal(t, float64(1), detail["reposts_count"])
+	require.Equal(t, float64(1), detail["quotes_count"])
+	require.Equal(t, true, detail["is_reposted"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/quotes", post.ID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), "Worth reading")
+	require.NotContains(t, recorder.Body.String(), reposter.ID.String())
+
+	quote, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:      spaceID,
+		AuthorID:     quoter.ID,
+		QuotedPostID: uuid.NullUUID{UUID: followersOnly.ID, Valid: true},
+		Content:      "Quoting a followers-only post",
+		Visibility:   sql.NullString{String: "public", Valid: true},
+	})
+	require.NoError(t, err)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", quote.ID), nil, reposterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	embedded := ParseSuccessResponse(t, recorder)["quoted_post"].(map[string]interface{})
+	require.Equal(t, true, embedded["unavailable"])
+	require.Nil(t, embedded["content"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", quote.ID), nil, authorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	embedded = ParseSuccessResponse(t, recorder)["quoted_post"].(map[string]interface{})
+	require.Equal(t, "Followers only", embedded["content"])
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/repost", post.ID), nil, reposterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/repost", post.ID), nil, reposterToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post.ID), nil, reposterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	detail = ParseSuccessResponse(t, recorder)
+	require.Equal(t, float64(0), detail["reposts_count"])
+	require.Equal(t, float64(1), detail["quotes_count"])
+	require.Equal(t, false, detail["is_reposted"])
+}
+
+
+
+
+
+func TestPostViewsAreDeduplicatedAndFlushed(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	viewer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	viewerToken := ts.CreateAuthToken(t, viewer.ID)
+
+	post, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    "Count my views",
+		Visibility: sql.NullString{String: "public", Valid: true},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	service := posts.NewService(ts.TestDB.Store, nil, nil)
+	service.RecordPostView(ctx, post.ID, viewer.ID, "10.0.0.1")
+	service.RecordPostView(ctx, post.ID, viewer.ID, "10.0.0.1")
+	service.RecordPostView(ctx, post.ID, uuid.Nil, "10.0.0.2")
+	service.RecordPostView(ctx, post.ID, uuid.Nil, "10.0.0.2")
+
+	flushed, err := service.FlushPostViews(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, flushed)
+
+	flushed, err = service.FlushPostViews(ctx)
+	require.NoError(t, err)
+	require.Zero(t, flushed)
+
+	recorder := ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/impressions?days=7", post.ID), nil, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusForbidden)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s/impressions?days=7", post.ID), nil, authorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	impressions := ParseSuccessResponse(t, recorder)
+	require.Equal(t, float64(2), impressions["total_views"])
+	require.Equal(t, float64(2), impressions["period_views"])
+
+	days := impressions["days"].([]interface{})
+	require.Len(t, days, 7)
+	today := days[len(days)-1].(map[string]interface{})
+	require.Equal(t, time.Now().UTC().Format("2006-01-02"), today["date"])
+	require.Equal(t, float64(2), today["views"])
+}
+
+
+
+
+
+func TestPostContentWarnings(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	viewer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	moderator := createAdminUser(t, ts.TestDB.Store, spaceID)
+	authorToken := ts.CreateAuthToken(t, author.ID)
+	viewerToken := ts.CreateAuthToken(t, viewer.ID)
+	moderatorToken := ts.CreateAuthToken(t, moderator.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id":        spaceID.String(),
+		"content":         "Talking about a hard week",
+		"content_warning": "mental health",
+	}, authorToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	created := ParseSuccessResponse(t, recorder)
+	require.Equal(t, "mental health", created["content_warning"])
+	require.Equal(t, true, created["collapsed"])
+	warnedID := created["id"].(string)
+
+	post, err := ts.TestDB.Store.CreatePost(context.Background(), db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    "Graphic photos from the lab",
+		Visibility: sql.NullString{String: "public", Valid: true},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		method       string
+		body         map[string]interface{}
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "StrangerCannotWarn",
+			method:       http.MethodPut,
+			body:         map[string]interface{}{"content_warning": "gore"},
+			token:        viewerToken,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "ModeratorAddsWarning",
+			method:       http.MethodPut,
+			body:         map[string]interface{}{"content_warning": "gore", "is_sensitive": true},
+			token:        moderatorToken,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "AuthorCannotRemoveModeratorWarning",
+			method:       http.MethodDelete,
+			token:        authorToken,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, tc.method, fmt.Sprintf("/api/posts/%s/content-warning", post.ID), tc.body, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post.ID), nil, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	detail := ParseSuccessResponse(t, recorder)
+	require.Equal(t, "gore", detail["content_warning"])
+	require.Equal(t, true, detail["is_sensitive"])
+	require.Equal(t, true, detail["collapsed"])
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/%s/quote", warnedID), map[string]interface{}{
+		"content": "Sending support",
+	}, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	quoted := ParseSuccessResponse(t, recorder)["quoted_post"].(map[string]interface{})
+	require.Equal(t, "mental health", quoted["content_warning"])
+	require.Equal(t, true, quoted["collapsed"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/search?space_id=%s&q=hard+week&types=post", spaceID), nil, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	results := ParseSuccessResponse(t, recorder)["items"].([]interface{})
+	require.Len(t, results, 1)
+	result := results[0].(map[string]interface{})
+	require.Equal(t, "mental health", result["content_warning"])
+	require.Equal(t, true, result["collapsed"])
+
+	recorder = ts.MakeRequest(t, http.MethodPut, "/api/users/content-warnings", map[string]interface{}{
+		"auto_expand_content_warnings": true,
+	}, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", warnedID), nil, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	detail = ParseSuccessResponse(t, recorder)
+	require.Equal(t, "mental health", detail["content_warning"])
+	require.Equal(t, false, detail["collapsed"])
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/posts/%s/content-warning", post.ID), nil, moderatorToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Nil(t, ParseSuccessResponse(t, recorder)["content_warning"])
+
+	var actions []string
+	rows, err := ts.TestDB.DB.Query(
+		`SELECT action FROM audit_logs WHERE resource_type = 'post' AND resource_id = $1 ORDER BY created_at`, post.ID)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var action string
+		require.NoError(t, rows.Scan(&action))
+		actions = append(actions, action)
+	}
+	require.Equal(t, []string{"add_content_warning", "remove_content_warning"}, actions)
+}
@@ -0,0 +1,161 @@
+package api_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	db "github.com/connect-univyn/connect-server/db/sqlc"
+	testhelpers "github.com/connect-univyn/connect-server/test/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+
+func TestUnifiedSearch(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	author := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	follower := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	stranger := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	followerToken := ts.CreateAuthToken(t, follower.ID)
+	strangerToken := ts.CreateAuthToken(t, stranger.ID)
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, follower.ID, author.ID, spaceID)
+
+	ctx := context.Background()
+	_, err := ts.TestDB.Store.CreatePost(ctx, db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    "Notes from the robotics workshop on autonomous navigation",
+		Visibility: sql.NullString{String: "public", Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = ts.TestDB.Store.CreatePost(ctx, db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    "Private robotics lab schedule",
+		Visibility: sql.NullString{String: "followers", Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = ts.TestDB.Store.CreateCommunity(ctx, db.CreateCommunityParams{
+		SpaceID:     spaceID,
+		Name:        "Robotics Society",
+		Description: sql.NullString{String: "Builders of robots and drones", Valid: true},
+		Category:    "engineering",
+		IsPublic:    sql.NullBool{Bool: true, Valid: true},
+		CreatedBy:   uuid.NullUUID{UUID: author.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	searchURL := func(query, extra string) string {
+		return fmt.Sprintf("/api/search?space_id=%s&q=%s%s", spaceID, query, extra)
+	}
+
+	testCases := []struct {
+		name         string
+		url          string
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "MissingQuery",
+			url:          searchURL("", ""),
+			token:        strangerToken,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "UnknownType",
+			url:          searchURL("robotics", "&types=post,widget"),
+			token:        strangerToken,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Anonymous",
+			url:          searchURL("robotics", ""),
+			token:        "",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := ts.MakeRequest(t, http.MethodGet, tc.url, nil, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	
+	recorder := ts.MakeRequest(t, http.MethodGet, searchURL("robotics", ""), nil, strangerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	data := ParseSuccessResponse(t, recorder)
+	facets := data["facets"].(map[string]interface{})
+	require.Equal(t, float64(1), facets["post"])
+	require.Equal(t, float64(1), facets["community"])
+	require.Equal(t, float64(2), data["total"])
+	require.Contains(t, recorder.Body.String(), "<mark>")
+	require.NotContains(t, recorder.Body.String(), "lab schedule")
+
+	recorder = ts.MakeRequest(t, http.MethodGet, searchURL("robotics", ""), nil, followerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	data = ParseSuccessResponse(t, recorder)
+	require.Equal(t, float64(2), data["facets"].(map[string]interface{})["post"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, searchURL("robotics", "&limit=2"), nil, followerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	first := ParseSuccessResponse(t, recorder)
+	require.Len(t, first["items"], 2)
+	require.Equal(t, true, first["has_more"])
+	require.NotNil(t, first["next_cursor"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, searchURL("robotics", fmt.Sprintf("&limit=2&cursor=%s", first["next_cursor"])), nil, followerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	second := ParseSuccessResponse(t, recorder)
+	require.Len(t, second["items"], 1)
+	require.Equal(t, false, second["has_more"])
+	seen := second["items"].([]interface{})[0].(map[string]interface{})["id"]
+	for _, item := range first["items"].([]interface{}) {
+		require.NotEqual(t, seen, item.(map[string]interface{})["id"])
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, searchURL("robotics", "&types=community"), nil, strangerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	data = ParseSuccessResponse(t, recorder)
+	results := data["items"].([]interface{})
+	require.Len(t, results, 1)
+	require.Equal(t, "community", results[0].(map[string]interface{})["type"])
+	require.Equal(t, float64(1), data["total"])
+
+	
+	recorder = ts.MakeRequest(t, http.MethodGet, searchURL("Robotcs+Society", "&types=community"), nil, strangerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	results = ParseSuccessResponse(t, recorder)["items"].([]interface{})
+	require.NotEmpty(t, results)
+	require.True(t, strings.Contains(results[0].(map[string]interface{})["title"].(string), "Robotics"))
+
+	_, err = ts.TestDB.Store.CreatePost(ctx, db.CreatePostParams{
+		SpaceID:    spaceID,
+		AuthorID:   author.ID,
+		Content:    `<img src=x onerror="alert(1)"> hackathon recap`,
+		Visibility: sql.NullString{String: "public", Valid: true},
+	})
+	require.NoError(t, err)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, searchURL("hackathon", "&types=post"), nil, strangerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	results = ParseSuccessResponse(t, recorder)["items"].([]interface{})
+	require.Len(t, results, 1)
+	result := results[0].(map[string]interface{})
+	for _, field := range []string{"snippet", "title_highlight"} {
+		highlight := result[field].(string)
+		require.NotContains(t, highlight, "<img", field)
+		require.Contains(t, highlight, "&lt;img", field)
+	}
+	require.Contains(t, result["snippet"], "<mark>hackathon</mark>")
+}
@@ -7,6 +7,7 @@ import (
 
 	testhelpers "github.com/connect-univyn/connect-server/test/db"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateUser(t *testing.T) {
@@ -671,3 +672,272 @@ func TestGetFollowing(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockAndMuteUser(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	user := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	blocked := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	muted := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	token := ts.CreateAuthToken(t, user.ID)
+	blockedToken := ts.CreateAuthToken(t, blocked.ID)
+	mutedToken := ts.CreateAuthToken(t, muted.ID)
+
+	testhelpers.CreateTestFollow(t, ts.TestDB.Store, user.ID, blocked.ID, spaceID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Post from a user who will be blocked",
+	}, blockedToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	blockedPost := ParseSuccessResponse(t, recorder)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Post from a user who will be muted",
+	}, mutedToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	mutedPost := ParseSuccessResponse(t, recorder)
+
+	testCases := []struct {
+		name         string
+		method       string
+		targetID     string
+		action       string
+		token        string
+		expectedCode int
+	}{
+		{
+			name:         "BlockNoAuth",
+			method:       http.MethodPost,
+			targetID:     blocked.ID.String(),
+			action:       "block",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "BlockInvalidUserID",
+			method:       http.MethodPost,
+			targetID:     "invalid-id",
+			action:       "block",
+			token:        token,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "BlockSelf",
+			method:       http.MethodPost,
+			targetID:     user.ID.String(),
+			action:       "block",
+			token:        token,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "BlockUnknownUser",
+			method:       http.MethodPost,
+			targetID:     uuid.New().String(),
+			action:       "block",
+			token:        token,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "ValidBlock",
+			method:       http.MethodPost,
+			targetID:     blocked.ID.String(),
+			action:       "block",
+			token:        token,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "ValidMute",
+			method:       http.MethodPost,
+			targetID:     muted.ID.String(),
+			action:       "mute",
+			token:        token,
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url := fmt.Sprintf("/api/users/%s/%s", tc.targetID, tc.action)
+			recorder := ts.MakeRequest(t, tc.method, url, nil, tc.token)
+			CheckResponseCode(t, recorder, tc.expectedCode)
+		})
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/users/%s/relationship", blocked.ID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	status := ParseSuccessResponse(t, recorder)
+	require.Equal(t, true, status["is_blocking"])
+	require.Equal(t, false, status["is_following"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/users/%s/relationship", user.ID), nil, blockedToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	status = ParseSuccessResponse(t, recorder)
+	require.Equal(t, true, status["is_blocked_by"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", blockedPost["id"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", mutedPost["id"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", mutedPost["id"]), nil, blockedToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/conversations/direct", map[string]interface{}{
+		"recipient_id": user.ID.String(),
+		"space_id":     spaceID.String(),
+	}, blockedToken)
+	CheckResponseCode(t, recorder, http.StatusForbidden)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/%s/follow?space_id=%s", user.ID, spaceID), nil, blockedToken)
+	CheckResponseCode(t, recorder, http.StatusForbidden)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/users/blocked", nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), blocked.ID.String())
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/users/%s/mute", muted.ID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", mutedPost["id"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/users/%s/block", blocked.ID), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", blockedPost["id"]), nil, token)
+	CheckResponseCode(t, recorder, http.StatusOK)
+}
+
+
+func TestLiveEventsFollowMuteChanges(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	host := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	viewer := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	actor := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	hostToken := ts.CreateAuthToken(t, host.ID)
+	viewerToken := ts.CreateAuthToken(t, viewer.ID)
+	actorToken := ts.CreateAuthToken(t, actor.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id": spaceID.String(),
+		"content":  "Live thread",
+	}, hostToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	postID := ParseSuccessResponse(t, recorder)["id"].(string)
+
+	conn := ts.DialWebSocket(t, viewerToken, "post:"+postID)
+
+	comment := func(token, content string) {
+		recorder := ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/posts/%s/comments", postID), map[string]interface{}{
+			"content": content,
+		}, token)
+		CheckResponseCode(t, recorder, http.StatusCreated)
+	}
+	nextComment := func() string {
+		payload := ReadWebSocketEvent(t, conn, "comment.created", nil)
+		return payload["content"].(string)
+	}
+
+	comment(actorToken, "before mute")
+	require.Equal(t, "before mute", nextComment())
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/%s/mute", actor.ID), nil, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	comment(actorToken, "while muted")
+	comment(hostToken, "from host")
+	require.Equal(t, "from host", nextComment())
+
+	recorder = ts.MakeRequest(t, http.MethodDelete, fmt.Sprintf("/api/users/%s/mute", actor.ID), nil, viewerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	comment(actorToken, "after unmute")
+	require.Equal(t, "after unmute", nextComment())
+}
+
+
+func TestFollowRequestsForPrivateAccount(t *testing.T) {
+	ts := SetupTestServer(t)
+	defer ts.Teardown()
+
+	spaceID := testhelpers.CreateTestSpace(t, ts.TestDB.DB)
+	owner := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	requester := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	rejected := testhelpers.CreateRandomUser(t, ts.TestDB.Store, spaceID)
+	ownerToken := ts.CreateAuthToken(t, owner.ID)
+	requesterToken := ts.CreateAuthToken(t, requester.ID)
+	rejectedToken := ts.CreateAuthToken(t, rejected.ID)
+
+	recorder := ts.MakeRequest(t, http.MethodPut, "/api/users/privacy", map[string]interface{}{}, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusBadRequest)
+
+	recorder = ts.MakeRequest(t, http.MethodPut, "/api/users/privacy", map[string]interface{}{
+		"private_account": true,
+	}, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, "/api/posts", map[string]interface{}{
+		"space_id":   spaceID.String(),
+		"content":    "Followers only update",
+		"visibility": "followers",
+	}, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusCreated)
+	post := ParseSuccessResponse(t, recorder)
+
+	for _, token := range []string{requesterToken, rejectedToken} {
+		recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/%s/follow?space_id=%s", owner.ID, spaceID), nil, token)
+		CheckResponseCode(t, recorder, http.StatusAccepted)
+		require.Equal(t, "requested", ParseSuccessResponse(t, recorder)["status"])
+	}
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/users/%s/relationship", owner.ID), nil, requesterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	status := ParseSuccessResponse(t, recorder)
+	require.Equal(t, true, status["is_requested"])
+	require.Equal(t, false, status["is_following"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post["id"]), nil, requesterToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/users/follow-requests", nil, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), requester.ID.String())
+	require.Contains(t, recorder.Body.String(), rejected.ID.String())
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/follow-requests/%s/approve", requester.ID), nil, requesterToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/follow-requests/%s/approve", requester.ID), nil, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/follow-requests/%s/reject", rejected.ID), nil, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodPost, fmt.Sprintf("/api/users/follow-requests/%s/reject", rejected.ID), nil, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post["id"]), nil, requesterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/%s", post["id"]), nil, rejectedToken)
+	CheckResponseCode(t, recorder, http.StatusNotFound)
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/posts/user/%s", owner.ID), nil, "")
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.NotContains(t, recorder.Body.String(), post["id"].(string))
+
+	recorder = ts.MakeRequest(t, http.MethodGet, fmt.Sprintf("/api/users/%s", owner.ID), nil, ownerToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Equal(t, float64(1), ParseSuccessResponse(t, recorder)["followers_count"])
+
+	recorder = ts.MakeRequest(t, http.MethodGet, "/api/notifications", nil, requesterToken)
+	CheckResponseCode(t, recorder, http.StatusOK)
+	require.Contains(t, recorder.Body.String(), "follow_request_approved")
+}
@@ -102,13 +102,33 @@ func CleanupTestData(t *testing.T, db *sql.DB) {
 		"login_attempts",
 
 		
+		"search_documents",
+		"mentions",
+		"post_tags",
+		"tag_space_usage",
+		"tags",
+		"trending_topics",
+		"bookmarks",
+		"bookmark_collections",
+		"poll_votes",
+		"poll_voters",
+		"poll_options",
+		"polls",
 		"likes",
 		"comments",
+		"post_daily_views",
 		"posts",
+		"follow_requests",
 		"follows",
+		"user_blocks",
+		"user_mutes",
 
 		
 		"message_reads",
+		"message_revisions",
+		"conversation_invites",
+		"message_pins",
+		"conversation_exports",
 		"messages",
 		"conversations",
 		"conversation_participants",